@@ -18,6 +18,8 @@ const TrackableCallbackTimeout = 2 * time.Second
 
 type callbackSet[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] map[int]HeadTrackable[H, BLOCK_HASH]
 
+type reorgCallbackSet[BLOCK_HASH types.Hashable] map[int]ReorgTrackable[BLOCK_HASH]
+
 func (set callbackSet[H, BLOCK_HASH]) values() []HeadTrackable[H, BLOCK_HASH] {
 	var values []HeadTrackable[H, BLOCK_HASH]
 	for _, callback := range set {
@@ -26,6 +28,14 @@ func (set callbackSet[H, BLOCK_HASH]) values() []HeadTrackable[H, BLOCK_HASH] {
 	return values
 }
 
+func (set reorgCallbackSet[BLOCK_HASH]) values() []ReorgTrackable[BLOCK_HASH] {
+	var values []ReorgTrackable[BLOCK_HASH]
+	for _, callback := range set {
+		values = append(values, callback)
+	}
+	return values
+}
+
 // HeadTrackable is implemented by the core txm to be able to receive head events from any chain.
 // Chain implementations should notify head events to the core txm via this interface.
 type HeadTrackable[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] interface {
@@ -38,7 +48,11 @@ type HeadTrackable[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] interfac
 type HeadBroadcaster[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] interface {
 	services.Service
 	BroadcastNewLongestChain(H)
+	// BroadcastReorg relays the reorg event to reorg subscribers. Reorg events are delivered before any head
+	// broadcasted after them.
+	BroadcastReorg(ReorgEvent[BLOCK_HASH])
 	Subscribe(callback HeadTrackable[H, BLOCK_HASH]) (currentLongestChain H, unsubscribe func())
+	SubscribeReorgs(callback ReorgTrackable[BLOCK_HASH]) (unsubscribe func())
 }
 
 type headBroadcaster[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] struct {
@@ -46,7 +60,9 @@ type headBroadcaster[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] struct
 	eng *services.Engine
 
 	callbacks      callbackSet[H, BLOCK_HASH]
+	reorgCallbacks reorgCallbackSet[BLOCK_HASH]
 	mailbox        *mailbox.Mailbox[H]
+	reorgMailbox   *mailbox.Mailbox[ReorgEvent[BLOCK_HASH]]
 	mutex          sync.Mutex
 	latest         H
 	lastCallbackID int
@@ -60,8 +76,10 @@ func NewHeadBroadcaster[
 	lggr logger.Logger,
 ) HeadBroadcaster[H, BLOCK_HASH] {
 	hb := &headBroadcaster[H, BLOCK_HASH]{
-		callbacks: make(callbackSet[H, BLOCK_HASH]),
-		mailbox:   mailbox.NewSingle[H](),
+		callbacks:      make(callbackSet[H, BLOCK_HASH]),
+		reorgCallbacks: make(reorgCallbackSet[BLOCK_HASH]),
+		mailbox:        mailbox.NewSingle[H](),
+		reorgMailbox:   mailbox.New[ReorgEvent[BLOCK_HASH]](HeadsBufferSize),
 	}
 	hb.Service, hb.eng = services.Config{
 		Name:  "HeadBroadcaster",
@@ -80,6 +98,7 @@ func (hb *headBroadcaster[H, BLOCK_HASH]) close() error {
 	hb.mutex.Lock()
 	// clear all callbacks
 	hb.callbacks = make(callbackSet[H, BLOCK_HASH])
+	hb.reorgCallbacks = make(reorgCallbackSet[BLOCK_HASH])
 	hb.mutex.Unlock()
 	return nil
 }
@@ -88,6 +107,10 @@ func (hb *headBroadcaster[H, BLOCK_HASH]) BroadcastNewLongestChain(head H) {
 	hb.mailbox.Deliver(head)
 }
 
+func (hb *headBroadcaster[H, BLOCK_HASH]) BroadcastReorg(event ReorgEvent[BLOCK_HASH]) {
+	hb.reorgMailbox.Deliver(event)
+}
+
 // Subscribe subscribes to OnNewLongestChain and Connect until HeadBroadcaster is closed,
 // or unsubscribe callback is called explicitly
 func (hb *headBroadcaster[H, BLOCK_HASH]) Subscribe(callback HeadTrackable[H, BLOCK_HASH]) (currentLongestChain H, unsubscribe func()) {
@@ -108,12 +131,32 @@ func (hb *headBroadcaster[H, BLOCK_HASH]) Subscribe(callback HeadTrackable[H, BL
 	return
 }
 
+// SubscribeReorgs subscribes to OnReorg until HeadBroadcaster is closed,
+// or unsubscribe callback is called explicitly
+func (hb *headBroadcaster[H, BLOCK_HASH]) SubscribeReorgs(callback ReorgTrackable[BLOCK_HASH]) (unsubscribe func()) {
+	hb.mutex.Lock()
+	defer hb.mutex.Unlock()
+
+	hb.lastCallbackID++
+	callbackID := hb.lastCallbackID
+	hb.reorgCallbacks[callbackID] = callback
+	return func() {
+		hb.mutex.Lock()
+		defer hb.mutex.Unlock()
+		delete(hb.reorgCallbacks, callbackID)
+	}
+}
+
 func (hb *headBroadcaster[H, BLOCK_HASH]) run(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-hb.reorgMailbox.Notify():
+			hb.executeReorgCallbacks(ctx)
 		case <-hb.mailbox.Notify():
+			// reorg must reach subscribers before the head of the new chain
+			hb.executeReorgCallbacks(ctx)
 			hb.executeCallbacks(ctx)
 		}
 	}
@@ -157,3 +200,35 @@ func (hb *headBroadcaster[H, BLOCK_HASH]) executeCallbacks(ctx context.Context)
 
 	wg.Wait()
 }
+
+func (hb *headBroadcaster[H, BLOCK_HASH]) executeReorgCallbacks(ctx context.Context) {
+	for {
+		event, exists := hb.reorgMailbox.Retrieve()
+		if !exists {
+			return
+		}
+
+		hb.mutex.Lock()
+		callbacks := hb.reorgCallbacks.values()
+		hb.mutex.Unlock()
+
+		hb.eng.Debugw("Initiating reorg callbacks",
+			"depth", event.Depth,
+			"commonAncestorNumber", event.CommonAncestorNumber,
+			"newHeadNumber", event.NewHeadNumber,
+			"numCallbacks", len(callbacks),
+		)
+
+		wg := sync.WaitGroup{}
+		wg.Add(len(callbacks))
+		for _, callback := range callbacks {
+			go func(trackable ReorgTrackable[BLOCK_HASH]) {
+				defer wg.Done()
+				cctx, cancel := context.WithTimeout(ctx, TrackableCallbackTimeout)
+				defer cancel()
+				trackable.OnReorg(cctx, event)
+			}(callback)
+		}
+		wg.Wait()
+	}
+}
@@ -28,6 +28,18 @@ var (
 		Name: "head_tracker_very_old_head",
 		Help: "Counter is incremented every time we get a head that is much lower than the highest seen head ('much lower' is defined as a block that is EVM.FinalityDepth or greater below the highest seen head)",
 	}, []string{"evmChainID"})
+
+	promReorgDepth = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "head_tracker_reorg_depth",
+		Help:    "The number of blocks of the canonical chain that were replaced by a reorg",
+		Buckets: []float64{1, 2, 3, 5, 10, 20, 50, 100, 500},
+	}, []string{"evmChainID"})
+
+	promReorgNewChainLength = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "head_tracker_reorg_new_chain_length",
+		Help:    "The number of blocks on the new canonical chain after the common ancestor of a reorg",
+		Buckets: []float64{1, 2, 3, 5, 10, 20, 50, 100, 500},
+	}, []string{"evmChainID"})
 )
 
 // HeadsBufferSize - The buffer is used when heads sampling is disabled, to ensure the callback is run for every head
@@ -233,6 +245,9 @@ func (ht *headTracker[HTH, S, ID, BLOCK_HASH]) handleNewHead(ctx context.Context
 		if !headWithChain.IsValid() {
 			return fmt.Errorf("HeadTracker#handleNewHighestHead headWithChain was unexpectedly nil")
 		}
+		if prevHead.IsValid() {
+			ht.handleReorg(ctx, prevHead, headWithChain)
+		}
 		ht.backfillMB.Deliver(headWithChain)
 		ht.broadcastMB.Deliver(headWithChain)
 	} else if head.BlockNumber() == prevHead.BlockNumber() {
@@ -255,6 +270,31 @@ func (ht *headTracker[HTH, S, ID, BLOCK_HASH]) handleNewHead(ctx context.Context
 	return nil
 }
 
+// handleReorg checks if the new longest chain replaced blocks of the previous one and, if so, records and broadcasts the reorg.
+func (ht *headTracker[HTH, S, ID, BLOCK_HASH]) handleReorg(ctx context.Context, prevHead, headWithChain HTH) {
+	event, found := findReorg[BLOCK_HASH](prevHead, headWithChain)
+	if !found {
+		return
+	}
+
+	promReorgDepth.WithLabelValues(ht.chainID.String()).Observe(float64(event.Depth))
+	promReorgNewChainLength.WithLabelValues(ht.chainID.String()).Observe(float64(event.NewHeadNumber - event.CommonAncestorNumber))
+	ht.log.Infow("Reorg detected",
+		"depth", event.Depth,
+		"oldHeadNumber", event.OldHeadNumber,
+		"oldHeadHash", event.OldHeadHash,
+		"newHeadNumber", event.NewHeadNumber,
+		"newHeadHash", event.NewHeadHash,
+		"commonAncestorNumber", event.CommonAncestorNumber,
+		"commonAncestorHash", event.CommonAncestorHash,
+	)
+
+	if err := ht.headSaver.SaveReorg(ctx, event); err != nil && ctx.Err() == nil {
+		ht.log.Warnw("Failed to save reorg", "err", err)
+	}
+	ht.headBroadcaster.BroadcastReorg(event)
+}
+
 func (ht *headTracker[HTH, S, ID, BLOCK_HASH]) broadcastLoop(ctx context.Context) {
 	samplingInterval := ht.htConfig.SamplingInterval()
 	if samplingInterval > 0 {
@@ -57,6 +57,39 @@ func (_c *HeadBroadcaster_BroadcastNewLongestChain_Call[H, BLOCK_HASH]) RunAndRe
 	return _c
 }
 
+// BroadcastReorg provides a mock function with given fields: _a0
+func (_m *HeadBroadcaster[H, BLOCK_HASH]) BroadcastReorg(_a0 headtracker.ReorgEvent[BLOCK_HASH]) {
+	_m.Called(_a0)
+}
+
+// HeadBroadcaster_BroadcastReorg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BroadcastReorg'
+type HeadBroadcaster_BroadcastReorg_Call[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] struct {
+	*mock.Call
+}
+
+// BroadcastReorg is a helper method to define mock.On call
+//   - _a0 headtracker.ReorgEvent[BLOCK_HASH]
+func (_e *HeadBroadcaster_Expecter[H, BLOCK_HASH]) BroadcastReorg(_a0 interface{}) *HeadBroadcaster_BroadcastReorg_Call[H, BLOCK_HASH] {
+	return &HeadBroadcaster_BroadcastReorg_Call[H, BLOCK_HASH]{Call: _e.mock.On("BroadcastReorg", _a0)}
+}
+
+func (_c *HeadBroadcaster_BroadcastReorg_Call[H, BLOCK_HASH]) Run(run func(_a0 headtracker.ReorgEvent[BLOCK_HASH])) *HeadBroadcaster_BroadcastReorg_Call[H, BLOCK_HASH] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(headtracker.ReorgEvent[BLOCK_HASH]))
+	})
+	return _c
+}
+
+func (_c *HeadBroadcaster_BroadcastReorg_Call[H, BLOCK_HASH]) Return() *HeadBroadcaster_BroadcastReorg_Call[H, BLOCK_HASH] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *HeadBroadcaster_BroadcastReorg_Call[H, BLOCK_HASH]) RunAndReturn(run func(headtracker.ReorgEvent[BLOCK_HASH])) *HeadBroadcaster_BroadcastReorg_Call[H, BLOCK_HASH] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with given fields:
 func (_m *HeadBroadcaster[H, BLOCK_HASH]) Close() error {
 	ret := _m.Called()
@@ -343,6 +376,54 @@ func (_c *HeadBroadcaster_Subscribe_Call[H, BLOCK_HASH]) RunAndReturn(run func(h
 	return _c
 }
 
+// SubscribeReorgs provides a mock function with given fields: callback
+func (_m *HeadBroadcaster[H, BLOCK_HASH]) SubscribeReorgs(callback headtracker.ReorgTrackable[BLOCK_HASH]) func() {
+	ret := _m.Called(callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeReorgs")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(headtracker.ReorgTrackable[BLOCK_HASH]) func()); ok {
+		r0 = rf(callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// HeadBroadcaster_SubscribeReorgs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeReorgs'
+type HeadBroadcaster_SubscribeReorgs_Call[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable] struct {
+	*mock.Call
+}
+
+// SubscribeReorgs is a helper method to define mock.On call
+//   - callback headtracker.ReorgTrackable[BLOCK_HASH]
+func (_e *HeadBroadcaster_Expecter[H, BLOCK_HASH]) SubscribeReorgs(callback interface{}) *HeadBroadcaster_SubscribeReorgs_Call[H, BLOCK_HASH] {
+	return &HeadBroadcaster_SubscribeReorgs_Call[H, BLOCK_HASH]{Call: _e.mock.On("SubscribeReorgs", callback)}
+}
+
+func (_c *HeadBroadcaster_SubscribeReorgs_Call[H, BLOCK_HASH]) Run(run func(callback headtracker.ReorgTrackable[BLOCK_HASH])) *HeadBroadcaster_SubscribeReorgs_Call[H, BLOCK_HASH] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(headtracker.ReorgTrackable[BLOCK_HASH]))
+	})
+	return _c
+}
+
+func (_c *HeadBroadcaster_SubscribeReorgs_Call[H, BLOCK_HASH]) Return(unsubscribe func()) *HeadBroadcaster_SubscribeReorgs_Call[H, BLOCK_HASH] {
+	_c.Call.Return(unsubscribe)
+	return _c
+}
+
+func (_c *HeadBroadcaster_SubscribeReorgs_Call[H, BLOCK_HASH]) RunAndReturn(run func(headtracker.ReorgTrackable[BLOCK_HASH]) func()) *HeadBroadcaster_SubscribeReorgs_Call[H, BLOCK_HASH] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewHeadBroadcaster creates a new instance of HeadBroadcaster. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHeadBroadcaster[H types.Head[BLOCK_HASH], BLOCK_HASH types.Hashable](t interface {
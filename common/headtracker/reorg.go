@@ -0,0 +1,79 @@
+package headtracker
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/common/types"
+)
+
+// ReorgEvent describes a reorg detected by the HeadTracker: the chain ending at OldHead was replaced
+// by the chain ending at NewHead. Both chains share the block at CommonAncestor.
+type ReorgEvent[BLOCK_HASH types.Hashable] struct {
+	// Depth is the number of blocks of the previous canonical chain that were reorged out.
+	Depth                int64      `db:"depth"`
+	OldHeadHash          BLOCK_HASH `db:"old_head_hash"`
+	OldHeadNumber        int64      `db:"old_head_number"`
+	NewHeadHash          BLOCK_HASH `db:"new_head_hash"`
+	NewHeadNumber        int64      `db:"new_head_number"`
+	CommonAncestorHash   BLOCK_HASH `db:"common_ancestor_hash"`
+	CommonAncestorNumber int64      `db:"common_ancestor_number"`
+	DetectedAt           time.Time  `db:"detected_at"`
+}
+
+// AffectedBlocks returns the inclusive range of block numbers whose contents were replaced by the reorg.
+func (e ReorgEvent[BLOCK_HASH]) AffectedBlocks() (from, to int64) {
+	return e.CommonAncestorNumber + 1, e.OldHeadNumber
+}
+
+// Merge returns a reorg covering the blocks affected by both reorgs, e.g. when a reorg is detected before the previous
+// one is processed: it forks from the lowest common ancestor and ends at the head of the later reorg.
+func (e ReorgEvent[BLOCK_HASH]) Merge(later ReorgEvent[BLOCK_HASH]) ReorgEvent[BLOCK_HASH] {
+	merged := later
+	if e.CommonAncestorNumber < later.CommonAncestorNumber {
+		merged.CommonAncestorNumber, merged.CommonAncestorHash = e.CommonAncestorNumber, e.CommonAncestorHash
+	}
+	if e.OldHeadNumber > later.OldHeadNumber {
+		merged.OldHeadNumber, merged.OldHeadHash = e.OldHeadNumber, e.OldHeadHash
+	}
+	merged.Depth = merged.OldHeadNumber - merged.CommonAncestorNumber
+	return merged
+}
+
+// ReorgTrackable is implemented by services that need to react to reorgs detected by the HeadTracker.
+type ReorgTrackable[BLOCK_HASH types.Hashable] interface {
+	// OnReorg is called once for every detected reorg, before the head of the new longest chain is delivered
+	// to OnNewLongestChain subscribers.
+	OnReorg(ctx context.Context, event ReorgEvent[BLOCK_HASH])
+}
+
+// findReorg checks whether newHead extends prevHead. If it does not, it returns the ReorgEvent describing the switch
+// from prevHead to newHead. Reorg can only be reported if both chains are long enough to contain the common ancestor.
+func findReorg[BLOCK_HASH types.Hashable](prevHead, newHead types.Head[BLOCK_HASH]) (event ReorgEvent[BLOCK_HASH], found bool) {
+	var zero BLOCK_HASH
+	hashAtPrevHeight := newHead.HashAtHeight(prevHead.BlockNumber())
+	if hashAtPrevHeight == zero || hashAtPrevHeight == prevHead.BlockHash() {
+		return event, false
+	}
+
+	for n := prevHead.BlockNumber() - 1; n >= 0; n-- {
+		oldHash, newHash := prevHead.HashAtHeight(n), newHead.HashAtHeight(n)
+		if oldHash == zero || newHash == zero {
+			// common ancestor is beyond in-memory history of one of the chains
+			return event, false
+		}
+		if oldHash == newHash {
+			return ReorgEvent[BLOCK_HASH]{
+				Depth:                prevHead.BlockNumber() - n,
+				OldHeadHash:          prevHead.BlockHash(),
+				OldHeadNumber:        prevHead.BlockNumber(),
+				NewHeadHash:          newHead.BlockHash(),
+				NewHeadNumber:        newHead.BlockNumber(),
+				CommonAncestorHash:   oldHash,
+				CommonAncestorNumber: n,
+				DetectedAt:           time.Now(),
+			}, true
+		}
+	}
+	return event, false
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/common/client"
 	commonfee "github.com/smartcontractkit/chainlink/v2/common/fee"
 	feetypes "github.com/smartcontractkit/chainlink/v2/common/fee/types"
+	"github.com/smartcontractkit/chainlink/v2/common/headtracker"
 	iutils "github.com/smartcontractkit/chainlink/v2/common/internal/utils"
 	txmgrtypes "github.com/smartcontractkit/chainlink/v2/common/txmgr/types"
 	"github.com/smartcontractkit/chainlink/v2/common/types"
@@ -109,11 +110,16 @@ type Confirmer[
 	enabledAddresses []ADDR
 
 	mb           *mailbox.Mailbox[HEAD]
+	lastReorg    *headtracker.ReorgEvent[BLOCK_HASH]
 	stopCh       services.StopChan
 	wg           sync.WaitGroup
 	initSync     sync.Mutex
 	isStarted    bool
 	isReceiptNil func(R) bool
+
+	// pendingReorg merges the reorgs detected since the last processed head, so that none of their blocks is skipped
+	pendingReorg   *headtracker.ReorgEvent[BLOCK_HASH]
+	pendingReorgMu sync.Mutex
 }
 
 func NewConfirmer[
@@ -248,6 +254,36 @@ func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) run
 	}
 }
 
+// OnReorg records the reorg detected by the HeadTracker, so that it can be taken into account while processing the next head.
+// Reorgs detected before the next head is processed are merged.
+func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) OnReorg(event headtracker.ReorgEvent[BLOCK_HASH]) {
+	ec.pendingReorgMu.Lock()
+	defer ec.pendingReorgMu.Unlock()
+	if ec.pendingReorg != nil {
+		event = ec.pendingReorg.Merge(event)
+	}
+	ec.pendingReorg = &event
+}
+
+// requeueReorg merges a reorg which failed to be processed into the pending one, so that it is processed again with the next head
+func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) requeueReorg(reorg headtracker.ReorgEvent[BLOCK_HASH]) {
+	ec.pendingReorgMu.Lock()
+	defer ec.pendingReorgMu.Unlock()
+	if ec.pendingReorg != nil {
+		reorg = reorg.Merge(*ec.pendingReorg)
+	}
+	ec.pendingReorg = &reorg
+}
+
+// takePendingReorg returns the reorg detected since the last processed head, if any
+func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) takePendingReorg() *headtracker.ReorgEvent[BLOCK_HASH] {
+	ec.pendingReorgMu.Lock()
+	defer ec.pendingReorgMu.Unlock()
+	reorg := ec.pendingReorg
+	ec.pendingReorg = nil
+	return reorg
+}
+
 // ProcessHead takes all required transactions for the confirmer on a new head
 func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) ProcessHead(ctx context.Context, head types.Head[BLOCK_HASH]) error {
 	ctx, cancel := context.WithTimeout(ctx, processHeadTimeout)
@@ -259,12 +295,27 @@ func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) Pro
 func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) processHead(ctx context.Context, head types.Head[BLOCK_HASH]) error {
 	ec.lggr.Debugw("processHead start", "headNum", head.BlockNumber(), "id", "confirmer")
 
+	ec.lastReorg = ec.takePendingReorg()
+	if reorg := ec.lastReorg; reorg != nil {
+		ec.lggr.Infow("Processing head after reorg", "headNum", head.BlockNumber(), "reorgDepth", reorg.Depth,
+			"commonAncestorNumber", reorg.CommonAncestorNumber, "commonAncestorHash", reorg.CommonAncestorHash.String(), "id", "confirmer")
+	}
+
 	mark := time.Now()
 	if err := ec.txStore.SetBroadcastBeforeBlockNum(ctx, head.BlockNumber(), ec.chainID); err != nil {
 		return err
 	}
 	ec.lggr.Debugw("Finished SetBroadcastBeforeBlockNum", "headNum", head.BlockNumber(), "time", time.Since(mark), "id", "confirmer")
 
+	if ec.lastReorg != nil {
+		mark = time.Now()
+		if err := ec.ProcessReorgedBlocks(ctx, *ec.lastReorg, head); err != nil {
+			ec.requeueReorg(*ec.lastReorg)
+			return err
+		}
+		ec.lggr.Debugw("Finished ProcessReorgedBlocks", "headNum", head.BlockNumber(), "time", time.Since(mark), "id", "confirmer")
+	}
+
 	mark = time.Now()
 	if err := ec.CheckForConfirmation(ctx, head); err != nil {
 		return err
@@ -320,6 +371,42 @@ func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) Che
 	return nil
 }
 
+// ProcessReorgedBlocks marks for rebroadcast the confirmed transactions whose receipts belong to blocks replaced by the reorg,
+// without waiting for the mined transaction count to drop below their sequence
+func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) ProcessReorgedBlocks(ctx context.Context, reorg headtracker.ReorgEvent[BLOCK_HASH], head types.Head[BLOCK_HASH]) error {
+	from, to := reorg.AffectedBlocks()
+	if to < from {
+		return nil
+	}
+	confirmedTxs, err := ec.txStore.FindConfirmedTxsInBlockRange(ctx, from, to, ec.chainID)
+	if err != nil {
+		return fmt.Errorf("failed to find transactions confirmed in re-org'd blocks %d-%d: %w", from, to, err)
+	}
+	var reorgTxs []*txmgrtypes.Tx[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]
+	for _, etx := range confirmedTxs {
+		if confirmedInReorgedBlock(etx, head, from, to) {
+			reorgTxs = append(reorgTxs, etx)
+		}
+	}
+	return ec.ProcessReorgTxs(ctx, reorgTxs, head)
+}
+
+// confirmedInReorgedBlock reports whether one of the tx receipts was included in an affected block that is no longer part of the chain of head
+func confirmedInReorgedBlock[CHAIN_ID types.ID, ADDR types.Hashable, TX_HASH types.Hashable, BLOCK_HASH types.Hashable, SEQ types.Sequence, FEE feetypes.Fee](etx *txmgrtypes.Tx[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE], head types.Head[BLOCK_HASH], from, to int64) bool {
+	for _, attempt := range etx.TxAttempts {
+		for _, receipt := range attempt.Receipts {
+			if receipt == nil {
+				continue
+			}
+			blockNum := receipt.GetBlockNumber().Int64()
+			if blockNum >= from && blockNum <= to && receipt.GetBlockHash() != head.HashAtHeight(blockNum) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) ProcessReorgTxs(ctx context.Context, reorgTxs []*txmgrtypes.Tx[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE], head types.Head[BLOCK_HASH]) error {
 	if len(reorgTxs) == 0 {
 		return nil
@@ -353,6 +440,15 @@ func (ec *Confirmer[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) Pro
 				"confirmedInBlockHash", receipt.GetBlockHash(),
 				"confirmedInTxIndex", receipt.GetTransactionIndex(),
 			)
+			if ec.lastReorg != nil {
+				from, to := ec.lastReorg.AffectedBlocks()
+				confirmedIn := receipt.GetBlockNumber().Int64()
+				logValues = append(logValues,
+					"reorgDepth", ec.lastReorg.Depth,
+					"reorgCommonAncestorNumber", ec.lastReorg.CommonAncestorNumber,
+					"confirmedInReorgedBlock", confirmedIn >= from && confirmedIn <= to,
+				)
+			}
 		}
 
 		if etx.State == TxFinalized {
@@ -7,6 +7,8 @@ import (
 	big "math/big"
 
 	feetypes "github.com/smartcontractkit/chainlink/v2/common/fee/types"
+	headtracker "github.com/smartcontractkit/chainlink/v2/common/headtracker"
+
 	mock "github.com/stretchr/testify/mock"
 
 	null "gopkg.in/guregu/null.v4"
@@ -847,6 +849,40 @@ func (_c *TxManager_OnNewLongestChain_Call[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_
 	return _c
 }
 
+// OnReorg provides a mock function with given fields: ctx, event
+func (_m *TxManager[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) OnReorg(ctx context.Context, event headtracker.ReorgEvent[BLOCK_HASH]) {
+	_m.Called(ctx, event)
+}
+
+// TxManager_OnReorg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnReorg'
+type TxManager_OnReorg_Call[CHAIN_ID types.ID, HEAD types.Head[BLOCK_HASH], ADDR types.Hashable, TX_HASH types.Hashable, BLOCK_HASH types.Hashable, SEQ types.Sequence, FEE feetypes.Fee] struct {
+	*mock.Call
+}
+
+// OnReorg is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event headtracker.ReorgEvent[BLOCK_HASH]
+func (_e *TxManager_Expecter[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) OnReorg(ctx interface{}, event interface{}) *TxManager_OnReorg_Call[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE] {
+	return &TxManager_OnReorg_Call[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]{Call: _e.mock.On("OnReorg", ctx, event)}
+}
+
+func (_c *TxManager_OnReorg_Call[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) Run(run func(ctx context.Context, event headtracker.ReorgEvent[BLOCK_HASH])) *TxManager_OnReorg_Call[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(headtracker.ReorgEvent[BLOCK_HASH]))
+	})
+	return _c
+}
+
+func (_c *TxManager_OnReorg_Call[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) Return() *TxManager_OnReorg_Call[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *TxManager_OnReorg_Call[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) RunAndReturn(run func(context.Context, headtracker.ReorgEvent[BLOCK_HASH])) *TxManager_OnReorg_Call[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Ready provides a mock function with given fields:
 func (_m *TxManager[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) Ready() error {
 	ret := _m.Called()
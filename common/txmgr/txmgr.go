@@ -43,6 +43,7 @@ type TxManager[
 	FEE feetypes.Fee,
 ] interface {
 	headtracker.HeadTrackable[HEAD, BLOCK_HASH]
+	headtracker.ReorgTrackable[BLOCK_HASH]
 	services.Service
 	Trigger(addr ADDR)
 	CreateTransaction(ctx context.Context, txRequest txmgrtypes.TxRequest[ADDR, TX_HASH]) (etx txmgrtypes.Tx[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE], err error)
@@ -501,6 +502,16 @@ func (b *Txm[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) OnNewLonge
 	}
 }
 
+// OnReorg conforms to ReorgTrackable
+func (b *Txm[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) OnReorg(ctx context.Context, event headtracker.ReorgEvent[BLOCK_HASH]) {
+	ok := b.IfStarted(func() {
+		b.confirmer.OnReorg(event)
+	})
+	if !ok {
+		b.logger.Debugw("Not started; ignoring reorg", "newHeadNumber", event.NewHeadNumber, "state", b.State())
+	}
+}
+
 // Trigger forces the Broadcaster to check early for the given address
 func (b *Txm[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) Trigger(addr ADDR) {
 	select {
@@ -529,6 +540,21 @@ func (b *Txm[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE]) CreateTran
 		return tx, err
 	}
 
+	if b.txConfig.ForwardersEnabled() && (!utils.IsZero(txRequest.ForwarderAddress)) {
+		authorized, authErr := b.fwdMgr.IsAuthorizedSender(ctx, txRequest.ForwarderAddress, txRequest.FromAddress)
+		if authErr != nil {
+			b.logger.Errorw("Failed to check if sender is authorized on forwarder",
+				"fromAddress", txRequest.FromAddress, "forwarderAddress", txRequest.ForwarderAddress, "err", authErr)
+			return tx, fmt.Errorf("Txm#CreateTransaction: failed to check if sender is authorized on forwarder: %w", authErr)
+		}
+		if !authorized {
+			b.logger.Warnw("Sender is no longer authorized on forwarder, falling back to sending directly",
+				"fromAddress", txRequest.FromAddress, "forwarderAddress", txRequest.ForwarderAddress)
+			var zero ADDR
+			txRequest.ForwarderAddress = zero
+		}
+	}
+
 	if b.txConfig.ForwardersEnabled() && (!utils.IsZero(txRequest.ForwarderAddress)) {
 		fwdPayload, fwdErr := b.fwdMgr.ConvertPayload(txRequest.ToAddress, txRequest.EncodedPayload)
 		if fwdErr == nil {
@@ -690,6 +716,9 @@ type NullTxManager[
 func (n *NullTxManager[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) OnNewLongestChain(context.Context, HEAD) {
 }
 
+func (n *NullTxManager[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) OnReorg(context.Context, headtracker.ReorgEvent[BLOCK_HASH]) {
+}
+
 // Start does noop for NullTxManager.
 func (n *NullTxManager[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) Start(context.Context) error {
 	return nil
@@ -14,4 +14,6 @@ type ForwarderManager[ADDR types.Hashable] interface {
 	ForwarderForOCR2Feeds(ctx context.Context, eoa, ocr2Aggregator ADDR) (forwarder ADDR, err error)
 	// Converts payload to be forwarder-friendly
 	ConvertPayload(dest ADDR, origPayload []byte) ([]byte, error)
+	// IsAuthorizedSender reports whether sender is currently authorized to send through forwarder
+	IsAuthorizedSender(ctx context.Context, forwarder, sender ADDR) (bool, error)
 }
@@ -289,6 +289,64 @@ func (_c *ForwarderManager_HealthReport_Call[ADDR]) RunAndReturn(run func() map[
 	return _c
 }
 
+// IsAuthorizedSender provides a mock function with given fields: ctx, forwarder, sender
+func (_m *ForwarderManager[ADDR]) IsAuthorizedSender(ctx context.Context, forwarder ADDR, sender ADDR) (bool, error) {
+	ret := _m.Called(ctx, forwarder, sender)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAuthorizedSender")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ADDR, ADDR) (bool, error)); ok {
+		return rf(ctx, forwarder, sender)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ADDR, ADDR) bool); ok {
+		r0 = rf(ctx, forwarder, sender)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ADDR, ADDR) error); ok {
+		r1 = rf(ctx, forwarder, sender)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForwarderManager_IsAuthorizedSender_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAuthorizedSender'
+type ForwarderManager_IsAuthorizedSender_Call[ADDR types.Hashable] struct {
+	*mock.Call
+}
+
+// IsAuthorizedSender is a helper method to define mock.On call
+//   - ctx context.Context
+//   - forwarder ADDR
+//   - sender ADDR
+func (_e *ForwarderManager_Expecter[ADDR]) IsAuthorizedSender(ctx interface{}, forwarder interface{}, sender interface{}) *ForwarderManager_IsAuthorizedSender_Call[ADDR] {
+	return &ForwarderManager_IsAuthorizedSender_Call[ADDR]{Call: _e.mock.On("IsAuthorizedSender", ctx, forwarder, sender)}
+}
+
+func (_c *ForwarderManager_IsAuthorizedSender_Call[ADDR]) Run(run func(ctx context.Context, forwarder ADDR, sender ADDR)) *ForwarderManager_IsAuthorizedSender_Call[ADDR] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ADDR), args[2].(ADDR))
+	})
+	return _c
+}
+
+func (_c *ForwarderManager_IsAuthorizedSender_Call[ADDR]) Return(_a0 bool, _a1 error) *ForwarderManager_IsAuthorizedSender_Call[ADDR] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ForwarderManager_IsAuthorizedSender_Call[ADDR]) RunAndReturn(run func(context.Context, ADDR, ADDR) (bool, error)) *ForwarderManager_IsAuthorizedSender_Call[ADDR] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Name provides a mock function with given fields:
 func (_m *ForwarderManager[ADDR]) Name() string {
 	ret := _m.Called()
@@ -1,6 +1,15 @@
 package compute
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
@@ -8,6 +17,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/host"
 )
@@ -101,9 +111,31 @@ func (mc *moduleCache) get(id string) (*module, bool) {
 
 	moduleCacheHit.WithLabelValues("true").Inc()
 	gotModule.lastFetchedAt = mc.clock.Now()
+	gotModule.warmed = false
 	return gotModule, true
 }
 
+// contains returns whether the module is cached, without counting it as a use of the module.
+func (mc *moduleCache) contains(id string) bool {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	_, ok := mc.m[id]
+	return ok
+}
+
+// warmedCount returns the number of cached modules that were warmed and haven't been executed yet.
+func (mc *moduleCache) warmedCount() int {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	count := 0
+	for _, m := range mc.m {
+		if m.warmed {
+			count++
+		}
+	}
+	return count
+}
+
 func (mc *moduleCache) evictOlderThan(duration time.Duration) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
@@ -130,4 +162,126 @@ func (mc *moduleCache) evictOlderThan(duration time.Duration) {
 type module struct {
 	module        *host.Module
 	lastFetchedAt time.Time
+
+	// fuel and maxMemoryMBs are the budgets of each execution of the module.
+	fuel         uint64
+	maxMemoryMBs int64
+	// warmed is whether the module was compiled ahead of its first execution, until it's executed.
+	warmed bool
+}
+
+const (
+	// defaultModuleDiskCacheMaxBytes bounds the total size of the compiled modules cached on disk.
+	defaultModuleDiskCacheMaxBytes = 1 << 30
+	wasmtimeModulePath             = "github.com/bytecodealliance/wasmtime-go"
+	// moduleDiskCacheHeader marks the wasmtime configuration files written by the node, which are the only ones it
+	// overwrites.
+	moduleDiskCacheHeader = "# Managed by the chainlink compute capability, changes are overwritten.\n"
+)
+
+// configureModuleDiskCache enables the on-disk cache of compiled modules, so that modules aren't compiled again after
+// a restart. wasmtime caches the modules it compiles when its configuration file, which is loaded by each new module,
+// enables its cache. The node writes that file to point the cache to a subdirectory of dir for the current version of
+// wasmtime, and removes the modules compiled by other versions, which can't be used anymore. Within a version, modules
+// are keyed by the hash of their binary and compilation settings, and the least recently used are evicted once the
+// cache grows beyond maxBytes.
+//
+// The cache is disabled if dir is empty. A configuration file that wasn't written by the node is left as is, since the
+// host may use it for other programs.
+func configureModuleDiskCache(lggr logger.Logger, dir string, maxBytes int64) error {
+	if maxBytes <= 0 {
+		maxBytes = defaultModuleDiskCacheMaxBytes
+	}
+	configPath, err := wasmtimeConfigPath()
+	if err != nil {
+		if dir == "" {
+			return nil
+		}
+		return err
+	}
+	existing, err := os.ReadFile(configPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read wasmtime configuration: %w", err)
+	}
+	managed := err == nil && bytes.HasPrefix(existing, []byte(moduleDiskCacheHeader))
+	if dir == "" {
+		if managed {
+			lggr.Info("disabling the on-disk cache of compiled modules")
+			return os.Remove(configPath)
+		}
+		return nil
+	}
+	if err == nil && !managed {
+		return fmt.Errorf("not overwriting the wasmtime configuration %s of the host", configPath)
+	}
+
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	version := wasmtimeVersion()
+	if err := os.MkdirAll(filepath.Join(dir, version), 0o700); err != nil {
+		return fmt.Errorf("failed to create module cache directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to list module cache directory: %w", err)
+	}
+	for _, e := range entries {
+		if e.Name() == version {
+			continue
+		}
+		lggr.Infow("removing modules compiled by another version of wasmtime", "wasmtimeVersion", e.Name())
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return fmt.Errorf("failed to remove stale compiled modules: %w", err)
+		}
+	}
+
+	config := fmt.Sprintf("%s[cache]\nenabled = true\ndirectory = %q\nfiles-total-size-soft-limit = \"%d\"\n",
+		moduleDiskCacheHeader, filepath.Join(dir, version), maxBytes)
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o700); err != nil {
+		return fmt.Errorf("failed to create wasmtime configuration directory: %w", err)
+	}
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		return fmt.Errorf("failed to write wasmtime configuration: %w", err)
+	}
+	lggr.Infow("enabled the on-disk cache of compiled modules", "dir", filepath.Join(dir, version), "maxBytes", maxBytes)
+	return nil
+}
+
+// wasmtimeConfigPath returns the path of the configuration file that wasmtime loads by default.
+func wasmtimeConfigPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	switch runtime.GOOS {
+	case "linux":
+		return filepath.Join(configDir, "wasmtime", "config.toml"), nil
+	case "darwin":
+		return filepath.Join(configDir, "BytecodeAlliance.wasmtime", "config.toml"), nil
+	default:
+		return "", fmt.Errorf("the on-disk cache of compiled modules isn't supported on %s", runtime.GOOS)
+	}
+}
+
+// wasmtimeVersion returns the version of the wasmtime bindings linked in the binary, which pins the version of
+// wasmtime.
+func wasmtimeVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if strings.HasPrefix(dep.Path, wasmtimeModulePath) {
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			if dep.Version == "" {
+				return "unknown"
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
 }
@@ -1,6 +1,9 @@
 package compute
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -103,3 +106,43 @@ func TestCache_EvictAfterSize(t *testing.T) {
 	_, ok = cache.get(id)
 	assert.True(t, ok)
 }
+
+// Verify that compiled modules are cached on disk for the current version of wasmtime.
+func TestModuleDiskCache(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the wasmtime configuration is looked up in XDG_CONFIG_HOME on linux only")
+	}
+	lggr := logger.TestLogger(t)
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	configPath := filepath.Join(configHome, "wasmtime", "config.toml")
+
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "v22.0.0")
+	require.NoError(t, os.MkdirAll(stale, 0o700))
+	require.NoError(t, configureModuleDiskCache(lggr, dir, 0))
+	assert.NoDirExists(t, stale)
+	config, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(config), `files-total-size-soft-limit = "1073741824"`)
+
+	binary := wasmtest.CreateTestBinary(binaryCmd, binaryLocation, false, t)
+	hmod, err := host.NewModule(&host.ModuleConfig{Logger: lggr, IsUncompressed: true}, binary)
+	require.NoError(t, err)
+	hmod.Close()
+	cached, err := filepath.Glob(filepath.Join(dir, wasmtimeVersion(), "modules", "*", "*"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, cached)
+
+	// the cache is disabled once the directory is unset
+	require.NoError(t, configureModuleDiskCache(lggr, "", 0))
+	assert.NoFileExists(t, configPath)
+
+	// the configuration of the host isn't overwritten
+	require.NoError(t, os.WriteFile(configPath, []byte("[cache]\nenabled = false\n"), 0o600))
+	require.Error(t, configureModuleDiskCache(lggr, dir, 0))
+	require.NoError(t, configureModuleDiskCache(lggr, "", 0))
+	config, err = os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "[cache]\nenabled = false\n", string(config))
+}
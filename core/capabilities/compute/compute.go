@@ -91,10 +91,30 @@ type Compute struct {
 	transformer              *transformer
 	outgoingConnectorHandler *webapi.OutgoingConnectorHandler
 	idGenerator              func() string
+	fetchLimiter             FetchLimiter
+	executionLimiter         ExecutionLimiter
 
 	numWorkers int
 	queue      chan request
 	wg         sync.WaitGroup
+
+	maxWarmedModules int
+	warmQueue        chan warmRequest
+
+	moduleCacheDir      string
+	moduleCacheMaxBytes int64
+}
+
+// FetchLimiter enforces the quota of outbound fetch calls of each workflow and workflow owner.
+type FetchLimiter interface {
+	AllowFetch(owner, workflowID string) error
+}
+
+// WithFetchLimiter makes the compute capability reject the fetch calls of workflows that exceeded their quota.
+func WithFetchLimiter(l FetchLimiter) func(*Compute) {
+	return func(c *Compute) {
+		c.fetchLimiter = l
+	}
 }
 
 func (c *Compute) RegisterToWorkflow(ctx context.Context, request capabilities.RegisterToWorkflowRequest) error {
@@ -162,10 +182,14 @@ func (c *Compute) execute(ctx context.Context, respCh chan response, req capabil
 	}
 
 	id := generateID(cfg.Binary)
+	if c.executionLimiter != nil {
+		limits := c.executionLimiter.ExecutionLimits(copiedReq.Metadata.WorkflowOwner, copiedReq.Metadata.WorkflowID)
+		id += applyExecutionLimits(cfg.ModuleConfig, limits)
+	}
 
 	m, ok := c.modules.get(id)
 	if !ok {
-		mod, innerErr := c.initModule(id, cfg.ModuleConfig, cfg.Binary, copiedReq.Metadata)
+		mod, innerErr := c.initModule(id, cfg.ModuleConfig, cfg.Binary, copiedReq.Metadata, false)
 		if innerErr != nil {
 			respCh <- response{err: innerErr}
 			return
@@ -174,7 +198,7 @@ func (c *Compute) execute(ctx context.Context, respCh chan response, req capabil
 		m = mod
 	}
 
-	resp, err := c.executeWithModule(ctx, m.module, cfg.Config, copiedReq)
+	resp, err := c.executeWithModule(ctx, m, cfg.Config, copiedReq)
 	select {
 	case <-c.stopCh:
 	case <-ctx.Done():
@@ -182,7 +206,7 @@ func (c *Compute) execute(ctx context.Context, respCh chan response, req capabil
 	}
 }
 
-func (c *Compute) initModule(id string, cfg *host.ModuleConfig, binary []byte, requestMetadata capabilities.RequestMetadata) (*module, error) {
+func (c *Compute) initModule(id string, cfg *host.ModuleConfig, binary []byte, requestMetadata capabilities.RequestMetadata, warmed bool) (*module, error) {
 	initStart := time.Now()
 
 	cfg.Fetch = c.createFetcher()
@@ -196,13 +220,15 @@ func (c *Compute) initModule(id string, cfg *host.ModuleConfig, binary []byte, r
 	initDuration := time.Since(initStart)
 	computeWASMInit.WithLabelValues(requestMetadata.WorkflowID, requestMetadata.ReferenceID).Observe(float64(initDuration))
 
-	m := &module{module: mod}
+	// the module normalizes its config, so that it holds the budgets executions actually run with
+	m := &module{module: mod, fuel: cfg.InitialFuel, maxMemoryMBs: cfg.MaxMemoryMBs, warmed: warmed}
 	c.modules.add(id, m)
 	return m, nil
 }
 
-func (c *Compute) executeWithModule(ctx context.Context, module *host.Module, config []byte, req capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error) {
+func (c *Compute) executeWithModule(ctx context.Context, m *module, config []byte, req capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error) {
 	executeStart := time.Now()
+	ctx, meter := withUsageMeter(ctx)
 	capReq := capabilitiespb.CapabilityRequestToProto(req)
 
 	wasmReq := &wasmpb.Request{
@@ -214,7 +240,23 @@ func (c *Compute) executeWithModule(ctx context.Context, module *host.Module, co
 			},
 		},
 	}
-	resp, err := module.Run(ctx, wasmReq)
+	resp, err := m.module.Run(ctx, wasmReq)
+
+	usage := ExecutionUsage{
+		WallTimeMs:      time.Since(executeStart).Milliseconds(),
+		FetchCalls:      meter.fetchCalls.Load(),
+		FuelBudget:      int64(m.fuel), //nolint:gosec // fuel budgets are far below math.MaxInt64
+		MemoryBudgetMBs: m.maxMemoryMBs,
+		OutOfFuel:       isOutOfFuel(err),
+	}
+	reportUsage(ctx, usage)
+	c.metrics.with(
+		platform.KeyWorkflowID, req.Metadata.WorkflowID,
+		platform.KeyWorkflowName, req.Metadata.WorkflowName,
+		platform.KeyWorkflowOwner, req.Metadata.WorkflowOwner,
+		platform.KeyStepRef, req.Metadata.ReferenceID,
+	).recordExecutionUsage(ctx, usage)
+
 	if err != nil {
 		return capabilities.CapabilityResponse{}, fmt.Errorf("error running module: %w", err)
 	}
@@ -246,6 +288,10 @@ func (c *Compute) Info(ctx context.Context) (capabilities.CapabilityInfo, error)
 }
 
 func (c *Compute) Start(ctx context.Context) error {
+	if err := configureModuleDiskCache(c.log, c.moduleCacheDir, c.moduleCacheMaxBytes); err != nil {
+		// modules are still cached in memory
+		c.log.Warnw("failed to configure the on-disk cache of compiled modules", "err", err)
+	}
 	c.modules.start()
 
 	c.wg.Add(c.numWorkers)
@@ -258,6 +304,12 @@ func (c *Compute) Start(ctx context.Context) error {
 			c.worker(innerCtx)
 		}()
 	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.warmLoop()
+	}()
 	return c.registry.Add(ctx, c)
 }
 
@@ -297,6 +349,11 @@ func (c *Compute) createFetcher() func(ctx context.Context, req *wasmpb.FetchReq
 		if err := validation.ValidateWorkflowOrExecutionID(req.Metadata.WorkflowExecutionId); err != nil {
 			return nil, fmt.Errorf("workflow execution ID %q is invalid: %w", req.Metadata.WorkflowExecutionId, err)
 		}
+		if c.fetchLimiter != nil {
+			if err := c.fetchLimiter.AllowFetch(req.Metadata.WorkflowOwner, req.Metadata.WorkflowId); err != nil {
+				return nil, err
+			}
+		}
 
 		cma := c.emitter.With(
 			platform.KeyWorkflowID, req.Metadata.WorkflowId,
@@ -318,6 +375,9 @@ func (c *Compute) createFetcher() func(ctx context.Context, req *wasmpb.FetchReq
 			headersReq[k] = v.String()
 		}
 
+		if meter := usageMeterFrom(ctx); meter != nil {
+			meter.fetchCalls.Add(1)
+		}
 		resp, err := c.outgoingConnectorHandler.HandleSingleNodeRequest(ctx, messageID, ghcapabilities.Request{
 			URL:       req.Url,
 			Method:    req.Method,
@@ -363,6 +423,12 @@ const (
 type Config struct {
 	webapi.ServiceConfig
 	NumWorkers int
+	// MaxWarmedModules is the maximum number of modules compiled ahead of their first execution, see Compute.Warm.
+	MaxWarmedModules int
+	// ModuleCacheDir is the directory of the on-disk cache of compiled modules, which is disabled if empty.
+	ModuleCacheDir string
+	// ModuleCacheMaxBytes bounds the total size of the on-disk cache of compiled modules, 1GiB by default.
+	ModuleCacheMaxBytes int64
 }
 
 func NewAction(
@@ -376,6 +442,9 @@ func NewAction(
 	if config.NumWorkers == 0 {
 		config.NumWorkers = defaultNumWorkers
 	}
+	if config.MaxWarmedModules == 0 {
+		config.MaxWarmedModules = defaultMaxWarmedModules
+	}
 	metricsLabeler, err := newComputeMetricsLabeler(metrics.NewLabeler().With("capability", CapabilityIDCompute))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create compute metrics labeler: %w", err)
@@ -395,6 +464,10 @@ func NewAction(
 			idGenerator:              idGenerator,
 			queue:                    make(chan request),
 			numWorkers:               defaultNumWorkers,
+			maxWarmedModules:         config.MaxWarmedModules,
+			moduleCacheDir:           config.ModuleCacheDir,
+			moduleCacheMaxBytes:      config.ModuleCacheMaxBytes,
+			warmQueue:                make(chan warmRequest, warmQueueSize),
 		}
 	)
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/assert"
@@ -14,7 +15,6 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/wasmtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
-	"github.com/smartcontractkit/chainlink/v2/core/utils/matches"
 
 	cappkg "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
@@ -26,6 +26,7 @@ import (
 	gcmocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/connector/mocks"
 	ghcapabilities "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/capabilities"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/quotas"
 )
 
 const (
@@ -189,8 +190,6 @@ func TestComputeFetch(t *testing.T) {
 	th := setup(t, defaultConfig)
 
 	th.connector.EXPECT().DonID().Return("don-id")
-	th.connector.EXPECT().AwaitConnection(matches.AnyContext, "gateway1").Return(nil)
-	th.connector.EXPECT().GatewayIDs().Return([]string{"gateway1", "gateway2"})
 
 	msgID := strings.Join([]string{
 		workflowExecutionID,
@@ -199,7 +198,7 @@ func TestComputeFetch(t *testing.T) {
 	}, "/")
 
 	gatewayResp := gatewayResponse(t, msgID)
-	th.connector.On("SignAndSendToGateway", mock.Anything, "gateway1", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+	th.connector.On("SignAndSendToAnyGateway", mock.Anything, mock.Anything).Return("gateway1", nil).Run(func(args mock.Arguments) {
 		th.connectorHandler.HandleGatewayMessage(context.Background(), "gateway1", gatewayResp)
 	}).Once()
 
@@ -240,11 +239,64 @@ func TestComputeFetch(t *testing.T) {
 		},
 	}
 
-	actual, err := th.compute.Execute(tests.Context(t), req)
+	var usage ExecutionUsage
+	ctx := WithUsageReporter(tests.Context(t), func(u ExecutionUsage) { usage = u })
+	actual, err := th.compute.Execute(ctx, req)
 	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage.FetchCalls)
+	assert.Equal(t, int64(128), usage.MemoryBudgetMBs)
+	assert.False(t, usage.OutOfFuel)
 	assert.EqualValues(t, expected, actual)
 }
 
+type stubExecutionLimiter quotas.ExecutionLimits
+
+func (l stubExecutionLimiter) ExecutionLimits(owner, workflowID string) quotas.ExecutionLimits {
+	return quotas.ExecutionLimits(l)
+}
+
+func TestComputeExecutionLimits(t *testing.T) {
+	t.Parallel()
+	th := setup(t, defaultConfig)
+	th.compute.executionLimiter = stubExecutionLimiter{Fuel: 1_000}
+	require.NoError(t, th.compute.Start(tests.Context(t)))
+
+	binary := wasmtest.CreateTestBinary(binaryCmd, binaryLocation, true, t)
+	config, err := values.WrapMap(map[string]any{
+		"config":       []byte(""),
+		"binary":       binary,
+		"maxMemoryMBs": 512,
+	})
+	require.NoError(t, err)
+	inputs, err := values.WrapMap(map[string]any{
+		"arg0": map[string]any{
+			"cool_output": "foo",
+		},
+	})
+	require.NoError(t, err)
+	req := cappkg.CapabilityRequest{
+		Inputs: inputs,
+		Config: config,
+		Metadata: cappkg.RequestMetadata{
+			WorkflowID:  "workflowID",
+			ReferenceID: "compute",
+		},
+	}
+	var usage ExecutionUsage
+	ctx := WithUsageReporter(tests.Context(t), func(u ExecutionUsage) { usage = u })
+	_, err = th.compute.Execute(ctx, req)
+	require.ErrorContains(t, err, errOutOfFuel)
+	assert.True(t, usage.OutOfFuel)
+
+	th.compute.executionLimiter = stubExecutionLimiter{Fuel: 10_000_000_000, MemoryMBs: 256}
+	_, err = th.compute.Execute(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10_000_000_000), usage.FuelBudget)
+	assert.Equal(t, int64(256), usage.MemoryBudgetMBs)
+	assert.Equal(t, int64(0), usage.FetchCalls)
+	assert.False(t, usage.OutOfFuel)
+}
+
 func gatewayResponse(t *testing.T, msgID string) *api.Message {
 	headers := map[string]string{"Content-Type": "application/json"}
 	body := []byte("response body")
@@ -263,3 +315,34 @@ func gatewayResponse(t *testing.T, msgID string) *api.Message {
 		},
 	}
 }
+
+func TestComputeWarm(t *testing.T) {
+	t.Parallel()
+	config := defaultConfig
+	config.MaxWarmedModules = 1
+	th := setup(t, config)
+	require.NoError(t, th.compute.Start(tests.Context(t)))
+
+	binary := wasmtest.CreateTestBinary(binaryCmd, binaryLocation, true, t)
+	th.compute.Warm(binary, "owner", "workflowID")
+	require.Eventually(t, func() bool {
+		return th.compute.modules.contains(generateID(binary))
+	}, tests.WaitTimeout(t), tests.TestInterval)
+
+	// the maximum number of warmed modules is reached
+	th.compute.executionLimiter = stubExecutionLimiter{Fuel: 1_000}
+	th.compute.Warm(binary, "owner", "workflowID")
+	require.Never(t, func() bool {
+		return th.compute.modules.contains(generateID(binary) + "-1000-0-0")
+	}, 100*time.Millisecond, tests.TestInterval)
+
+	// once the warmed module is executed, it no longer counts towards the maximum
+	_, ok := th.compute.modules.get(generateID(binary))
+	require.True(t, ok)
+	assert.Equal(t, 0, th.compute.modules.warmedCount())
+	th.compute.Warm(binary, "owner", "workflowID")
+	require.Eventually(t, func() bool {
+		return th.compute.modules.contains(generateID(binary) + "-1000-0-0")
+	}, tests.WaitTimeout(t), tests.TestInterval)
+	assert.Equal(t, 1, th.compute.modules.warmedCount())
+}
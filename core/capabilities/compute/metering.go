@@ -0,0 +1,117 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/host"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/quotas"
+)
+
+// errOutOfFuel is the message of the trap raised by wasmtime when a module consumed all of its fuel.
+const errOutOfFuel = "all fuel consumed"
+
+// ExecutionUsage is the resources used by an execution of a compute step.
+//
+// The host module doesn't expose the fuel consumed nor the peak memory of an execution, since its wasmtime store
+// doesn't outlive Run, so only the fuel and memory budgets the execution ran with are reported, along with whether it
+// ran out of fuel.
+type ExecutionUsage struct {
+	// WallTimeMs is the time spent running the module, in milliseconds.
+	WallTimeMs int64
+	// FetchCalls is the number of outbound HTTP calls made by the module.
+	FetchCalls int64
+	// FuelBudget is the maximum number of WASM instructions the module could execute, or 0 if it wasn't metered.
+	FuelBudget int64
+	// MemoryBudgetMBs is the maximum memory the module could use.
+	MemoryBudgetMBs int64
+	// OutOfFuel is whether the module ran out of fuel.
+	OutOfFuel bool
+}
+
+// Add accumulates the usage of another execution, e.g. of another element of a map-over step.
+func (u *ExecutionUsage) Add(o ExecutionUsage) {
+	u.WallTimeMs += o.WallTimeMs
+	u.FetchCalls += o.FetchCalls
+	u.FuelBudget = max(u.FuelBudget, o.FuelBudget)
+	u.MemoryBudgetMBs = max(u.MemoryBudgetMBs, o.MemoryBudgetMBs)
+	u.OutOfFuel = u.OutOfFuel || o.OutOfFuel
+}
+
+// ToMap returns the usage as a values.Map, to be recorded in step results.
+func (u ExecutionUsage) ToMap() (*values.Map, error) {
+	return values.WrapMap(u)
+}
+
+type usageReporterKey struct{}
+
+// WithUsageReporter makes the compute capability pass the usage of the executions requested with ctx to report. The
+// usage is reported out of band, rather than in the responses, since their value is produced by the module and must be
+// identical on all the nodes.
+func WithUsageReporter(ctx context.Context, report func(ExecutionUsage)) context.Context {
+	return context.WithValue(ctx, usageReporterKey{}, report)
+}
+
+func reportUsage(ctx context.Context, usage ExecutionUsage) {
+	if report, ok := ctx.Value(usageReporterKey{}).(func(ExecutionUsage)); ok {
+		report(usage)
+	}
+}
+
+// ExecutionLimiter provides the hard limits of a single execution of a compute step of a workflow.
+type ExecutionLimiter interface {
+	ExecutionLimits(owner, workflowID string) quotas.ExecutionLimits
+}
+
+// WithExecutionLimiter makes the compute capability enforce the hard limits of each workflow, and workflow owner, on
+// every execution.
+func WithExecutionLimiter(l ExecutionLimiter) func(*Compute) {
+	return func(c *Compute) {
+		c.executionLimiter = l
+	}
+}
+
+// applyExecutionLimits restricts the config of a module to the limits. It returns a suffix identifying the limits,
+// to cache the module separately from the modules of the same binary with other limits.
+func applyExecutionLimits(cfg *host.ModuleConfig, limits quotas.ExecutionLimits) string {
+	if limits == (quotas.ExecutionLimits{}) {
+		return ""
+	}
+	if limits.Fuel > 0 {
+		cfg.InitialFuel = limits.Fuel
+	}
+	// a config without max memory runs with the minimum memory, so it's only ever lowered
+	if limits.MemoryMBs > 0 && cfg.MaxMemoryMBs > int64(limits.MemoryMBs) {
+		cfg.MaxMemoryMBs = int64(limits.MemoryMBs)
+	}
+	if limits.FetchCalls > 0 {
+		cfg.MaxFetchRequests = int(limits.FetchCalls)
+	}
+	return fmt.Sprintf("-%d-%d-%d", limits.Fuel, limits.MemoryMBs, limits.FetchCalls)
+}
+
+// usageMeter counts the fetch calls of a single execution of a module. It is carried by the context of the execution,
+// which the module passes on to the fetcher.
+type usageMeter struct {
+	fetchCalls atomic.Int64
+}
+
+type usageMeterKey struct{}
+
+func withUsageMeter(ctx context.Context) (context.Context, *usageMeter) {
+	m := &usageMeter{}
+	return context.WithValue(ctx, usageMeterKey{}, m), m
+}
+
+func usageMeterFrom(ctx context.Context) *usageMeter {
+	m, _ := ctx.Value(usageMeterKey{}).(*usageMeter)
+	return m
+}
+
+func isOutOfFuel(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errOutOfFuel)
+}
@@ -0,0 +1,41 @@
+package compute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/host"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/quotas"
+)
+
+func TestWithUsageReporter(t *testing.T) {
+	reportUsage(context.Background(), ExecutionUsage{WallTimeMs: 1})
+
+	var reported []ExecutionUsage
+	ctx := WithUsageReporter(context.Background(), func(u ExecutionUsage) { reported = append(reported, u) })
+	reportUsage(ctx, ExecutionUsage{WallTimeMs: 12, FetchCalls: 2, OutOfFuel: true})
+	assert.Equal(t, []ExecutionUsage{{WallTimeMs: 12, FetchCalls: 2, OutOfFuel: true}}, reported)
+}
+
+func TestExecutionUsage_Add(t *testing.T) {
+	usage := ExecutionUsage{WallTimeMs: 10, FetchCalls: 1, FuelBudget: 1000, MemoryBudgetMBs: 128}
+	usage.Add(ExecutionUsage{WallTimeMs: 5, FetchCalls: 2, FuelBudget: 2000, MemoryBudgetMBs: 64, OutOfFuel: true})
+	assert.Equal(t, ExecutionUsage{WallTimeMs: 15, FetchCalls: 3, FuelBudget: 2000, MemoryBudgetMBs: 128, OutOfFuel: true}, usage)
+}
+
+func TestApplyExecutionLimits(t *testing.T) {
+	cfg := &host.ModuleConfig{MaxMemoryMBs: 512}
+	assert.Empty(t, applyExecutionLimits(cfg, quotas.ExecutionLimits{}))
+	assert.Equal(t, &host.ModuleConfig{MaxMemoryMBs: 512}, cfg)
+
+	suffix := applyExecutionLimits(cfg, quotas.ExecutionLimits{Fuel: 1000, MemoryMBs: 256, FetchCalls: 2})
+	assert.Equal(t, "-1000-256-2", suffix)
+	assert.Equal(t, &host.ModuleConfig{InitialFuel: 1000, MaxMemoryMBs: 256, MaxFetchRequests: 2}, cfg)
+
+	cfg = &host.ModuleConfig{MaxMemoryMBs: 64}
+	applyExecutionLimits(cfg, quotas.ExecutionLimits{MemoryMBs: 256})
+	assert.Equal(t, int64(64), cfg.MaxMemoryMBs)
+}
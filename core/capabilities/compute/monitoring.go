@@ -16,7 +16,12 @@ const timestampKey = "computeTimestamp"
 
 type computeMetricsLabeler struct {
 	metrics.Labeler
-	computeHTTPRequestCounter metric.Int64Counter
+	computeHTTPRequestCounter      metric.Int64Counter
+	executionWallTimeHistogram     metric.Int64Histogram
+	executionFetchCallsHistogram   metric.Int64Histogram
+	executionFuelBudgetHistogram   metric.Int64Histogram
+	executionMemoryBudgetHistogram metric.Int64Histogram
+	executionOutOfFuelCounter      metric.Int64Counter
 }
 
 func newComputeMetricsLabeler(l metrics.Labeler) (*computeMetricsLabeler, error) {
@@ -25,11 +30,66 @@ func newComputeMetricsLabeler(l metrics.Labeler) (*computeMetricsLabeler, error)
 		return nil, fmt.Errorf("failed to register compute http request counter: %w", err)
 	}
 
-	return &computeMetricsLabeler{Labeler: l, computeHTTPRequestCounter: computeHTTPRequestCounter}, nil
+	executionWallTimeHistogram, err := beholder.GetMeter().Int64Histogram("capabilities_compute_execution_wall_time_ms")
+	if err != nil {
+		return nil, fmt.Errorf("failed to register compute execution wall time histogram: %w", err)
+	}
+
+	executionFetchCallsHistogram, err := beholder.GetMeter().Int64Histogram("capabilities_compute_execution_fetch_calls")
+	if err != nil {
+		return nil, fmt.Errorf("failed to register compute execution fetch calls histogram: %w", err)
+	}
+
+	executionFuelBudgetHistogram, err := beholder.GetMeter().Int64Histogram("capabilities_compute_execution_fuel_budget")
+	if err != nil {
+		return nil, fmt.Errorf("failed to register compute execution fuel budget histogram: %w", err)
+	}
+
+	executionMemoryBudgetHistogram, err := beholder.GetMeter().Int64Histogram("capabilities_compute_execution_memory_budget_mb")
+	if err != nil {
+		return nil, fmt.Errorf("failed to register compute execution memory budget histogram: %w", err)
+	}
+
+	executionOutOfFuelCounter, err := beholder.GetMeter().Int64Counter("capabilities_compute_execution_out_of_fuel_count")
+	if err != nil {
+		return nil, fmt.Errorf("failed to register compute execution out of fuel counter: %w", err)
+	}
+
+	return &computeMetricsLabeler{
+		Labeler:                        l,
+		computeHTTPRequestCounter:      computeHTTPRequestCounter,
+		executionWallTimeHistogram:     executionWallTimeHistogram,
+		executionFetchCallsHistogram:   executionFetchCallsHistogram,
+		executionFuelBudgetHistogram:   executionFuelBudgetHistogram,
+		executionMemoryBudgetHistogram: executionMemoryBudgetHistogram,
+		executionOutOfFuelCounter:      executionOutOfFuelCounter,
+	}, nil
 }
 
 func (c *computeMetricsLabeler) with(keyValues ...string) *computeMetricsLabeler {
-	return &computeMetricsLabeler{c.With(keyValues...), c.computeHTTPRequestCounter}
+	return &computeMetricsLabeler{
+		Labeler:                        c.With(keyValues...),
+		computeHTTPRequestCounter:      c.computeHTTPRequestCounter,
+		executionWallTimeHistogram:     c.executionWallTimeHistogram,
+		executionFetchCallsHistogram:   c.executionFetchCallsHistogram,
+		executionFuelBudgetHistogram:   c.executionFuelBudgetHistogram,
+		executionMemoryBudgetHistogram: c.executionMemoryBudgetHistogram,
+		executionOutOfFuelCounter:      c.executionOutOfFuelCounter,
+	}
+}
+
+// recordExecutionUsage emits the resources used by an execution of a module.
+func (c *computeMetricsLabeler) recordExecutionUsage(ctx context.Context, usage ExecutionUsage) {
+	otelLabels := metric.WithAttributes(localMonitoring.KvMapToOtelAttributes(c.Labels)...)
+	c.executionWallTimeHistogram.Record(ctx, usage.WallTimeMs, otelLabels)
+	c.executionFetchCallsHistogram.Record(ctx, usage.FetchCalls, otelLabels)
+	if usage.FuelBudget > 0 {
+		c.executionFuelBudgetHistogram.Record(ctx, usage.FuelBudget, otelLabels)
+	}
+	c.executionMemoryBudgetHistogram.Record(ctx, usage.MemoryBudgetMBs, otelLabels)
+	if usage.OutOfFuel {
+		c.executionOutOfFuelCounter.Add(ctx, 1, otelLabels)
+	}
 }
 
 func (c *computeMetricsLabeler) incrementHTTPRequestCounter(ctx context.Context) {
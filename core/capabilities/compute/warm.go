@@ -0,0 +1,87 @@
+package compute
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/host"
+)
+
+const (
+	defaultMaxWarmedModules = 10
+	warmQueueSize           = 100
+)
+
+var moduleCacheWarm = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "compute_module_cache_warm",
+	Help: "modules compiled ahead of their first execution, by outcome",
+}, []string{"outcome"})
+
+// warmRequest is a workflow binary whose module is compiled ahead of its first execution.
+type warmRequest struct {
+	binary     []byte
+	owner      string
+	workflowID string
+}
+
+// Warm compiles the module of a workflow binary in the background and adds it to the module cache, so that the
+// first execution of the workflow, e.g. after a restart, isn't delayed by the compilation of its module.
+//
+// When the on-disk cache of compiled modules is enabled with ModuleCacheDir, warming a module after a restart loads it
+// from the disk rather than compiling it again, see configureModuleDiskCache.
+//
+// At most MaxWarmedModules cached modules are warmed and not yet executed, since they hold memory until they are
+// evicted, even if their workflows never execute. Warm never blocks: requests are dropped when the queue is full.
+func (c *Compute) Warm(binary []byte, owner, workflowID string) {
+	select {
+	case c.warmQueue <- warmRequest{binary: binary, owner: owner, workflowID: workflowID}:
+	default:
+		c.log.Debugw("warm queue full, not warming module", "workflowID", workflowID)
+		moduleCacheWarm.WithLabelValues("dropped").Inc()
+	}
+}
+
+func (c *Compute) warmLoop() {
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case r := <-c.warmQueue:
+			if c.modules.warmedCount() >= c.maxWarmedModules {
+				c.log.Debugw("maximum number of warmed modules reached, not warming module", "workflowID", r.workflowID)
+				moduleCacheWarm.WithLabelValues("dropped").Inc()
+				continue
+			}
+			ok, err := c.warm(r)
+			switch {
+			case err != nil:
+				c.log.Warnw("failed to warm module", "workflowID", r.workflowID, "err", err)
+				moduleCacheWarm.WithLabelValues("failed").Inc()
+			case ok:
+				moduleCacheWarm.WithLabelValues("warmed").Inc()
+			}
+		}
+	}
+}
+
+// warm compiles the module of the binary, unless it is already cached, and returns whether it did.
+func (c *Compute) warm(r warmRequest) (bool, error) {
+	cfg := &host.ModuleConfig{Logger: c.transformer.logger, Labeler: c.transformer.emitter}
+	// the ID must match the one of executions, for them to use the warmed module
+	id := generateID(r.binary)
+	if c.executionLimiter != nil {
+		id += applyExecutionLimits(cfg, c.executionLimiter.ExecutionLimits(r.owner, r.workflowID))
+	}
+	if c.modules.contains(id) {
+		return false, nil
+	}
+
+	if _, err := c.initModule(id, cfg, r.binary, capabilities.RequestMetadata{WorkflowID: r.workflowID, WorkflowOwner: r.owner}, true); err != nil {
+		return false, fmt.Errorf("failed to compile module: %w", err)
+	}
+	c.log.Debugw("warmed module", "workflowID", r.workflowID)
+	return true, nil
+}
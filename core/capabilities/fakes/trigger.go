@@ -1,15 +1,16 @@
-package framework
+// Package fakes implements trigger and target capabilities that are driven and recorded in-process. They are used by
+// the capabilities integration tests and by the workflow simulator.
+package fakes
 
 import (
 	"context"
+	"errors"
 	"sync"
-	"testing"
 
 	"github.com/google/uuid"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
-	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
 	"github.com/smartcontractkit/chainlink-common/pkg/values"
 )
 
@@ -26,15 +27,13 @@ type TriggerSink struct {
 	wg     sync.WaitGroup
 }
 
-func NewTriggerSink(t *testing.T, triggerName string, version string) *TriggerSink {
-	triggersFactory := &TriggerSink{
+func NewTriggerSink(triggerName string, version string) *TriggerSink {
+	return &TriggerSink{
 		triggerID:   triggerName + "@" + version,
 		triggerName: triggerName,
 		version:     version,
 		stopCh:      make(services.StopChan),
 	}
-	servicetest.Run(t, triggersFactory)
-	return triggersFactory
 }
 
 func (r *TriggerSink) GetTriggerVersion() string {
@@ -79,14 +78,13 @@ func (r *TriggerSink) SendOutput(outputs *values.Map) {
 	}
 }
 
-func (r *TriggerSink) CreateNewTrigger(t *testing.T) capabilities.TriggerCapability {
-	trigger := newFakeTrigger(t, r.triggerID, &r.wg, r.stopCh)
+func (r *TriggerSink) CreateNewTrigger() capabilities.TriggerCapability {
+	trigger := newFakeTrigger(r.triggerID, &r.wg, r.stopCh)
 	r.triggers = append(r.triggers, trigger)
 	return &trigger
 }
 
 type fakeTrigger struct {
-	t         *testing.T
 	triggerID string
 	cancel    context.CancelFunc
 	toSend    chan capabilities.TriggerResponse
@@ -95,9 +93,8 @@ type fakeTrigger struct {
 	stopCh services.StopChan
 }
 
-func newFakeTrigger(t *testing.T, triggerID string, wg *sync.WaitGroup, stopCh services.StopChan) fakeTrigger {
+func newFakeTrigger(triggerID string, wg *sync.WaitGroup, stopCh services.StopChan) fakeTrigger {
 	return fakeTrigger{
-		t:         t,
 		triggerID: triggerID,
 		toSend:    make(chan capabilities.TriggerResponse, 1000),
 		wg:        wg,
@@ -119,7 +116,7 @@ func (s *fakeTrigger) Info(ctx context.Context) (capabilities.CapabilityInfo, er
 
 func (s *fakeTrigger) RegisterTrigger(ctx context.Context, request capabilities.TriggerRegistrationRequest) (<-chan capabilities.TriggerResponse, error) {
 	if s.cancel != nil {
-		s.t.Fatal("trigger already registered")
+		return nil, errors.New("trigger already registered")
 	}
 
 	responseCh := make(chan capabilities.TriggerResponse)
@@ -146,7 +143,7 @@ func (s *fakeTrigger) RegisterTrigger(ctx context.Context, request capabilities.
 
 func (s *fakeTrigger) UnregisterTrigger(ctx context.Context, request capabilities.TriggerRegistrationRequest) error {
 	if s.cancel == nil {
-		s.t.Fatal("trigger not registered")
+		return errors.New("trigger not registered")
 	}
 
 	s.cancel()
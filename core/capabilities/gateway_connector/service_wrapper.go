@@ -39,13 +39,14 @@ func translateConfigs(f config.GatewayConnector) connector.ConnectorConfig {
 	if len(f.Gateways()) != 0 {
 		r.Gateways = make([]connector.ConnectorGatewayConfig, len(f.Gateways()))
 		for index, element := range f.Gateways() {
-			r.Gateways[index] = connector.ConnectorGatewayConfig{Id: element.ID(), URL: element.URL()}
+			r.Gateways[index] = connector.ConnectorGatewayConfig{Id: element.ID(), URL: element.URL(), Weight: element.Weight()}
 		}
 	}
 
 	r.WsClientConfig = network.WebSocketClientConfig{HandshakeTimeoutMillis: f.WSHandshakeTimeoutMillis()}
 	r.AuthMinChallengeLen = f.AuthMinChallengeLen()
 	r.AuthTimestampToleranceSec = f.AuthTimestampToleranceSec()
+	r.AllowGatewayAuthenticatedSenders = f.AllowGatewayAuthenticatedSenders()
 	return r
 }
 
@@ -217,7 +217,7 @@ func (d *DON) GetPeerIDs() []peer {
 func (d *DON) Start(ctx context.Context) error {
 	for _, triggerFactory := range d.triggerFactories {
 		for _, node := range d.nodes {
-			trigger := triggerFactory.CreateNewTrigger(d.t)
+			trigger := triggerFactory.CreateNewTrigger()
 			if err := node.registry.Add(ctx, trigger); err != nil {
 				return fmt.Errorf("failed to add trigger: %w", err)
 			}
@@ -226,7 +226,7 @@ func (d *DON) Start(ctx context.Context) error {
 
 	for _, targetFactory := range d.targetFactories {
 		for _, node := range d.nodes {
-			target := targetFactory.CreateNewTarget(d.t)
+			target := targetFactory.CreateNewTarget()
 			if err := node.registry.Add(ctx, target); err != nil {
 				return fmt.Errorf("failed to add target: %w", err)
 			}
@@ -344,14 +344,14 @@ func (d *DON) AddJob(ctx context.Context, j *job.Job) error {
 }
 
 type TriggerFactory interface {
-	CreateNewTrigger(t *testing.T) commoncap.TriggerCapability
+	CreateNewTrigger() commoncap.TriggerCapability
 	GetTriggerID() string
 	GetTriggerName() string
 	GetTriggerVersion() string
 }
 
 type TargetFactory interface {
-	CreateNewTarget(t *testing.T) commoncap.TargetCapability
+	CreateNewTarget() commoncap.TargetCapability
 	GetTargetID() string
 	GetTargetName() string
 	GetTargetVersion() string
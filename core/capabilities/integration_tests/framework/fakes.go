@@ -0,0 +1,20 @@
+package framework
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/fakes"
+)
+
+// NewTriggerSink creates a trigger sink that is started for the duration of the test.
+func NewTriggerSink(t *testing.T, triggerName string, version string) *fakes.TriggerSink {
+	triggerSink := fakes.NewTriggerSink(triggerName, version)
+	servicetest.Run(t, triggerSink)
+	return triggerSink
+}
+
+func NewTargetSink(targetName string, version string) *fakes.TargetSink {
+	return fakes.NewTargetSink(targetName, version)
+}
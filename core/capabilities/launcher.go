@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/protobuf/proto"
@@ -48,14 +49,23 @@ type launcher struct {
 	dispatcher          remotetypes.Dispatcher
 	registry            *Registry
 	subServices         []services.Service
+	subServicesMu       sync.RWMutex // protects subServices
 	workflowDonNotifier donNotifier
 }
 
 func unmarshalCapabilityConfig(data []byte) (capabilities.CapabilityConfiguration, error) {
+	cfg, _, err := unmarshalRemoteCapabilityConfig(data)
+	return cfg, err
+}
+
+// unmarshalRemoteCapabilityConfig also returns the aggregator config of remote triggers, which is removed from the
+// default config, so that it never reaches workflows.
+func unmarshalRemoteCapabilityConfig(data []byte) (capabilities.CapabilityConfiguration, remote.TriggerAggregatorConfig, error) {
+	var aggregatorConfig remote.TriggerAggregatorConfig
 	cconf := &capabilitiespb.CapabilityConfig{}
 	err := proto.Unmarshal(data, cconf)
 	if err != nil {
-		return capabilities.CapabilityConfiguration{}, err
+		return capabilities.CapabilityConfiguration{}, aggregatorConfig, err
 	}
 
 	var remoteTriggerConfig *capabilities.RemoteTriggerConfig
@@ -77,16 +87,32 @@ func unmarshalCapabilityConfig(data []byte) (capabilities.CapabilityConfiguratio
 
 	dc, err := values.FromMapValueProto(cconf.DefaultConfig)
 	if err != nil {
-		return capabilities.CapabilityConfiguration{}, err
+		return capabilities.CapabilityConfiguration{}, aggregatorConfig, err
+	}
+
+	if dc != nil {
+		if v, ok := dc.Underlying[remote.TriggerAggregatorConfigKey]; ok {
+			delete(dc.Underlying, remote.TriggerAggregatorConfigKey)
+			if err := v.UnwrapTo(&aggregatorConfig); err != nil {
+				return capabilities.CapabilityConfiguration{}, aggregatorConfig, fmt.Errorf("invalid %s: %w", remote.TriggerAggregatorConfigKey, err)
+			}
+		}
 	}
 
 	return capabilities.CapabilityConfiguration{
 		DefaultConfig:       dc,
 		RemoteTriggerConfig: remoteTriggerConfig,
 		RemoteTargetConfig:  remoteTargetConfig,
-	}, nil
+	}, aggregatorConfig, nil
 }
 
+// ShimLister lists the shims of remote capabilities.
+type ShimLister interface {
+	Shims() []remotetypes.ShimStats
+}
+
+var _ ShimLister = &launcher{}
+
 type donNotifier interface {
 	NotifyDonSet(don capabilities.DON)
 }
@@ -113,6 +139,8 @@ func (w *launcher) Start(ctx context.Context) error {
 }
 
 func (w *launcher) Close() error {
+	w.subServicesMu.RLock()
+	defer w.subServicesMu.RUnlock()
 	for _, s := range w.subServices {
 		if err := s.Close(); err != nil {
 			w.lggr.Errorw("failed to close a sub-service", "name", s.Name(), "error", err)
@@ -134,6 +162,26 @@ func (w *launcher) Name() string {
 	return w.lggr.Name()
 }
 
+func (w *launcher) addSubService(s services.Service) {
+	w.subServicesMu.Lock()
+	defer w.subServicesMu.Unlock()
+	w.subServices = append(w.subServices, s)
+}
+
+// Shims returns a snapshot of the state of the shims of remote capabilities, i.e. of the trigger publishers and
+// subscribers, and the executable clients and servers created by the launcher.
+func (w *launcher) Shims() []remotetypes.ShimStats {
+	w.subServicesMu.RLock()
+	defer w.subServicesMu.RUnlock()
+	var shims []remotetypes.ShimStats
+	for _, s := range w.subServices {
+		if r, ok := s.(remotetypes.StatsReporter); ok {
+			shims = append(shims, r.Stats())
+		}
+	}
+	return shims
+}
+
 func (w *launcher) Launch(ctx context.Context, state *registrysyncer.LocalRegistry) error {
 	w.lggr.Debug("CapabilitiesLauncher triggered...")
 	w.registry.SetLocalRegistry(state)
@@ -255,7 +303,7 @@ func (w *launcher) addRemoteCapabilities(ctx context.Context, myDON registrysync
 			return fmt.Errorf("could not find capability matching id %s", cid)
 		}
 
-		capabilityConfig, err := unmarshalCapabilityConfig(c.Config)
+		capabilityConfig, aggregatorConfig, err := unmarshalRemoteCapabilityConfig(c.Config)
 		if err != nil {
 			return fmt.Errorf("could not unmarshal capability config for id %s", cid)
 		}
@@ -264,6 +312,7 @@ func (w *launcher) addRemoteCapabilities(ctx context.Context, myDON registrysync
 		case capabilities.CapabilityTypeTrigger:
 			newTriggerFn := func(info capabilities.CapabilityInfo) (capabilityService, error) {
 				var aggregator remotetypes.Aggregator
+				var verifier remote.TriggerEventVerifier
 				if strings.HasPrefix(info.ID, "streams-trigger") {
 					codec := streams.NewCodec(w.lggr)
 
@@ -272,15 +321,24 @@ func (w *launcher) addRemoteCapabilities(ctx context.Context, myDON registrysync
 						return nil, err
 					}
 
-					aggregator = triggers.NewMercuryRemoteAggregator(
-						codec,
-						signers,
-						int(remoteDON.F+1),
-						info.ID,
-						w.lggr,
-					)
-				} else {
-					aggregator = remote.NewDefaultModeAggregator(uint32(remoteDON.F) + 1)
+					if aggregatorConfig.Type == "" {
+						aggregator = triggers.NewMercuryRemoteAggregator(
+							codec,
+							signers,
+							int(remoteDON.F+1),
+							info.ID,
+							w.lggr,
+						)
+					} else {
+						verifier = remote.NewReportVerifier(codec, signers, int(remoteDON.F+1))
+					}
+				}
+				if aggregator == nil {
+					var err error
+					aggregator, err = remote.NewTriggerAggregator(aggregatorConfig, remoteDON.F, verifier, w.lggr)
+					if err != nil {
+						return nil, fmt.Errorf("failed to create aggregator for capability %s: %w", info.ID, err)
+					}
 				}
 
 				// TODO: We need to implement a custom, Mercury-specific
@@ -393,7 +451,7 @@ func (w *launcher) addToRegistryAndSetDispatcher(ctx context.Context, capability
 	if err != nil {
 		return fmt.Errorf("failed to start capability: %w", err)
 	}
-	w.subServices = append(w.subServices, cp)
+	w.addSubService(cp)
 	return nil
 }
 
@@ -550,7 +608,7 @@ func (w *launcher) addReceiver(ctx context.Context, capability registrysyncer.Ca
 		return fmt.Errorf("failed to start receiver: %w", err)
 	}
 
-	w.subServices = append(w.subServices, receiver)
+	w.addSubService(receiver)
 	return nil
 }
 
@@ -211,6 +211,12 @@ func TestLauncher(t *testing.T) {
 		err = launcher.Launch(ctx, state)
 		require.NoError(t, err)
 		defer launcher.Close()
+
+		kinds := []string{}
+		for _, shim := range launcher.Shims() {
+			kinds = append(kinds, shim.Kind)
+		}
+		assert.ElementsMatch(t, []string{remotetypes.ShimTriggerPublisher, remotetypes.ShimExecutableServer}, kinds)
 	})
 
 	t.Run("NOK-invalid_trigger_capability", func(t *testing.T) {
@@ -664,6 +670,24 @@ func TestLauncher_RemoteTriggerModeAggregatorShim(t *testing.T) {
 
 	// Checks if response is same as minIdenticalResponses = F + 1, F = 1
 	require.Equal(t, response.Event.Outputs, triggerEventValue)
+
+	shims := launcher.Shims()
+	require.Len(t, shims, 2)
+	for _, shim := range shims {
+		assert.Equal(t, capDonID, shim.DonID)
+		assert.ElementsMatch(t, capabilityDonNodes, shim.Peers)
+		switch shim.Kind {
+		case remotetypes.ShimTriggerSubscriber:
+			assert.Equal(t, fullTriggerCapID, shim.CapabilityID)
+			assert.Equal(t, 1, shim.Registrations)
+			assert.Equal(t, uint64(3), shim.MessagesReceived)
+		case remotetypes.ShimExecutableClient:
+			assert.Equal(t, fullTargetID, shim.CapabilityID)
+			assert.Equal(t, 0, shim.PendingRequests)
+		default:
+			t.Fatalf("unexpected shim %s", shim.Kind)
+		}
+	}
 }
 
 func TestSyncer_IgnoresCapabilitiesForPrivateDON(t *testing.T) {
@@ -1252,3 +1276,32 @@ func TestLauncher_SucceedsEvenIfDispatcherAlreadyHasReceiver(t *testing.T) {
 	require.NoError(t, err)
 	defer launcher.Close()
 }
+
+func TestUnmarshalRemoteCapabilityConfig_TriggerAggregator(t *testing.T) {
+	defaultConfig, err := values.NewMap(map[string]any{
+		"feedIds": []string{"feed-1"},
+		remote.TriggerAggregatorConfigKey: map[string]any{
+			"type":         remote.AggregatorMedian,
+			"minResponses": 3,
+		},
+	})
+	require.NoError(t, err)
+	data, err := proto.Marshal(&capabilitiespb.CapabilityConfig{
+		DefaultConfig: values.ProtoMap(defaultConfig),
+		RemoteConfig: &capabilitiespb.CapabilityConfig_RemoteTriggerConfig{
+			RemoteTriggerConfig: &capabilitiespb.RemoteTriggerConfig{MinResponsesToAggregate: 3},
+		},
+	})
+	require.NoError(t, err)
+
+	cfg, aggregatorConfig, err := unmarshalRemoteCapabilityConfig(data)
+	require.NoError(t, err)
+	assert.Equal(t, remote.TriggerAggregatorConfig{Type: remote.AggregatorMedian, MinResponses: 3}, aggregatorConfig)
+	assert.NotContains(t, cfg.DefaultConfig.Underlying, remote.TriggerAggregatorConfigKey)
+	assert.Contains(t, cfg.DefaultConfig.Underlying, "feedIds")
+
+	// the config of workflows never contains the aggregator config
+	cfg, err = unmarshalCapabilityConfig(data)
+	require.NoError(t, err)
+	assert.NotContains(t, cfg.DefaultConfig.Underlying, remote.TriggerAggregatorConfigKey)
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -23,9 +24,14 @@ import (
 
 var (
 	ErrReceiverExists = errors.New("receiver already exists")
+	ErrSendQueueFull  = errors.New("send queue is full")
 )
 
-// dispatcher en/decodes messages and routes traffic between peers and capabilities
+// dispatcher en/decodes messages and routes traffic between peers and capabilities.
+//
+// Sent messages are queued by priority: the messages of executable capabilities are always sent ahead of trigger
+// messages. When batching is enabled, trigger messages are also queued per peer and sent in batches. Large messages
+// and batches are compressed when compression is enabled.
 type dispatcher struct {
 	cfg         config.Dispatcher
 	peerWrapper p2ptypes.PeerWrapper
@@ -36,6 +42,10 @@ type dispatcher struct {
 	rateLimiter *common.RateLimiter
 	receivers   map[key]*receiver
 	mu          sync.RWMutex
+	outbound    [numPriorities]chan outboundMessage
+	pending     map[p2ptypes.PeerID]*pendingBatch // only accessed by sendLoop, and by Close once it returned
+	encoder     *zstd.Encoder
+	decoder     *zstd.Decoder
 	stopCh      services.StopChan
 	wg          sync.WaitGroup
 	lggr        logger.Logger
@@ -58,16 +68,27 @@ func NewDispatcher(cfg config.Dispatcher, peerWrapper p2ptypes.PeerWrapper, sign
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create rate limiter")
 	}
-	return &dispatcher{
+	encoder, decoder, err := newZstdCodec()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create zstd codec")
+	}
+	d := &dispatcher{
 		cfg:         cfg,
 		peerWrapper: peerWrapper,
 		signer:      signer,
 		registry:    registry,
 		rateLimiter: rl,
 		receivers:   make(map[key]*receiver),
+		pending:     make(map[p2ptypes.PeerID]*pendingBatch),
+		encoder:     encoder,
+		decoder:     decoder,
 		stopCh:      make(services.StopChan),
 		lggr:        lggr.Named("Dispatcher"),
-	}, nil
+	}
+	for i := range d.outbound {
+		d.outbound[i] = make(chan outboundMessage, outboundQueueSize)
+	}
+	return d, nil
 }
 
 func (d *dispatcher) Start(ctx context.Context) error {
@@ -81,6 +102,11 @@ func (d *dispatcher) Start(ctx context.Context) error {
 		defer d.wg.Done()
 		d.receive()
 	}()
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.sendLoop()
+	}()
 
 	d.lggr.Info("dispatcher started")
 	return nil
@@ -89,6 +115,8 @@ func (d *dispatcher) Start(ctx context.Context) error {
 func (d *dispatcher) Close() error {
 	close(d.stopCh)
 	d.wg.Wait()
+	d.flush()
+	d.decoder.Close()
 	d.lggr.Info("dispatcher closed")
 	return nil
 }
@@ -150,6 +178,10 @@ func (d *dispatcher) RemoveReceiver(capabilityId string, donId uint32) {
 	}
 }
 
+// Send signs the message and queues it to be sent to the peer. It returns ErrSendQueueFull rather than blocking if
+// the messages of the same priority are queued faster than they are sent. Once queued, the messages of all peers are
+// sent by a single loop, as sending to a peer only hands the message over to its p2p stream, and errors of sending
+// them are logged, as the caller has moved on by then.
 func (d *dispatcher) Send(peerID p2ptypes.PeerID, msgBody *types.MessageBody) error {
 	msgBody.Version = uint32(d.cfg.SupportedVersion())
 	msgBody.Sender = d.peerID[:]
@@ -168,7 +200,11 @@ func (d *dispatcher) Send(peerID p2ptypes.PeerID, msgBody *types.MessageBody) er
 	if err != nil {
 		return err
 	}
-	return d.peer.Send(peerID, rawMsg)
+	priority := priorityHigh
+	if isTriggerMessage(msgBody) {
+		priority = priorityLow
+	}
+	return d.queue(priority, outboundMessage{peerID: peerID, rawMsg: rawMsg})
 }
 
 func (d *dispatcher) receive() {
@@ -179,37 +215,55 @@ func (d *dispatcher) receive() {
 			d.lggr.Info("stopped - exiting receive")
 			return
 		case msg := <-recvCh:
+			// Every message is charged a single token, whether it was sent alone or in a batch. The token of the p2p
+			// message is taken before it's unbatched, which is the most expensive to handle, and is the token of the
+			// first message of a batch.
 			if !d.rateLimiter.Allow(msg.Sender.String()) {
 				d.lggr.Debugw("rate limit exceeded, dropping message", "sender", msg.Sender)
 				continue
 			}
-			body, err := ValidateMessage(msg, d.peerID)
+			msgs, batched, err := d.unbatch(msg)
 			if err != nil {
-				d.lggr.Debugw("received invalid message", "error", err)
-				d.tryRespondWithError(msg.Sender, body, types.Error_VALIDATION_FAILED)
-				continue
-			}
-			k := key{body.CapabilityId, body.CapabilityDonId}
-			d.mu.RLock()
-			receiver, ok := d.receivers[k]
-			d.mu.RUnlock()
-			if !ok {
-				d.lggr.Debugw("received message for unregistered capability", "capabilityId", SanitizeLogString(k.capId), "donId", k.donId)
-				d.tryRespondWithError(msg.Sender, body, types.Error_CAPABILITY_NOT_FOUND)
+				d.lggr.Debugw("received invalid batch", "sender", msg.Sender, "error", err)
 				continue
 			}
-
-			receiverQueueUsage := float64(len(receiver.ch)) / float64(d.cfg.ReceiverBufferSize())
-			capReceiveChannelUsage.WithLabelValues(k.capId, fmt.Sprint(k.donId)).Set(receiverQueueUsage)
-			select {
-			case receiver.ch <- body:
-			default:
-				d.lggr.Warnw("receiver channel full, dropping message", "capabilityId", k.capId, "donId", k.donId)
+			for i, m := range msgs {
+				if batched && i > 0 && !d.rateLimiter.Allow(m.Sender.String()) {
+					d.lggr.Debugw("rate limit exceeded, dropping batched message", "sender", m.Sender)
+					continue
+				}
+				d.receiveMessage(m)
 			}
 		}
 	}
 }
 
+func (d *dispatcher) receiveMessage(msg p2ptypes.Message) {
+	body, err := ValidateMessage(msg, d.peerID)
+	if err != nil {
+		d.lggr.Debugw("received invalid message", "error", err)
+		d.tryRespondWithError(msg.Sender, body, types.Error_VALIDATION_FAILED)
+		return
+	}
+	k := key{body.CapabilityId, body.CapabilityDonId}
+	d.mu.RLock()
+	receiver, ok := d.receivers[k]
+	d.mu.RUnlock()
+	if !ok {
+		d.lggr.Debugw("received message for unregistered capability", "capabilityId", SanitizeLogString(k.capId), "donId", k.donId)
+		d.tryRespondWithError(msg.Sender, body, types.Error_CAPABILITY_NOT_FOUND)
+		return
+	}
+
+	receiverQueueUsage := float64(len(receiver.ch)) / float64(d.cfg.ReceiverBufferSize())
+	capReceiveChannelUsage.WithLabelValues(k.capId, fmt.Sprint(k.donId)).Set(receiverQueueUsage)
+	select {
+	case receiver.ch <- body:
+	default:
+		d.lggr.Warnw("receiver channel full, dropping message", "capabilityId", k.capId, "donId", k.donId)
+	}
+}
+
 func (d *dispatcher) tryRespondWithError(peerID p2ptypes.PeerID, body *types.MessageBody, errType types.Error) {
 	if body == nil {
 		return
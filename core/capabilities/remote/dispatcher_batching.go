@@ -0,0 +1,244 @@
+package remote
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types"
+	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
+)
+
+const (
+	// maxBatchBytes bounds the size of batches, comfortably below the maximum size of p2p messages.
+	maxBatchBytes = 400_000
+	// maxDecompressedBytes bounds the size of received batches once decompressed, against decompression bombs.
+	maxDecompressedBytes = 10_000_000
+	// maxBatchedMessages bounds the number of messages of received batches.
+	maxBatchedMessages = 10_000
+)
+
+const (
+	priorityHigh = iota
+	priorityLow
+	numPriorities
+)
+
+// outboundQueueSize is the number of signed messages of each priority queued until they are sent.
+const outboundQueueSize = 1000
+
+// outboundMessage is a signed message queued until it's sent to the peer.
+type outboundMessage struct {
+	peerID p2ptypes.PeerID
+	rawMsg []byte
+}
+
+// pendingBatch is the messages queued for a peer, until they are sent in a batch.
+type pendingBatch struct {
+	messages [][]byte
+	size     int
+}
+
+// isTriggerMessage returns whether the message is trigger traffic, which is sent with a low priority, and in batches
+// when batching is enabled. Other messages, i.e. of executable capabilities, are sent with a high priority, so that
+// they never wait behind the fan-out of trigger events.
+func isTriggerMessage(msgBody *types.MessageBody) bool {
+	switch msgBody.Method {
+	case types.MethodRegisterTrigger, types.MethodUnRegisterTrigger, types.MethodTriggerEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+// queue queues the signed message until sendLoop sends it, or returns ErrSendQueueFull if the queue of its priority
+// is full.
+func (d *dispatcher) queue(priority int, m outboundMessage) error {
+	select {
+	case <-d.stopCh:
+		return errors.New("dispatcher closed")
+	default:
+	}
+	select {
+	case d.outbound[priority] <- m:
+		return nil
+	default:
+		return ErrSendQueueFull
+	}
+}
+
+// sendLoop sends the queued messages, always sending the queued messages of executable capabilities before trigger
+// messages, and the pending batches on every tick when batching is enabled.
+func (d *dispatcher) sendLoop() {
+	var tick <-chan time.Time
+	if d.cfg.BatchPeriod() > 0 {
+		ticker := time.NewTicker(d.cfg.BatchPeriod())
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case m := <-d.outbound[priorityHigh]:
+			d.sendOutbound(priorityHigh, m)
+			continue
+		default:
+		}
+
+		select {
+		case <-d.stopCh:
+			return
+		case m := <-d.outbound[priorityHigh]:
+			d.sendOutbound(priorityHigh, m)
+		case m := <-d.outbound[priorityLow]:
+			d.sendOutbound(priorityLow, m)
+		case <-tick:
+			d.sendPending()
+		}
+	}
+}
+
+// flush sends the messages still queued once the dispatcher is closed, by priority, and then the pending batches.
+func (d *dispatcher) flush() {
+	for priority := range d.outbound {
+	drain:
+		for {
+			select {
+			case m := <-d.outbound[priority]:
+				d.sendOutbound(priority, m)
+			default:
+				break drain
+			}
+		}
+	}
+	d.sendPending()
+}
+
+func (d *dispatcher) sendOutbound(priority int, m outboundMessage) {
+	var err error
+	if priority == priorityLow && d.cfg.BatchPeriod() > 0 {
+		err = d.enqueue(m)
+	} else {
+		err = d.send(m.peerID, m.rawMsg)
+	}
+	if err != nil {
+		d.lggr.Errorw("failed to send message", "peerId", m.peerID, "err", err)
+	}
+}
+
+// enqueue adds the signed message to the pending batch of the peer. The batch is sent when it's full, or on the next
+// tick of sendLoop.
+func (d *dispatcher) enqueue(m outboundMessage) error {
+	b, ok := d.pending[m.peerID]
+	if !ok {
+		b = &pendingBatch{}
+		d.pending[m.peerID] = b
+	}
+	b.messages = append(b.messages, m.rawMsg)
+	b.size += len(m.rawMsg)
+	if len(b.messages) < d.cfg.MaxBatchSize() && b.size < maxBatchBytes {
+		return nil
+	}
+	delete(d.pending, m.peerID)
+	return d.send(m.peerID, b.messages...)
+}
+
+func (d *dispatcher) sendPending() {
+	pending := d.pending
+	d.pending = make(map[p2ptypes.PeerID]*pendingBatch)
+	for peerID, b := range pending {
+		if err := d.send(peerID, b.messages...); err != nil {
+			d.lggr.Errorw("failed to send batch", "peerId", peerID, "nMessages", len(b.messages), "err", err)
+		}
+	}
+}
+
+// send sends signed messages to the peer, in a signed batch if there are several of them. A single message is sent as
+// is, unless it is large enough to be compressed.
+func (d *dispatcher) send(peerID p2ptypes.PeerID, rawMsgs ...[]byte) error {
+	if len(rawMsgs) == 1 && !d.shouldCompress(len(rawMsgs[0])) {
+		return d.peer.Send(peerID, rawMsgs[0])
+	}
+	batch, err := proto.Marshal(&types.MessageBatch{Messages: rawMsgs})
+	if err != nil {
+		return err
+	}
+	compression := types.Compression_NONE
+	if d.shouldCompress(len(batch)) {
+		batch = d.encoder.EncodeAll(batch, nil)
+		compression = types.Compression_ZSTD
+	}
+	signature, err := d.signer.Sign(batch)
+	if err != nil {
+		return err
+	}
+	rawMsg, err := proto.Marshal(&types.Message{Signature: signature, Batch: batch, Compression: compression})
+	if err != nil {
+		return err
+	}
+	return d.peer.Send(peerID, rawMsg)
+}
+
+func (d *dispatcher) shouldCompress(size int) bool {
+	threshold := d.cfg.CompressionThreshold()
+	return threshold > 0 && uint64(size) >= uint64(threshold)
+}
+
+// unbatch returns the messages of a received batch, or the message itself if it isn't a batch, and whether it was a
+// batch. Batches are accepted even if batching and compression are disabled, so that they can be enabled on the peers
+// one by one. The signature of a batch is verified before it's decompressed, so that only the sender can make the
+// dispatcher decompress it.
+func (d *dispatcher) unbatch(msg p2ptypes.Message) ([]p2ptypes.Message, bool, error) {
+	var topLevelMessage types.Message
+	if err := proto.Unmarshal(msg.Payload, &topLevelMessage); err != nil || len(topLevelMessage.Batch) == 0 {
+		// invalid messages are reported by ValidateMessage
+		return []p2ptypes.Message{msg}, false, nil
+	}
+
+	if !ed25519.Verify(msg.Sender[:], topLevelMessage.Batch, topLevelMessage.Signature) {
+		return nil, true, errors.New("failed to verify batch signature")
+	}
+
+	rawBatch := topLevelMessage.Batch
+	switch topLevelMessage.Compression {
+	case types.Compression_NONE:
+	case types.Compression_ZSTD:
+		var err error
+		rawBatch, err = d.decoder.DecodeAll(rawBatch, nil)
+		if err != nil {
+			return nil, true, fmt.Errorf("failed to decompress batch: %w", err)
+		}
+	default:
+		return nil, true, fmt.Errorf("unsupported compression %d", topLevelMessage.Compression)
+	}
+
+	var batch types.MessageBatch
+	if err := proto.Unmarshal(rawBatch, &batch); err != nil {
+		return nil, true, fmt.Errorf("failed to unmarshal batch: %w", err)
+	}
+	if len(batch.Messages) > maxBatchedMessages {
+		return nil, true, errors.New("too many messages in batch")
+	}
+	msgs := make([]p2ptypes.Message, 0, len(batch.Messages))
+	for _, rawMsg := range batch.Messages {
+		msgs = append(msgs, p2ptypes.Message{Sender: msg.Sender, Payload: rawMsg})
+	}
+	return msgs, true, nil
+}
+
+func newZstdCodec() (*zstd.Encoder, *zstd.Decoder, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxDecompressedBytes), zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, nil, err
+	}
+	return encoder, decoder, nil
+}
@@ -2,11 +2,15 @@ package remote_test
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/remote"
 	remotetypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types"
@@ -15,6 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
 	"github.com/smartcontractkit/chainlink/v2/core/services/p2p/types/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
 
 	commonMocks "github.com/smartcontractkit/chainlink-common/pkg/types/core/mocks"
 )
@@ -57,9 +62,12 @@ func (c testRateLimitConfig) PerSenderBurst() int {
 }
 
 type testConfig struct {
-	supportedVersion   int
-	receiverBufferSize int
-	rateLimit          testRateLimitConfig
+	supportedVersion     int
+	receiverBufferSize   int
+	batchPeriod          time.Duration
+	maxBatchSize         int
+	compressionThreshold utils.FileSize
+	rateLimit            testRateLimitConfig
 }
 
 func (c testConfig) SupportedVersion() int {
@@ -70,6 +78,18 @@ func (c testConfig) ReceiverBufferSize() int {
 	return c.receiverBufferSize
 }
 
+func (c testConfig) BatchPeriod() time.Duration {
+	return c.batchPeriod
+}
+
+func (c testConfig) MaxBatchSize() int {
+	return c.maxBatchSize
+}
+
+func (c testConfig) CompressionThreshold() utils.FileSize {
+	return c.compressionThreshold
+}
+
 func (c testConfig) RateLimit() config.DispatcherRateLimit {
 	return c.rateLimit
 }
@@ -195,3 +215,271 @@ func TestDispatcher_RespondWithError(t *testing.T) {
 
 	require.NoError(t, dispatcher.Close())
 }
+
+// newLoopbackDispatchers returns a dispatcher sending to a receiving dispatcher, and a channel of the p2p messages
+// sent between them.
+func newLoopbackDispatchers(t *testing.T, cfg testConfig) (remotetypes.Dispatcher, p2ptypes.PeerID, *testReceiver, <-chan *remotetypes.Message) {
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	senderKey, senderID := newKeyPair(t)
+	_, receiverID := newKeyPair(t)
+	registry := commonMocks.NewCapabilitiesRegistry(t)
+
+	recvCh := make(chan p2ptypes.Message)
+	receiverPeer := mocks.NewPeer(t)
+	receiverPeer.On("Receive", mock.Anything).Return((<-chan p2ptypes.Message)(recvCh))
+	receiverPeer.On("ID", mock.Anything).Return(receiverID)
+	receiverWrapper := mocks.NewPeerWrapper(t)
+	receiverWrapper.On("GetPeer").Return(receiverPeer)
+	receiver, err := remote.NewDispatcher(cfg, receiverWrapper, mocks.NewSigner(t), registry, lggr)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(ctx))
+	t.Cleanup(func() { require.NoError(t, receiver.Close()) })
+	rcv := newReceiver()
+	require.NoError(t, receiver.SetReceiver(capId1, donId1, rcv))
+
+	sentCh := make(chan *remotetypes.Message, 100)
+	senderPeer := mocks.NewPeer(t)
+	senderPeer.On("Receive", mock.Anything).Return((<-chan p2ptypes.Message)(make(chan p2ptypes.Message)))
+	senderPeer.On("ID", mock.Anything).Return(senderID)
+	senderPeer.On("Send", receiverID, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		payload := args.Get(1).([]byte)
+		var msg remotetypes.Message
+		assert.NoError(t, proto.Unmarshal(payload, &msg))
+		sentCh <- &msg
+		recvCh <- p2ptypes.Message{Sender: senderID, Payload: payload}
+	})
+	senderWrapper := mocks.NewPeerWrapper(t)
+	senderWrapper.On("GetPeer").Return(senderPeer)
+	signer := mocks.NewSigner(t)
+	signer.On("Sign", mock.Anything).Return(func(data []byte) ([]byte, error) {
+		return ed25519.Sign(senderKey, data), nil
+	})
+	sender, err := remote.NewDispatcher(cfg, senderWrapper, signer, registry, lggr)
+	require.NoError(t, err)
+	require.NoError(t, sender.Start(ctx))
+	t.Cleanup(func() { require.NoError(t, sender.Close()) })
+
+	return sender, receiverID, rcv, sentCh
+}
+
+var batchingConfig = testConfig{
+	supportedVersion:   1,
+	receiverBufferSize: 10000,
+	rateLimit: testRateLimitConfig{
+		globalRPS:   800.0,
+		globalBurst: 100,
+		rps:         100.0,
+		burst:       100,
+	},
+}
+
+func TestDispatcher_BatchesTriggerMessages(t *testing.T) {
+	cfg := batchingConfig
+	cfg.batchPeriod = 200 * time.Millisecond
+	cfg.maxBatchSize = 100
+	sender, receiverID, rcv, sentCh := newLoopbackDispatchers(t, cfg)
+
+	for _, payload := range []string{"event1", "event2", "event3"} {
+		require.NoError(t, sender.Send(receiverID, &remotetypes.MessageBody{
+			CapabilityId:    capId1,
+			CapabilityDonId: donId1,
+			Method:          remotetypes.MethodTriggerEvent,
+			Payload:         []byte(payload),
+		}))
+	}
+	// messages of executable capabilities are sent right away, ahead of the queued trigger messages
+	require.NoError(t, sender.Send(receiverID, &remotetypes.MessageBody{
+		CapabilityId:    capId1,
+		CapabilityDonId: donId1,
+		Method:          remotetypes.MethodExecute,
+		Payload:         []byte("response"),
+	}))
+
+	sent := <-sentCh
+	assert.Empty(t, sent.Batch)
+	assert.Equal(t, "response", string((<-rcv.ch).Payload))
+
+	sent = <-sentCh
+	assert.Equal(t, remotetypes.Compression_NONE, sent.Compression)
+	var batch remotetypes.MessageBatch
+	require.NoError(t, proto.Unmarshal(sent.Batch, &batch))
+	assert.Len(t, batch.Messages, 3)
+	for _, payload := range []string{"event1", "event2", "event3"} {
+		assert.Equal(t, payload, string((<-rcv.ch).Payload))
+	}
+}
+
+func TestDispatcher_SendsFullBatchesAndCompresses(t *testing.T) {
+	cfg := batchingConfig
+	cfg.batchPeriod = time.Hour
+	cfg.maxBatchSize = 2
+	cfg.compressionThreshold = 1
+	sender, receiverID, rcv, sentCh := newLoopbackDispatchers(t, cfg)
+
+	for _, payload := range []string{"event1", "event2"} {
+		require.NoError(t, sender.Send(receiverID, &remotetypes.MessageBody{
+			CapabilityId:    capId1,
+			CapabilityDonId: donId1,
+			Method:          remotetypes.MethodTriggerEvent,
+			Payload:         []byte(payload),
+		}))
+	}
+
+	sent := <-sentCh
+	assert.Equal(t, remotetypes.Compression_ZSTD, sent.Compression)
+	for _, payload := range []string{"event1", "event2"} {
+		assert.Equal(t, payload, string((<-rcv.ch).Payload))
+	}
+}
+
+func TestDispatcher_ChargesBatchedMessagesOnce(t *testing.T) {
+	cfg := batchingConfig
+	cfg.batchPeriod = time.Hour
+	cfg.maxBatchSize = 3
+	cfg.rateLimit.rps = 0.001
+	cfg.rateLimit.burst = 3
+	sender, receiverID, rcv, _ := newLoopbackDispatchers(t, cfg)
+
+	// a full batch uses the whole burst of the sender, but no more
+	for _, payload := range []string{"event1", "event2", "event3"} {
+		sendTriggerEvent(t, sender, receiverID, payload)
+	}
+	for _, payload := range []string{"event1", "event2", "event3"} {
+		assert.Equal(t, payload, string((<-rcv.ch).Payload))
+	}
+}
+
+func TestDispatcher_ReceiveInvalidBatch(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	privKey1, peerId1 := newKeyPair(t)
+	_, peerId2 := newKeyPair(t)
+
+	peer := mocks.NewPeer(t)
+	recvCh := make(chan p2ptypes.Message)
+	peer.On("Receive", mock.Anything).Return((<-chan p2ptypes.Message)(recvCh))
+	peer.On("ID", mock.Anything).Return(peerId2)
+	wrapper := mocks.NewPeerWrapper(t)
+	wrapper.On("GetPeer").Return(peer)
+	registry := commonMocks.NewCapabilitiesRegistry(t)
+
+	dispatcher, err := remote.NewDispatcher(batchingConfig, wrapper, mocks.NewSigner(t), registry, lggr)
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Start(ctx))
+	rcv := newReceiver()
+	require.NoError(t, dispatcher.SetReceiver(capId1, donId1, rcv))
+
+	// batches which aren't signed by their sender are dropped before they are decompressed
+	_, otherPeerID := newKeyPair(t)
+	batch := []byte("not zstd")
+	forged, err := proto.Marshal(&remotetypes.Message{Signature: ed25519.Sign(privKey1, batch), Batch: batch, Compression: remotetypes.Compression_ZSTD})
+	require.NoError(t, err)
+	recvCh <- p2ptypes.Message{Sender: otherPeerID, Payload: forged}
+
+	// batches which can't be decompressed are dropped
+	recvCh <- p2ptypes.Message{Sender: peerId1, Payload: forged}
+
+	recvCh <- encodeAndSign(t, privKey1, peerId1, peerId2, capId1, donId1, []byte(payload1))
+	require.Equal(t, payload1, string((<-rcv.ch).Payload))
+	require.NoError(t, dispatcher.Close())
+}
+
+// newSenderDispatcher returns a dispatcher which isn't started yet, and a channel of the bodies of the messages it
+// sends, in order. Sending a message blocks until gate is closed.
+func newSenderDispatcher(t *testing.T, cfg testConfig, gate <-chan struct{}) (remotetypes.Dispatcher, p2ptypes.PeerID, <-chan *remotetypes.MessageBody) {
+	senderKey, senderID := newKeyPair(t)
+	_, receiverID := newKeyPair(t)
+
+	sentCh := make(chan *remotetypes.MessageBody, 2000)
+	peer := mocks.NewPeer(t)
+	peer.On("Receive", mock.Anything).Return((<-chan p2ptypes.Message)(make(chan p2ptypes.Message)))
+	peer.On("ID", mock.Anything).Return(senderID)
+	peer.On("Send", receiverID, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		body, err := remote.ValidateMessage(p2ptypes.Message{Sender: senderID, Payload: args.Get(1).([]byte)}, receiverID)
+		assert.NoError(t, err)
+		sentCh <- body
+		<-gate
+	}).Maybe()
+	wrapper := mocks.NewPeerWrapper(t)
+	wrapper.On("GetPeer").Return(peer)
+	signer := mocks.NewSigner(t)
+	signer.On("Sign", mock.Anything).Return(func(data []byte) ([]byte, error) {
+		return ed25519.Sign(senderKey, data), nil
+	})
+	sender, err := remote.NewDispatcher(cfg, wrapper, signer, commonMocks.NewCapabilitiesRegistry(t), logger.TestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, sender.Start(testutils.Context(t)))
+	return sender, receiverID, sentCh
+}
+
+func sendTriggerEvent(t *testing.T, sender remotetypes.Dispatcher, receiverID p2ptypes.PeerID, payload string) {
+	require.NoError(t, sender.Send(receiverID, &remotetypes.MessageBody{
+		CapabilityId: capId1, CapabilityDonId: donId1, Method: remotetypes.MethodTriggerEvent, Payload: []byte(payload),
+	}))
+}
+
+func TestDispatcher_SendsExecutableMessagesFirst(t *testing.T) {
+	gate := make(chan struct{})
+	sender, receiverID, sentCh := newSenderDispatcher(t, batchingConfig, gate)
+	t.Cleanup(func() { require.NoError(t, sender.Close()) })
+
+	// the messages are queued while the first one is being sent
+	sendTriggerEvent(t, sender, receiverID, "event1")
+	assert.Equal(t, "event1", string((<-sentCh).Payload))
+	sendTriggerEvent(t, sender, receiverID, "event2")
+	require.NoError(t, sender.Send(receiverID, &remotetypes.MessageBody{
+		CapabilityId: capId1, CapabilityDonId: donId1, Method: remotetypes.MethodExecute, Payload: []byte("response"),
+	}))
+	close(gate)
+
+	for _, payload := range []string{"response", "event2"} {
+		assert.Equal(t, payload, string((<-sentCh).Payload))
+	}
+}
+
+func TestDispatcher_FlushesOnClose(t *testing.T) {
+	cfg := batchingConfig
+	cfg.batchPeriod = time.Hour
+	cfg.maxBatchSize = 100
+	gate := make(chan struct{})
+	close(gate)
+	sender, receiverID, sentCh := newSenderDispatcher(t, cfg, gate)
+
+	sendTriggerEvent(t, sender, receiverID, "event1")
+	require.Never(t, func() bool {
+		return len(sentCh) > 0
+	}, 100*time.Millisecond, testutils.TestInterval)
+	require.NoError(t, sender.Close())
+
+	// the pending batch of a single message is sent as is
+	require.Len(t, sentCh, 1)
+	assert.Equal(t, "event1", string((<-sentCh).Payload))
+	require.Error(t, sender.Send(receiverID, &remotetypes.MessageBody{CapabilityId: capId1, CapabilityDonId: donId1}))
+}
+
+func TestDispatcher_SendQueueFull(t *testing.T) {
+	gate := make(chan struct{})
+	sender, receiverID, sentCh := newSenderDispatcher(t, batchingConfig, gate)
+	t.Cleanup(func() { require.NoError(t, sender.Close()) })
+	defer close(gate)
+
+	// the first message is being sent while the others fill up the queue
+	sendTriggerEvent(t, sender, receiverID, "event0")
+	<-sentCh
+	for i := 1; ; i++ {
+		err := sender.Send(receiverID, &remotetypes.MessageBody{
+			CapabilityId: capId1, CapabilityDonId: donId1, Method: remotetypes.MethodTriggerEvent, Payload: []byte("event"),
+		})
+		if err != nil {
+			require.ErrorIs(t, err, remote.ErrSendQueueFull)
+			require.Greater(t, i, 1)
+			break
+		}
+	}
+
+	// messages of executable capabilities are queued separately
+	require.NoError(t, sender.Send(receiverID, &remotetypes.MessageBody{
+		CapabilityId: capId1, CapabilityDonId: donId1, Method: remotetypes.MethodExecute, Payload: []byte("response"),
+	}))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
@@ -35,11 +36,15 @@ type client struct {
 	mutex                    sync.Mutex
 	stopCh                   services.StopChan
 	wg                       sync.WaitGroup
+
+	nReceived atomic.Uint64
+	nErrors   atomic.Uint64
 }
 
 var _ commoncap.ExecutableCapability = &client{}
 var _ types.Receiver = &client{}
 var _ services.Service = &client{}
+var _ types.StatsReporter = &client{}
 
 const expiryCheckInterval = 30 * time.Second
 
@@ -166,6 +171,7 @@ func (c *client) Execute(ctx context.Context, capReq commoncap.CapabilityRequest
 
 	resp := <-req.ResponseChan()
 	if resp.Err != nil {
+		c.nErrors.Add(1)
 		return commoncap.CapabilityResponse{}, fmt.Errorf("error executing request: %w", resp.Err)
 	}
 
@@ -192,11 +198,13 @@ func (c *client) sendRequest(req *request.ClientRequest) error {
 }
 
 func (c *client) Receive(ctx context.Context, msg *types.MessageBody) {
+	c.nReceived.Add(1)
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
 	messageID, err := GetMessageID(msg)
 	if err != nil {
+		c.nErrors.Add(1)
 		c.lggr.Errorw("invalid message ID", "err", err, "id", remote.SanitizeLogString(string(msg.MessageId)))
 		return
 	}
@@ -210,10 +218,30 @@ func (c *client) Receive(ctx context.Context, msg *types.MessageBody) {
 	}
 
 	if err := req.OnMessage(ctx, msg); err != nil {
+		c.nErrors.Add(1)
 		c.lggr.Errorw("failed to add response to request", "messageID", messageID, "err", err)
 	}
 }
 
+// Stats returns a snapshot of the state of the client. Its peers are the members of the capability DON.
+func (c *client) Stats() types.ShimStats {
+	c.mutex.Lock()
+	nPending := len(c.requestIDToCallerRequest)
+	c.mutex.Unlock()
+	stats := types.ShimStats{
+		Kind:             types.ShimExecutableClient,
+		CapabilityID:     c.remoteCapabilityInfo.ID,
+		PendingRequests:  nPending,
+		MessagesReceived: c.nReceived.Load(),
+		Errors:           c.nErrors.Load(),
+	}
+	if don := c.remoteCapabilityInfo.DON; don != nil {
+		stats.DonID = don.ID
+		stats.Peers = don.Members
+	}
+	return stats
+}
+
 func (c *client) Ready() error {
 	return nil
 }
@@ -5,7 +5,10 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
@@ -47,10 +50,14 @@ type server struct {
 	receiveLock sync.Mutex
 	stopCh      services.StopChan
 	wg          sync.WaitGroup
+
+	nReceived atomic.Uint64
+	nErrors   atomic.Uint64
 }
 
 var _ types.Receiver = &server{}
 var _ services.Service = &server{}
+var _ types.StatsReporter = &server{}
 
 type requestAndMsgID struct {
 	request   *request.ServerRequest
@@ -124,6 +131,7 @@ func (r *server) expireRequests() {
 		if executeReq.request.Expired() {
 			err := executeReq.request.Cancel(types.Error_TIMEOUT, "request expired by executable server")
 			if err != nil {
+				r.nErrors.Add(1)
 				r.lggr.Errorw("failed to cancel request", "request", executeReq, "err", err)
 			}
 			delete(r.requestIDToRequest, requestID)
@@ -133,23 +141,27 @@ func (r *server) expireRequests() {
 }
 
 func (r *server) Receive(ctx context.Context, msg *types.MessageBody) {
+	r.nReceived.Add(1)
 	r.receiveLock.Lock()
 	defer r.receiveLock.Unlock()
 
 	switch msg.Method {
 	case types.MethodExecute, types.MethodRegisterToWorkflow, types.MethodUnregisterFromWorkflow:
 	default:
+		r.nErrors.Add(1)
 		r.lggr.Errorw("received request for unsupported method type", "method", remote.SanitizeLogString(msg.Method))
 	}
 
 	messageId, err := GetMessageID(msg)
 	if err != nil {
+		r.nErrors.Add(1)
 		r.lggr.Errorw("invalid message id", "err", err, "id", remote.SanitizeLogString(string(msg.MessageId)))
 		return
 	}
 
 	msgHash, err := r.getMessageHash(msg)
 	if err != nil {
+		r.nErrors.Add(1)
 		r.lggr.Errorw("failed to get message hash", "err", err)
 		return
 	}
@@ -176,6 +188,7 @@ func (r *server) Receive(ctx context.Context, msg *types.MessageBody) {
 	if _, ok := r.requestIDToRequest[requestID]; !ok {
 		callingDon, ok := r.workflowDONs[msg.CallerDonId]
 		if !ok {
+			r.nErrors.Add(1)
 			r.lggr.Errorw("received request from unregistered don", "donId", msg.CallerDonId)
 			return
 		}
@@ -191,6 +204,7 @@ func (r *server) Receive(ctx context.Context, msg *types.MessageBody) {
 
 	err = reqAndMsgID.request.OnMessage(ctx, msg)
 	if err != nil {
+		r.nErrors.Add(1)
 		r.lggr.Errorw("request failed to OnMessage new message", "messageID", reqAndMsgID.messageID, "err", err)
 	}
 }
@@ -223,6 +237,26 @@ func GetMessageID(msg *types.MessageBody) (string, error) {
 	return idStr, nil
 }
 
+// Stats returns a snapshot of the state of the server. Its peers are the members of the workflow DONs.
+func (r *server) Stats() types.ShimStats {
+	var peers []p2ptypes.PeerID
+	for _, id := range slices.Sorted(maps.Keys(r.workflowDONs)) {
+		peers = append(peers, r.workflowDONs[id].Members...)
+	}
+	r.receiveLock.Lock()
+	nPending := len(r.requestIDToRequest)
+	r.receiveLock.Unlock()
+	return types.ShimStats{
+		Kind:             types.ShimExecutableServer,
+		CapabilityID:     r.capInfo.ID,
+		DonID:            r.localDonInfo.ID,
+		Peers:            peers,
+		PendingRequests:  nPending,
+		MessagesReceived: r.nReceived.Load(),
+		Errors:           r.nErrors.Load(),
+	}
+}
+
 func (r *server) Ready() error {
 	return nil
 }
@@ -0,0 +1,329 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/datastreams"
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/pb"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	remotetypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	// TriggerAggregatorConfigKey is the key of the TriggerAggregatorConfig in the default config of remote trigger
+	// capabilities in the capabilities registry. It is removed from the default config before it reaches workflows.
+	TriggerAggregatorConfigKey = "remoteTriggerAggregator"
+
+	// AggregatorMode requires identical trigger events from enough nodes.
+	AggregatorMode = "mode"
+	// AggregatorMedian aggregates the outputs of trigger events field by field, taking the median of numeric fields.
+	AggregatorMedian = "median"
+	// AggregatorSignedQuorum requires identical trigger events, whose signatures are valid, from enough nodes.
+	AggregatorSignedQuorum = "signed-quorum"
+	// AggregatorFirstValidSigned takes the first trigger event whose signatures are valid.
+	AggregatorFirstValidSigned = "first-valid-signed"
+)
+
+// TriggerAggregatorConfig selects the aggregator of the trigger events of a remote trigger capability.
+type TriggerAggregatorConfig struct {
+	// Type is the aggregator, one of mode, median, signed-quorum and first-valid-signed. It defaults to mode.
+	Type string `mapstructure:"type"`
+	// MinResponses is the number of responses the mode, median and signed-quorum aggregators require, which defaults
+	// to F+1. The median of numeric fields is only guaranteed to be within the values of honest nodes with at least
+	// 2F+1 responses, which requires MinResponsesToAggregate to be at least 2F+1 as well.
+	MinResponses uint32 `mapstructure:"minResponses"`
+}
+
+// TriggerEventVerifier verifies the signatures of trigger events, e.g. of the reports they carry.
+type TriggerEventVerifier interface {
+	Verify(event commoncap.TriggerEvent) error
+}
+
+// NewTriggerAggregator returns the aggregator selected by config for the trigger events of a capability DON with f
+// faulty nodes. The signed aggregators require a verifier.
+func NewTriggerAggregator(config TriggerAggregatorConfig, f uint8, verifier TriggerEventVerifier, lggr logger.Logger) (remotetypes.Aggregator, error) {
+	minResponses := config.MinResponses
+	if minResponses == 0 {
+		minResponses = uint32(f) + 1
+	}
+	switch config.Type {
+	case "", AggregatorMode:
+		return NewDefaultModeAggregator(minResponses), nil
+	case AggregatorMedian:
+		return NewMedianAggregator(minResponses), nil
+	case AggregatorSignedQuorum, AggregatorFirstValidSigned:
+		if verifier == nil {
+			return nil, fmt.Errorf("aggregator %s requires the signatures of trigger events to be verifiable", config.Type)
+		}
+		if config.Type == AggregatorSignedQuorum {
+			return NewSignedQuorumAggregator(verifier, minResponses, lggr), nil
+		}
+		return NewFirstValidSignedAggregator(verifier, lggr), nil
+	default:
+		return nil, fmt.Errorf("unknown trigger aggregator %q", config.Type)
+	}
+}
+
+// medianAggregator aggregates the outputs of trigger events field by field: numeric and time fields take the median
+// of the values reported by the nodes, and other fields the value reported identically by enough nodes. Fields
+// reported by too few nodes are dropped. This suits triggers whose nodes observe slightly different values, e.g.
+// prices.
+type medianAggregator struct {
+	minResponses uint32
+}
+
+var _ remotetypes.Aggregator = &medianAggregator{}
+
+func NewMedianAggregator(minResponses uint32) *medianAggregator {
+	return &medianAggregator{minResponses: minResponses}
+}
+
+func (a *medianAggregator) Aggregate(eventID string, responses [][]byte) (commoncap.TriggerResponse, error) {
+	events := unmarshalTriggerEvents(responses, eventID)
+	if uint32(len(events)) < a.minResponses {
+		return commoncap.TriggerResponse{}, fmt.Errorf("not enough valid responses: %d < %d", len(events), a.minResponses)
+	}
+
+	triggerType, err := modeOf(events, a.minResponses, func(e commoncap.TriggerEvent) string { return e.TriggerType })
+	if err != nil {
+		return commoncap.TriggerResponse{}, fmt.Errorf("failed to aggregate trigger type: %w", err)
+	}
+	outputs := make([]*values.Map, 0, len(events))
+	for _, e := range events {
+		if e.TriggerType == triggerType && e.Outputs != nil {
+			outputs = append(outputs, e.Outputs)
+		}
+	}
+	if uint32(len(outputs)) < a.minResponses {
+		return commoncap.TriggerResponse{}, fmt.Errorf("not enough responses with outputs: %d < %d", len(outputs), a.minResponses)
+	}
+	aggregated, err := a.aggregateMaps(outputs)
+	if err != nil {
+		return commoncap.TriggerResponse{}, err
+	}
+	return commoncap.TriggerResponse{Event: commoncap.TriggerEvent{TriggerType: triggerType, ID: eventID, Outputs: aggregated}}, nil
+}
+
+func (a *medianAggregator) aggregateMaps(maps []*values.Map) (*values.Map, error) {
+	fields := map[string][]values.Value{}
+	for _, m := range maps {
+		for k, v := range m.Underlying {
+			fields[k] = append(fields[k], v)
+		}
+	}
+
+	aggregated := values.EmptyMap()
+	for k, vs := range fields {
+		if uint32(len(vs)) < a.minResponses {
+			continue
+		}
+		v, err := a.aggregateValues(vs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate field %s: %w", k, err)
+		}
+		aggregated.Underlying[k] = v
+	}
+	return aggregated, nil
+}
+
+func (a *medianAggregator) aggregateValues(vs []values.Value) (values.Value, error) {
+	if maps, ok := allOfType[*values.Map](vs); ok {
+		return a.aggregateMaps(maps)
+	}
+	if v, ok := median(vs); ok {
+		return v, nil
+	}
+
+	// other values must be identical
+	encoded := make([]string, len(vs))
+	for i, v := range vs {
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(values.Proto(v))
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = string(b)
+	}
+	found, err := modeOf(encoded, a.minResponses, func(e string) string { return e })
+	if err != nil {
+		return nil, err
+	}
+	return vs[slices.Index(encoded, found)], nil
+}
+
+// median returns the median of numeric or time values of the same type. The lower median is taken for an even number
+// of values, so that the median is always a value reported by a node.
+func median(vs []values.Value) (values.Value, bool) {
+	var less func(i, j int) bool
+	switch {
+	case isAllOfType[*values.Int64](vs):
+		less = func(i, j int) bool { return vs[i].(*values.Int64).Underlying < vs[j].(*values.Int64).Underlying }
+	case isAllOfType[*values.Float64](vs):
+		less = func(i, j int) bool { return vs[i].(*values.Float64).Underlying < vs[j].(*values.Float64).Underlying }
+	case isAllOfType[*values.Decimal](vs):
+		less = func(i, j int) bool {
+			return vs[i].(*values.Decimal).Underlying.LessThan(vs[j].(*values.Decimal).Underlying)
+		}
+	case isAllOfType[*values.BigInt](vs):
+		less = func(i, j int) bool {
+			return vs[i].(*values.BigInt).Underlying.Cmp(vs[j].(*values.BigInt).Underlying) < 0
+		}
+	case isAllOfType[*values.Time](vs):
+		less = func(i, j int) bool { return vs[i].(*values.Time).Underlying.Before(vs[j].(*values.Time).Underlying) }
+	default:
+		return nil, false
+	}
+	vs = append([]values.Value(nil), vs...)
+	sort.SliceStable(vs, less)
+	return vs[(len(vs)-1)/2], true
+}
+
+func allOfType[T values.Value](vs []values.Value) ([]T, bool) {
+	ts := make([]T, 0, len(vs))
+	for _, v := range vs {
+		t, ok := v.(T)
+		if !ok {
+			return nil, false
+		}
+		ts = append(ts, t)
+	}
+	return ts, true
+}
+
+func isAllOfType[T values.Value](vs []values.Value) bool {
+	_, ok := allOfType[T](vs)
+	return ok
+}
+
+// signedQuorumAggregator requires identical trigger events, whose signatures are valid, from enough nodes. Unlike the
+// mode aggregator, responses of faulty nodes with invalid signatures never count towards the quorum.
+type signedQuorumAggregator struct {
+	verifier     TriggerEventVerifier
+	minResponses uint32
+	lggr         logger.Logger
+}
+
+var _ remotetypes.Aggregator = &signedQuorumAggregator{}
+
+func NewSignedQuorumAggregator(verifier TriggerEventVerifier, minResponses uint32, lggr logger.Logger) *signedQuorumAggregator {
+	return &signedQuorumAggregator{verifier: verifier, minResponses: minResponses, lggr: lggr.Named("SignedQuorumAggregator")}
+}
+
+func (a *signedQuorumAggregator) Aggregate(eventID string, responses [][]byte) (commoncap.TriggerResponse, error) {
+	var verified [][]byte
+	for _, response := range responses {
+		if _, err := verifyTriggerResponse(a.verifier, response); err != nil {
+			a.lggr.Errorw("invalid trigger event (faulty sender?)", "triggerEventID", eventID, "err", err)
+			continue
+		}
+		verified = append(verified, response)
+	}
+	found, err := AggregateModeRaw(verified, a.minResponses)
+	if err != nil {
+		return commoncap.TriggerResponse{}, fmt.Errorf("failed to aggregate responses, err: %w", err)
+	}
+	return pb.UnmarshalTriggerResponse(found)
+}
+
+// firstValidSignedAggregator takes the first trigger event whose signatures are valid. It suits triggers whose events
+// are signed by a quorum of the capability DON, e.g. with OCR, so that a single valid event can be trusted.
+type firstValidSignedAggregator struct {
+	verifier TriggerEventVerifier
+	lggr     logger.Logger
+}
+
+var _ remotetypes.Aggregator = &firstValidSignedAggregator{}
+
+func NewFirstValidSignedAggregator(verifier TriggerEventVerifier, lggr logger.Logger) *firstValidSignedAggregator {
+	return &firstValidSignedAggregator{verifier: verifier, lggr: lggr.Named("FirstValidSignedAggregator")}
+}
+
+func (a *firstValidSignedAggregator) Aggregate(eventID string, responses [][]byte) (commoncap.TriggerResponse, error) {
+	for _, response := range responses {
+		resp, err := verifyTriggerResponse(a.verifier, response)
+		if err != nil {
+			a.lggr.Errorw("invalid trigger event (faulty sender?)", "triggerEventID", eventID, "err", err)
+			continue
+		}
+		return resp, nil
+	}
+	return commoncap.TriggerResponse{}, errors.New("no valid signed responses found")
+}
+
+func verifyTriggerResponse(verifier TriggerEventVerifier, response []byte) (commoncap.TriggerResponse, error) {
+	resp, err := pb.UnmarshalTriggerResponse(response)
+	if err != nil {
+		return commoncap.TriggerResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if resp.Err != nil {
+		return commoncap.TriggerResponse{}, fmt.Errorf("response is an error: %w", resp.Err)
+	}
+	if err := verifier.Verify(resp.Event); err != nil {
+		return commoncap.TriggerResponse{}, err
+	}
+	return resp, nil
+}
+
+// unmarshalTriggerEvents returns the events of the responses of the trigger event eventID, skipping invalid and error
+// responses.
+func unmarshalTriggerEvents(responses [][]byte, eventID string) []commoncap.TriggerEvent {
+	var events []commoncap.TriggerEvent
+	for _, response := range responses {
+		resp, err := pb.UnmarshalTriggerResponse(response)
+		if err != nil || resp.Err != nil || resp.Event.ID != eventID {
+			continue
+		}
+		events = append(events, resp.Event)
+	}
+	return events
+}
+
+// modeOf returns the key of the elements shared by at least minCount of them.
+func modeOf[T any](elems []T, minCount uint32, key func(T) string) (string, error) {
+	counts := map[string]uint32{}
+	for _, e := range elems {
+		k := key(e)
+		counts[k]++
+		if counts[k] >= minCount {
+			return k, nil
+		}
+	}
+	return "", errors.New("not enough identical responses found")
+}
+
+// reportVerifier verifies that every report carried by a trigger event is signed by enough of the allowed signers.
+type reportVerifier struct {
+	codec                 datastreams.ReportCodec
+	allowedSigners        [][]byte
+	minRequiredSignatures int
+}
+
+var _ TriggerEventVerifier = &reportVerifier{}
+
+// NewReportVerifier returns a TriggerEventVerifier of events carrying reports encoded with codec, e.g. of streams
+// triggers.
+func NewReportVerifier(codec datastreams.ReportCodec, allowedSigners [][]byte, minRequiredSignatures int) *reportVerifier {
+	return &reportVerifier{codec: codec, allowedSigners: allowedSigners, minRequiredSignatures: minRequiredSignatures}
+}
+
+func (v *reportVerifier) Verify(event commoncap.TriggerEvent) error {
+	reports, err := v.codec.Unwrap(event.Outputs)
+	if err != nil {
+		return fmt.Errorf("failed to unwrap reports: %w", err)
+	}
+	if len(reports) == 0 {
+		return errors.New("no reports found")
+	}
+	for _, report := range reports {
+		if err := v.codec.Validate(report, v.allowedSigners, v.minRequiredSignatures); err != nil {
+			return fmt.Errorf("invalid report for feed %s: %w", report.FeedID, err)
+		}
+	}
+	return nil
+}
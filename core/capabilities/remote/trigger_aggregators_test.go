@@ -0,0 +1,211 @@
+package remote_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/pb"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/remote"
+	remotetypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types"
+	remoteMocks "github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
+)
+
+const triggerEventID = "event-1"
+
+func marshalTriggerEvent(t *testing.T, outputs map[string]any) []byte {
+	t.Helper()
+	m, err := values.NewMap(outputs)
+	require.NoError(t, err)
+	marshaled, err := pb.MarshalTriggerResponse(commoncap.TriggerResponse{
+		Event: commoncap.TriggerEvent{TriggerType: "price-trigger@1.0.0", ID: triggerEventID, Outputs: m},
+	})
+	require.NoError(t, err)
+	return marshaled
+}
+
+func TestMedianAggregator_Aggregate(t *testing.T) {
+	ts := time.Unix(1700000000, 0).UTC()
+	responses := [][]byte{
+		marshalTriggerEvent(t, map[string]any{
+			"feed":   "ETH/USD",
+			"price":  decimal.RequireFromString("3000.5"),
+			"volume": int64(10),
+			"ts":     ts,
+			"nested": map[string]any{"ratio": 0.5, "big": big.NewInt(100)},
+		}),
+		marshalTriggerEvent(t, map[string]any{
+			"feed":   "ETH/USD",
+			"price":  decimal.RequireFromString("3001"),
+			"volume": int64(12),
+			"ts":     ts.Add(time.Second),
+			"nested": map[string]any{"ratio": 0.7, "big": big.NewInt(300)},
+		}),
+		marshalTriggerEvent(t, map[string]any{
+			"feed":   "ETH/USD",
+			"price":  decimal.RequireFromString("1000000"), // faulty node
+			"volume": int64(11),
+			"ts":     ts.Add(2 * time.Second),
+			"nested": map[string]any{"ratio": 0.6, "big": big.NewInt(200)},
+			"extra":  "only one node reports this",
+		}),
+	}
+
+	agg := remote.NewMedianAggregator(3)
+	resp, err := agg.Aggregate(triggerEventID, responses)
+	require.NoError(t, err)
+	assert.Equal(t, "price-trigger@1.0.0", resp.Event.TriggerType)
+	assert.Equal(t, triggerEventID, resp.Event.ID)
+
+	expected, err := values.NewMap(map[string]any{
+		"feed":   "ETH/USD",
+		"price":  decimal.RequireFromString("3001"),
+		"volume": int64(11),
+		"ts":     ts.Add(time.Second),
+		"nested": map[string]any{"ratio": 0.6, "big": big.NewInt(200)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp.Event.Outputs)
+
+	t.Run("not enough responses", func(t *testing.T) {
+		_, err := agg.Aggregate(triggerEventID, responses[:2])
+		require.ErrorContains(t, err, "not enough valid responses")
+	})
+
+	t.Run("differing non-numeric fields", func(t *testing.T) {
+		_, err := agg.Aggregate(triggerEventID, append(responses[:2:2], marshalTriggerEvent(t, map[string]any{
+			"feed":   "BTC/USD",
+			"price":  decimal.RequireFromString("3000"),
+			"volume": int64(11),
+			"ts":     ts,
+			"nested": map[string]any{"ratio": 0.6, "big": big.NewInt(200)},
+		})))
+		require.ErrorContains(t, err, "failed to aggregate field feed")
+	})
+
+	t.Run("responses for other events are ignored", func(t *testing.T) {
+		_, err := agg.Aggregate("event-2", responses)
+		require.ErrorContains(t, err, "not enough valid responses")
+	})
+}
+
+// signatureVerifier accepts the events whose outputs carry a valid signature.
+type signatureVerifier struct{}
+
+func (signatureVerifier) Verify(event commoncap.TriggerEvent) error {
+	if sig, ok := event.Outputs.Underlying["signature"].(*values.String); !ok || sig.Underlying != "valid" {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func TestSignedQuorumAggregator_Aggregate(t *testing.T) {
+	valid := marshalTriggerEvent(t, map[string]any{"price": int64(1), "signature": "valid"})
+	invalid := marshalTriggerEvent(t, map[string]any{"price": int64(1), "signature": "forged"})
+
+	agg := remote.NewSignedQuorumAggregator(signatureVerifier{}, 2, logger.TestLogger(t))
+	_, err := agg.Aggregate(triggerEventID, [][]byte{valid, invalid, invalid})
+	require.Error(t, err)
+
+	resp, err := agg.Aggregate(triggerEventID, [][]byte{invalid, valid, valid})
+	require.NoError(t, err)
+	expected, err := pb.UnmarshalTriggerResponse(valid)
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestFirstValidSignedAggregator_Aggregate(t *testing.T) {
+	valid := marshalTriggerEvent(t, map[string]any{"price": int64(1), "signature": "valid"})
+	invalid := marshalTriggerEvent(t, map[string]any{"price": int64(2), "signature": "forged"})
+
+	agg := remote.NewFirstValidSignedAggregator(signatureVerifier{}, logger.TestLogger(t))
+	_, err := agg.Aggregate(triggerEventID, [][]byte{invalid, []byte("garbage")})
+	require.Error(t, err)
+
+	resp, err := agg.Aggregate(triggerEventID, [][]byte{invalid, valid})
+	require.NoError(t, err)
+	expected, err := pb.UnmarshalTriggerResponse(valid)
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestNewTriggerAggregator(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	for _, typ := range []string{"", remote.AggregatorMode, remote.AggregatorMedian} {
+		_, err := remote.NewTriggerAggregator(remote.TriggerAggregatorConfig{Type: typ}, 1, nil, lggr)
+		require.NoError(t, err, typ)
+	}
+	for _, typ := range []string{remote.AggregatorSignedQuorum, remote.AggregatorFirstValidSigned} {
+		_, err := remote.NewTriggerAggregator(remote.TriggerAggregatorConfig{Type: typ}, 1, nil, lggr)
+		require.ErrorContains(t, err, "requires the signatures", typ)
+		_, err = remote.NewTriggerAggregator(remote.TriggerAggregatorConfig{Type: typ}, 1, signatureVerifier{}, lggr)
+		require.NoError(t, err, typ)
+	}
+	_, err := remote.NewTriggerAggregator(remote.TriggerAggregatorConfig{Type: "average"}, 1, nil, lggr)
+	require.ErrorContains(t, err, "unknown trigger aggregator")
+}
+
+func TestTriggerSubscriber_MedianAggregator(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	capInfo := commoncap.CapabilityInfo{
+		ID:             "price-trigger@1.0.0",
+		CapabilityType: commoncap.CapabilityTypeTrigger,
+		Description:    "Remote Trigger",
+	}
+	capDonPeers := make([]p2ptypes.PeerID, 3)
+	for i := range capDonPeers {
+		capDonPeers[i] = p2ptypes.PeerID(testutils.Random32Byte())
+	}
+	capDonInfo := commoncap.DON{ID: 1, Members: capDonPeers, F: 1}
+	workflowDonInfo := commoncap.DON{ID: 2, Members: []p2ptypes.PeerID{p2ptypes.PeerID(testutils.Random32Byte())}}
+
+	dispatcher := remoteMocks.NewDispatcher(t)
+	dispatcher.On("Send", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	config := &commoncap.RemoteTriggerConfig{
+		RegistrationRefresh:     100 * time.Millisecond,
+		RegistrationExpiry:      100 * time.Second,
+		MinResponsesToAggregate: 3,
+		MessageExpiry:           100 * time.Second,
+	}
+	aggregator, err := remote.NewTriggerAggregator(remote.TriggerAggregatorConfig{Type: remote.AggregatorMedian, MinResponses: 3}, capDonInfo.F, nil, lggr)
+	require.NoError(t, err)
+	subscriber := remote.NewTriggerSubscriber(config, capInfo, capDonInfo, workflowDonInfo, dispatcher, aggregator, lggr)
+	require.NoError(t, subscriber.Start(ctx))
+	defer func() { require.NoError(t, subscriber.Close()) }()
+
+	req := commoncap.TriggerRegistrationRequest{Metadata: commoncap.RequestMetadata{WorkflowID: workflowID1}}
+	callbackCh, err := subscriber.RegisterTrigger(ctx, req)
+	require.NoError(t, err)
+
+	for i, price := range []int64{101, 99, 100} {
+		subscriber.Receive(ctx, &remotetypes.MessageBody{
+			Sender: capDonPeers[i][:],
+			Method: remotetypes.MethodTriggerEvent,
+			Metadata: &remotetypes.MessageBody_TriggerEventMetadata{
+				TriggerEventMetadata: &remotetypes.TriggerEventMetadata{
+					TriggerEventId: triggerEventID,
+					WorkflowIds:    []string{workflowID1},
+				},
+			},
+			Payload: marshalTriggerEvent(t, map[string]any{"price": price}),
+		})
+	}
+
+	response := <-callbackCh
+	expected, err := values.NewMap(map[string]any{"price": int64(100)})
+	require.NoError(t, err)
+	require.Equal(t, expected, response.Event.Outputs)
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
+	"maps"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
@@ -39,6 +42,9 @@ type triggerPublisher struct {
 	stopCh          services.StopChan
 	wg              sync.WaitGroup
 	lggr            logger.Logger
+
+	nReceived atomic.Uint64
+	nErrors   atomic.Uint64
 }
 
 type registrationKey struct {
@@ -59,6 +65,7 @@ type batchedResponse struct {
 }
 
 var _ types.ReceiverService = &triggerPublisher{}
+var _ types.StatsReporter = &triggerPublisher{}
 
 const minAllowedBatchCollectionPeriod = 10 * time.Millisecond
 
@@ -105,8 +112,10 @@ func (p *triggerPublisher) Start(ctx context.Context) error {
 }
 
 func (p *triggerPublisher) Receive(_ context.Context, msg *types.MessageBody) {
+	p.nReceived.Add(1)
 	sender, err := ToPeerID(msg.Sender)
 	if err != nil {
+		p.nErrors.Add(1)
 		p.lggr.Errorw("failed to convert message sender to PeerID", "err", err)
 		return
 	}
@@ -114,19 +123,23 @@ func (p *triggerPublisher) Receive(_ context.Context, msg *types.MessageBody) {
 	if msg.Method == types.MethodRegisterTrigger {
 		req, err := pb.UnmarshalTriggerRegistrationRequest(msg.Payload)
 		if err != nil {
+			p.nErrors.Add(1)
 			p.lggr.Errorw("failed to unmarshal trigger registration request", "capabilityId", p.capInfo.ID, "err", err)
 			return
 		}
 		callerDon, ok := p.workflowDONs[msg.CallerDonId]
 		if !ok {
+			p.nErrors.Add(1)
 			p.lggr.Errorw("received a message from unsupported workflow DON", "capabilityId", p.capInfo.ID, "callerDonId", msg.CallerDonId)
 			return
 		}
 		if !p.membersCache[msg.CallerDonId][sender] {
+			p.nErrors.Add(1)
 			p.lggr.Errorw("sender not a member of its workflow DON", "capabilityId", p.capInfo.ID, "callerDonId", msg.CallerDonId, "sender", sender)
 			return
 		}
 		if err = validation.ValidateWorkflowOrExecutionID(req.Metadata.WorkflowID); err != nil {
+			p.nErrors.Add(1)
 			p.lggr.Errorw("received trigger request with invalid workflow ID", "capabilityId", p.capInfo.ID, "workflowId", SanitizeLogString(req.Metadata.WorkflowID), "err", err)
 			return
 		}
@@ -150,11 +163,13 @@ func (p *triggerPublisher) Receive(_ context.Context, msg *types.MessageBody) {
 		}
 		aggregated, err := AggregateModeRaw(payloads, uint32(callerDon.F+1))
 		if err != nil {
+			p.nErrors.Add(1)
 			p.lggr.Errorw("failed to aggregate trigger registrations", "capabilityId", p.capInfo.ID, "workflowId", req.Metadata.WorkflowID, "err", err)
 			return
 		}
 		unmarshaled, err := pb.UnmarshalTriggerRegistrationRequest(aggregated)
 		if err != nil {
+			p.nErrors.Add(1)
 			p.lggr.Errorw("failed to unmarshal request", "capabilityId", p.capInfo.ID, "err", err)
 			return
 		}
@@ -170,13 +185,35 @@ func (p *triggerPublisher) Receive(_ context.Context, msg *types.MessageBody) {
 			go p.triggerEventLoop(callbackCh, key)
 			p.lggr.Debugw("updated trigger registration", "capabilityId", p.capInfo.ID, "workflowId", req.Metadata.WorkflowID)
 		} else {
+			p.nErrors.Add(1)
 			p.lggr.Errorw("failed to register trigger", "capabilityId", p.capInfo.ID, "workflowId", req.Metadata.WorkflowID, "err", err)
 		}
 	} else {
+		p.nErrors.Add(1)
 		p.lggr.Errorw("received trigger request with unknown method", "method", SanitizeLogString(msg.Method), "sender", sender)
 	}
 }
 
+// Stats returns a snapshot of the state of the publisher. Its peers are the members of the workflow DONs.
+func (p *triggerPublisher) Stats() types.ShimStats {
+	var peers []p2ptypes.PeerID
+	for _, id := range slices.Sorted(maps.Keys(p.workflowDONs)) {
+		peers = append(peers, p.workflowDONs[id].Members...)
+	}
+	p.mu.RLock()
+	nRegistrations := len(p.registrations)
+	p.mu.RUnlock()
+	return types.ShimStats{
+		Kind:             types.ShimTriggerPublisher,
+		CapabilityID:     p.capInfo.ID,
+		DonID:            p.capDonInfo.ID,
+		Peers:            peers,
+		Registrations:    nRegistrations,
+		MessagesReceived: p.nReceived.Load(),
+		Errors:           p.nErrors.Load(),
+	}
+}
+
 func (p *triggerPublisher) registrationCleanupLoop() {
 	defer p.wg.Done()
 	ticker := time.NewTicker(p.config.RegistrationExpiry)
@@ -290,6 +327,7 @@ func (p *triggerPublisher) sendBatch(resp *batchedResponse) {
 		for _, peerID := range p.workflowDONs[resp.callerDonID].Members {
 			err := p.dispatcher.Send(peerID, msg)
 			if err != nil {
+				p.nErrors.Add(1)
 				p.lggr.Errorw("failed to send trigger event", "capabilityId", p.capInfo.ID, "peerID", peerID, "err", err)
 			}
 		}
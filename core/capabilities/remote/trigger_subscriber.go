@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
@@ -35,6 +36,9 @@ type triggerSubscriber struct {
 	stopCh              services.StopChan
 	wg                  sync.WaitGroup
 	lggr                logger.Logger
+
+	nReceived atomic.Uint64
+	nErrors   atomic.Uint64
 }
 
 type triggerEventKey struct {
@@ -55,6 +59,7 @@ type TriggerSubscriber interface {
 var _ commoncap.TriggerCapability = &triggerSubscriber{}
 var _ types.Receiver = &triggerSubscriber{}
 var _ services.Service = &triggerSubscriber{}
+var _ types.StatsReporter = &triggerSubscriber{}
 
 // TODO makes this configurable with a default
 const (
@@ -156,6 +161,7 @@ func (s *triggerSubscriber) registrationLoop() {
 					}
 					err := s.dispatcher.Send(peerID, m)
 					if err != nil {
+						s.nErrors.Add(1)
 						s.lggr.Errorw("failed to send message", "capabilityId", s.capInfo.ID, "donId", s.capDonInfo.ID, "peerId", peerID, "err", err)
 					}
 				}
@@ -180,23 +186,28 @@ func (s *triggerSubscriber) UnregisterTrigger(ctx context.Context, request commo
 }
 
 func (s *triggerSubscriber) Receive(_ context.Context, msg *types.MessageBody) {
+	s.nReceived.Add(1)
 	sender, err := ToPeerID(msg.Sender)
 	if err != nil {
+		s.nErrors.Add(1)
 		s.lggr.Errorw("failed to convert message sender to PeerID", "err", err)
 		return
 	}
 
 	if _, found := s.capDonMembers[sender]; !found {
+		s.nErrors.Add(1)
 		s.lggr.Errorw("received message from unexpected node", "capabilityId", s.capInfo.ID, "sender", sender)
 		return
 	}
 	if msg.Method == types.MethodTriggerEvent {
 		meta := msg.GetTriggerEventMetadata()
 		if meta == nil {
+			s.nErrors.Add(1)
 			s.lggr.Errorw("received message with invalid trigger metadata", "capabilityId", s.capInfo.ID, "sender", sender)
 			return
 		}
 		if len(meta.WorkflowIds) > maxBatchedWorkflowIDs {
+			s.nErrors.Add(1)
 			s.lggr.Errorw("received message with too many workflow IDs - truncating", "capabilityId", s.capInfo.ID, "nWorkflows", len(meta.WorkflowIds), "sender", sender)
 			meta.WorkflowIds = meta.WorkflowIds[:maxBatchedWorkflowIDs]
 		}
@@ -205,6 +216,7 @@ func (s *triggerSubscriber) Receive(_ context.Context, msg *types.MessageBody) {
 			registration, found := s.registeredWorkflows[workflowId]
 			s.mu.RUnlock()
 			if !found {
+				s.nErrors.Add(1)
 				s.lggr.Errorw("received message for unregistered workflow", "capabilityId", s.capInfo.ID, "workflowID", SanitizeLogString(workflowId), "sender", sender)
 				continue
 			}
@@ -225,6 +237,7 @@ func (s *triggerSubscriber) Receive(_ context.Context, msg *types.MessageBody) {
 				s.lggr.Debugw("trigger event ready to aggregate", "triggerEventID", meta.TriggerEventId, "capabilityId", s.capInfo.ID, "workflowId", workflowId)
 				aggregatedResponse, err := s.aggregator.Aggregate(meta.TriggerEventId, payloads)
 				if err != nil {
+					s.nErrors.Add(1)
 					s.lggr.Errorw("failed to aggregate responses", "triggerEventID", meta.TriggerEventId, "capabilityId", s.capInfo.ID, "workflowId", workflowId, "err", err)
 					continue
 				}
@@ -233,10 +246,27 @@ func (s *triggerSubscriber) Receive(_ context.Context, msg *types.MessageBody) {
 			}
 		}
 	} else {
+		s.nErrors.Add(1)
 		s.lggr.Errorw("received trigger event with unknown method", "method", SanitizeLogString(msg.Method), "sender", sender)
 	}
 }
 
+// Stats returns a snapshot of the state of the subscriber. Its peers are the members of the capability DON.
+func (s *triggerSubscriber) Stats() types.ShimStats {
+	s.mu.RLock()
+	nRegistrations := len(s.registeredWorkflows)
+	s.mu.RUnlock()
+	return types.ShimStats{
+		Kind:             types.ShimTriggerSubscriber,
+		CapabilityID:     s.capInfo.ID,
+		DonID:            s.capDonInfo.ID,
+		Peers:            s.capDonInfo.Members,
+		Registrations:    nRegistrations,
+		MessagesReceived: s.nReceived.Load(),
+		Errors:           s.nErrors.Load(),
+	}
+}
+
 func (s *triggerSubscriber) eventCleanupLoop() {
 	defer s.wg.Done()
 	ticker := time.NewTicker(s.config.MessageExpiry)
@@ -25,6 +25,7 @@ type Dispatcher interface {
 	services.Service
 	SetReceiver(capabilityId string, donId uint32, receiver Receiver) error
 	RemoveReceiver(capabilityId string, donId uint32)
+	// Send queues the message to be sent to the peer. Errors of sending a queued message are not returned.
 	Send(peerID p2ptypes.PeerID, msgBody *MessageBody) error
 }
 
@@ -47,3 +48,32 @@ type DON struct {
 	Members []p2ptypes.PeerID
 	F       uint8
 }
+
+// Kinds of shims, i.e. of the local ends of remote capabilities created by the launcher.
+const (
+	ShimTriggerPublisher  = "trigger-publisher"
+	ShimTriggerSubscriber = "trigger-subscriber"
+	ShimExecutableClient  = "executable-client"
+	ShimExecutableServer  = "executable-server"
+)
+
+// ShimStats is a snapshot of the state of a shim.
+type ShimStats struct {
+	Kind         string
+	CapabilityID string
+	// DonID is the ID of the DON the capability belongs to.
+	DonID uint32
+	// Peers are the remote nodes the shim exchanges messages with.
+	Peers []p2ptypes.PeerID
+	// Registrations is the number of active trigger registrations; always zero for executable capabilities.
+	Registrations int
+	// PendingRequests is the number of executable requests awaiting responses; always zero for triggers.
+	PendingRequests  int
+	MessagesReceived uint64
+	Errors           uint64
+}
+
+// StatsReporter is implemented by the shims, to report their state.
+type StatsReporter interface {
+	Stats() ShimStats
+}
@@ -0,0 +1,73 @@
+// Code generated by github.com/smartcontractkit/chainlink-common/pkg/capabilities/cli, DO NOT EDIT.
+
+package croncap
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Config struct {
+	// Maximum delay after the scheduled time, e.g. '30s'. The delay is derived from
+	// the trigger ID, so it is the same on every node of the DON.
+	Jitter *string `json:"jitter,omitempty" yaml:"jitter,omitempty" mapstructure:"jitter,omitempty"`
+
+	// Cron expression with an optional seconds field, e.g. '*/5 * * * *', or a
+	// descriptor such as '@every 10m'. Evaluated in UTC unless prefixed with CRON_TZ.
+	Schedule string `json:"schedule" yaml:"schedule" mapstructure:"schedule"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Config) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["schedule"]; raw != nil && !ok {
+		return fmt.Errorf("field schedule in Config: required")
+	}
+	type Plain Config
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if len(plain.Schedule) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "schedule", 1)
+	}
+	*j = Config(plain)
+	return nil
+}
+
+type Payload struct {
+	// Time the workflow was scheduled to run at, excluding jitter (RFC 3339).
+	ScheduledExecutionTime string `json:"ScheduledExecutionTime" yaml:"ScheduledExecutionTime" mapstructure:"ScheduledExecutionTime"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Payload) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["ScheduledExecutionTime"]; raw != nil && !ok {
+		return fmt.Errorf("field ScheduledExecutionTime in Payload: required")
+	}
+	type Plain Payload
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if len(plain.ScheduledExecutionTime) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "ScheduledExecutionTime", 1)
+	}
+	*j = Payload(plain)
+	return nil
+}
+
+type Trigger struct {
+	// Config corresponds to the JSON schema field "Config".
+	Config *Config `json:"Config,omitempty" yaml:"Config,omitempty" mapstructure:"Config,omitempty"`
+
+	// Outputs corresponds to the JSON schema field "Outputs".
+	Outputs *Payload `json:"Outputs,omitempty" yaml:"Outputs,omitempty" mapstructure:"Outputs,omitempty"`
+}
@@ -0,0 +1,265 @@
+package cron
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	robfigcron "github.com/robfig/cron/v3"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/core"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/triggers/cron/croncap"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+const ID = "cron-trigger@1.0.0"
+
+const defaultSendChannelBufferSize = 1000
+
+var cronTriggerInfo = capabilities.MustNewCapabilityInfo(
+	ID,
+	capabilities.CapabilityTypeTrigger,
+	"A trigger that starts a workflow run on a cron schedule",
+)
+
+// cronTrigger is a single registered schedule.
+type cronTrigger struct {
+	ch       chan capabilities.TriggerResponse
+	schedule robfigcron.Schedule
+	// offset is the jitter applied to every scheduled run of this trigger
+	offset time.Duration
+	stopCh services.StopChan
+	done   chan struct{}
+}
+
+// Service is the cron trigger capability. It emits an event for every scheduled run of each registered trigger.
+//
+// Every node of a DON emits identical events at the same time for the same trigger, so the events of a remote
+// cron trigger are aggregated by the subscribers:
+//   - schedules are evaluated in UTC unless they specify CRON_TZ,
+//   - @every schedules are aligned to the interval instead of to the time the trigger was registered,
+//   - the jitter of a trigger is derived from its ID instead of being random,
+//   - the event ID and outputs only depend on the trigger ID and the scheduled time.
+type Service struct {
+	services.StateMachine
+	capabilities.CapabilityInfo
+	capabilities.Validator[croncap.Config, struct{}, capabilities.TriggerResponse]
+	lggr     logger.Logger
+	registry core.CapabilitiesRegistry
+	clock    clockwork.Clock
+
+	mu       sync.Mutex
+	triggers map[string]*cronTrigger
+}
+
+var _ capabilities.TriggerCapability = (*Service)(nil)
+var _ services.Service = &Service{}
+
+// NewService returns a cron trigger capability, which adds itself to registry when started.
+func NewService(registry core.CapabilitiesRegistry, clock clockwork.Clock, lggr logger.Logger) *Service {
+	return &Service{
+		CapabilityInfo: cronTriggerInfo,
+		Validator:      capabilities.NewValidator[croncap.Config, struct{}, capabilities.TriggerResponse](capabilities.ValidatorArgs{Info: cronTriggerInfo}),
+		lggr:           lggr.Named("CronTrigger"),
+		registry:       registry,
+		clock:          clock,
+		triggers:       map[string]*cronTrigger{},
+	}
+}
+
+// ParseSchedule parses a cron expression with an optional seconds field, or a descriptor such as @every.
+// Schedules without CRON_TZ are evaluated in UTC, so that they fire at the same time on every node.
+func ParseSchedule(schedule string) (robfigcron.Schedule, error) {
+	s, err := models.CronParser.Parse(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron schedule %q: %w", schedule, err)
+	}
+	if spec, ok := s.(*robfigcron.SpecSchedule); ok && spec.Location == time.Local {
+		spec.Location = time.UTC
+	}
+	return s, nil
+}
+
+// nextRun returns the first scheduled time after t.
+func nextRun(schedule robfigcron.Schedule, t time.Time) time.Time {
+	if every, ok := schedule.(robfigcron.ConstantDelaySchedule); ok {
+		// robfig schedules @every relative to t, which would differ between nodes
+		return t.Truncate(every.Delay).Add(every.Delay)
+	}
+	return schedule.Next(t)
+}
+
+// jitterOffset deterministically picks a delay in [0, jitter) for triggerID.
+func jitterOffset(triggerID string, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	h := sha256.Sum256([]byte(triggerID))
+	return time.Duration(binary.BigEndian.Uint64(h[:8]) % uint64(jitter))
+}
+
+func (s *Service) RegisterTrigger(ctx context.Context, req capabilities.TriggerRegistrationRequest) (<-chan capabilities.TriggerResponse, error) {
+	if req.Config == nil {
+		return nil, errors.New("config is required to register a cron trigger")
+	}
+	cfg, err := s.ValidateConfig(req.Config)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := ParseSchedule(cfg.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	var jitter time.Duration
+	if cfg.Jitter != nil && *cfg.Jitter != "" {
+		jitter, err = time.ParseDuration(*cfg.Jitter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jitter %q: %w", *cfg.Jitter, err)
+		}
+		if jitter < 0 {
+			return nil, fmt.Errorf("jitter must not be negative, got %s", jitter)
+		}
+	}
+
+	t := &cronTrigger{
+		ch:       make(chan capabilities.TriggerResponse, defaultSendChannelBufferSize),
+		schedule: schedule,
+		offset:   jitterOffset(req.TriggerID, jitter),
+		stopCh:   make(services.StopChan),
+		done:     make(chan struct{}),
+	}
+	var exists bool
+	ok := s.IfStarted(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, exists = s.triggers[req.TriggerID]; exists {
+			return
+		}
+		s.triggers[req.TriggerID] = t
+		go s.run(req.TriggerID, req.Metadata.WorkflowID, t)
+	})
+	if !ok {
+		return nil, errors.New("cannot register a trigger since the cron trigger capability is not started")
+	}
+	if exists {
+		return nil, fmt.Errorf("triggerId %s already registered", req.TriggerID)
+	}
+	s.lggr.Infow("RegisterTrigger", "triggerId", req.TriggerID, "workflowID", req.Metadata.WorkflowID, "schedule", cfg.Schedule, "offset", t.offset)
+	return t.ch, nil
+}
+
+func (s *Service) UnregisterTrigger(ctx context.Context, req capabilities.TriggerRegistrationRequest) error {
+	s.mu.Lock()
+	t, ok := s.triggers[req.TriggerID]
+	delete(s.triggers, req.TriggerID)
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("triggerId %s not registered", req.TriggerID)
+	}
+	t.stop()
+	s.lggr.Infow("UnregisterTrigger", "triggerId", req.TriggerID, "workflowID", req.Metadata.WorkflowID)
+	return nil
+}
+
+func (t *cronTrigger) stop() {
+	close(t.stopCh)
+	<-t.done
+	close(t.ch)
+}
+
+// run sends an event for every scheduled run until the trigger is stopped.
+// Runs that were missed while the event channel was full are skipped.
+func (s *Service) run(triggerID string, workflowID string, t *cronTrigger) {
+	defer close(t.done)
+	lggr := s.lggr.With("triggerId", triggerID, "workflowID", workflowID)
+
+	next := nextRun(t.schedule, s.clock.Now().Add(-t.offset))
+	for {
+		timer := s.clock.NewTimer(next.Add(t.offset).Sub(s.clock.Now()))
+		select {
+		case <-t.stopCh:
+			timer.Stop()
+			return
+		case <-timer.Chan():
+		}
+
+		lggr.Debugw("Sending cron trigger event", "scheduledExecutionTime", next)
+		select {
+		case <-t.stopCh:
+			return
+		case t.ch <- createTriggerResponse(triggerID, next):
+		}
+
+		after := s.clock.Now().Add(-t.offset)
+		if missed := nextRun(t.schedule, next); missed.Before(after) {
+			lggr.Warnw("Skipping missed cron trigger runs", "from", missed, "to", after)
+		}
+		next = nextRun(t.schedule, after)
+	}
+}
+
+// createTriggerResponse returns the event for the run of triggerID scheduled at scheduled, which is the same on
+// every node.
+func createTriggerResponse(triggerID string, scheduled time.Time) capabilities.TriggerResponse {
+	scheduled = scheduled.UTC()
+	outputs, err := values.WrapMap(croncap.Payload{
+		ScheduledExecutionTime: scheduled.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return capabilities.TriggerResponse{
+			Err: fmt.Errorf("error wrapping trigger event: %w", err),
+		}
+	}
+	return capabilities.TriggerResponse{
+		Event: capabilities.TriggerEvent{
+			TriggerType: ID,
+			ID:          fmt.Sprintf("%s-%d", triggerID, scheduled.UnixMilli()),
+			Outputs:     outputs,
+		},
+	}
+}
+
+func (s *Service) Info(ctx context.Context) (capabilities.CapabilityInfo, error) {
+	return s.CapabilityInfo, nil
+}
+
+func (s *Service) Start(ctx context.Context) error {
+	return s.StartOnce("CronTrigger", func() error {
+		return s.registry.Add(ctx, s)
+	})
+}
+
+// Close removes the capability from the registry and stops all triggers.
+func (s *Service) Close() error {
+	return s.StopOnce("CronTrigger", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := s.registry.Remove(ctx, s.ID)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for id, t := range s.triggers {
+			t.stop()
+			delete(s.triggers, id)
+		}
+		return err
+	})
+}
+
+func (s *Service) HealthReport() map[string]error {
+	return map[string]error{s.Name(): s.Healthy()}
+}
+
+func (s *Service) Name() string {
+	return s.lggr.Name()
+}
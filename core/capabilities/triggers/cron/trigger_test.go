@@ -0,0 +1,150 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	registrymock "github.com/smartcontractkit/chainlink-common/pkg/types/core/mocks"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/triggers/cron/croncap"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	triggerID  = "wf_123_trigger_0"
+	workflowID = "15c631d295ef5e32deb99a10ee6804bc4af13855687559d7ff6552ac6dbb2ce0"
+)
+
+func newService(t *testing.T, clock clockwork.Clock) *Service {
+	registry := registrymock.NewCapabilitiesRegistry(t)
+	registry.On("Add", mock.Anything, mock.Anything).Return(nil)
+	registry.On("Remove", mock.Anything, ID).Return(nil)
+	return servicetest.Run(t, NewService(registry, clock, logger.TestLogger(t)))
+}
+
+func registrationRequest(t *testing.T, triggerID string, cfg map[string]any) capabilities.TriggerRegistrationRequest {
+	config, err := values.NewMap(cfg)
+	require.NoError(t, err)
+	return capabilities.TriggerRegistrationRequest{
+		TriggerID: triggerID,
+		Metadata:  capabilities.RequestMetadata{WorkflowID: workflowID},
+		Config:    config,
+	}
+}
+
+func requireEvent(t *testing.T, ch <-chan capabilities.TriggerResponse, scheduled time.Time) {
+	select {
+	case resp := <-ch:
+		require.NoError(t, resp.Err)
+		assert.Equal(t, ID, resp.Event.TriggerType)
+		assert.Equal(t, createTriggerResponse(triggerID, scheduled).Event.ID, resp.Event.ID)
+		var payload croncap.Payload
+		require.NoError(t, resp.Event.Outputs.UnwrapTo(&payload))
+		assert.Equal(t, scheduled.UTC().Format(time.RFC3339Nano), payload.ScheduledExecutionTime)
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("timed out waiting for trigger event")
+	}
+}
+
+func TestCronTrigger(t *testing.T) {
+	ctx := testutils.Context(t)
+	start := time.Date(2024, 1, 1, 11, 59, 30, 0, time.UTC)
+	clock := clockwork.NewFakeClockAt(start)
+	s := newService(t, clock)
+
+	ch, err := s.RegisterTrigger(ctx, registrationRequest(t, triggerID, map[string]any{"schedule": "*/5 * * * *"}))
+	require.NoError(t, err)
+
+	_, err = s.RegisterTrigger(ctx, registrationRequest(t, triggerID, map[string]any{"schedule": "*/5 * * * *"}))
+	require.ErrorContains(t, err, "already registered")
+
+	clock.BlockUntil(1)
+	clock.Advance(30 * time.Second)
+	requireEvent(t, ch, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	clock.BlockUntil(1)
+	clock.Advance(5 * time.Minute)
+	requireEvent(t, ch, time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC))
+
+	require.NoError(t, s.UnregisterTrigger(ctx, registrationRequest(t, triggerID, nil)))
+	_, ok := <-ch
+	assert.False(t, ok, "channel should be closed")
+	require.Error(t, s.UnregisterTrigger(ctx, registrationRequest(t, triggerID, nil)))
+}
+
+func TestCronTrigger_Every(t *testing.T) {
+	ctx := testutils.Context(t)
+	// @every is aligned to the interval, not to the registration time
+	clock := clockwork.NewFakeClockAt(time.Date(2024, 1, 1, 12, 3, 17, 0, time.UTC))
+	s := newService(t, clock)
+
+	ch, err := s.RegisterTrigger(ctx, registrationRequest(t, triggerID, map[string]any{"schedule": "@every 10m"}))
+	require.NoError(t, err)
+
+	clock.BlockUntil(1)
+	clock.Advance(7 * time.Minute)
+	requireEvent(t, ch, time.Date(2024, 1, 1, 12, 10, 0, 0, time.UTC))
+}
+
+func TestCronTrigger_Jitter(t *testing.T) {
+	ctx := testutils.Context(t)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := clockwork.NewFakeClockAt(start)
+	s := newService(t, clock)
+
+	offset := jitterOffset(triggerID, time.Minute)
+	require.Less(t, offset, time.Minute)
+	assert.Equal(t, offset, jitterOffset(triggerID, time.Minute), "jitter must be the same on every node")
+
+	ch, err := s.RegisterTrigger(ctx, registrationRequest(t, triggerID, map[string]any{"schedule": "0 * * * *", "jitter": "1m"}))
+	require.NoError(t, err)
+
+	clock.BlockUntil(1)
+	clock.Advance(offset - time.Nanosecond)
+	select {
+	case <-ch:
+		t.Fatal("trigger fired before its jittered time")
+	default:
+	}
+
+	// the event is the same as without jitter
+	clock.Advance(time.Nanosecond)
+	requireEvent(t, ch, start)
+}
+
+func TestCronTrigger_InvalidConfig(t *testing.T) {
+	ctx := testutils.Context(t)
+	s := newService(t, clockwork.NewFakeClock())
+
+	for name, cfg := range map[string]map[string]any{
+		"missing schedule": {},
+		"invalid schedule": {"schedule": "every minute"},
+		"invalid jitter":   {"schedule": "* * * * *", "jitter": "soon"},
+		"negative jitter":  {"schedule": "* * * * *", "jitter": "-1s"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.RegisterTrigger(ctx, registrationRequest(t, triggerID, cfg))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	s, err := ParseSchedule("0 12 * * *")
+	require.NoError(t, err)
+	local := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), s.Next(local).UTC(), "schedules default to UTC")
+
+	s, err = ParseSchedule("CRON_TZ=Asia/Tokyo 0 12 * * *")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), s.Next(local).UTC())
+}
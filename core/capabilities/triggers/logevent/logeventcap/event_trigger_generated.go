@@ -8,6 +8,11 @@ import (
 )
 
 type Config struct {
+	// The number of blocks a log must be buried under before it is delivered.
+	// Delivered logs are retracted if they are reorged out before they are finalized.
+	// If set to 0, only finalized logs are delivered
+	ConfirmationDepth *uint64 `json:"confirmationDepth,omitempty" yaml:"confirmationDepth,omitempty" mapstructure:"confirmationDepth,omitempty"`
+
 	// ContractAddress corresponds to the JSON schema field "contractAddress".
 	ContractAddress string `json:"contractAddress" yaml:"contractAddress" mapstructure:"contractAddress"`
 
@@ -133,6 +138,9 @@ type Output struct {
 
 	// Head corresponds to the JSON schema field "Head".
 	Head Head `json:"Head" yaml:"Head" mapstructure:"Head"`
+
+	// Whether the log, delivered before, was reorged out of the chain
+	Retracted bool `json:"Retracted" yaml:"Retracted" mapstructure:"Retracted"`
 }
 
 type OutputData map[string]interface{}
@@ -152,6 +160,9 @@ func (j *Output) UnmarshalJSON(b []byte) error {
 	if _, ok := raw["Head"]; raw != nil && !ok {
 		return fmt.Errorf("field Head in Output: required")
 	}
+	if _, ok := raw["Retracted"]; raw != nil && !ok {
+		return fmt.Errorf("field Retracted in Output: required")
+	}
 	type Plain Output
 	var plain Plain
 	if err := json.Unmarshal(b, &plain); err != nil {
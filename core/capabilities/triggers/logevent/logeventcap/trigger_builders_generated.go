@@ -13,6 +13,7 @@ func (cfg Config) New(w *sdk.WorkflowSpecFactory, id string) OutputCap {
 		ID: id, Ref: ref,
 		Inputs: sdk.StepInputs{},
 		Config: map[string]any{
+			"confirmationDepth":    cfg.ConfirmationDepth,
 			"contractAddress":      cfg.ContractAddress,
 			"contractEventName":    cfg.ContractEventName,
 			"contractName":         cfg.ContractName,
@@ -111,6 +112,7 @@ type OutputCap interface {
 	Cursor() sdk.CapDefinition[string]
 	Data() OutputDataCap
 	Head() HeadCap
+	Retracted() sdk.CapDefinition[bool]
 	private()
 }
 
@@ -128,6 +130,9 @@ func (c *outputCap) Data() OutputDataCap {
 func (c *outputCap) Head() HeadCap {
 	return HeadWrapper(sdk.AccessField[Output, Head](c.CapDefinition, "Head"))
 }
+func (c *outputCap) Retracted() sdk.CapDefinition[bool] {
+	return sdk.AccessField[Output, bool](c.CapDefinition, "Retracted")
+}
 
 func ConstantOutput(value Output) OutputCap {
 	return &outputCap{CapDefinition: sdk.ConstantDefinition(value)}
@@ -136,24 +141,28 @@ func ConstantOutput(value Output) OutputCap {
 func NewOutputFromFields(
 	cursor sdk.CapDefinition[string],
 	data OutputDataCap,
-	head HeadCap) OutputCap {
+	head HeadCap,
+	retracted sdk.CapDefinition[bool]) OutputCap {
 	return &simpleOutput{
 		CapDefinition: sdk.ComponentCapDefinition[Output]{
-			"Cursor": cursor.Ref(),
-			"Data":   data.Ref(),
-			"Head":   head.Ref(),
+			"Cursor":    cursor.Ref(),
+			"Data":      data.Ref(),
+			"Head":      head.Ref(),
+			"Retracted": retracted.Ref(),
 		},
-		cursor: cursor,
-		data:   data,
-		head:   head,
+		cursor:    cursor,
+		data:      data,
+		head:      head,
+		retracted: retracted,
 	}
 }
 
 type simpleOutput struct {
 	sdk.CapDefinition[Output]
-	cursor sdk.CapDefinition[string]
-	data   OutputDataCap
-	head   HeadCap
+	cursor    sdk.CapDefinition[string]
+	data      OutputDataCap
+	head      HeadCap
+	retracted sdk.CapDefinition[bool]
 }
 
 func (c *simpleOutput) Cursor() sdk.CapDefinition[string] {
@@ -165,6 +174,9 @@ func (c *simpleOutput) Data() OutputDataCap {
 func (c *simpleOutput) Head() HeadCap {
 	return c.head
 }
+func (c *simpleOutput) Retracted() sdk.CapDefinition[bool] {
+	return c.retracted
+}
 
 func (c *simpleOutput) private() {}
 
@@ -30,6 +30,7 @@ type TriggerService struct {
 	lggr           logger.Logger
 	triggers       CapabilitiesStore[logEventTrigger, capabilities.TriggerResponse]
 	relayer        core.Relayer
+	cursorStore    *cursorStore
 	logEventConfig Config
 	stopCh         services.StopChan
 }
@@ -50,12 +51,15 @@ func (config Config) Version(capabilityVersion string) string {
 var _ capabilities.TriggerCapability = (*TriggerService)(nil)
 var _ services.Service = &TriggerService{}
 
-// Creates a new Cron Trigger Service.
+// Creates a new Log Event Trigger Service.
+// Triggers persist their cursors in store to resume from them after a restart,
+// or keep them in memory if store is nil.
 // Scheduling will commence on calling .Start()
 func NewTriggerService(ctx context.Context,
 	lggr logger.Logger,
 	relayer core.Relayer,
-	logEventConfig Config) (*TriggerService, error) {
+	logEventConfig Config,
+	store core.KeyValueStore) (*TriggerService, error) {
 	l := logger.Named(lggr, "LogEventTriggerCapabilityService")
 
 	logEventStore := NewCapabilitiesStore[logEventTrigger, capabilities.TriggerResponse]()
@@ -64,6 +68,7 @@ func NewTriggerService(ctx context.Context,
 		lggr:           l,
 		triggers:       logEventStore,
 		relayer:        relayer,
+		cursorStore:    newCursorStore(store),
 		logEventConfig: logEventConfig,
 		stopCh:         make(services.StopChan),
 	}
@@ -99,7 +104,7 @@ func (s *TriggerService) RegisterTrigger(ctx context.Context,
 	var respCh chan capabilities.TriggerResponse
 	ok := s.IfNotStopped(func() {
 		respCh, err = s.triggers.InsertIfNotExists(req.TriggerID, func() (*logEventTrigger, chan capabilities.TriggerResponse, error) {
-			l, ch, tErr := newLogEventTrigger(ctx, s.lggr, req.Metadata.WorkflowID, reqConfig, s.logEventConfig, s.relayer)
+			l, ch, tErr := newLogEventTrigger(ctx, s.lggr, req.TriggerID, req.Metadata.WorkflowID, reqConfig, s.logEventConfig, s.relayer, s.cursorStore)
 			if tErr != nil {
 				return l, ch, tErr
 			}
@@ -129,6 +134,10 @@ func (s *TriggerService) UnregisterTrigger(ctx context.Context, req capabilities
 	}
 	// Remove from triggers context
 	s.triggers.Delete(req.TriggerID)
+	// Forget the cursor, so that a trigger registered again with the same ID starts from the lookback blocks
+	if err = s.cursorStore.Delete(ctx, req.TriggerID); err != nil {
+		return fmt.Errorf("error deleting cursor of trigger %s: %w", req.TriggerID, err)
+	}
 	s.lggr.Infow("UnregisterTrigger", "triggerId", req.TriggerID, "WorkflowID", req.Metadata.WorkflowID)
 	return nil
 }
@@ -1,8 +1,13 @@
 package logevent
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types/core"
 )
 
 type RegisterCapabilityFn[T any, Resp any] func() (*T, chan Resp, error)
@@ -80,3 +85,94 @@ func (cs *capabilitiesStore[T, Resp]) Delete(capabilityID string) {
 	defer cs.mu.Unlock()
 	delete(cs.capabilities, capabilityID)
 }
+
+// Persisted delivery state of a log event trigger
+type triggerState struct {
+	// Cursor of the last log read from the ContractReader
+	Cursor string
+	// Lowest block number to read logs from
+	StartBlockNum uint64
+	// Delivered logs that are not finalized yet, checked for reorgs
+	Pending []pendingLog
+}
+
+// Log delivered to the workflow that could still be reorged out of the chain
+type pendingLog struct {
+	Cursor    string
+	Height    uint64
+	Hash      []byte
+	Timestamp uint64
+}
+
+// Persists the delivery state of log event triggers, keyed by trigger ID,
+// so that triggers resume from their cursor after a restart.
+// The key value store must read missing keys as empty values: errors of a store
+// given to a LOOP plugin only reach the plugin as gRPC status messages, so
+// a missing key cannot be told apart from a failed read by its error.
+type cursorStore struct {
+	kv core.KeyValueStore
+}
+
+// Constructor for cursorStore, keeping the state in memory if kv is nil
+func newCursorStore(kv core.KeyValueStore) *cursorStore {
+	if kv == nil {
+		kv = &inMemoryKeyValueStore{values: map[string][]byte{}}
+	}
+	return &cursorStore{kv: kv}
+}
+
+func cursorStoreKey(triggerID string) string {
+	return "log-event-trigger/" + triggerID
+}
+
+func (cs *cursorStore) Read(ctx context.Context, triggerID string) (triggerState, error) {
+	var state triggerState
+	val, err := cs.kv.Get(ctx, cursorStoreKey(triggerID))
+	if err != nil {
+		return state, err
+	}
+	// missing and deleted states are both empty
+	if len(val) == 0 {
+		return state, errNoTriggerState
+	}
+	if err = json.Unmarshal(val, &state); err != nil {
+		return state, fmt.Errorf("error decoding state of trigger %s: %w", triggerID, err)
+	}
+	return state, nil
+}
+
+func (cs *cursorStore) Write(ctx context.Context, triggerID string, state triggerState) error {
+	val, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("error encoding state of trigger %s: %w", triggerID, err)
+	}
+	return cs.kv.Store(ctx, cursorStoreKey(triggerID), val)
+}
+
+// Delete deletes the state of the trigger, so that it starts from the lookback blocks if registered again.
+// The state is overwritten with an empty value, which reads as no state, as core.KeyValueStore has no
+// operation to remove a key. The empty value is removed with the job.
+func (cs *cursorStore) Delete(ctx context.Context, triggerID string) error {
+	return cs.kv.Store(ctx, cursorStoreKey(triggerID), []byte{})
+}
+
+var errNoTriggerState = errors.New("no state stored for trigger")
+
+// In-memory core.KeyValueStore used when the capability is not given a persistent store
+type inMemoryKeyValueStore struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+func (s *inMemoryKeyValueStore) Store(ctx context.Context, key string, val []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = val
+	return nil
+}
+
+func (s *inMemoryKeyValueStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values[key], nil
+}
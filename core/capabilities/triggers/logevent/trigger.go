@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -24,14 +25,18 @@ import (
 // in a loop with a periodic delay of pollPeriod milliseconds, which is specified in
 // the job spec
 type logEventTrigger struct {
-	ch   chan<- capabilities.TriggerResponse
-	lggr logger.Logger
+	ch        chan<- capabilities.TriggerResponse
+	lggr      logger.Logger
+	triggerID string
 
 	// Contract address and Event Signature to monitor for
 	reqConfig      *logeventcap.Config
 	contractReader types.ContractReader
 	relayer        core.Relayer
-	startBlockNum  uint64
+
+	// Delivery state, persisted in cursorStore after every delivered event
+	cursorStore *cursorStore
+	state       triggerState
 
 	// Log Event Trigger config with pollPeriod and lookbackBlocks
 	logEventConfig Config
@@ -43,10 +48,12 @@ type logEventTrigger struct {
 // Construct for logEventTrigger struct
 func newLogEventTrigger(ctx context.Context,
 	lggr logger.Logger,
+	triggerID string,
 	workflowID string,
 	reqConfig *logeventcap.Config,
 	logEventConfig Config,
-	relayer core.Relayer) (*logEventTrigger, chan capabilities.TriggerResponse, error) {
+	relayer core.Relayer,
+	cursorStore *cursorStore) (*logEventTrigger, chan capabilities.TriggerResponse, error) {
 	jsonBytes, err := json.Marshal(reqConfig.ContractReaderConfig)
 	if err != nil {
 		return nil, nil, err
@@ -72,18 +79,24 @@ func newLogEventTrigger(ctx context.Context,
 		return nil, nil, err
 	}
 
-	// Get current block HEAD/tip of the blockchain to start polling from
-	latestHead, err := relayer.LatestHead(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error getting latestHead from relayer client: %w", err)
-	}
-	height, err := strconv.ParseUint(latestHead.Height, 10, 64)
-	if err != nil {
-		return nil, nil, fmt.Errorf("invalid height in latestHead from relayer client: %w", err)
-	}
-	startBlockNum := uint64(0)
-	if height > logEventConfig.LookbackBlocks {
-		startBlockNum = height - logEventConfig.LookbackBlocks
+	l := logger.Named(lggr, fmt.Sprintf("LogEventTrigger.%s", workflowID))
+
+	// Resume from the persisted cursor, or start polling from the lookback blocks
+	// before the current block HEAD/tip of the blockchain
+	state, err := cursorStore.Read(ctx, triggerID)
+	switch {
+	case errors.Is(err, errNoTriggerState):
+		l.Infow("No persisted cursor, starting from lookback blocks", "triggerID", triggerID)
+		state, err = initialTriggerState(ctx, relayer, logEventConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	case err != nil:
+		// starting from the lookback blocks could skip or redeliver events
+		return nil, nil, fmt.Errorf("error reading persisted cursor of trigger %s: %w", triggerID, err)
+	default:
+		l.Infow("Resuming from persisted cursor", "triggerID", triggerID, "cursor", state.Cursor,
+			"startBlockNum", state.StartBlockNum, "pendingLogs", len(state.Pending))
 	}
 
 	// Setup callback channel, logger and ticker to poll ContractReader
@@ -95,21 +108,48 @@ func newLogEventTrigger(ctx context.Context,
 	}
 
 	// Initialise a Log Event Trigger
-	l := &logEventTrigger{
-		ch:   callbackCh,
-		lggr: logger.Named(lggr, fmt.Sprintf("LogEventTrigger.%s", workflowID)),
+	return &logEventTrigger{
+		ch:        callbackCh,
+		lggr:      l,
+		triggerID: triggerID,
 
 		reqConfig:      reqConfig,
 		contractReader: contractReader,
 		relayer:        relayer,
-		startBlockNum:  startBlockNum,
+
+		cursorStore: cursorStore,
+		state:       state,
 
 		logEventConfig: logEventConfig,
 		ticker:         ticker,
 		stopChan:       make(services.StopChan),
 		done:           make(chan bool),
+	}, callbackCh, nil
+}
+
+// Get current block HEAD/tip of the blockchain to start polling from
+func initialTriggerState(ctx context.Context, relayer core.Relayer, logEventConfig Config) (triggerState, error) {
+	height, err := latestHeight(ctx, relayer)
+	if err != nil {
+		return triggerState{}, err
+	}
+	startBlockNum := uint64(0)
+	if height > logEventConfig.LookbackBlocks {
+		startBlockNum = height - logEventConfig.LookbackBlocks
+	}
+	return triggerState{StartBlockNum: startBlockNum}, nil
+}
+
+func latestHeight(ctx context.Context, relayer core.Relayer) (uint64, error) {
+	latestHead, err := relayer.LatestHead(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error getting latestHead from relayer client: %w", err)
+	}
+	height, err := strconv.ParseUint(latestHead.Height, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid height in latestHead from relayer client: %w", err)
 	}
-	return l, callbackCh, nil
+	return height, nil
 }
 
 func (l *logEventTrigger) Start(ctx context.Context) error {
@@ -123,15 +163,6 @@ func (l *logEventTrigger) listen() {
 	defer cancel()
 	defer close(l.done)
 
-	// Listen for events from lookbackPeriod
-	var logs []types.Sequence
-	var err error
-	var logData values.Value
-	cursor := ""
-	limitAndSort := query.LimitAndSort{
-		SortBy: []query.SortBy{query.NewSortByTimestamp(query.Asc)},
-		Limit:  query.Limit{Count: l.logEventConfig.QueryCount},
-	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -142,50 +173,207 @@ func (l *logEventTrigger) listen() {
 			return
 		case t := <-l.ticker.C:
 			l.lggr.Infow("Polling event logs from ContractReader using QueryKey at", "time", t,
-				"startBlockNum", l.startBlockNum,
-				"cursor", cursor)
-			if cursor != "" {
-				limitAndSort.Limit = query.CursorLimit(cursor, query.CursorFollowing, l.logEventConfig.QueryCount)
-			}
-			logs, err = l.contractReader.QueryKey(
-				ctx,
-				types.BoundContract{Name: l.reqConfig.ContractName, Address: l.reqConfig.ContractAddress},
-				query.KeyFilter{
-					Key: l.reqConfig.ContractEventName,
-					Expressions: []query.Expression{
-						query.Confidence(primitives.Finalized),
-						query.Block(fmt.Sprintf("%d", l.startBlockNum), primitives.Gte),
-					},
-				},
-				limitAndSort,
-				&logData,
-			)
-			if err != nil {
-				l.lggr.Errorw("QueryKey failure", "err", err)
-				continue
-			}
-			// ChainReader QueryKey API provides logs including the cursor value and not
-			// after the cursor value. If the response only consists of the log corresponding
-			// to the cursor and no log after it, then we understand that there are no new
-			// logs
-			if len(logs) == 1 && logs[0].Cursor == cursor {
-				l.lggr.Infow("No new logs since", "cursor", cursor)
-				continue
-			}
-			for _, log := range logs {
-				if log.Cursor == cursor {
-					continue
-				}
-				triggerResp := createTriggerResponse(log, l.logEventConfig.Version(ID))
-				l.ch <- triggerResp
-				cursor = log.Cursor
+				"startBlockNum", l.state.StartBlockNum,
+				"cursor", l.state.Cursor)
+			l.poll(ctx)
+		}
+	}
+}
+
+func (l *logEventTrigger) confirmationDepth() uint64 {
+	if l.reqConfig.ConfirmationDepth == nil {
+		return 0
+	}
+	return *l.reqConfig.ConfirmationDepth
+}
+
+// Poll new logs from the cursor and deliver them. Without confirmation depth only
+// finalized logs are delivered, otherwise logs buried under confirmationDepth blocks
+// are delivered, after retracting the delivered logs that were reorged out
+func (l *logEventTrigger) poll(ctx context.Context) {
+	expressions := []query.Expression{query.Confidence(primitives.Finalized)}
+	depth := l.confirmationDepth()
+	if depth > 0 {
+		height, err := latestHeight(ctx, l.relayer)
+		if err != nil {
+			l.lggr.Errorw("LatestHead failure", "err", err)
+			return
+		}
+		if height < depth {
+			return
+		}
+		confirmedHeight := height - depth
+		if err = l.retractReorgedLogs(ctx); err != nil {
+			l.lggr.Errorw("Reorg check failure", "err", err)
+			return
+		}
+		expressions = []query.Expression{
+			query.Confidence(primitives.Unconfirmed),
+			query.Block(strconv.FormatUint(confirmedHeight, 10), primitives.Lte),
+		}
+	}
+	expressions = append(expressions, query.Block(strconv.FormatUint(l.state.StartBlockNum, 10), primitives.Gte))
+
+	limitAndSort := query.LimitAndSort{
+		SortBy: []query.SortBy{query.NewSortByTimestamp(query.Asc)},
+		Limit:  query.Limit{Count: l.logEventConfig.QueryCount},
+	}
+	cursor := l.state.Cursor
+	if cursor != "" {
+		limitAndSort.Limit = query.CursorLimit(cursor, query.CursorFollowing, l.logEventConfig.QueryCount)
+	}
+	logs, err := l.queryLogs(ctx, expressions, limitAndSort)
+	if err != nil {
+		l.lggr.Errorw("QueryKey failure", "err", err)
+		return
+	}
+	// ChainReader QueryKey API provides logs including the cursor value and not
+	// after the cursor value. If the response only consists of the log corresponding
+	// to the cursor and no log after it, then we understand that there are no new
+	// logs
+	if len(logs) == 1 && logs[0].Cursor == cursor {
+		l.lggr.Infow("No new logs since", "cursor", cursor)
+		return
+	}
+	for _, log := range logs {
+		if log.Cursor == cursor {
+			continue
+		}
+		// Logs delivered before the cursor was rewound by a reorg are not delivered again
+		if l.isPending(log) {
+			l.state.Cursor = log.Cursor
+			continue
+		}
+		triggerResp := createTriggerResponse(log, l.logEventConfig.Version(ID), depth > 0)
+		select {
+		case l.ch <- triggerResp:
+		case <-ctx.Done():
+			return
+		}
+		l.state.Cursor = log.Cursor
+		if depth > 0 {
+			l.state.Pending = append(l.state.Pending, newPendingLog(log))
+		}
+		l.persistState(ctx)
+	}
+}
+
+func (l *logEventTrigger) queryLogs(ctx context.Context, expressions []query.Expression, limitAndSort query.LimitAndSort) ([]types.Sequence, error) {
+	var logData values.Value
+	return l.contractReader.QueryKey(
+		ctx,
+		types.BoundContract{Name: l.reqConfig.ContractName, Address: l.reqConfig.ContractAddress},
+		query.KeyFilter{
+			Key:         l.reqConfig.ContractEventName,
+			Expressions: expressions,
+		},
+		limitAndSort,
+		&logData,
+	)
+}
+
+// Forget the delivered logs that got finalized and retract the ones that are
+// no longer on chain. The cursor is rewound to the lowest retracted log, so
+// that the logs replacing them are delivered
+func (l *logEventTrigger) retractReorgedLogs(ctx context.Context) error {
+	if len(l.state.Pending) == 0 {
+		return nil
+	}
+	minHeight, maxHeight := l.state.Pending[0].Height, l.state.Pending[0].Height
+	for _, p := range l.state.Pending {
+		minHeight = min(minHeight, p.Height)
+		maxHeight = max(maxHeight, p.Height)
+	}
+	finalized, err := l.queryLogs(ctx, []query.Expression{
+		query.Confidence(primitives.Finalized),
+		query.Block(strconv.FormatUint(minHeight, 10), primitives.Gte),
+		query.Block(strconv.FormatUint(maxHeight, 10), primitives.Lte),
+	}, query.LimitAndSort{})
+	if err != nil {
+		return err
+	}
+	onChain, err := l.queryLogs(ctx, []query.Expression{
+		query.Confidence(primitives.Unconfirmed),
+		query.Block(strconv.FormatUint(minHeight, 10), primitives.Gte),
+	}, query.LimitAndSort{})
+	if err != nil {
+		return err
+	}
+	finalizedKeys := pendingLogKeys(finalized)
+	onChainKeys := pendingLogKeys(onChain)
+
+	var pending, retracted []pendingLog
+	for _, p := range l.state.Pending {
+		switch {
+		case finalizedKeys[p.key()]:
+		case onChainKeys[p.key()]:
+			pending = append(pending, p)
+		default:
+			retracted = append(retracted, p)
+		}
+	}
+	if len(pending) == len(l.state.Pending) {
+		return nil
+	}
+	l.state.Pending = pending
+	if len(retracted) > 0 {
+		rewindTo := retracted[0].Height
+		for _, p := range retracted {
+			rewindTo = min(rewindTo, p.Height)
+		}
+		l.lggr.Warnw("Retracting logs reorged out of the chain", "retractedLogs", len(retracted), "rewindTo", rewindTo)
+		for _, p := range retracted {
+			select {
+			case l.ch <- createRetractionResponse(p, l.logEventConfig.Version(ID)):
+			case <-ctx.Done():
+				return ctx.Err()
 			}
 		}
+		l.state.Cursor = ""
+		l.state.StartBlockNum = max(l.state.StartBlockNum, rewindTo)
+	}
+	l.persistState(ctx)
+	return nil
+}
+
+func (l *logEventTrigger) isPending(log types.Sequence) bool {
+	key := newPendingLog(log).key()
+	for _, p := range l.state.Pending {
+		if p.key() == key {
+			return true
+		}
 	}
+	return false
+}
+
+func (l *logEventTrigger) persistState(ctx context.Context) {
+	if err := l.cursorStore.Write(ctx, l.triggerID, l.state); err != nil {
+		l.lggr.Errorw("Failed to persist cursor", "cursor", l.state.Cursor, "err", err)
+	}
+}
+
+func newPendingLog(log types.Sequence) pendingLog {
+	height, _ := strconv.ParseUint(log.Height, 10, 64)
+	return pendingLog{Cursor: log.Cursor, Height: height, Hash: log.Hash, Timestamp: log.Timestamp}
 }
 
-// Create log event trigger capability response
-func createTriggerResponse(log types.Sequence, version string) capabilities.TriggerResponse {
+// Logs are identified by their cursor and block hash, as a log can be included
+// with the same cursor in a block of another fork
+func (p pendingLog) key() string {
+	return p.Cursor + "/" + hex.EncodeToString(p.Hash)
+}
+
+func pendingLogKeys(logs []types.Sequence) map[string]bool {
+	keys := make(map[string]bool, len(logs))
+	for _, log := range logs {
+		keys[newPendingLog(log).key()] = true
+	}
+	return keys
+}
+
+// Create log event trigger capability response. Event IDs of unfinalized logs
+// include the block hash, as the same log can be delivered again from another fork
+func createTriggerResponse(log types.Sequence, version string, unfinalized bool) capabilities.TriggerResponse {
 	dataAsValuesMap, err := values.WrapMap(log.Data)
 	if err != nil {
 		return capabilities.TriggerResponse{
@@ -200,7 +388,11 @@ func createTriggerResponse(log types.Sequence, version string) capabilities.Trig
 		}
 	}
 
-	wrappedPayload, err := values.WrapMap(&logeventcap.Output{
+	eventID := log.Cursor
+	if unfinalized {
+		eventID = newPendingLog(log).key()
+	}
+	return newTriggerResponse(eventID, version, &logeventcap.Output{
 		Cursor: log.Cursor,
 		Data:   dataAsMap,
 		Head: logeventcap.Head{
@@ -209,6 +401,24 @@ func createTriggerResponse(log types.Sequence, version string) capabilities.Trig
 			Timestamp: log.Timestamp,
 		},
 	})
+}
+
+// Create log event trigger capability response retracting a delivered log
+func createRetractionResponse(p pendingLog, version string) capabilities.TriggerResponse {
+	return newTriggerResponse("retracted/"+p.key(), version, &logeventcap.Output{
+		Cursor: p.Cursor,
+		Data:   map[string]any{},
+		Head: logeventcap.Head{
+			Hash:      "0x" + hex.EncodeToString(p.Hash),
+			Height:    strconv.FormatUint(p.Height, 10),
+			Timestamp: p.Timestamp,
+		},
+		Retracted: true,
+	})
+}
+
+func newTriggerResponse(eventID string, version string, output *logeventcap.Output) capabilities.TriggerResponse {
+	wrappedPayload, err := values.WrapMap(output)
 	if err != nil {
 		return capabilities.TriggerResponse{
 			Err: fmt.Errorf("error wrapping trigger event: %w", err),
@@ -218,7 +428,7 @@ func createTriggerResponse(log types.Sequence, version string) capabilities.Trig
 	return capabilities.TriggerResponse{
 		Event: capabilities.TriggerEvent{
 			TriggerType: version,
-			ID:          log.Cursor,
+			ID:          eventID,
 			Outputs:     wrappedPayload,
 		},
 	}
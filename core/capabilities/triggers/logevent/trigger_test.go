@@ -0,0 +1,422 @@
+package logevent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/loop"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/core"
+	commonmocks "github.com/smartcontractkit/chainlink-common/pkg/types/core/mocks"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/triggers/logevent/logeventcap"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+const testTriggerID = "trigger-1"
+
+type fakeLog struct {
+	height    uint64
+	index     int
+	hash      byte
+	finalized bool
+}
+
+func (f fakeLog) cursor() string {
+	return fmt.Sprintf("%d-%d", f.height, f.index)
+}
+
+func (f fakeLog) before(cursor string) bool {
+	var height uint64
+	var index int
+	_, _ = fmt.Sscanf(cursor, "%d-%d", &height, &index)
+	return f.height < height || (f.height == height && f.index < index)
+}
+
+// fakeContractReader serves logs ordered by height and index, filtering them like
+// the EVM ContractReader by confidence level, block range and cursor
+type fakeContractReader struct {
+	types.UnimplementedContractReader
+	mu   sync.Mutex
+	logs []fakeLog
+}
+
+func (r *fakeContractReader) setLogs(logs ...fakeLog) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = logs
+}
+
+func (r *fakeContractReader) Bind(context.Context, []types.BoundContract) error { return nil }
+
+func (r *fakeContractReader) Start(context.Context) error { return nil }
+
+func (r *fakeContractReader) QueryKey(_ context.Context, _ types.BoundContract, filter query.KeyFilter, limitAndSort query.LimitAndSort, _ any) ([]types.Sequence, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sequences []types.Sequence
+	for _, log := range r.logs {
+		if !matches(log, filter.Expressions) || (limitAndSort.Limit.Cursor != "" && log.before(limitAndSort.Limit.Cursor)) {
+			continue
+		}
+		sequences = append(sequences, types.Sequence{
+			Cursor: log.cursor(),
+			Head:   types.Head{Height: strconv.FormatUint(log.height, 10), Hash: []byte{log.hash}, Timestamp: log.height},
+			Data:   map[string]any{"Height": log.height},
+		})
+		if limitAndSort.Limit.Count > 0 && uint64(len(sequences)) == limitAndSort.Limit.Count {
+			break
+		}
+	}
+	return sequences, nil
+}
+
+func matches(log fakeLog, expressions []query.Expression) bool {
+	for _, expression := range expressions {
+		switch p := expression.Primitive.(type) {
+		case *primitives.Confidence:
+			if p.ConfidenceLevel == primitives.Finalized && !log.finalized {
+				return false
+			}
+		case *primitives.Block:
+			block, _ := strconv.ParseUint(p.Block, 10, 64)
+			if (p.Operator == primitives.Gte && log.height < block) || (p.Operator == primitives.Lte && log.height > block) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type triggerTH struct {
+	reader      *fakeContractReader
+	relayer     *commonmocks.Relayer
+	cursorStore *cursorStore
+	height      atomic.Uint64
+}
+
+func newTriggerTH(t *testing.T) *triggerTH {
+	th := &triggerTH{
+		reader:      &fakeContractReader{},
+		relayer:     commonmocks.NewRelayer(t),
+		cursorStore: newCursorStore(nil),
+	}
+	th.relayer.EXPECT().NewContractReader(mock.Anything, mock.Anything).Return(th.reader, nil).Maybe()
+	th.relayer.EXPECT().LatestHead(mock.Anything).RunAndReturn(func(context.Context) (types.Head, error) {
+		return types.Head{Height: strconv.FormatUint(th.height.Load(), 10)}, nil
+	}).Maybe()
+	return th
+}
+
+func testConfigs(confirmationDepth uint64) (*logeventcap.Config, Config) {
+	reqConfig := &logeventcap.Config{
+		ContractName:         "LogEmitter",
+		ContractAddress:      "0x0000000000000000000000000000000000000001",
+		ContractEventName:    "Log1",
+		ContractReaderConfig: logeventcap.ConfigContractReaderConfig{Contracts: map[string]any{}},
+		ConfirmationDepth:    &confirmationDepth,
+	}
+	return reqConfig, Config{ChainID: "1", Network: "evm", LookbackBlocks: 5, PollPeriod: 1000}
+}
+
+func (th *triggerTH) newTrigger(t *testing.T, confirmationDepth uint64) (*logEventTrigger, chan capabilities.TriggerResponse) {
+	reqConfig, logEventConfig := testConfigs(confirmationDepth)
+	l, ch, err := newLogEventTrigger(testutils.Context(t), logger.Test(t), testTriggerID, "workflow", reqConfig, logEventConfig, th.relayer, th.cursorStore)
+	require.NoError(t, err)
+	t.Cleanup(l.ticker.Stop)
+	return l, ch
+}
+
+func receiveAll(ch <-chan capabilities.TriggerResponse) []capabilities.TriggerResponse {
+	var responses []capabilities.TriggerResponse
+	for {
+		select {
+		case resp := <-ch:
+			responses = append(responses, resp)
+		default:
+			return responses
+		}
+	}
+}
+
+func requireOutputs(t *testing.T, responses []capabilities.TriggerResponse, expected ...logeventcap.Output) {
+	require.Len(t, responses, len(expected))
+	for i, resp := range responses {
+		require.NoError(t, resp.Err)
+		var output logeventcap.Output
+		require.NoError(t, resp.Event.Outputs.UnwrapTo(&output))
+		require.Equal(t, expected[i].Cursor, output.Cursor)
+		require.Equal(t, expected[i].Head.Hash, output.Head.Hash)
+		require.Equal(t, expected[i].Retracted, output.Retracted)
+	}
+}
+
+func TestLogEventTrigger_Finalized(t *testing.T) {
+	th := newTriggerTH(t)
+	th.height.Store(10)
+	th.reader.setLogs(
+		fakeLog{height: 4, hash: 1, finalized: true},
+		fakeLog{height: 6, hash: 1, finalized: true},
+		fakeLog{height: 8, hash: 1},
+	)
+	l, ch := th.newTrigger(t, 0)
+	require.Equal(t, uint64(5), l.state.StartBlockNum)
+
+	ctx := testutils.Context(t)
+	l.poll(ctx)
+	responses := receiveAll(ch)
+	requireOutputs(t, responses, logeventcap.Output{Cursor: "6-0", Head: logeventcap.Head{Hash: "0x01"}})
+	require.Equal(t, "6-0", responses[0].Event.ID)
+	require.Empty(t, l.state.Pending)
+
+	l.poll(ctx)
+	require.Empty(t, receiveAll(ch))
+}
+
+func TestLogEventTrigger_ConfirmationDepth(t *testing.T) {
+	th := newTriggerTH(t)
+	th.height.Store(10)
+	th.reader.setLogs(
+		fakeLog{height: 7, hash: 1},
+		fakeLog{height: 9, hash: 1},
+	)
+	l, ch := th.newTrigger(t, 2)
+
+	ctx := testutils.Context(t)
+	l.poll(ctx)
+	responses := receiveAll(ch)
+	requireOutputs(t, responses, logeventcap.Output{Cursor: "7-0", Head: logeventcap.Head{Hash: "0x01"}})
+	require.Equal(t, "7-0/01", responses[0].Event.ID)
+
+	th.height.Store(11)
+	l.poll(ctx)
+	requireOutputs(t, receiveAll(ch), logeventcap.Output{Cursor: "9-0", Head: logeventcap.Head{Hash: "0x01"}})
+	require.Len(t, l.state.Pending, 2)
+
+	// finalized logs are no longer checked for reorgs
+	th.reader.setLogs(
+		fakeLog{height: 7, hash: 1, finalized: true},
+		fakeLog{height: 9, hash: 1},
+	)
+	l.poll(ctx)
+	require.Empty(t, receiveAll(ch))
+	require.Equal(t, []pendingLog{{Cursor: "9-0", Height: 9, Hash: []byte{1}, Timestamp: 9}}, l.state.Pending)
+}
+
+func TestLogEventTrigger_Reorg(t *testing.T) {
+	th := newTriggerTH(t)
+	th.height.Store(10)
+	th.reader.setLogs(
+		fakeLog{height: 7, hash: 1},
+		fakeLog{height: 8, hash: 1},
+		fakeLog{height: 9, hash: 1},
+	)
+	l, ch := th.newTrigger(t, 1)
+
+	ctx := testutils.Context(t)
+	l.poll(ctx)
+	require.Len(t, receiveAll(ch), 3)
+
+	// logs from block 8 are reorged: the log of block 8 is included in another
+	// block 8 and the log of block 9 is dropped, while a new log is included
+	th.reader.setLogs(
+		fakeLog{height: 7, hash: 1},
+		fakeLog{height: 8, hash: 2},
+		fakeLog{height: 8, index: 1, hash: 2},
+	)
+	l.poll(ctx)
+	responses := receiveAll(ch)
+	requireOutputs(t, responses,
+		logeventcap.Output{Cursor: "8-0", Head: logeventcap.Head{Hash: "0x01"}, Retracted: true},
+		logeventcap.Output{Cursor: "9-0", Head: logeventcap.Head{Hash: "0x01"}, Retracted: true},
+		logeventcap.Output{Cursor: "8-0", Head: logeventcap.Head{Hash: "0x02"}},
+		logeventcap.Output{Cursor: "8-1", Head: logeventcap.Head{Hash: "0x02"}},
+	)
+	require.Equal(t, "retracted/8-0/01", responses[0].Event.ID)
+	require.Equal(t, "8-0/02", responses[2].Event.ID)
+	require.Len(t, l.state.Pending, 3)
+
+	l.poll(ctx)
+	require.Empty(t, receiveAll(ch))
+}
+
+func TestLogEventTrigger_ResumeFromCursor(t *testing.T) {
+	th := newTriggerTH(t)
+	th.height.Store(10)
+	th.reader.setLogs(
+		fakeLog{height: 7, hash: 1},
+		fakeLog{height: 8, hash: 1},
+	)
+	l, ch := th.newTrigger(t, 1)
+
+	ctx := testutils.Context(t)
+	l.poll(ctx)
+	require.Len(t, receiveAll(ch), 2)
+
+	// while the trigger is down, the log of block 8 is reorged out and new logs are included
+	th.height.Store(100)
+	th.reader.setLogs(
+		fakeLog{height: 7, hash: 1},
+		fakeLog{height: 9, hash: 2},
+		fakeLog{height: 60, hash: 2},
+	)
+	l, ch = th.newTrigger(t, 1)
+	require.Equal(t, "8-0", l.state.Cursor)
+	require.Equal(t, uint64(5), l.state.StartBlockNum)
+
+	l.poll(ctx)
+	requireOutputs(t, receiveAll(ch),
+		logeventcap.Output{Cursor: "8-0", Head: logeventcap.Head{Hash: "0x01"}, Retracted: true},
+		logeventcap.Output{Cursor: "9-0", Head: logeventcap.Head{Hash: "0x02"}},
+		logeventcap.Output{Cursor: "60-0", Head: logeventcap.Head{Hash: "0x02"}},
+	)
+
+	stored, err := th.cursorStore.Read(ctx, testTriggerID)
+	require.NoError(t, err)
+	require.Equal(t, l.state, stored)
+}
+
+// failingKeyValueStore fails to read any value, like a database which is down
+type failingKeyValueStore struct {
+	inMemoryKeyValueStore
+	err error
+}
+
+func (s *failingKeyValueStore) Get(context.Context, string) ([]byte, error) {
+	return nil, s.err
+}
+
+func TestLogEventTrigger_CursorReadError(t *testing.T) {
+	ctx := testutils.Context(t)
+
+	// a read error fails the registration rather than starting from the lookback blocks
+	th := newTriggerTH(t)
+	th.cursorStore = newCursorStore(&failingKeyValueStore{err: errors.New("connection refused")})
+	reqConfig, logEventConfig := testConfigs(1)
+	_, _, err := newLogEventTrigger(ctx, logger.Test(t), testTriggerID, "workflow", reqConfig, logEventConfig, th.relayer, th.cursorStore)
+	require.ErrorContains(t, err, "connection refused")
+}
+
+// sqlKeyValueStore fails to read missing keys with sql.ErrNoRows, like job.NewKVStore
+type sqlKeyValueStore struct {
+	inMemoryKeyValueStore
+}
+
+func (s *sqlKeyValueStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	val, ok := s.values[key]
+	if !ok {
+		return nil, fmt.Errorf("failed to get value by key: %s: %w", key, sql.ErrNoRows)
+	}
+	return val, nil
+}
+
+// kvStoreCapabilities is a standard capability which only hands out the key value store it is initialised with
+type kvStoreCapabilities struct {
+	stores chan core.KeyValueStore
+}
+
+func (c *kvStoreCapabilities) Start(context.Context) error    { return nil }
+func (c *kvStoreCapabilities) Close() error                   { return nil }
+func (c *kvStoreCapabilities) Ready() error                   { return nil }
+func (c *kvStoreCapabilities) HealthReport() map[string]error { return map[string]error{c.Name(): nil} }
+func (c *kvStoreCapabilities) Name() string                   { return "kvStoreCapabilities" }
+func (c *kvStoreCapabilities) Infos(context.Context) ([]capabilities.CapabilityInfo, error) {
+	return nil, nil
+}
+func (c *kvStoreCapabilities) Initialise(_ context.Context, _ string, _ core.TelemetryService, store core.KeyValueStore,
+	_ core.CapabilitiesRegistry, _ core.ErrorLog, _ core.PipelineRunnerService, _ core.RelayerSet, _ core.OracleFactory) error {
+	c.stores <- store
+	return nil
+}
+
+// newLOOPKeyValueStore returns the store of the node as the log event trigger plugin receives it over gRPC
+func newLOOPKeyValueStore(t *testing.T, store core.KeyValueStore) core.KeyValueStore {
+	lggr := logger.Test(t)
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	impl := &kvStoreCapabilities{stores: make(chan core.KeyValueStore, 1)}
+	client, server := plugin.TestPluginGRPCConn(t, true, map[string]plugin.Plugin{
+		loop.PluginStandardCapabilitiesName: &loop.StandardCapabilitiesLoop{
+			Logger:       lggr,
+			PluginServer: impl,
+			BrokerConfig: loop.BrokerConfig{Logger: lggr, StopCh: stopCh},
+		},
+	})
+	t.Cleanup(server.Stop)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	raw, err := client.Dispense(loop.PluginStandardCapabilitiesName)
+	require.NoError(t, err)
+	require.NoError(t, raw.(loop.StandardCapabilities).Initialise(testutils.Context(t), "", nil, store, nil, nil, nil, nil, nil))
+	return <-impl.stores
+}
+
+func TestCursorStore_LOOP(t *testing.T) {
+	ctx := testutils.Context(t)
+	state := triggerState{Cursor: "7-0", StartBlockNum: 5}
+
+	// the error of a missing key does not survive gRPC
+	cs := newCursorStore(newLOOPKeyValueStore(t, &sqlKeyValueStore{inMemoryKeyValueStore{values: map[string][]byte{}}}))
+	_, err := cs.Read(ctx, testTriggerID)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, errNoTriggerState)
+
+	// the node reads missing keys as empty values for standard capabilities
+	cs = newCursorStore(newLOOPKeyValueStore(t, job.NewNotFoundAsEmptyKVStore(&sqlKeyValueStore{inMemoryKeyValueStore{values: map[string][]byte{}}})))
+	_, err = cs.Read(ctx, testTriggerID)
+	require.ErrorIs(t, err, errNoTriggerState)
+
+	require.NoError(t, cs.Write(ctx, testTriggerID, state))
+	stored, err := cs.Read(ctx, testTriggerID)
+	require.NoError(t, err)
+	require.Equal(t, state, stored)
+
+	require.NoError(t, cs.Delete(ctx, testTriggerID))
+	_, err = cs.Read(ctx, testTriggerID)
+	require.ErrorIs(t, err, errNoTriggerState)
+}
+
+func TestTriggerService_UnregisterDeletesCursor(t *testing.T) {
+	th := newTriggerTH(t)
+	th.height.Store(10)
+	th.reader.setLogs(fakeLog{height: 7, hash: 1})
+	l, ch := th.newTrigger(t, 1)
+
+	ctx := testutils.Context(t)
+	l.poll(ctx)
+	require.Len(t, receiveAll(ch), 1)
+	_, err := th.cursorStore.Read(ctx, testTriggerID)
+	require.NoError(t, err)
+
+	s := &TriggerService{
+		lggr:        logger.Test(t),
+		triggers:    NewCapabilitiesStore[logEventTrigger, capabilities.TriggerResponse](),
+		cursorStore: th.cursorStore,
+	}
+	s.triggers.Write(testTriggerID, l)
+	require.NoError(t, l.Start(ctx))
+	require.NoError(t, s.UnregisterTrigger(ctx, capabilities.TriggerRegistrationRequest{TriggerID: testTriggerID}))
+
+	_, err = th.cursorStore.Read(ctx, testTriggerID)
+	require.ErrorIs(t, err, errNoTriggerState)
+	// registered again, the trigger starts from the lookback blocks
+	l, _ = th.newTrigger(t, 1)
+	require.Empty(t, l.state.Cursor)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
@@ -55,7 +54,7 @@ func NewOutgoingConnectorHandler(gc connector.GatewayConnector, config ServiceCo
 	}, nil
 }
 
-// HandleSingleNodeRequest sends a request to first available gateway node and blocks until response is received
+// HandleSingleNodeRequest sends a request to one of the connected gateways and blocks until response is received
 // TODO: handle retries
 func (c *OutgoingConnectorHandler) HandleSingleNodeRequest(ctx context.Context, messageID string, req capabilities.Request) (*api.Message, error) {
 	// set default timeout if not provided for all outgoing requests
@@ -88,25 +87,12 @@ func (c *OutgoingConnectorHandler) HandleSingleNodeRequest(ctx context.Context,
 		Payload:   payload,
 	}
 
-	// simply, send request to first available gateway node from sorted list
-	// this allows for deterministic selection of gateway node receiver for easier debugging
-	gatewayIDs := c.gc.GatewayIDs()
-	if len(gatewayIDs) == 0 {
-		return nil, errors.New("no gateway nodes available")
-	}
-	sort.Strings(gatewayIDs)
-
-	selectedGateway := gatewayIDs[0]
-
-	l.Infow("selected gateway, awaiting connection", "gatewayID", selectedGateway)
-
-	if err := c.gc.AwaitConnection(ctx, selectedGateway); err != nil {
-		return nil, errors.Wrap(err, "await connection canceled")
-	}
-
-	if err := c.gc.SignAndSendToGateway(ctx, selectedGateway, body); err != nil {
+	// the connector picks a gateway by weight, health and latency, and fails over to the others
+	gatewayID, err := c.gc.SignAndSendToAnyGateway(ctx, body)
+	if err != nil {
 		return nil, errors.Wrap(err, "failed to send request to gateway")
 	}
+	l.Debugw("sent request to gateway", "gatewayID", gatewayID)
 
 	select {
 	case resp := <-ch:
@@ -10,7 +10,6 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
-	"github.com/smartcontractkit/chainlink/v2/core/utils/matches"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
 	gcmocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/connector/mocks"
@@ -37,8 +36,6 @@ func TestHandleSingleNodeRequest(t *testing.T) {
 		msgID := "msgID"
 		testURL := "http://localhost:8080"
 		connector.EXPECT().DonID().Return("donID")
-		connector.EXPECT().AwaitConnection(matches.AnyContext, "gateway1").Return(nil)
-		connector.EXPECT().GatewayIDs().Return([]string{"gateway1"})
 
 		// build the expected body with the default timeout
 		req := ghcapabilities.Request{
@@ -56,9 +53,9 @@ func TestHandleSingleNodeRequest(t *testing.T) {
 		}
 
 		// expect the request body to contain the default timeout
-		connector.EXPECT().SignAndSendToGateway(mock.Anything, "gateway1", expectedBody).Run(func(ctx context.Context, gatewayID string, msg *api.MessageBody) {
+		connector.EXPECT().SignAndSendToAnyGateway(mock.Anything, expectedBody).Run(func(ctx context.Context, msg *api.MessageBody) {
 			connectorHandler.HandleGatewayMessage(ctx, "gateway1", gatewayResponse(t, msgID))
-		}).Return(nil).Times(1)
+		}).Return("gateway1", nil).Times(1)
 
 		_, err = connectorHandler.HandleSingleNodeRequest(ctx, msgID, ghcapabilities.Request{
 			URL: testURL,
@@ -84,8 +81,6 @@ func TestHandleSingleNodeRequest(t *testing.T) {
 		msgID := "msgID"
 		testURL := "http://localhost:8080"
 		connector.EXPECT().DonID().Return("donID")
-		connector.EXPECT().AwaitConnection(matches.AnyContext, "gateway1").Return(nil)
-		connector.EXPECT().GatewayIDs().Return([]string{"gateway1"})
 
 		// build the expected body with the defined timeout
 		req := ghcapabilities.Request{
@@ -103,9 +98,9 @@ func TestHandleSingleNodeRequest(t *testing.T) {
 		}
 
 		// expect the request body to contain the defined timeout
-		connector.EXPECT().SignAndSendToGateway(mock.Anything, "gateway1", expectedBody).Run(func(ctx context.Context, gatewayID string, msg *api.MessageBody) {
+		connector.EXPECT().SignAndSendToAnyGateway(mock.Anything, expectedBody).Run(func(ctx context.Context, msg *api.MessageBody) {
 			connectorHandler.HandleGatewayMessage(ctx, "gateway1", gatewayResponse(t, msgID))
-		}).Return(nil).Times(1)
+		}).Return("gateway1", nil).Times(1)
 
 		_, err = connectorHandler.HandleSingleNodeRequest(ctx, msgID, ghcapabilities.Request{
 			URL:       testURL,
@@ -2,11 +2,20 @@ package target
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/tidwall/gjson"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/types/core"
@@ -32,6 +41,8 @@ const (
 	DefaultHTTPMethod   = "GET"
 	DefaultTimeoutMs    = 30000
 	MaxTimeoutMs        = 600000
+
+	DefaultRetryBackoffMs = 1000
 )
 
 // Capability is a target capability that sends HTTP requests to external clients via the Chainlink Gateway.
@@ -93,7 +104,14 @@ func defaultIfNil[T any](value *T, defaultValue T) T {
 	return defaultValue
 }
 
-func getPayload(input webapicap.TargetPayload, cfg webapicap.TargetConfig) (ghcapabilities.Request, error) {
+// getIdempotencyKey returns a key identifying the workflow step execution, so that it is the same across retries
+// and across the nodes of the DON executing the step.
+func getIdempotencyKey(req capabilities.CapabilityRequest) string {
+	hash := sha256.Sum256([]byte(req.Metadata.WorkflowExecutionID + "/" + req.Metadata.ReferenceID))
+	return hex.EncodeToString(hash[:])
+}
+
+func getPayload(input webapicap.TargetPayload, cfg webapicap.TargetConfig, idempotencyKey string) (ghcapabilities.Request, error) {
 	method := defaultIfNil(input.Method, DefaultHTTPMethod)
 	body := defaultIfNil(input.Body, "")
 	timeoutMs := defaultIfNil(cfg.TimeoutMs, DefaultTimeoutMs)
@@ -101,15 +119,41 @@ func getPayload(input webapicap.TargetPayload, cfg webapicap.TargetConfig) (ghca
 		return ghcapabilities.Request{}, fmt.Errorf("timeoutMs must be between 0 and %d", MaxTimeoutMs)
 	}
 
+	headers := input.Headers
+	if header := defaultIfNil(cfg.IdempotencyKeyHeader, ""); header != "" {
+		headers = maps.Clone(input.Headers)
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		headers[header] = idempotencyKey
+	}
+
 	return ghcapabilities.Request{
 		URL:       input.Url,
 		Method:    method,
-		Headers:   input.Headers,
+		Headers:   headers,
 		Body:      []byte(body),
 		TimeoutMs: timeoutMs,
 	}, nil
 }
 
+// compileResponseSchema compiles the JSON schema of the response body. Referencing external schemas is not allowed.
+func compileResponseSchema(schema string) (*jsonschema.Schema, error) {
+	const url = "responseSchema.json"
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("loading external schema %s is not allowed", s)
+	}
+	if err := compiler.AddResource(url, strings.NewReader(schema)); err != nil {
+		return nil, fmt.Errorf("invalid responseSchema: %w", err)
+	}
+	compiled, err := compiler.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid responseSchema: %w", err)
+	}
+	return compiled, nil
+}
+
 func (c *Capability) Execute(ctx context.Context, req capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error) {
 	c.lggr.Debugw("executing http target", "capabilityRequest", req)
 
@@ -130,34 +174,31 @@ func (c *Capability) Execute(ctx context.Context, req capabilities.CapabilityReq
 		return capabilities.CapabilityResponse{}, err
 	}
 
-	payload, err := getPayload(input, workflowCfg)
+	payload, err := getPayload(input, workflowCfg, getIdempotencyKey(req))
 	if err != nil {
 		return capabilities.CapabilityResponse{}, err
 	}
 
+	var responseSchema *jsonschema.Schema
+	if workflowCfg.ResponseSchema != nil {
+		responseSchema, err = compileResponseSchema(*workflowCfg.ResponseSchema)
+		if err != nil {
+			return capabilities.CapabilityResponse{}, err
+		}
+	}
+
 	// Default to SingleNode delivery mode
 	deliveryMode := defaultIfNil(workflowCfg.DeliveryMode, webapi.SingleNode)
 
 	switch deliveryMode {
 	case webapi.SingleNode:
-		// blocking call to handle single node request. waits for response from gateway
-		resp, err := c.connectorHandler.HandleSingleNodeRequest(ctx, messageID, payload)
-		if err != nil {
-			return capabilities.CapabilityResponse{}, err
-		}
-		c.lggr.Debugw("received gateway response", "resp", resp)
-		var payload ghcapabilities.Response
-		err = json.Unmarshal(resp.Body.Payload, &payload)
+		// blocking call to handle single node requests. waits for responses from gateway
+		resp, err := c.sendWithRetries(ctx, messageID, payload, workflowCfg)
 		if err != nil {
 			return capabilities.CapabilityResponse{}, err
 		}
 
-		// TODO: check target response format and fields CM-473
-		values, err := values.NewMap(map[string]any{
-			"statusCode": payload.StatusCode,
-			"headers":    payload.Headers,
-			"body":       payload.Body,
-		})
+		values, err := getResponseValues(resp, responseSchema, workflowCfg.Extract)
 		if err != nil {
 			return capabilities.CapabilityResponse{}, err
 		}
@@ -169,9 +210,108 @@ func (c *Capability) Execute(ctx context.Context, req capabilities.CapabilityReq
 	}
 }
 
+// sendWithRetries sends the request until it gets a response with a status code not listed in retryOnStatus,
+// retrying failed requests at most retryCount times. Each attempt is sent with its own message ID, while the
+// idempotency key stays the same. Attempts and backoffs never outlast the deadline of the step, if any.
+func (c *Capability) sendWithRetries(ctx context.Context, messageID string, payload ghcapabilities.Request, cfg webapicap.TargetConfig) (ghcapabilities.Response, error) {
+	retryCount := int(defaultIfNil(cfg.RetryCount, 0))
+	backoff := time.Duration(defaultIfNil(cfg.RetryBackoffMs, DefaultRetryBackoffMs)) * time.Millisecond
+	deadline, hasDeadline := ctx.Deadline()
+	for attempt := 1; ; attempt++ {
+		attemptPayload := payload
+		if remainingMs := time.Until(deadline).Milliseconds(); hasDeadline && remainingMs < int64(payload.TimeoutMs) {
+			// a timeout of 0 would be the default timeout, so the attempt gets at least a millisecond
+			attemptPayload.TimeoutMs = uint32(max(remainingMs, 1)) //nolint:gosec // G115 bounded by TimeoutMs
+		}
+		resp, err := c.send(ctx, getAttemptMessageID(messageID, attempt), attemptPayload)
+		if err == nil && slices.Contains(cfg.RetryOnStatus, uint16(resp.StatusCode)) { //nolint:gosec // G115 status codes are 3 digits
+			err = fmt.Errorf("received retryable status code %d", resp.StatusCode)
+		}
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return ghcapabilities.Response{}, err
+		}
+		if attempt > retryCount {
+			return ghcapabilities.Response{}, fmt.Errorf("request failed after %d attempt(s): %w", attempt, err)
+		}
+		if hasDeadline && time.Until(deadline) <= backoff {
+			return ghcapabilities.Response{}, fmt.Errorf("request failed after %d attempt(s), no time left to retry before the step deadline: %w", attempt, err)
+		}
+		c.lggr.Warnw("web API request failed, retrying", "messageID", messageID, "attempt", attempt, "backoff", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ghcapabilities.Response{}, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// getAttemptMessageID returns the message ID of an attempt to send a request, so that late responses to an attempt
+// are never mistaken for responses to a retry.
+func getAttemptMessageID(messageID string, attempt int) string {
+	if attempt == 1 {
+		return messageID
+	}
+	return messageID + "/" + strconv.Itoa(attempt)
+}
+
+func (c *Capability) send(ctx context.Context, messageID string, payload ghcapabilities.Request) (ghcapabilities.Response, error) {
+	resp, err := c.connectorHandler.HandleSingleNodeRequest(ctx, messageID, payload)
+	if err != nil {
+		return ghcapabilities.Response{}, err
+	}
+	c.lggr.Debugw("received gateway response", "resp", resp)
+	var response ghcapabilities.Response
+	err = json.Unmarshal(resp.Body.Payload, &response)
+	if err != nil {
+		return ghcapabilities.Response{}, err
+	}
+	if response.ExecutionError {
+		return ghcapabilities.Response{}, fmt.Errorf("gateway failed to execute request: %s", response.ErrorMessage)
+	}
+	return response, nil
+}
+
+// getResponseValues validates the response body against the response schema, if any, and returns the response
+// together with the fields extracted from its body.
+func getResponseValues(resp ghcapabilities.Response, responseSchema *jsonschema.Schema, extract webapicap.TargetConfigExtract) (*values.Map, error) {
+	if responseSchema != nil {
+		var body any
+		if err := json.Unmarshal(resp.Body, &body); err != nil {
+			return nil, fmt.Errorf("response body is not valid JSON: %w", err)
+		}
+		if err := responseSchema.Validate(body); err != nil {
+			return nil, fmt.Errorf("response body does not match responseSchema: %w", err)
+		}
+	}
+
+	result := map[string]any{
+		"statusCode": resp.StatusCode,
+		"headers":    resp.Headers,
+		"body":       resp.Body,
+	}
+	if len(extract) > 0 {
+		if !gjson.ValidBytes(resp.Body) {
+			return nil, fmt.Errorf("cannot extract fields: response body is not valid JSON")
+		}
+		extracted := make(map[string]any, len(extract))
+		for name, path := range extract {
+			field := gjson.GetBytes(resp.Body, path)
+			if !field.Exists() {
+				return nil, fmt.Errorf("cannot extract field %s: path %s not found in response body", name, path)
+			}
+			extracted[name] = field.Value()
+		}
+		result["extracted"] = extracted
+	}
+	return values.NewMap(result)
+}
+
 func (c *Capability) RegisterToWorkflow(ctx context.Context, req capabilities.RegisterToWorkflowRequest) error {
 	// Workflow engine guarantees registration requests are valid
-	// TODO: handle retry configuration CM-472
 	return nil
 }
 
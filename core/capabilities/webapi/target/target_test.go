@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -110,10 +112,13 @@ func capabilityRequest(t *testing.T) capabilities.CapabilityRequest {
 }
 
 func gatewayResponse(t *testing.T, msgID string) *api.Message {
+	return gatewayResponseWithStatus(t, msgID, 200, []byte("response body"))
+}
+
+func gatewayResponseWithStatus(t *testing.T, msgID string, statusCode int, body []byte) *api.Message {
 	headers := map[string]string{"Content-Type": "application/json"}
-	body := []byte("response body")
 	responsePayload, err := json.Marshal(ghcapabilities.Response{
-		StatusCode:     200,
+		StatusCode:     statusCode,
 		Headers:        headers,
 		Body:           body,
 		ExecutionError: false,
@@ -177,7 +182,6 @@ func TestCapability_Execute(t *testing.T) {
 	th := setup(t, defaultConfig)
 	ctx := testutils.Context(t)
 	th.connector.EXPECT().DonID().Return("donID")
-	th.connector.EXPECT().GatewayIDs().Return([]string{"gateway2", "gateway1"})
 
 	t.Run("happy case", func(t *testing.T) {
 		regReq := capabilities.RegisterToWorkflowRequest{
@@ -194,8 +198,7 @@ func TestCapability_Execute(t *testing.T) {
 		require.NoError(t, err)
 
 		gatewayResp := gatewayResponse(t, msgID)
-		th.connector.EXPECT().AwaitConnection(mock.Anything, "gateway1").Return(nil)
-		th.connector.On("SignAndSendToGateway", mock.Anything, "gateway1", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		th.connector.On("SignAndSendToAnyGateway", mock.Anything, mock.Anything).Return("gateway1", nil).Run(func(args mock.Arguments) {
 			th.connectorHandler.HandleGatewayMessage(ctx, "gateway1", gatewayResp)
 		}).Once()
 
@@ -219,7 +222,7 @@ func TestCapability_Execute(t *testing.T) {
 		require.NoError(t, err)
 
 		newCtx, cancel := context.WithCancel(ctx)
-		th.connector.On("SignAndSendToGateway", mock.Anything, "gateway1", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		th.connector.On("SignAndSendToAnyGateway", mock.Anything, mock.Anything).Return("gateway1", nil).Run(func(args mock.Arguments) {
 			cancel()
 		}).Once()
 
@@ -329,7 +332,7 @@ func TestCapability_Execute(t *testing.T) {
 		req := capabilityRequest(t)
 		require.NoError(t, err)
 
-		th.connector.EXPECT().SignAndSendToGateway(mock.Anything, "gateway1", mock.Anything).Return(errors.New("gateway error")).Once()
+		th.connector.EXPECT().SignAndSendToAnyGateway(mock.Anything, mock.Anything).Return("", errors.New("gateway error")).Once()
 		_, err = th.capability.Execute(ctx, req)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "gateway error")
@@ -358,7 +361,7 @@ func TestCapability_Execute(t *testing.T) {
 		msgID, err := getMessageID(req)
 		require.NoError(t, err)
 		gatewayResp := gatewayResponse(t, msgID)
-		th.connector.On("SignAndSendToGateway", mock.Anything, "gateway1", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		th.connector.On("SignAndSendToAnyGateway", mock.Anything, mock.Anything).Return("gateway1", nil).Run(func(args mock.Arguments) {
 			th.connectorHandler.HandleGatewayMessage(ctx, "gateway1", gatewayResp)
 		}).Once()
 
@@ -379,3 +382,155 @@ func verifyResp(t *testing.T, resp capabilities.CapabilityResponse) {
 	require.True(t, ok)
 	require.Equal(t, "response body", string(respBody))
 }
+
+func capabilityRequestWithConfig(t *testing.T, config map[string]any) capabilities.CapabilityRequest {
+	req := capabilityRequest(t)
+	wfConfig, err := values.NewMap(config)
+	require.NoError(t, err)
+	req.Config = wfConfig
+	return req
+}
+
+func TestCapability_Execute_ResponseHandling(t *testing.T) {
+	th := setup(t, defaultConfig)
+	ctx := testutils.Context(t)
+	th.connector.EXPECT().DonID().Return("donID")
+	msgID, err := getMessageID(capabilityRequest(t))
+	require.NoError(t, err)
+	jsonBody := []byte(`{"data":{"id":"abc","confirmed":true}}`)
+
+	// responses are sent to the message ID of each attempt, which are recorded
+	var sentIDs []string
+	respondWith := func(responses ...*api.Message) {
+		sentIDs = nil
+		for _, resp := range responses {
+			th.connector.EXPECT().SignAndSendToAnyGateway(mock.Anything, mock.Anything).Return("gateway1", nil).Run(func(_ context.Context, msg *api.MessageBody) {
+				sentIDs = append(sentIDs, msg.MessageId)
+				resp.Body.MessageId = msg.MessageId
+				th.connectorHandler.HandleGatewayMessage(ctx, "gateway1", resp)
+			}).Once()
+		}
+	}
+
+	t.Run("retries on status", func(t *testing.T) {
+		respondWith(
+			gatewayResponseWithStatus(t, msgID, 503, nil),
+			gatewayResponseWithStatus(t, msgID, 429, nil),
+			gatewayResponse(t, msgID),
+		)
+		req := capabilityRequestWithConfig(t, map[string]any{"retryCount": 2, "retryOnStatus": []int{429, 503}, "retryBackoffMs": 1})
+		resp, err := th.capability.Execute(ctx, req)
+		require.NoError(t, err)
+		verifyResp(t, resp)
+		require.Equal(t, []string{msgID, msgID + "/2", msgID + "/3"}, sentIDs)
+	})
+
+	t.Run("retries capped by step deadline", func(t *testing.T) {
+		respondWith(gatewayResponseWithStatus(t, msgID, 503, nil))
+		req := capabilityRequestWithConfig(t, map[string]any{"retryCount": 2, "retryOnStatus": []int{503}, "retryBackoffMs": 60000})
+		stepCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		_, err := th.capability.Execute(stepCtx, req)
+		require.ErrorContains(t, err, "request failed after 1 attempt(s), no time left to retry before the step deadline")
+	})
+
+	t.Run("attempt timeout capped by step deadline", func(t *testing.T) {
+		th.connector.EXPECT().SignAndSendToAnyGateway(mock.Anything, mock.Anything).Return("gateway1", nil).Run(func(_ context.Context, msg *api.MessageBody) {
+			var payload ghcapabilities.Request
+			assert.NoError(t, json.Unmarshal(msg.Payload, &payload))
+			assert.LessOrEqual(t, payload.TimeoutMs, uint32(10000))
+			th.connectorHandler.HandleGatewayMessage(ctx, "gateway1", gatewayResponse(t, msg.MessageId))
+		}).Once()
+		req := capabilityRequestWithConfig(t, map[string]any{"timeoutMs": 60000})
+		stepCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		resp, err := th.capability.Execute(stepCtx, req)
+		require.NoError(t, err)
+		verifyResp(t, resp)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		respondWith(
+			gatewayResponseWithStatus(t, msgID, 503, nil),
+			gatewayResponseWithStatus(t, msgID, 503, nil),
+		)
+		req := capabilityRequestWithConfig(t, map[string]any{"retryCount": 1, "retryOnStatus": []int{503}, "retryBackoffMs": 1})
+		_, err := th.capability.Execute(ctx, req)
+		require.ErrorContains(t, err, "request failed after 2 attempt(s): received retryable status code 503")
+	})
+
+	t.Run("retries gateway errors", func(t *testing.T) {
+		th.connector.EXPECT().SignAndSendToAnyGateway(mock.Anything, mock.Anything).Return("", errors.New("gateway error")).Once()
+		respondWith(gatewayResponse(t, msgID))
+		req := capabilityRequestWithConfig(t, map[string]any{"retryCount": 1, "retryBackoffMs": 1})
+		resp, err := th.capability.Execute(ctx, req)
+		require.NoError(t, err)
+		verifyResp(t, resp)
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		responsePayload, err := json.Marshal(ghcapabilities.Response{ExecutionError: true, ErrorMessage: "connection refused"})
+		require.NoError(t, err)
+		respondWith(&api.Message{Body: api.MessageBody{MessageId: msgID, Method: ghcapabilities.MethodWebAPITarget, Payload: responsePayload}})
+		_, err = th.capability.Execute(ctx, capabilityRequestWithConfig(t, map[string]any{}))
+		require.ErrorContains(t, err, "gateway failed to execute request: connection refused")
+	})
+
+	t.Run("idempotency key", func(t *testing.T) {
+		req := capabilityRequestWithConfig(t, map[string]any{"idempotencyKeyHeader": "Idempotency-Key", "retryCount": 1, "retryOnStatus": []int{503}, "retryBackoffMs": 1})
+		req.Metadata.ReferenceID = "write"
+		var keys []string
+		for _, resp := range []*api.Message{gatewayResponseWithStatus(t, msgID, 503, nil), gatewayResponse(t, msgID)} {
+			th.connector.EXPECT().SignAndSendToAnyGateway(mock.Anything, mock.Anything).Return("gateway1", nil).Run(func(_ context.Context, msg *api.MessageBody) {
+				var payload ghcapabilities.Request
+				assert.NoError(t, json.Unmarshal(msg.Payload, &payload))
+				assert.Equal(t, "application/json", payload.Headers["Content-Type"])
+				keys = append(keys, payload.Headers["Idempotency-Key"])
+				resp.Body.MessageId = msg.MessageId
+				th.connectorHandler.HandleGatewayMessage(ctx, "gateway1", resp)
+			}).Once()
+		}
+		_, err := th.capability.Execute(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		require.Equal(t, getIdempotencyKey(req), keys[0])
+		require.Equal(t, keys[0], keys[1])
+
+		otherStep := req
+		otherStep.Metadata.ReferenceID = "other_write"
+		require.NotEqual(t, getIdempotencyKey(req), getIdempotencyKey(otherStep))
+	})
+
+	t.Run("response schema and extraction", func(t *testing.T) {
+		respondWith(gatewayResponseWithStatus(t, msgID, 200, jsonBody))
+		req := capabilityRequestWithConfig(t, map[string]any{
+			"responseSchema": `{"type":"object","required":["data"],"properties":{"data":{"type":"object","required":["id"]}}}`,
+			"extract":        map[string]string{"id": "data.id", "confirmed": "data.confirmed"},
+		})
+		resp, err := th.capability.Execute(ctx, req)
+		require.NoError(t, err)
+		var values map[string]any
+		require.NoError(t, resp.Value.UnwrapTo(&values))
+		require.Equal(t, map[string]any{"id": "abc", "confirmed": true}, values["extracted"])
+	})
+
+	t.Run("response not matching schema", func(t *testing.T) {
+		respondWith(gatewayResponseWithStatus(t, msgID, 200, []byte(`{"error":"failed"}`)))
+		req := capabilityRequestWithConfig(t, map[string]any{"responseSchema": `{"type":"object","required":["data"]}`})
+		_, err := th.capability.Execute(ctx, req)
+		require.ErrorContains(t, err, "response body does not match responseSchema")
+	})
+
+	t.Run("invalid response schema", func(t *testing.T) {
+		req := capabilityRequestWithConfig(t, map[string]any{"responseSchema": `{"$ref":"https://example.com/schema.json"}`})
+		_, err := th.capability.Execute(ctx, req)
+		require.ErrorContains(t, err, "invalid responseSchema")
+	})
+
+	t.Run("missing extracted field", func(t *testing.T) {
+		respondWith(gatewayResponseWithStatus(t, msgID, 200, jsonBody))
+		req := capabilityRequestWithConfig(t, map[string]any{"extract": map[string]string{"tx": "data.txHash"}})
+		_, err := th.capability.Execute(ctx, req)
+		require.ErrorContains(t, err, "cannot extract field tx: path data.txHash not found in response body")
+	})
+}
@@ -13,9 +13,14 @@ func (cfg TargetConfig) New(w *sdk.WorkflowSpecFactory, input TargetInput) {
 		ID:     "web-api-target@1.0.0",
 		Inputs: input.ToSteps(),
 		Config: map[string]any{
-			"deliveryMode": cfg.DeliveryMode,
-			"retryCount":   cfg.RetryCount,
-			"timeoutMs":    cfg.TimeoutMs,
+			"deliveryMode":         cfg.DeliveryMode,
+			"extract":              cfg.Extract,
+			"idempotencyKeyHeader": cfg.IdempotencyKeyHeader,
+			"responseSchema":       cfg.ResponseSchema,
+			"retryBackoffMs":       cfg.RetryBackoffMs,
+			"retryCount":           cfg.RetryCount,
+			"retryOnStatus":        cfg.RetryOnStatus,
+			"timeoutMs":            cfg.TimeoutMs,
 		},
 		CapabilityType: capabilities.CapabilityTypeTarget,
 	}
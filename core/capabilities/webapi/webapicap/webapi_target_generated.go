@@ -20,14 +20,37 @@ type TargetConfig struct {
 	// The delivery mode for the request. Defaults to SingleNode
 	DeliveryMode *string `json:"deliveryMode,omitempty" yaml:"deliveryMode,omitempty" mapstructure:"deliveryMode,omitempty"`
 
-	// The number of times to retry the request. Defaults to 0 retries
+	// The fields to extract from the JSON response body, keyed by output name. Values
+	// are GJSON paths, e.g. data.id
+	Extract TargetConfigExtract `json:"extract,omitempty" yaml:"extract,omitempty" mapstructure:"extract,omitempty"`
+
+	// The header carrying an idempotency key of the request, identical across retries
+	// and nodes of the DON executing the same workflow step
+	IdempotencyKeyHeader *string `json:"idempotencyKeyHeader,omitempty" yaml:"idempotencyKeyHeader,omitempty" mapstructure:"idempotencyKeyHeader,omitempty"`
+
+	// A JSON schema the JSON response body must satisfy for the request to succeed
+	ResponseSchema *string `json:"responseSchema,omitempty" yaml:"responseSchema,omitempty" mapstructure:"responseSchema,omitempty"`
+
+	// The delay in milliseconds before the first retry, doubling on every further
+	// retry. Defaults to 1 second
+	RetryBackoffMs *uint16 `json:"retryBackoffMs,omitempty" yaml:"retryBackoffMs,omitempty" mapstructure:"retryBackoffMs,omitempty"`
+
+	// The number of times to retry the request, as long as the step timeout allows. Defaults to 0 retries
 	RetryCount *uint8 `json:"retryCount,omitempty" yaml:"retryCount,omitempty" mapstructure:"retryCount,omitempty"`
 
+	// The HTTP status codes of responses to retry the request on, e.g. 429 or 503.
+	// Requests failing to reach the gateway are always retried
+	RetryOnStatus []uint16 `json:"retryOnStatus,omitempty" yaml:"retryOnStatus,omitempty" mapstructure:"retryOnStatus,omitempty"`
+
 	// The timeout in milliseconds for the request. If set to 0, the default value is
 	// 30 seconds
 	TimeoutMs *uint32 `json:"timeoutMs,omitempty" yaml:"timeoutMs,omitempty" mapstructure:"timeoutMs,omitempty"`
 }
 
+// The fields to extract from the JSON response body, keyed by output name. Values
+// are GJSON paths, e.g. data.id
+type TargetConfigExtract map[string]string
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (j *TargetConfig) UnmarshalJSON(b []byte) error {
 	type Plain TargetConfig
@@ -35,6 +58,9 @@ func (j *TargetConfig) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &plain); err != nil {
 		return err
 	}
+	if plain.RetryBackoffMs != nil && 60000 < *plain.RetryBackoffMs {
+		return fmt.Errorf("field %s: must be <= %v", "retryBackoffMs", 60000)
+	}
 	if plain.RetryCount != nil && 10 < *plain.RetryCount {
 		return fmt.Errorf("field %s: must be <= %v", "retryCount", 10)
 	}
@@ -49,7 +49,7 @@ func (e *EVMConfig) TOMLString() (string, error) {
 }
 
 func (e *EVMConfig) BalanceMonitor() BalanceMonitor {
-	return &balanceMonitorConfig{c: e.C.BalanceMonitor}
+	return &balanceMonitorConfig{c: e.C.BalanceMonitor, k: e.C.KeySpecific}
 }
 
 func (e *EVMConfig) Transactions() Transactions {
@@ -180,6 +180,10 @@ func (e *EVMConfig) LogBroadcasterEnabled() bool {
 	return e.C.LogBroadcasterEnabled == nil || *e.C.LogBroadcasterEnabled
 }
 
+func (e *EVMConfig) LogBroadcasterUseLogPoller() bool {
+	return e.C.LogBroadcasterUseLogPoller != nil && *e.C.LogBroadcasterUseLogPoller
+}
+
 func (e *EVMConfig) LogPrunePageSize() uint32 {
 	return *e.C.LogPrunePageSize
 }
@@ -1,11 +1,66 @@
 package config
 
-import "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
+import (
+	"net/url"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+)
 
 type balanceMonitorConfig struct {
 	c toml.BalanceMonitor
+	k toml.KeySpecificConfig
 }
 
 func (b *balanceMonitorConfig) Enabled() bool {
 	return *b.c.Enabled
 }
+
+func (b *balanceMonitorConfig) LowBalanceThreshold() *assets.Wei {
+	return b.c.LowBalanceThreshold
+}
+
+// LowBalanceThresholdKey returns the KeySpecific threshold for addr, falling back to LowBalanceThreshold.
+func (b *balanceMonitorConfig) LowBalanceThresholdKey(addr gethcommon.Address) *assets.Wei {
+	for i := range b.k {
+		ks := b.k[i]
+		if ks.Key.Address() == addr && ks.BalanceMonitor.LowBalanceThreshold != nil {
+			return ks.BalanceMonitor.LowBalanceThreshold
+		}
+	}
+	return b.c.LowBalanceThreshold
+}
+
+func (b *balanceMonitorConfig) AlertWebhookURL() *url.URL {
+	if b.c.AlertWebhookURL == nil {
+		return nil
+	}
+	return b.c.AlertWebhookURL.URL()
+}
+
+func (b *balanceMonitorConfig) TopUp() BalanceMonitorTopUp {
+	return &balanceMonitorTopUpConfig{c: b.c.TopUp}
+}
+
+type balanceMonitorTopUpConfig struct {
+	c toml.BalanceMonitorTopUp
+}
+
+func (t *balanceMonitorTopUpConfig) Enabled() bool {
+	return *t.c.Enabled
+}
+
+func (t *balanceMonitorTopUpConfig) FundingAddress() *types.EIP55Address {
+	return t.c.FundingAddress
+}
+
+func (t *balanceMonitorTopUpConfig) Amount() *assets.Wei {
+	return t.c.Amount
+}
+
+func (t *balanceMonitorTopUpConfig) DailyLimit() *assets.Wei {
+	return t.c.DailyLimit
+}
@@ -39,6 +39,10 @@ func (t *transactionsConfig) AutoPurge() AutoPurgeConfig {
 	return &autoPurgeConfig{c: t.c.AutoPurge}
 }
 
+func (t *transactionsConfig) RevertReason() RevertReasonConfig {
+	return &revertReasonConfig{c: t.c.RevertReason}
+}
+
 type autoPurgeConfig struct {
 	c toml.AutoPurgeConfig
 }
@@ -58,3 +62,15 @@ func (a *autoPurgeConfig) MinAttempts() *uint32 {
 func (a *autoPurgeConfig) DetectionApiUrl() *url.URL {
 	return a.c.DetectionApiUrl.URL()
 }
+
+type revertReasonConfig struct {
+	c toml.RevertReasonConfig
+}
+
+func (r *revertReasonConfig) Enabled() bool {
+	return *r.c.Enabled
+}
+
+func (r *revertReasonConfig) Trace() bool {
+	return *r.c.Trace
+}
@@ -45,6 +45,7 @@ type EVM interface {
 	NonceAutoSync() bool
 	OperatorFactoryAddress() string
 	LogBroadcasterEnabled() bool
+	LogBroadcasterUseLogPoller() bool
 	RPCDefaultBatchSize() uint32
 	NodeNoNewHeadsThreshold() time.Duration
 	FinalizedBlockOffset() uint32
@@ -82,6 +83,17 @@ type HeadTracker interface {
 
 type BalanceMonitor interface {
 	Enabled() bool
+	LowBalanceThreshold() *assets.Wei
+	LowBalanceThresholdKey(gethcommon.Address) *assets.Wei
+	AlertWebhookURL() *url.URL
+	TopUp() BalanceMonitorTopUp
+}
+
+type BalanceMonitorTopUp interface {
+	Enabled() bool
+	FundingAddress() *types.EIP55Address
+	Amount() *assets.Wei
+	DailyLimit() *assets.Wei
 }
 
 type ClientErrors interface {
@@ -110,6 +122,7 @@ type Transactions interface {
 	MaxInFlight() uint32
 	MaxQueued() uint64
 	AutoPurge() AutoPurgeConfig
+	RevertReason() RevertReasonConfig
 }
 
 type AutoPurgeConfig interface {
@@ -119,6 +132,11 @@ type AutoPurgeConfig interface {
 	DetectionApiUrl() *url.URL
 }
 
+type RevertReasonConfig interface {
+	Enabled() bool
+	Trace() bool
+}
+
 type GasEstimator interface {
 	BlockHistory() BlockHistory
 	FeeHistory() FeeHistory
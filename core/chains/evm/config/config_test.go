@@ -224,6 +224,15 @@ func TestChainScopedConfig(t *testing.T) {
 
 		})
 	})
+
+	t.Run("LogBroadcasterUseLogPoller", func(t *testing.T) {
+		assert.False(t, cfg.EVM().LogBroadcasterUseLogPoller())
+
+		cfg3 := testutils.NewTestChainScopedConfig(t, func(c *toml.EVMConfig) {
+			c.LogBroadcasterUseLogPoller = ptr(true)
+		})
+		assert.True(t, cfg3.EVM().LogBroadcasterUseLogPoller())
+	})
 }
 
 func TestChainScopedConfig_BlockHistory(t *testing.T) {
@@ -346,6 +355,32 @@ func TestChainScopedConfig_HeadTracker(t *testing.T) {
 	assert.Equal(t, true, ht.PersistenceEnabled())
 }
 
+func TestChainScopedConfig_BalanceMonitor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		bm := testutils.NewTestChainScopedConfig(t, nil).EVM().BalanceMonitor()
+		assert.True(t, bm.Enabled())
+		assert.True(t, bm.LowBalanceThreshold().IsZero())
+		assert.Nil(t, bm.AlertWebhookURL())
+		assert.False(t, bm.TopUp().Enabled())
+	})
+
+	t.Run("LowBalanceThresholdKey", func(t *testing.T) {
+		addr := testutils.NewAddress()
+		other := testutils.NewAddress()
+		bm := testutils.NewTestChainScopedConfig(t, func(c *toml.EVMConfig) {
+			c.BalanceMonitor.LowBalanceThreshold = assets.NewWeiI(10)
+			c.KeySpecific = toml.KeySpecificConfig{{
+				Key:            ptr(types.EIP55AddressFromAddress(addr)),
+				BalanceMonitor: toml.KeySpecificBalanceMonitor{LowBalanceThreshold: assets.NewWeiI(42)},
+			}}
+		}).EVM().BalanceMonitor()
+		assert.Equal(t, assets.NewWeiI(42), bm.LowBalanceThresholdKey(addr))
+		assert.Equal(t, assets.NewWeiI(10), bm.LowBalanceThresholdKey(other))
+	})
+}
+
 func TestNodePoolConfig(t *testing.T) {
 	cfg := testutils.NewTestChainScopedConfig(t, nil)
 
@@ -318,6 +318,10 @@ func (c *EVMConfig) ValidateConfig() (err error) {
 		if c.LogBroadcasterEnabled != nil {
 			logBroadcasterEnabled = *c.LogBroadcasterEnabled
 		}
+		if c.LogBroadcasterUseLogPoller != nil && *c.LogBroadcasterUseLogPoller {
+			// logs are read by the LogPoller, which does not need a websocket connection
+			logBroadcasterEnabled = false
+		}
 
 		if c.NodePool.NewHeadsPollInterval != nil {
 			newHeadsPollingInterval = *c.NodePool.NewHeadsPollInterval
@@ -382,6 +386,7 @@ type Chain struct {
 	NoNewHeadsThreshold          *commonconfig.Duration
 	OperatorFactoryAddress       *types.EIP55Address
 	LogBroadcasterEnabled        *bool
+	LogBroadcasterUseLogPoller   *bool
 	RPCDefaultBatchSize          *uint32
 	RPCBlockQueryDelay           *uint16
 	FinalizedBlockOffset         *uint32
@@ -412,6 +417,10 @@ func (c *Chain) ValidateConfig() (err error) {
 		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "FinalityDepth", Value: *c.FinalityDepth,
 			Msg: "must be greater than or equal to 1"})
 	}
+	if c.LogBroadcasterUseLogPoller != nil && *c.LogBroadcasterUseLogPoller && (c.LogPollInterval == nil || c.LogPollInterval.Duration() <= 0) {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "LogPollInterval", Value: c.LogPollInterval,
+			Msg: "must be greater than 0 when LogBroadcasterUseLogPoller is enabled"})
+	}
 	if *c.MinIncomingConfirmations < 1 {
 		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "MinIncomingConfirmations", Value: *c.MinIncomingConfirmations,
 			Msg: "must be greater than or equal to 1"})
@@ -479,7 +488,8 @@ type Transactions struct {
 	ReaperThreshold      *commonconfig.Duration
 	ResendAfterThreshold *commonconfig.Duration
 
-	AutoPurge AutoPurgeConfig `toml:",omitempty"`
+	AutoPurge    AutoPurgeConfig    `toml:",omitempty"`
+	RevertReason RevertReasonConfig `toml:",omitempty"`
 }
 
 func (t *Transactions) setFrom(f *Transactions) {
@@ -502,6 +512,7 @@ func (t *Transactions) setFrom(f *Transactions) {
 		t.ResendAfterThreshold = v
 	}
 	t.AutoPurge.setFrom(&f.AutoPurge)
+	t.RevertReason.setFrom(&f.RevertReason)
 }
 
 type AutoPurgeConfig struct {
@@ -526,6 +537,20 @@ func (a *AutoPurgeConfig) setFrom(f *AutoPurgeConfig) {
 	}
 }
 
+type RevertReasonConfig struct {
+	Enabled *bool
+	Trace   *bool
+}
+
+func (r *RevertReasonConfig) setFrom(f *RevertReasonConfig) {
+	if v := f.Enabled; v != nil {
+		r.Enabled = v
+	}
+	if v := f.Trace; v != nil {
+		r.Trace = v
+	}
+}
+
 type OCR2 struct {
 	Automation Automation `toml:",omitempty"`
 }
@@ -564,13 +589,80 @@ func (m *Workflow) setFrom(f *Workflow) {
 }
 
 type BalanceMonitor struct {
-	Enabled *bool
+	Enabled             *bool
+	LowBalanceThreshold *assets.Wei
+	AlertWebhookURL     *commonconfig.URL
+
+	TopUp BalanceMonitorTopUp `toml:",omitempty"`
 }
 
 func (m *BalanceMonitor) setFrom(f *BalanceMonitor) {
 	if v := f.Enabled; v != nil {
 		m.Enabled = v
 	}
+	if v := f.LowBalanceThreshold; v != nil {
+		m.LowBalanceThreshold = v
+	}
+	if v := f.AlertWebhookURL; v != nil {
+		m.AlertWebhookURL = v
+	}
+	m.TopUp.setFrom(&f.TopUp)
+}
+
+func (m *BalanceMonitor) ValidateConfig() (err error) {
+	if m.LowBalanceThreshold != nil && m.LowBalanceThreshold.IsNegative() {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "LowBalanceThreshold", Value: m.LowBalanceThreshold, Msg: "must not be negative"})
+	}
+	if m.AlertWebhookURL != nil && !m.AlertWebhookURL.IsZero() {
+		switch m.AlertWebhookURL.Scheme {
+		case "http", "https":
+		default:
+			err = multierr.Append(err, commonconfig.ErrInvalid{Name: "AlertWebhookURL", Value: m.AlertWebhookURL.Scheme, Msg: "must be http or https"})
+		}
+	}
+	return
+}
+
+type BalanceMonitorTopUp struct {
+	Enabled        *bool
+	FundingAddress *types.EIP55Address
+	Amount         *assets.Wei
+	DailyLimit     *assets.Wei
+}
+
+func (t *BalanceMonitorTopUp) setFrom(f *BalanceMonitorTopUp) {
+	if v := f.Enabled; v != nil {
+		t.Enabled = v
+	}
+	if v := f.FundingAddress; v != nil {
+		t.FundingAddress = v
+	}
+	if v := f.Amount; v != nil {
+		t.Amount = v
+	}
+	if v := f.DailyLimit; v != nil {
+		t.DailyLimit = v
+	}
+}
+
+func (t *BalanceMonitorTopUp) ValidateConfig() (err error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return
+	}
+	if t.FundingAddress == nil {
+		err = multierr.Append(err, commonconfig.ErrMissing{Name: "FundingAddress", Msg: "must be set if top-up is enabled"})
+	}
+	if t.Amount == nil {
+		err = multierr.Append(err, commonconfig.ErrMissing{Name: "Amount", Msg: "must be set if top-up is enabled"})
+	} else if t.Amount.Cmp(assets.NewWeiI(0)) <= 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "Amount", Value: t.Amount, Msg: "must be greater than 0"})
+	}
+	if t.DailyLimit == nil {
+		err = multierr.Append(err, commonconfig.ErrMissing{Name: "DailyLimit", Msg: "must be set if top-up is enabled"})
+	} else if t.Amount != nil && t.DailyLimit.Cmp(t.Amount) < 0 {
+		err = multierr.Append(err, commonconfig.ErrInvalid{Name: "DailyLimit", Value: t.DailyLimit, Msg: "must be greater than or equal to Amount"})
+	}
+	return
 }
 
 type GasEstimator struct {
@@ -840,8 +932,9 @@ func (ks KeySpecificConfig) ValidateConfig() (err error) {
 }
 
 type KeySpecific struct {
-	Key          *types.EIP55Address
-	GasEstimator KeySpecificGasEstimator `toml:",omitempty"`
+	Key            *types.EIP55Address
+	GasEstimator   KeySpecificGasEstimator   `toml:",omitempty"`
+	BalanceMonitor KeySpecificBalanceMonitor `toml:",omitempty"`
 }
 
 type KeySpecificGasEstimator struct {
@@ -854,6 +947,16 @@ func (e *KeySpecificGasEstimator) setFrom(f *KeySpecificGasEstimator) {
 	}
 }
 
+type KeySpecificBalanceMonitor struct {
+	LowBalanceThreshold *assets.Wei
+}
+
+func (m *KeySpecificBalanceMonitor) setFrom(f *KeySpecificBalanceMonitor) {
+	if v := f.LowBalanceThreshold; v != nil {
+		m.LowBalanceThreshold = v
+	}
+}
+
 type HeadTracker struct {
 	HistoryDepth            *uint32
 	MaxBufferSize           *uint32
@@ -227,6 +227,9 @@ func (c *Chain) SetFrom(f *Chain) {
 	if v := f.LogBroadcasterEnabled; v != nil {
 		c.LogBroadcasterEnabled = v
 	}
+	if v := f.LogBroadcasterUseLogPoller; v != nil {
+		c.LogBroadcasterUseLogPoller = v
+	}
 	if v := f.RPCDefaultBatchSize; v != nil {
 		c.RPCDefaultBatchSize = v
 	}
@@ -252,6 +255,7 @@ func (c *Chain) SetFrom(f *Chain) {
 				c.KeySpecific = append(c.KeySpecific, v)
 			} else {
 				c.KeySpecific[i].GasEstimator.setFrom(&v.GasEstimator)
+				c.KeySpecific[i].BalanceMonitor.setFrom(&v.BalanceMonitor)
 			}
 		}
 	}
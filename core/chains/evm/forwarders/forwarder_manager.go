@@ -17,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	evmclient "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/keystore"
 	evmlogpoller "github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
@@ -28,6 +29,9 @@ import (
 var forwardABI = evmtypes.MustGetABI(authorized_forwarder.AuthorizedForwarderABI).Methods["forward"]
 var authChangedTopic = authorized_receiver.AuthorizedReceiverAuthorizedSendersChanged{}.Topic()
 
+// verifyInterval is how often the authorized senders of every tracked forwarder are verified on-chain.
+const verifyInterval = 5 * time.Minute
+
 type Config interface {
 	FinalityDepth() uint32
 }
@@ -38,6 +42,7 @@ type FwdMgr struct {
 
 	ORM       ORM
 	evmClient evmclient.Client
+	ks        keystore.Eth
 	cfg       Config
 	logger    logger.SugaredLogger
 	logpoller evmlogpoller.LogPoller
@@ -51,15 +56,20 @@ type FwdMgr struct {
 	offchainAgg offchain_aggregator_wrapper.OffchainAggregatorInterface
 
 	cacheMu sync.RWMutex
+
+	statuses map[common.Address]Status
+	statusMu sync.RWMutex
 }
 
-func NewFwdMgr(ds sqlutil.DataSource, client evmclient.Client, logpoller evmlogpoller.LogPoller, lggr logger.Logger, cfg Config) *FwdMgr {
+func NewFwdMgr(ds sqlutil.DataSource, client evmclient.Client, logpoller evmlogpoller.LogPoller, ks keystore.Eth, lggr logger.Logger, cfg Config) *FwdMgr {
 	fm := FwdMgr{
 		cfg:          cfg,
 		evmClient:    client,
+		ks:           ks,
 		ORM:          NewORM(ds),
 		logpoller:    logpoller,
 		sendersCache: make(map[common.Address][]common.Address),
+		statuses:     make(map[common.Address]Status),
 	}
 	fm.Service, fm.eng = services.Config{
 		Name:  "ForwarderManager",
@@ -94,9 +104,42 @@ func (f *FwdMgr) start(ctx context.Context) error {
 	}
 
 	f.eng.Go(f.runLoop)
+	f.eng.Go(f.verifyLoop)
 	return nil
 }
 
+// HealthReport returns the health of the manager, plus one entry per tracked forwarder.
+func (f *FwdMgr) HealthReport() map[string]error {
+	report := f.Service.HealthReport()
+	f.statusMu.RLock()
+	defer f.statusMu.RUnlock()
+	for addr, status := range f.statuses {
+		report[f.Name()+".Forwarder."+addr.Hex()] = status.Err
+	}
+	return report
+}
+
+// Statuses returns the result of the latest on-chain verification of each tracked forwarder.
+func (f *FwdMgr) Statuses() []Status {
+	f.statusMu.RLock()
+	defer f.statusMu.RUnlock()
+	statuses := make([]Status, 0, len(f.statuses))
+	for _, status := range f.statuses {
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b Status) int { return a.Address.Cmp(b.Address) })
+	return statuses
+}
+
+// IsAuthorizedSender reports whether sender is an authorized sender of forwarder, according to the cached senders.
+func (f *FwdMgr) IsAuthorizedSender(ctx context.Context, forwarder, sender common.Address) (bool, error) {
+	senders, err := f.getContractSenders(ctx, forwarder)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(senders, sender), nil
+}
+
 func FilterName(addr common.Address) string {
 	return evmlogpoller.FilterName("ForwarderManager AuthorizedSendersChanged", addr.String())
 }
@@ -307,6 +350,50 @@ func (f *FwdMgr) runLoop(ctx context.Context) {
 	}
 }
 
+func (f *FwdMgr) verifyLoop(ctx context.Context) {
+	ticker := services.NewTicker(verifyInterval)
+	defer ticker.Stop()
+
+	f.verifyForwarders(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			f.verifyForwarders(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// verifyForwarders checks the authorized senders of every forwarder tracked for this chain, refreshing the senders cache.
+func (f *FwdMgr) verifyForwarders(ctx context.Context) {
+	fwdrs, err := f.ORM.FindForwardersByChain(ctx, big.Big(*f.evmClient.ConfiguredChainID()))
+	if err != nil {
+		f.logger.Errorw("Failed to retrieve forwarders for verification", "err", err)
+		return
+	}
+
+	statuses := make(map[common.Address]Status, len(fwdrs))
+	for _, fwdr := range fwdrs {
+		status := Verify(ctx, f.evmClient, f.ks, fwdr)
+		statuses[fwdr.Address] = status
+		if status.AuthorizedSenders != nil {
+			f.setCachedSenders(fwdr.Address, status.AuthorizedSenders)
+		}
+		if status.Err != nil {
+			f.logger.Warnw("Forwarder verification failed, transactions will be sent directly from unauthorized keys",
+				"forwarder", fwdr.Address, "unauthorizedKeys", status.UnauthorizedKeys, "err", status.Err)
+		} else if len(status.UnauthorizedKeys) > 0 {
+			f.logger.Infow("Forwarder does not authorize some enabled keys, transactions from them will be sent directly",
+				"forwarder", fwdr.Address, "unauthorizedKeys", status.UnauthorizedKeys)
+		}
+	}
+
+	f.statusMu.Lock()
+	f.statuses = statuses
+	f.statusMu.Unlock()
+}
+
 func (f *FwdMgr) handleAuthChange(log evmlogpoller.Log) error {
 	if f.latestBlock > log.BlockNumber {
 		return nil
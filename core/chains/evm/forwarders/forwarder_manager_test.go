@@ -1,6 +1,7 @@
 package forwarders_test
 
 import (
+	"errors"
 	"math/big"
 	"slices"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient/simulated"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/libocr/gethwrappers2/testocr2aggregator"
@@ -23,6 +25,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/forwarders"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	ksmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/keystore/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	ubig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
@@ -40,6 +43,12 @@ var GetAuthorisedSendersABI = evmtypes.MustGetABI(authorized_receiver.Authorized
 
 var SimpleOracleCallABI = evmtypes.MustGetABI(operator_wrapper.OperatorABI).Methods["getChainlinkToken"]
 
+func newEthKeyStore(t *testing.T, keys ...common.Address) *ksmocks.Eth {
+	ks := ksmocks.NewEth(t)
+	ks.On("EnabledAddressesForChain", mock.Anything, mock.Anything).Maybe().Return(keys, nil)
+	return ks
+}
+
 func TestFwdMgr_MaybeForwardTransaction(t *testing.T) {
 	lggr := logger.Test(t)
 	db := pgtest.NewSqlxDB(t)
@@ -78,7 +87,7 @@ func TestFwdMgr_MaybeForwardTransaction(t *testing.T) {
 	}
 	ht := headtracker.NewSimulatedHeadTracker(evmClient, lpOpts.UseFinalityTag, lpOpts.FinalityDepth)
 	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr), evmClient, lggr, ht, lpOpts)
-	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, lggr, evmcfg.EVM())
+	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, newEthKeyStore(t, owner.From), lggr, evmcfg.EVM())
 	fwdMgr.ORM = forwarders.NewORM(db)
 
 	fwd, err := fwdMgr.ORM.CreateForwarder(ctx, forwarderAddr, ubig.Big(*testutils.FixtureChainID))
@@ -92,6 +101,9 @@ func TestFwdMgr_MaybeForwardTransaction(t *testing.T) {
 	addr, err := fwdMgr.ForwarderFor(ctx, owner.From)
 	require.NoError(t, err)
 	require.Equal(t, addr.String(), forwarderAddr.String())
+	isAuthorized, err := fwdMgr.IsAuthorizedSender(ctx, forwarderAddr, owner.From)
+	require.NoError(t, err)
+	require.True(t, isAuthorized)
 	err = fwdMgr.Close()
 	require.NoError(t, err)
 
@@ -140,7 +152,7 @@ func TestFwdMgr_AccountUnauthorizedToForward_SkipsForwarding(t *testing.T) {
 	}
 	ht := headtracker.NewSimulatedHeadTracker(evmClient, lpOpts.UseFinalityTag, lpOpts.FinalityDepth)
 	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr), evmClient, lggr, ht, lpOpts)
-	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, lggr, evmcfg.EVM())
+	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, newEthKeyStore(t, owner.From), lggr, evmcfg.EVM())
 	fwdMgr.ORM = forwarders.NewORM(db)
 
 	_, err = fwdMgr.ORM.CreateForwarder(ctx, forwarderAddr, ubig.Big(*testutils.FixtureChainID))
@@ -155,6 +167,12 @@ func TestFwdMgr_AccountUnauthorizedToForward_SkipsForwarding(t *testing.T) {
 	addr, err := fwdMgr.ForwarderFor(ctx, owner.From)
 	require.ErrorIs(t, err, forwarders.ErrForwarderForEOANotFound)
 	require.True(t, utils.IsZero(addr))
+	authorized, err := fwdMgr.IsAuthorizedSender(ctx, forwarderAddr, owner.From)
+	require.NoError(t, err)
+	require.False(t, authorized)
+	require.Eventually(t, func() bool {
+		return errors.Is(fwdMgr.HealthReport()[fwdMgr.Name()+".Forwarder."+forwarderAddr.Hex()], forwarders.ErrNoAuthorizedKeys)
+	}, testutils.WaitTimeout(t), 100*time.Millisecond)
 	err = fwdMgr.Close()
 	require.NoError(t, err)
 }
@@ -206,7 +224,7 @@ func TestFwdMgr_InvalidForwarderForOCR2FeedsStates(t *testing.T) {
 	}
 	ht := headtracker.NewSimulatedHeadTracker(evmClient, lpOpts.UseFinalityTag, lpOpts.FinalityDepth)
 	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr), evmClient, lggr, ht, lpOpts)
-	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, lggr, evmcfg.EVM())
+	fwdMgr := forwarders.NewFwdMgr(db, evmClient, lp, newEthKeyStore(t, owner.From), lggr, evmcfg.EVM())
 	fwdMgr.ORM = forwarders.NewORM(db)
 
 	_, err = fwdMgr.ORM.CreateForwarder(ctx, forwarderAddr, ubig.Big(*testutils.FixtureChainID))
@@ -216,7 +234,7 @@ func TestFwdMgr_InvalidForwarderForOCR2FeedsStates(t *testing.T) {
 	require.Equal(t, len(lst), 1)
 	require.Equal(t, lst[0].Address, forwarderAddr)
 
-	fwdMgr = forwarders.NewFwdMgr(db, evmClient, lp, lggr, evmcfg.EVM())
+	fwdMgr = forwarders.NewFwdMgr(db, evmClient, lp, newEthKeyStore(t, owner.From), lggr, evmcfg.EVM())
 	require.NoError(t, fwdMgr.Start(testutils.Context(t)))
 	// cannot find forwarder because it isn't authorized nor added as a transmitter
 	addr, err := fwdMgr.ForwarderForOCR2Feeds(ctx, owner.From, ocr2Address)
@@ -254,7 +272,7 @@ func TestFwdMgr_InvalidForwarderForOCR2FeedsStates(t *testing.T) {
 	require.True(t, slices.Contains(transmitters, forwarderAddr))
 
 	// create new fwd to have an empty cache that has to fetch authorized forwarders from log poller
-	fwdMgr = forwarders.NewFwdMgr(db, evmClient, lp, lggr, evmcfg.EVM())
+	fwdMgr = forwarders.NewFwdMgr(db, evmClient, lp, newEthKeyStore(t, owner.From), lggr, evmcfg.EVM())
 	require.NoError(t, fwdMgr.Start(testutils.Context(t)))
 	addr, err = fwdMgr.ForwarderForOCR2Feeds(ctx, owner.From, ocr2Address)
 	require.NoError(t, err, "forwarder should be valid and found because it is both authorized and set as a transmitter")
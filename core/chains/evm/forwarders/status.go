@@ -0,0 +1,75 @@
+package forwarders
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/authorized_receiver"
+)
+
+// ErrNoAuthorizedKeys is reported for a forwarder that does not authorize any of the node's enabled keys.
+var ErrNoAuthorizedKeys = errors.New("none of the enabled keys for this chain are authorized senders")
+
+// Status is the result of verifying a forwarder's authorized senders on-chain.
+type Status struct {
+	Address    common.Address
+	EVMChainID big.Big
+	// AuthorizedSenders is the result of getAuthorizedSenders on the forwarder.
+	AuthorizedSenders []common.Address
+	// AuthorizedKeys are the node's enabled keys that are authorized senders.
+	AuthorizedKeys []common.Address
+	// UnauthorizedKeys are the node's enabled keys that are not authorized senders.
+	UnauthorizedKeys []common.Address
+	VerifiedAt       time.Time
+	Err              error
+}
+
+// Verify calls getAuthorizedSenders on fwdr and checks which of the node's enabled keys are authorized to send through it.
+// A Status is always returned, with Err set if the forwarder could not be verified or authorizes none of the keys.
+func Verify(ctx context.Context, caller bind.ContractCaller, ks keystore.Eth, fwdr Forwarder) Status {
+	status := Status{
+		Address:    fwdr.Address,
+		EVMChainID: fwdr.EVMChainID,
+		VerifiedAt: time.Now(),
+	}
+
+	c, err := authorized_receiver.NewAuthorizedReceiverCaller(fwdr.Address, caller)
+	if err != nil {
+		status.Err = pkgerrors.Wrap(err, "failed to init forwarder caller")
+		return status
+	}
+	status.AuthorizedSenders, err = c.GetAuthorizedSenders(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		status.Err = pkgerrors.Wrapf(err, "failed to call getAuthorizedSenders on %s", fwdr.Address)
+		return status
+	}
+	if status.AuthorizedSenders == nil {
+		// distinguish a forwarder without senders from one that could not be called
+		status.AuthorizedSenders = []common.Address{}
+	}
+
+	keys, err := ks.EnabledAddressesForChain(ctx, fwdr.EVMChainID.ToInt())
+	if err != nil {
+		status.Err = pkgerrors.Wrap(err, "failed to get enabled keys")
+		return status
+	}
+	for _, k := range keys {
+		if slices.Contains(status.AuthorizedSenders, k) {
+			status.AuthorizedKeys = append(status.AuthorizedKeys, k)
+		} else {
+			status.UnauthorizedKeys = append(status.UnauthorizedKeys, k)
+		}
+	}
+	if len(status.AuthorizedKeys) == 0 {
+		status.Err = ErrNoAuthorizedKeys
+	}
+	return status
+}
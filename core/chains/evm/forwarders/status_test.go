@@ -0,0 +1,65 @@
+package forwarders_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/forwarders"
+	ubig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/authorized_forwarder"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/operator_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+)
+
+func TestVerify(t *testing.T) {
+	ctx := testutils.Context(t)
+	owner := testutils.MustNewSimTransactor(t)
+	b := simulated.NewBackend(types.GenesisAlloc{
+		owner.From: {
+			Balance: big.NewInt(0).Mul(big.NewInt(10), big.NewInt(1e18)),
+		},
+	}, simulated.WithBlockGasLimit(10e6))
+	t.Cleanup(func() { b.Close() })
+	linkAddr := common.HexToAddress("0x01BE23585060835E02B77ef475b0Cc51aA1e0709")
+	operatorAddr, _, _, err := operator_wrapper.DeployOperator(owner, b.Client(), linkAddr, owner.From)
+	require.NoError(t, err)
+	forwarderAddr, _, forwarder, err := authorized_forwarder.DeployAuthorizedForwarder(owner, b.Client(), linkAddr, owner.From, operatorAddr, []byte{})
+	require.NoError(t, err)
+	b.Commit()
+
+	evmClient := client.NewSimulatedBackendClient(t, b, testutils.FixtureChainID)
+	fwdr := forwarders.Forwarder{Address: forwarderAddr, EVMChainID: ubig.Big(*testutils.FixtureChainID)}
+	otherKey := testutils.NewAddress()
+
+	t.Run("no authorized keys", func(t *testing.T) {
+		status := forwarders.Verify(ctx, evmClient, newEthKeyStore(t, owner.From), fwdr)
+		require.ErrorIs(t, status.Err, forwarders.ErrNoAuthorizedKeys)
+		assert.Empty(t, status.AuthorizedSenders)
+		assert.Equal(t, []common.Address{owner.From}, status.UnauthorizedKeys)
+	})
+
+	_, err = forwarder.SetAuthorizedSenders(owner, []common.Address{owner.From})
+	require.NoError(t, err)
+	b.Commit()
+
+	t.Run("some keys authorized", func(t *testing.T) {
+		status := forwarders.Verify(ctx, evmClient, newEthKeyStore(t, owner.From, otherKey), fwdr)
+		require.NoError(t, status.Err)
+		assert.Equal(t, []common.Address{owner.From}, status.AuthorizedSenders)
+		assert.Equal(t, []common.Address{owner.From}, status.AuthorizedKeys)
+		assert.Equal(t, []common.Address{otherKey}, status.UnauthorizedKeys)
+	})
+
+	t.Run("not a forwarder", func(t *testing.T) {
+		status := forwarders.Verify(ctx, evmClient, newEthKeyStore(t, owner.From), forwarders.Forwarder{Address: otherKey, EVMChainID: fwdr.EVMChainID})
+		require.Error(t, status.Err)
+		assert.Nil(t, status.AuthorizedSenders)
+	})
+}
@@ -2,6 +2,8 @@ package headtracker_test
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -173,6 +175,73 @@ func TestHeadBroadcaster_TrackableCallbackTimeout(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestHeadBroadcaster_BroadcastReorg(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	lggr := logger.Test(t)
+	broadcaster := headtracker.NewHeadBroadcaster(lggr)
+	servicetest.Run(t, broadcaster)
+	waitHeadBroadcasterToStart(t, broadcaster)
+
+	subscriber := &reorgSubscriber{}
+	_, unsubscribeHeads := broadcaster.Subscribe(subscriber)
+	unsubscribeReorgs := broadcaster.SubscribeReorgs(subscriber)
+
+	newHead := testutils.Head(3)
+	event := types.ReorgEvent{
+		Depth:                1,
+		OldHeadHash:          utils.NewHash(),
+		OldHeadNumber:        2,
+		NewHeadHash:          newHead.Hash,
+		NewHeadNumber:        newHead.Number,
+		CommonAncestorHash:   utils.NewHash(),
+		CommonAncestorNumber: 1,
+	}
+	broadcaster.BroadcastReorg(event)
+	broadcaster.BroadcastNewLongestChain(newHead)
+	g.Eventually(subscriber.calls).Should(gomega.Equal([]string{"reorg", "head"}))
+	assert.Equal(t, event, subscriber.lastReorg())
+
+	unsubscribeReorgs()
+	broadcaster.BroadcastReorg(event)
+	broadcaster.BroadcastNewLongestChain(testutils.Head(4))
+	g.Eventually(subscriber.calls).Should(gomega.Equal([]string{"reorg", "head", "head"}))
+
+	unsubscribeHeads()
+}
+
+type reorgSubscriber struct {
+	mu     sync.Mutex
+	events []string
+	reorg  types.ReorgEvent
+}
+
+func (s *reorgSubscriber) OnNewLongestChain(context.Context, *evmtypes.Head) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, "head")
+}
+
+func (s *reorgSubscriber) OnReorg(_ context.Context, event types.ReorgEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, "reorg")
+	s.reorg = event
+}
+
+func (s *reorgSubscriber) calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.events)
+}
+
+func (s *reorgSubscriber) lastReorg() types.ReorgEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reorg
+}
+
 type sleepySubscriber struct {
 	awaiter     testutils.Awaiter
 	delay       time.Duration
@@ -3,6 +3,7 @@ package headtracker
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -89,6 +90,16 @@ func (hs *headSaver) MarkFinalized(ctx context.Context, finalized *evmtypes.Head
 	return hs.orm.TrimOldHeads(ctx, minBlockToKeep)
 }
 
+// reorgRetention is how long detected reorgs are kept in the database
+const reorgRetention = 30 * 24 * time.Hour
+
+func (hs *headSaver) SaveReorg(ctx context.Context, event httypes.ReorgEvent) error {
+	if err := hs.orm.InsertReorg(ctx, event); err != nil {
+		return err
+	}
+	return hs.orm.TrimOldReorgs(ctx, event.DetectedAt.Add(-reorgRetention))
+}
+
 var NullSaver httypes.HeadSaver = &nullSaver{}
 
 type nullSaver struct{}
@@ -103,3 +114,4 @@ func (*nullSaver) Chain(hash common.Hash) *evmtypes.Head
 func (*nullSaver) MarkFinalized(ctx context.Context, latestFinalized *evmtypes.Head) error {
 	return nil
 }
+func (*nullSaver) SaveReorg(ctx context.Context, event httypes.ReorgEvent) error { return nil }
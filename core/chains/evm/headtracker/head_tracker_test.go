@@ -793,6 +793,101 @@ func TestHeadTracker_SwitchesToLongestChainWithHeadSamplingDisabled(t *testing.T
 	}
 }
 
+func TestHeadTracker_BroadcastsReorg(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+
+	config := testutils.NewTestChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.FinalityDepth = ptr[uint32](50)
+		c.HeadTracker.MaxBufferSize = ptr[uint32](100)
+		c.HeadTracker.SamplingInterval = commonconfig.MustNewDuration(0)
+	})
+
+	ethClient := testutils.NewEthClientMockWithDefaultChain(t)
+
+	checker := htmocks.NewHeadTrackable[*evmtypes.Head, common.Hash](t)
+	checker.On("OnNewLongestChain", mock.Anything, mock.Anything).Return().Maybe()
+	orm := headtracker.NewORM(*testutils.FixtureChainID, db)
+	ht := createHeadTrackerWithChecker(t, ethClient, config.EVM(), config.EVM().HeadTracker(), orm, checker)
+
+	reorgs := make(chan httypes.ReorgEvent, 1)
+	ht.headBroadcaster.SubscribeReorgs(reorgTrackableFunc(func(_ context.Context, event httypes.ReorgEvent) {
+		reorgs <- event
+	}))
+
+	chchHeaders := make(chan testutils.RawSub[*evmtypes.Head], 1)
+	mockEth := &testutils.MockEth{EthClient: ethClient}
+	chHead := make(chan *evmtypes.Head)
+	ethClient.On("SubscribeToHeads", mock.Anything).
+		Return(
+			func(ctx context.Context) (<-chan *evmtypes.Head, ethereum.Subscription, error) {
+				sub := mockEth.NewSub(t)
+				chchHeaders <- testutils.NewRawSub(chHead, sub.Err())
+				return chHead, sub, nil
+			},
+		)
+
+	blocks := NewBlocks(t, 4)
+	ethClient.On("HeadByNumber", mock.Anything, (*big.Int)(nil)).Return(blocks.Head(0), nil)
+	ethClient.On("HeadByNumber", mock.Anything, big.NewInt(0)).Return(blocks.Head(0), nil)
+	ethClient.On("HeadByHash", mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Maybe()
+
+	// Reorg happened forking from block 2
+	blocksForked := blocks.ForkAt(t, 2, 2)
+
+	ht.Start(t)
+	headers := <-chchHeaders
+	for _, h := range []*evmtypes.Head{blocks.Head(0), blocks.Head(1), blocks.Head(2), blocks.Head(3),
+		blocksForked.Head(2), blocksForked.Head(3), blocksForked.Head(4)} {
+		headers.TrySend(h)
+		time.Sleep(tests.TestInterval)
+	}
+
+	select {
+	case event := <-reorgs:
+		assert.Equal(t, int64(2), event.Depth)
+		assert.Equal(t, blocks.Head(3).Hash, event.OldHeadHash)
+		assert.Equal(t, blocksForked.Head(4).Hash, event.NewHeadHash)
+		assert.Equal(t, int64(1), event.CommonAncestorNumber)
+		assert.Equal(t, blocks.Head(1).Hash, event.CommonAncestorHash)
+	case <-time.After(tests.WaitTimeout(t)):
+		t.Fatal("timed out waiting for reorg event")
+	}
+
+	persisted, err := orm.LatestReorgs(tests.Context(t), 10)
+	require.NoError(t, err)
+	require.Len(t, persisted, 1)
+	assert.Equal(t, blocksForked.Head(4).Hash, persisted[0].NewHeadHash)
+}
+
+type reorgTrackableFunc func(context.Context, httypes.ReorgEvent)
+
+func (fn reorgTrackableFunc) OnReorg(ctx context.Context, event httypes.ReorgEvent) {
+	fn(ctx, event)
+}
+
+func TestReorgEvent_Merge(t *testing.T) {
+	t.Parallel()
+
+	h95, h98 := testutils.NewHash(), testutils.NewHash()
+	old102, old103 := testutils.NewHash(), testutils.NewHash()
+	// a deep reorg forking from block 95, then a shallower one forking from block 98 of the new chain
+	deep := httypes.ReorgEvent{Depth: 7, OldHeadNumber: 102, OldHeadHash: old102, NewHeadNumber: 103, CommonAncestorNumber: 95, CommonAncestorHash: h95}
+	shallow := httypes.ReorgEvent{Depth: 5, OldHeadNumber: 103, OldHeadHash: old103, NewHeadNumber: 104, NewHeadHash: testutils.NewHash(), CommonAncestorNumber: 98, CommonAncestorHash: h98}
+
+	merged := deep.Merge(shallow)
+	assert.Equal(t, int64(95), merged.CommonAncestorNumber)
+	assert.Equal(t, h95, merged.CommonAncestorHash)
+	assert.Equal(t, int64(103), merged.OldHeadNumber)
+	assert.Equal(t, old103, merged.OldHeadHash)
+	assert.Equal(t, shallow.NewHeadHash, merged.NewHeadHash)
+	assert.Equal(t, int64(8), merged.Depth)
+	from, to := merged.AffectedBlocks()
+	assert.Equal(t, int64(96), from)
+	assert.Equal(t, int64(103), to)
+}
+
 func TestHeadTracker_Backfill(t *testing.T) {
 	t.Parallel()
 	t.Run("Enabled Persistence", func(t *testing.T) {
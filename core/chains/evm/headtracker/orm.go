@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	pkgerrors "github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 
+	httypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker/types"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	ubig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
 )
@@ -26,6 +28,12 @@ type ORM interface {
 	LatestHeads(ctx context.Context, minBlockNumber int64) (heads []*evmtypes.Head, err error)
 	// HeadByHash fetches the head with the given hash from the db, returns nil if none exists
 	HeadByHash(ctx context.Context, hash common.Hash) (head *evmtypes.Head, err error)
+	// InsertReorg records a reorg detected by the HeadTracker
+	InsertReorg(ctx context.Context, event httypes.ReorgEvent) error
+	// LatestReorgs returns up to limit most recently detected reorgs, newest first
+	LatestReorgs(ctx context.Context, limit int) (events []httypes.ReorgEvent, err error)
+	// TrimOldReorgs deletes reorgs detected before the given time
+	TrimOldReorgs(ctx context.Context, before time.Time) (err error)
 }
 
 var _ ORM = &DbORM{}
@@ -84,6 +92,28 @@ func (orm *DbORM) HeadByHash(ctx context.Context, hash common.Hash) (head *evmty
 	return head, err
 }
 
+func (orm *DbORM) InsertReorg(ctx context.Context, event httypes.ReorgEvent) error {
+	query := `
+	INSERT INTO evm.reorgs (evm_chain_id, depth, old_head_hash, old_head_number, new_head_hash, new_head_number, common_ancestor_hash, common_ancestor_number, detected_at) VALUES (
+	$1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := orm.ds.ExecContext(ctx, query, orm.chainID, event.Depth, event.OldHeadHash, event.OldHeadNumber, event.NewHeadHash, event.NewHeadNumber,
+		event.CommonAncestorHash, event.CommonAncestorNumber, event.DetectedAt)
+	return pkgerrors.Wrap(err, "InsertReorg failed to insert reorg")
+}
+
+func (orm *DbORM) LatestReorgs(ctx context.Context, limit int) (events []httypes.ReorgEvent, err error) {
+	query := `SELECT depth, old_head_hash, old_head_number, new_head_hash, new_head_number, common_ancestor_hash, common_ancestor_number, detected_at
+	FROM evm.reorgs WHERE evm_chain_id = $1 ORDER BY detected_at DESC, id DESC LIMIT $2`
+	err = orm.ds.SelectContext(ctx, &events, query, orm.chainID, limit)
+	err = pkgerrors.Wrap(err, "LatestReorgs failed")
+	return
+}
+
+func (orm *DbORM) TrimOldReorgs(ctx context.Context, before time.Time) (err error) {
+	_, err = orm.ds.ExecContext(ctx, `DELETE FROM evm.reorgs WHERE evm_chain_id = $1 AND detected_at < $2`, orm.chainID, before)
+	return pkgerrors.Wrap(err, "TrimOldReorgs failed")
+}
+
 type nullORM struct{}
 
 func NewNullORM() ORM {
@@ -109,3 +139,15 @@ func (orm *nullORM) LatestHeads(ctx context.Context, minBlockNumer int64) (heads
 func (orm *nullORM) HeadByHash(ctx context.Context, hash common.Hash) (head *evmtypes.Head, err error) {
 	return nil, nil
 }
+
+func (orm *nullORM) InsertReorg(ctx context.Context, event httypes.ReorgEvent) error {
+	return nil
+}
+
+func (orm *nullORM) LatestReorgs(ctx context.Context, limit int) (events []httypes.ReorgEvent, err error) {
+	return nil, nil
+}
+
+func (orm *nullORM) TrimOldReorgs(ctx context.Context, before time.Time) (err error) {
+	return nil
+}
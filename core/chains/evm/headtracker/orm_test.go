@@ -2,6 +2,7 @@ package headtracker_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
@@ -10,6 +11,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	httypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 )
@@ -110,3 +112,43 @@ func TestORM_LatestHeads_NoRows(t *testing.T) {
 	require.Zero(t, len(heads))
 	require.NoError(t, err)
 }
+
+func TestORM_InsertReorg(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	orm := headtracker.NewORM(*testutils.FixtureChainID, db)
+
+	reorgs, err := orm.LatestReorgs(tests.Context(t), 10)
+	require.NoError(t, err)
+	assert.Empty(t, reorgs)
+
+	for i := int64(1); i <= 3; i++ {
+		event := httypes.ReorgEvent{
+			Depth:                i,
+			OldHeadHash:          testutils.NewHash(),
+			OldHeadNumber:        100 + i,
+			NewHeadHash:          testutils.NewHash(),
+			NewHeadNumber:        101 + i,
+			CommonAncestorHash:   testutils.NewHash(),
+			CommonAncestorNumber: 100,
+			DetectedAt:           time.Now().Add(time.Duration(i) * time.Second),
+		}
+		require.NoError(t, orm.InsertReorg(tests.Context(t), event))
+	}
+
+	reorgs, err = orm.LatestReorgs(tests.Context(t), 2)
+	require.NoError(t, err)
+	require.Len(t, reorgs, 2)
+	assert.Equal(t, int64(3), reorgs[0].Depth)
+	assert.Equal(t, int64(2), reorgs[1].Depth)
+	from, to := reorgs[0].AffectedBlocks()
+	assert.Equal(t, int64(101), from)
+	assert.Equal(t, int64(103), to)
+
+	require.NoError(t, orm.TrimOldReorgs(tests.Context(t), reorgs[1].DetectedAt))
+	reorgs, err = orm.LatestReorgs(tests.Context(t), 10)
+	require.NoError(t, err)
+	require.Len(t, reorgs, 2)
+	assert.Equal(t, int64(2), reorgs[1].Depth)
+}
@@ -25,5 +25,7 @@ type (
 	HeadTrackable   = headtracker.HeadTrackable[*evmtypes.Head, common.Hash]
 	HeadListener    = headtracker.HeadListener[*evmtypes.Head, common.Hash]
 	HeadBroadcaster = headtracker.HeadBroadcaster[*evmtypes.Head, common.Hash]
+	ReorgTrackable  = headtracker.ReorgTrackable[common.Hash]
+	ReorgEvent      = headtracker.ReorgEvent[common.Hash]
 	Client          = htrktypes.Client[*evmtypes.Head, ethereum.Subscription, *big.Int, common.Hash]
 )
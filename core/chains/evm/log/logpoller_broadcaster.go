@@ -0,0 +1,547 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/mailbox"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	evmutils "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers"
+)
+
+// logPollerBroadcaster is a Broadcaster that reads logs from the LogPoller instead of maintaining its own
+// eth_subscribe subscription. Each registered listener is backed by a LogPoller filter, and consumption is tracked
+// in the log_broadcasts table exactly as for the subscription based broadcaster, so listeners can switch between the
+// two implementations without re-processing logs.
+//
+// Each listener keeps a cursor of the next block to read. On every head, logs between the cursor and the newest block
+// with enough confirmations (capped at the latest block processed by the LogPoller) are sent to the listener.
+// The cursor remembers the hashes of the unfinalized blocks it was advanced to, and is rewound to the first block
+// after the fork when a reorg replaces them, so that logs of the new chain are sent as well.
+//
+// Listeners of the same job and contract share a LogPoller filter, which is unregistered with the last of them.
+// A filter which fails to register is retried on every head and reported in the health of the broadcaster, while its
+// listeners wait at their cursors. Once the filter covers the events of a listener, the LogPoller replays the logs from
+// the cursor of the listener, as it did not store them before.
+type logPollerBroadcaster struct {
+	services.Service
+	eng *services.Engine
+	utils.DependentAwaiter
+
+	orm        ORM
+	lp         logpoller.LogPoller
+	config     Config
+	evmChainID big.Int
+	mailMon    *mailbox.Monitor
+
+	changeSubscriberStatus *mailbox.Mailbox[changeSubscriberStatus]
+	newHeads               *mailbox.Mailbox[*evmtypes.Head]
+	replayChannel          chan replayRequest
+
+	// only accessed from the run loop
+	cursors      map[*subscriber]*cursor
+	filters      map[string]int
+	unregistered map[string]common.Address
+	resumeFrom   *int64
+	backfilled   bool
+	highestConf  uint32
+}
+
+type cursor struct {
+	// nextBlock is the next block to read logs from, or -1 if the listener was not initialized yet
+	nextBlock int64
+	// delivered holds the unfinalized blocks the cursor was advanced to, oldest first
+	delivered []deliveredBlock
+	// replay is set when the logs of the listener must be replayed by the LogPoller before they are read
+	replay bool
+}
+
+type deliveredBlock struct {
+	number int64
+	hash   common.Hash
+}
+
+// advance moves the cursor past the block to, whose hash on the chain of head is remembered to detect reorgs.
+func (c *cursor) advance(head *evmtypes.Head, to int64, finalityDepth uint32) {
+	c.nextBlock = to + 1
+	finalized := head.Number - int64(finalityDepth)
+	i := 0
+	for i < len(c.delivered) && c.delivered[i].number <= finalized {
+		i++
+	}
+	c.delivered = c.delivered[i:]
+	if hash := head.HashAtHeight(to); hash != (common.Hash{}) && to > finalized {
+		c.delivered = append(c.delivered, deliveredBlock{number: to, hash: hash})
+	}
+}
+
+// rewind moves the cursor back to the first block after the fork if blocks it was advanced to are no longer part of
+// the chain of head. Blocks older than the chain of head are assumed to be canonical.
+func (c *cursor) rewind(head *evmtypes.Head, finalityDepth uint32) (rewound bool) {
+	canonical := len(c.delivered)
+	for ; canonical > 0; canonical-- {
+		block := c.delivered[canonical-1]
+		if hash := head.HashAtHeight(block.number); hash == (common.Hash{}) || hash == block.hash {
+			break
+		}
+	}
+	if canonical == len(c.delivered) {
+		return false
+	}
+	c.delivered = c.delivered[:canonical]
+	if canonical > 0 {
+		c.nextBlock = min(c.nextBlock, c.delivered[canonical-1].number+1)
+	} else {
+		// the fork is older than all remembered blocks, so only the finalized blocks are known to be unaffected
+		c.nextBlock = min(c.nextBlock, max(head.Number-int64(finalityDepth)+1, 0))
+	}
+	return true
+}
+
+var _ Broadcaster = (*logPollerBroadcaster)(nil)
+
+// NewLogPollerBroadcaster creates a Broadcaster backed by the LogPoller.
+func NewLogPollerBroadcaster(orm ORM, lp logpoller.LogPoller, evmChainID big.Int, config Config, lggr logger.Logger, mailMon *mailbox.Monitor) *logPollerBroadcaster {
+	b := &logPollerBroadcaster{
+		DependentAwaiter:       utils.NewDependentAwaiter(),
+		orm:                    orm,
+		lp:                     lp,
+		config:                 config,
+		evmChainID:             evmChainID,
+		mailMon:                mailMon,
+		changeSubscriberStatus: mailbox.NewHighCapacity[changeSubscriberStatus](),
+		newHeads:               mailbox.NewSingle[*evmtypes.Head](),
+		replayChannel:          make(chan replayRequest, 1),
+		cursors:                make(map[*subscriber]*cursor),
+		filters:                make(map[string]int),
+		unregistered:           make(map[string]common.Address),
+	}
+	b.Service, b.eng = services.Config{
+		Name:  "LogBroadcaster",
+		Start: b.start,
+		Close: b.close,
+	}.NewServiceEngine(lggr)
+	return b
+}
+
+func (b *logPollerBroadcaster) start(context.Context) error {
+	b.mailMon.Monitor(b.changeSubscriberStatus, "LogBroadcaster", "ChangeSubscriber", b.evmChainID.String())
+	b.eng.Go(b.run)
+	return nil
+}
+
+func (b *logPollerBroadcaster) close() error {
+	return b.changeSubscriberStatus.Close()
+}
+
+// FilterName returns the name of the LogPoller filter registered for a listener of the given job and contract.
+func FilterName(jobID int32, contract common.Address) string {
+	return logpoller.FilterName("LogBroadcaster", jobID, contract.Hex())
+}
+
+// Register implements the Broadcaster interface. The LogPoller filter is registered asynchronously, in the order of
+// Register and unsubscribe calls.
+func (b *logPollerBroadcaster) Register(listener Listener, opts ListenerOpts) (unsubscribe func()) {
+	err := b.eng.IfNotStopped(func() error {
+		if len(opts.LogsWithTopics) == 0 {
+			b.eng.Panic("Must supply at least 1 LogsWithTopics element to Register")
+		}
+		if opts.MinIncomingConfirmations <= 0 {
+			b.eng.Warnw(fmt.Sprintf("LogBroadcaster requires that MinIncomingConfirmations must be at least 1 (got %v). MinIncomingConfirmations will be set to 1.", opts.MinIncomingConfirmations), "addr", opts.Contract.Hex(), "jobID", listener.JobID())
+			opts.MinIncomingConfirmations = 1
+		}
+
+		sub := &subscriber{listener, opts}
+		b.eng.Debugf("Registering subscriber %p with job ID %v", sub, sub.listener.JobID())
+		if b.changeSubscriberStatus.Deliver(changeSubscriberStatus{subscriberStatusSubscribe, sub}) {
+			b.eng.Panicf("LogBroadcaster subscribe: cannot subscribe %p with job ID %v; changeSubscriberStatus channel was full", sub, sub.listener.JobID())
+		}
+		unsubscribe = func() {
+			b.eng.Debugf("Unregistering subscriber %p with job ID %v", sub, sub.listener.JobID())
+			if b.changeSubscriberStatus.Deliver(changeSubscriberStatus{subscriberStatusUnsubscribe, sub}) {
+				b.eng.Panicf("LogBroadcaster unsubscribe: cannot unsubscribe %p with job ID %v; changeSubscriberStatus channel was full", sub, sub.listener.JobID())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		b.eng.Panic("Register cannot be called on a stopped log broadcaster (this is an invariant violation because all dependent services should have unregistered themselves before logbroadcaster.Close was called)")
+	}
+	return
+}
+
+// ReplayFromBlock implements the Broadcaster interface.
+func (b *logPollerBroadcaster) ReplayFromBlock(number int64, forceBroadcast bool) {
+	b.eng.Infow("Replay requested", "block number", number, "force", forceBroadcast)
+	select {
+	case b.replayChannel <- replayRequest{fromBlock: number, forceBroadcast: forceBroadcast}:
+	default:
+	}
+}
+
+// IsConnected reports whether the underlying LogPoller is healthy.
+func (b *logPollerBroadcaster) IsConnected() bool {
+	return b.lp.Healthy() == nil
+}
+
+func (b *logPollerBroadcaster) OnNewLongestChain(_ context.Context, head *evmtypes.Head) {
+	b.newHeads.Deliver(head)
+}
+
+// WasAlreadyConsumed reports whether the given consumer had already consumed the given log
+func (b *logPollerBroadcaster) WasAlreadyConsumed(ctx context.Context, lb Broadcast) (bool, error) {
+	return b.orm.WasBroadcastConsumed(ctx, lb.RawLog().BlockHash, lb.RawLog().Index, lb.JobID())
+}
+
+// MarkConsumed marks the log as having been successfully consumed by the subscriber
+func (b *logPollerBroadcaster) MarkConsumed(ctx context.Context, ds sqlutil.DataSource, lb Broadcast) error {
+	orm := b.orm
+	if ds != nil {
+		orm = orm.WithDataSource(ds)
+	}
+	return orm.MarkBroadcastConsumed(ctx, lb.RawLog().BlockHash, lb.RawLog().BlockNumber, lb.RawLog().Index, lb.JobID())
+}
+
+func (b *logPollerBroadcaster) run(ctx context.Context) {
+	b.eng.Debug("Starting to await initial subscribers until all dependents are ready...")
+	for waiting := true; waiting; {
+		select {
+		case <-b.changeSubscriberStatus.Notify():
+			b.onChangeSubscriberStatus(ctx)
+		case <-b.AwaitDependents():
+			// ensure that any queued dependent subscriptions are registered first
+			b.onChangeSubscriberStatus(ctx)
+			waiting = false
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	// Remove leftover unconsumed logs and find out where the previous run stopped.
+	evmutils.RetryWithBackoff(ctx, func() bool {
+		var err error
+		b.resumeFrom, err = b.orm.Reinitialize(ctx)
+		if err != nil {
+			b.eng.Errorw("Failed to reinitialize database", "err", err)
+			return true
+		}
+		return false
+	})
+	if ctx.Err() != nil {
+		return
+	}
+
+	for {
+		select {
+		case req := <-b.replayChannel:
+			b.onReplayRequest(ctx, req)
+		case <-b.changeSubscriberStatus.Notify():
+			b.onChangeSubscriberStatus(ctx)
+		case <-b.newHeads.Notify():
+			if head := b.newHeads.RetrieveLatestAndClear(); head != nil {
+				b.onNewHead(ctx, head)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *logPollerBroadcaster) onChangeSubscriberStatus(ctx context.Context) {
+	for {
+		change, exists := b.changeSubscriberStatus.Retrieve()
+		if !exists {
+			return
+		}
+		sub := change.sub
+		name := FilterName(sub.listener.JobID(), sub.opts.Contract)
+
+		if change.newStatus == subscriberStatusSubscribe {
+			b.eng.Debugw("Subscribing listener", "requiredBlockConfirmations", sub.opts.MinIncomingConfirmations, "address", sub.opts.Contract, "jobID", sub.listener.JobID())
+			b.cursors[sub] = &cursor{nextBlock: -1}
+			b.filters[name]++
+			b.registerFilter(ctx, name, sub.opts.Contract)
+			if sub.opts.MinIncomingConfirmations > b.highestConf {
+				b.highestConf = sub.opts.MinIncomingConfirmations
+			}
+		} else {
+			b.eng.Debugw("Unsubscribing listener", "requiredBlockConfirmations", sub.opts.MinIncomingConfirmations, "address", sub.opts.Contract, "jobID", sub.listener.JobID())
+			if _, ok := b.cursors[sub]; !ok {
+				continue
+			}
+			delete(b.cursors, sub)
+			if b.filters[name]--; b.filters[name] > 0 {
+				continue
+			}
+			delete(b.filters, name)
+			if _, ok := b.unregistered[name]; ok {
+				delete(b.unregistered, name)
+				b.eng.ClearHealthCond(name)
+				continue
+			}
+			if err := b.lp.UnregisterFilter(ctx, name); err != nil {
+				b.eng.Errorw("Failed to unregister LogPoller filter", "filter", name, "err", err)
+			}
+		}
+	}
+}
+
+// registerFilter registers the LogPoller filter with the given name. The filter is shared by the listeners of the same
+// job and contract, so it covers the events of all of them. Listeners whose events were not covered by the filter yet
+// are marked for a replay. If the filter fails to register, it is retried by retryFilters.
+func (b *logPollerBroadcaster) registerFilter(ctx context.Context, name string, contract common.Address) {
+	filter := logpoller.Filter{
+		Name:      name,
+		EventSigs: b.filterEventSigs(name),
+		Addresses: []common.Address{contract},
+	}
+	existing, exists := b.lp.GetFilters()[name]
+	if err := b.lp.RegisterFilter(ctx, filter); err != nil {
+		b.eng.Errorw("Failed to register LogPoller filter, listeners wait until it is registered", "filter", name, "err", err)
+		b.eng.SetHealthCond(name, fmt.Errorf("failed to register LogPoller filter %s: %w", name, err))
+		b.unregistered[name] = contract
+		for sub, c := range b.cursors {
+			if FilterName(sub.listener.JobID(), sub.opts.Contract) == name {
+				c.replay = true
+			}
+		}
+		return
+	}
+	if _, ok := b.unregistered[name]; ok {
+		delete(b.unregistered, name)
+		b.eng.ClearHealthCond(name)
+	}
+	for sub, c := range b.cursors {
+		if FilterName(sub.listener.JobID(), sub.opts.Contract) != name {
+			continue
+		}
+		covered := exists && existing.Contains(&logpoller.Filter{
+			EventSigs: maps.Keys(sub.opts.LogsWithTopics),
+			Addresses: []common.Address{contract},
+		})
+		if !covered {
+			c.replay = true
+		}
+	}
+}
+
+// retryFilters registers the LogPoller filters which failed to register before.
+func (b *logPollerBroadcaster) retryFilters(ctx context.Context) {
+	for name, contract := range b.unregistered {
+		b.registerFilter(ctx, name, contract)
+	}
+}
+
+// filterEventSigs returns the event signatures of all listeners sharing the LogPoller filter with the given name.
+func (b *logPollerBroadcaster) filterEventSigs(name string) []common.Hash {
+	seen := make(map[common.Hash]struct{})
+	var sigs []common.Hash
+	for sub := range b.cursors {
+		if FilterName(sub.listener.JobID(), sub.opts.Contract) != name {
+			continue
+		}
+		for sig := range sub.opts.LogsWithTopics {
+			if _, ok := seen[sig]; !ok {
+				seen[sig] = struct{}{}
+				sigs = append(sigs, sig)
+			}
+		}
+	}
+	return sigs
+}
+
+func (b *logPollerBroadcaster) onReplayRequest(ctx context.Context, req replayRequest) {
+	for sub := range b.cursors {
+		if sub.opts.ReplayStartedCallback != nil {
+			sub.opts.ReplayStartedCallback()
+		}
+	}
+	if req.forceBroadcast {
+		// Use a longer timeout in the event that a very large amount of logs need to be marked as unconsumed.
+		ctx, cancel := context.WithTimeout(sqlutil.WithoutDefaultTimeout(ctx), time.Minute)
+		defer cancel()
+		if err := b.orm.MarkBroadcastsUnconsumed(ctx, req.fromBlock); err != nil {
+			b.eng.Errorw("Error marking broadcasts as unconsumed", "err", err, "fromBlock", req.fromBlock)
+		}
+	}
+	// Make sure the LogPoller has the logs of all filters from the replayed range.
+	if err := b.lp.Replay(ctx, req.fromBlock); err != nil {
+		b.eng.Errorw("LogPoller replay failed", "err", err, "fromBlock", req.fromBlock)
+	}
+	for _, c := range b.cursors {
+		c.nextBlock = req.fromBlock
+		c.delivered = nil
+		c.replay = false
+	}
+}
+
+// startBlock returns where a listener starts reading logs when it receives its first head.
+func (b *logPollerBroadcaster) startBlock(head *evmtypes.Head, confs uint32) int64 {
+	if b.backfilled || b.config.BlockBackfillSkip() {
+		// Listeners registered after the initial backfill only receive new logs.
+		return max(head.Number-int64(confs)+1, 0)
+	}
+	from := max(head.Number-int64(b.highestConf)-int64(b.config.BlockBackfillDepth()), 0)
+	if b.resumeFrom != nil && *b.resumeFrom < from {
+		from = *b.resumeFrom
+	}
+	return from
+}
+
+func (b *logPollerBroadcaster) onNewHead(ctx context.Context, head *evmtypes.Head) {
+	latest, err := b.lp.LatestBlock(ctx)
+	if err != nil {
+		b.eng.Warnw("Skipping head, failed to get latest LogPoller block", "blockNumber", head.Number, "err", err)
+		return
+	}
+	if hash := head.HashAtHeight(latest.BlockNumber); hash != (common.Hash{}) && hash != latest.BlockHash {
+		// The LogPoller did not process the reorg yet, so its logs may still belong to the old chain.
+		b.eng.Debugw("Skipping head, LogPoller is on another chain", "blockNumber", head.Number, "logPollerBlockNumber", latest.BlockNumber, "logPollerBlockHash", latest.BlockHash)
+		return
+	}
+
+	b.retryFilters(ctx)
+
+	var replayFrom *int64
+	var replaying []*cursor
+	for sub, c := range b.cursors {
+		if c.nextBlock < 0 {
+			c.nextBlock = b.startBlock(head, sub.opts.MinIncomingConfirmations)
+		} else if c.rewind(head, b.config.FinalityDepth()) {
+			b.eng.Infow("Reorg detected, rewinding listener", "jobID", sub.listener.JobID(), "nextBlock", c.nextBlock, "blockNumber", head.Number)
+		}
+		if !c.replay {
+			continue
+		}
+		if _, ok := b.unregistered[FilterName(sub.listener.JobID(), sub.opts.Contract)]; ok {
+			continue
+		}
+		if c.nextBlock > latest.BlockNumber {
+			// the LogPoller polls the logs of the filter from now on
+			c.replay = false
+			continue
+		}
+		replaying = append(replaying, c)
+		if replayFrom == nil || c.nextBlock < *replayFrom {
+			from := c.nextBlock
+			replayFrom = &from
+		}
+	}
+	if replayFrom != nil {
+		from := max(*replayFrom, 1)
+		b.eng.Infow("Replaying logs of new LogPoller filters", "fromBlock", from)
+		if err := b.lp.Replay(ctx, from); err != nil {
+			b.eng.Errorw("LogPoller replay of new filters failed, retrying on next head", "fromBlock", from, "err", err)
+		} else {
+			for _, c := range replaying {
+				c.replay = false
+			}
+		}
+	}
+
+	var pendingMin *int64
+	for sub, c := range b.cursors {
+		// A log in block n has head.Number-n+1 confirmations.
+		to := min(head.Number-int64(sub.opts.MinIncomingConfirmations)+1, latest.BlockNumber)
+		if c.replay {
+			// the logs of the listener are not stored by the LogPoller yet
+			to = -1
+		}
+		if to >= c.nextBlock {
+			if err := b.sendLogs(ctx, sub, head, c.nextBlock, to); err != nil {
+				b.eng.Errorw("Failed to send logs", "jobID", sub.listener.JobID(), "fromBlock", c.nextBlock, "toBlock", to, "err", err)
+			} else {
+				c.advance(head, to, b.config.FinalityDepth())
+			}
+		}
+		if pendingMin == nil || c.nextBlock < *pendingMin {
+			next := c.nextBlock
+			pendingMin = &next
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+	b.backfilled = true
+	b.resumeFrom = nil
+
+	if err := b.orm.SetPendingMinBlock(ctx, pendingMin); err != nil {
+		b.eng.Errorw("Failed to set pending broadcasts number", "err", err)
+	}
+}
+
+func (b *logPollerBroadcaster) sendLogs(ctx context.Context, sub *subscriber, head *evmtypes.Head, from, to int64) error {
+	var sigs []common.Hash
+	for sig := range sub.opts.LogsWithTopics {
+		sigs = append(sigs, sig)
+	}
+	logs, err := b.lp.LogsWithSigs(ctx, from, to, sigs, sub.opts.Contract)
+	if err != nil {
+		return err
+	}
+	if len(logs) == 0 {
+		return nil
+	}
+	broadcasts, err := b.orm.FindBroadcasts(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	existing := make(map[LogBroadcastAsKey]bool, len(broadcasts))
+	for _, bc := range broadcasts {
+		existing[bc.AsKey()] = bc.Consumed
+	}
+
+	jobID := sub.listener.JobID()
+	for _, l := range logs {
+		log := l.ToGethLog()
+		consumed, exists := existing[NewLogBroadcastAsKey(log, sub.listener)]
+		if exists && consumed {
+			continue
+		}
+		if filters := sub.opts.LogsWithTopics[log.Topics[0]]; len(filters) > 0 && len(log.Topics) > 1 {
+			if !filtersContainValues(log.Topics[1:], filters) {
+				continue
+			}
+		}
+
+		logCopy := gethwrappers.DeepCopyLog(log)
+		decodedLog, err := sub.opts.ParseLog(logCopy)
+		if err != nil {
+			b.eng.Errorw("Could not parse contract log", "err", err)
+			continue
+		}
+		if !exists {
+			if err := b.orm.CreateBroadcast(ctx, log.BlockHash, log.BlockNumber, log.Index, jobID); err != nil {
+				return fmt.Errorf("could not create broadcast log: %w", err)
+			}
+		}
+
+		b.eng.Debugw("LogBroadcaster: Sending out log",
+			"blockNumber", log.BlockNumber, "blockHash", log.BlockHash,
+			"address", log.Address, "latestBlockNumber", head.Number, "jobID", jobID)
+		sub.listener.HandleLog(ctx, &broadcast{
+			latestBlockNumber: uint64(head.Number),
+			latestBlockHash:   head.Hash,
+			receiptsRoot:      head.ReceiptsRoot,
+			transactionsRoot:  head.TransactionsRoot,
+			stateRoot:         head.StateRoot,
+			decodedLog:        decodedLog,
+			rawLog:            logCopy,
+			jobID:             jobID,
+			evmChainID:        b.evmChainID,
+		})
+	}
+	return nil
+}
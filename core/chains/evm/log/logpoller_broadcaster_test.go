@@ -0,0 +1,425 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/mailbox/mailboxtest"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/log"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	lpmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller/mocks"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	evmutils "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+)
+
+func TestLogPollerBroadcaster(t *testing.T) {
+	ctx := testutils.Context(t)
+	orm := newMemORM()
+	lp := lpmocks.NewLogPoller(t)
+	contract := testutils.NewAddress()
+	eventSig := evmutils.NewHash()
+	logs := []logpoller.Log{
+		newLogPollerLog(contract, eventSig, 5, 0),
+		newLogPollerLog(contract, eventSig, 8, 1),
+		newLogPollerLog(contract, eventSig, 10, 0),
+	}
+
+	const jobID = 42
+	lp.On("RegisterFilter", mock.Anything, logpoller.Filter{
+		Name:      log.FilterName(jobID, contract),
+		EventSigs: []common.Hash{eventSig},
+		Addresses: []common.Address{contract},
+	}).Return(nil).Once()
+	lp.On("GetFilters").Return(map[string]logpoller.Filter{}).Once()
+	lp.On("UnregisterFilter", mock.Anything, log.FilterName(jobID, contract)).Return(nil).Maybe()
+	lp.On("LatestBlock", mock.Anything).Return(logpoller.LogPollerBlock{BlockNumber: 100}, nil)
+	// the logs of the new filter are replayed from the start of the listener
+	lp.On("Replay", mock.Anything, int64(1)).Return(nil).Once()
+	lp.On("LogsWithSigs", mock.Anything, mock.Anything, mock.Anything, []common.Hash{eventSig}, contract).
+		Return(func(_ context.Context, start, end int64, _ []common.Hash, _ common.Address) ([]logpoller.Log, error) {
+			var res []logpoller.Log
+			for _, l := range logs {
+				if l.BlockNumber >= start && l.BlockNumber <= end {
+					res = append(res, l)
+				}
+			}
+			return res, nil
+		})
+	lp.On("Healthy").Return(nil)
+
+	mailMon := servicetest.Run(t, mailboxtest.NewMonitor(t))
+	lb := log.NewLogPollerBroadcaster(orm, lp, *big.NewInt(1), lpBroadcasterConfig{blockBackfillDepth: 10}, logger.Test(t), mailMon)
+	lb.AddDependents(1)
+	servicetest.Run(t, lb)
+	require.True(t, lb.IsConnected())
+
+	listener := &lpListener{jobID: jobID, received: make(chan log.Broadcast, 10)}
+	var replayStarted int
+	unsubscribe := lb.Register(listener, log.ListenerOpts{
+		Contract:                 contract,
+		LogsWithTopics:           map[common.Hash][][]log.Topic{eventSig: nil},
+		ParseLog:                 func(types.Log) (generated.AbigenLog, error) { return nil, nil },
+		MinIncomingConfirmations: 3,
+		ReplayStartedCallback:    func() { replayStarted++ },
+	})
+	lb.DependentReady()
+
+	// logs in blocks 5 and 8 have 3 confirmations at head 10
+	lb.OnNewLongestChain(ctx, &evmtypes.Head{Number: 10})
+	first := <-listener.received
+	assert.Equal(t, uint64(5), first.RawLog().BlockNumber)
+	assert.Equal(t, uint64(10), first.LatestBlockNumber())
+	second := <-listener.received
+	assert.Equal(t, uint64(8), second.RawLog().BlockNumber)
+	require.NoError(t, lb.MarkConsumed(ctx, nil, first))
+
+	consumed, err := lb.WasAlreadyConsumed(ctx, first)
+	require.NoError(t, err)
+	assert.True(t, consumed)
+	consumed, err = lb.WasAlreadyConsumed(ctx, second)
+	require.NoError(t, err)
+	assert.False(t, consumed)
+
+	// the log in block 10 is sent once it has enough confirmations
+	lb.OnNewLongestChain(ctx, &evmtypes.Head{Number: 12})
+	third := <-listener.received
+	assert.Equal(t, uint64(10), third.RawLog().BlockNumber)
+	require.NoError(t, lb.MarkConsumed(ctx, nil, third))
+
+	// a replay only re-sends the unconsumed log
+	replayed := make(chan struct{})
+	lp.On("Replay", mock.Anything, int64(0)).Return(nil).Run(func(mock.Arguments) { close(replayed) }).Once()
+	lb.ReplayFromBlock(0, false)
+	<-replayed
+	lb.OnNewLongestChain(ctx, &evmtypes.Head{Number: 13})
+	resent := <-listener.received
+	assert.Equal(t, second.RawLog(), resent.RawLog())
+	assert.Equal(t, 1, replayStarted)
+	assert.Empty(t, listener.received)
+
+	unsubscribe()
+}
+
+func TestLogPollerBroadcaster_Reorg(t *testing.T) {
+	ctx := testutils.Context(t)
+	lp := lpmocks.NewLogPoller(t)
+	contract := testutils.NewAddress()
+	eventSig := evmutils.NewHash()
+
+	oldChain := newHeadChain(nil, 10)
+	newChain := newHeadChain(oldChain.Parent.Load().Parent.Load().Parent.Load(), 11) // forks after block 7
+	var mu sync.Mutex
+	logs := []logpoller.Log{
+		newLogPollerLogInBlock(contract, eventSig, oldChain.HashAtHeight(5), 5, 0),
+		newLogPollerLogInBlock(contract, eventSig, oldChain.HashAtHeight(8), 8, 0),
+	}
+
+	const jobID = 42
+	lp.On("RegisterFilter", mock.Anything, mock.Anything).Return(nil).Once()
+	// the filter was registered before, so its logs are not replayed
+	lp.On("GetFilters").Return(map[string]logpoller.Filter{log.FilterName(jobID, contract): {
+		Name:      log.FilterName(jobID, contract),
+		EventSigs: []common.Hash{eventSig},
+		Addresses: []common.Address{contract},
+	}}).Once()
+	lp.On("UnregisterFilter", mock.Anything, log.FilterName(jobID, contract)).Return(nil).Maybe()
+	lp.On("LatestBlock", mock.Anything).Return(logpoller.LogPollerBlock{BlockNumber: 100}, nil)
+	lp.On("LogsWithSigs", mock.Anything, mock.Anything, mock.Anything, []common.Hash{eventSig}, contract).
+		Return(func(_ context.Context, start, end int64, _ []common.Hash, _ common.Address) ([]logpoller.Log, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			var res []logpoller.Log
+			for _, l := range logs {
+				if l.BlockNumber >= start && l.BlockNumber <= end {
+					res = append(res, l)
+				}
+			}
+			return res, nil
+		})
+
+	mailMon := servicetest.Run(t, mailboxtest.NewMonitor(t))
+	lb := log.NewLogPollerBroadcaster(newMemORM(), lp, *big.NewInt(1), lpBroadcasterConfig{blockBackfillDepth: 10}, logger.Test(t), mailMon)
+	lb.AddDependents(1)
+	servicetest.Run(t, lb)
+
+	listener := &lpListener{jobID: jobID, received: make(chan log.Broadcast, 10)}
+	lb.Register(listener, log.ListenerOpts{
+		Contract:                 contract,
+		LogsWithTopics:           map[common.Hash][][]log.Topic{eventSig: nil},
+		ParseLog:                 func(types.Log) (generated.AbigenLog, error) { return nil, nil },
+		MinIncomingConfirmations: 1,
+	})
+	lb.DependentReady()
+
+	lb.OnNewLongestChain(ctx, oldChain.Parent.Load().Parent.Load().Parent.Load())
+	assert.Equal(t, uint64(5), (<-listener.received).RawLog().BlockNumber)
+	lb.OnNewLongestChain(ctx, oldChain)
+	assert.Equal(t, oldChain.HashAtHeight(8), (<-listener.received).RawLog().BlockHash)
+
+	// block 8 is replaced by a block of the new chain, with another log
+	mu.Lock()
+	logs = []logpoller.Log{
+		newLogPollerLogInBlock(contract, eventSig, oldChain.HashAtHeight(5), 5, 0),
+		newLogPollerLogInBlock(contract, eventSig, newChain.HashAtHeight(8), 8, 1),
+	}
+	mu.Unlock()
+	lb.OnNewLongestChain(ctx, newChain)
+	resent := <-listener.received
+	assert.Equal(t, newChain.HashAtHeight(8), resent.RawLog().BlockHash)
+	assert.Equal(t, uint(1), resent.RawLog().Index)
+	assert.Equal(t, uint64(11), resent.LatestBlockNumber())
+	assert.Empty(t, listener.received)
+}
+
+func TestLogPollerBroadcaster_SharedFilter(t *testing.T) {
+	lp := lpmocks.NewLogPoller(t)
+	contract := testutils.NewAddress()
+	eventSig1, eventSig2 := evmutils.NewHash(), evmutils.NewHash()
+
+	const jobID = 42
+	name := log.FilterName(jobID, contract)
+	registered := make(chan []common.Hash, 2)
+	lp.On("RegisterFilter", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		filter := args.Get(1).(logpoller.Filter)
+		assert.Equal(t, name, filter.Name)
+		registered <- filter.EventSigs
+	}).Twice()
+	lp.On("GetFilters").Return(map[string]logpoller.Filter{})
+	unregistered := make(chan struct{})
+	lp.On("UnregisterFilter", mock.Anything, name).Return(nil).Run(func(mock.Arguments) { close(unregistered) }).Once()
+
+	mailMon := servicetest.Run(t, mailboxtest.NewMonitor(t))
+	lb := log.NewLogPollerBroadcaster(newMemORM(), lp, *big.NewInt(1), lpBroadcasterConfig{}, logger.Test(t), mailMon)
+	lb.AddDependents(1)
+	servicetest.Run(t, lb)
+
+	register := func(eventSig common.Hash) func() {
+		return lb.Register(&lpListener{jobID: jobID}, log.ListenerOpts{
+			Contract:                 contract,
+			LogsWithTopics:           map[common.Hash][][]log.Topic{eventSig: nil},
+			ParseLog:                 func(types.Log) (generated.AbigenLog, error) { return nil, nil },
+			MinIncomingConfirmations: 1,
+		})
+	}
+	unsubscribe1 := register(eventSig1)
+	unsubscribe2 := register(eventSig2)
+	lb.DependentReady()
+
+	assert.Equal(t, []common.Hash{eventSig1}, <-registered)
+	assert.ElementsMatch(t, []common.Hash{eventSig1, eventSig2}, <-registered)
+
+	// the filter is only unregistered with its last listener
+	unsubscribe1()
+	unsubscribe2()
+	<-unregistered
+}
+
+func TestLogPollerBroadcaster_FilterRegistrationRetried(t *testing.T) {
+	ctx := testutils.Context(t)
+	lp := lpmocks.NewLogPoller(t)
+	contract := testutils.NewAddress()
+	eventSig := evmutils.NewHash()
+	logs := []logpoller.Log{
+		newLogPollerLog(contract, eventSig, 9, 0),
+		newLogPollerLog(contract, eventSig, 10, 0),
+	}
+
+	const jobID = 42
+	name := log.FilterName(jobID, contract)
+	failed := make(chan struct{}, 2)
+	lp.On("GetFilters").Return(map[string]logpoller.Filter{})
+	lp.On("RegisterFilter", mock.Anything, mock.Anything).Return(errors.New("db unavailable")).Run(func(mock.Arguments) {
+		failed <- struct{}{}
+	}).Twice()
+	lp.On("RegisterFilter", mock.Anything, mock.Anything).Return(nil).Once()
+	lp.On("UnregisterFilter", mock.Anything, name).Return(nil).Maybe()
+	lp.On("LatestBlock", mock.Anything).Return(logpoller.LogPollerBlock{BlockNumber: 100}, nil)
+	// the listener started at block 9 while its filter was not registered
+	lp.On("Replay", mock.Anything, int64(9)).Return(nil).Once()
+	lp.On("LogsWithSigs", mock.Anything, mock.Anything, mock.Anything, []common.Hash{eventSig}, contract).
+		Return(func(_ context.Context, start, end int64, _ []common.Hash, _ common.Address) ([]logpoller.Log, error) {
+			var res []logpoller.Log
+			for _, l := range logs {
+				if l.BlockNumber >= start && l.BlockNumber <= end {
+					res = append(res, l)
+				}
+			}
+			return res, nil
+		})
+
+	mailMon := servicetest.Run(t, mailboxtest.NewMonitor(t))
+	lb := log.NewLogPollerBroadcaster(newMemORM(), lp, *big.NewInt(1), lpBroadcasterConfig{}, logger.Test(t), mailMon)
+	lb.AddDependents(1)
+	servicetest.Run(t, lb)
+
+	listener := &lpListener{jobID: jobID, received: make(chan log.Broadcast, 10)}
+	lb.Register(listener, log.ListenerOpts{
+		Contract:                 contract,
+		LogsWithTopics:           map[common.Hash][][]log.Topic{eventSig: nil},
+		ParseLog:                 func(types.Log) (generated.AbigenLog, error) { return nil, nil },
+		MinIncomingConfirmations: 1,
+	})
+	lb.DependentReady()
+	<-failed
+
+	// the registration is retried on the head, and the listener waits for it
+	lb.OnNewLongestChain(ctx, &evmtypes.Head{Number: 10})
+	<-failed
+	require.Eventually(t, func() bool {
+		return lb.HealthReport()[lb.Name()] != nil
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+	assert.Empty(t, listener.received)
+
+	// once registered, the missed logs are replayed and sent
+	lb.OnNewLongestChain(ctx, &evmtypes.Head{Number: 11})
+	assert.Equal(t, uint64(9), (<-listener.received).RawLog().BlockNumber)
+	assert.Equal(t, uint64(10), (<-listener.received).RawLog().BlockNumber)
+	assert.NoError(t, lb.HealthReport()[lb.Name()])
+}
+
+// newHeadChain returns the head of block number to, built with random hashes on top of parent (or of genesis if nil).
+func newHeadChain(parent *evmtypes.Head, to int64) *evmtypes.Head {
+	head := parent
+	if head == nil {
+		head = &evmtypes.Head{Hash: evmutils.NewHash()}
+	}
+	for head.Number < to {
+		h := &evmtypes.Head{Hash: evmutils.NewHash(), Number: head.Number + 1, ParentHash: head.Hash}
+		h.Parent.Store(head)
+		head = h
+	}
+	return head
+}
+
+func newLogPollerLogInBlock(address common.Address, eventSig common.Hash, blockHash common.Hash, blockNumber int64, logIndex int64) logpoller.Log {
+	l := newLogPollerLog(address, eventSig, blockNumber, logIndex)
+	l.BlockHash = blockHash
+	return l
+}
+
+func newLogPollerLog(address common.Address, eventSig common.Hash, blockNumber int64, logIndex int64) logpoller.Log {
+	return logpoller.Log{
+		LogIndex:    logIndex,
+		BlockHash:   common.BigToHash(big.NewInt(blockNumber)),
+		BlockNumber: blockNumber,
+		Topics:      [][]byte{eventSig.Bytes()},
+		EventSig:    eventSig,
+		Address:     address,
+	}
+}
+
+type lpBroadcasterConfig struct {
+	blockBackfillDepth uint64
+}
+
+func (c lpBroadcasterConfig) BlockBackfillDepth() uint64   { return c.blockBackfillDepth }
+func (c lpBroadcasterConfig) BlockBackfillSkip() bool      { return false }
+func (c lpBroadcasterConfig) FinalityDepth() uint32        { return 50 }
+func (c lpBroadcasterConfig) LogBackfillBatchSize() uint32 { return 100 }
+
+type lpListener struct {
+	jobID    int32
+	received chan log.Broadcast
+}
+
+func (l *lpListener) JobID() int32 { return l.jobID }
+func (l *lpListener) HandleLog(_ context.Context, b log.Broadcast) {
+	l.received <- b
+}
+
+// memORM is an in-memory log.ORM
+type memORM struct {
+	mu         sync.Mutex
+	broadcasts map[log.LogBroadcastAsKey]*memBroadcast
+	pendingMin *int64
+}
+
+type memBroadcast struct {
+	blockNumber uint64
+	consumed    bool
+}
+
+var _ log.ORM = (*memORM)(nil)
+
+func newMemORM() *memORM {
+	return &memORM{broadcasts: make(map[log.LogBroadcastAsKey]*memBroadcast)}
+}
+
+func (o *memORM) FindBroadcasts(_ context.Context, fromBlockNum int64, toBlockNum int64) ([]log.LogBroadcast, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var res []log.LogBroadcast
+	for k, b := range o.broadcasts {
+		if int64(b.blockNumber) >= fromBlockNum && int64(b.blockNumber) <= toBlockNum {
+			res = append(res, log.LogBroadcast{BlockHash: k.BlockHash, LogIndex: k.LogIndex, JobID: k.JobId, Consumed: b.consumed})
+		}
+	}
+	return res, nil
+}
+
+func (o *memORM) CreateBroadcast(_ context.Context, blockHash common.Hash, blockNumber uint64, logIndex uint, jobID int32) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.broadcasts[log.LogBroadcastAsKey{BlockHash: blockHash, LogIndex: logIndex, JobId: jobID}] = &memBroadcast{blockNumber: blockNumber}
+	return nil
+}
+
+func (o *memORM) WasBroadcastConsumed(_ context.Context, blockHash common.Hash, logIndex uint, jobID int32) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	b, ok := o.broadcasts[log.LogBroadcastAsKey{BlockHash: blockHash, LogIndex: logIndex, JobId: jobID}]
+	return ok && b.consumed, nil
+}
+
+func (o *memORM) MarkBroadcastConsumed(_ context.Context, blockHash common.Hash, blockNumber uint64, logIndex uint, jobID int32) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.broadcasts[log.LogBroadcastAsKey{BlockHash: blockHash, LogIndex: logIndex, JobId: jobID}] = &memBroadcast{blockNumber: blockNumber, consumed: true}
+	return nil
+}
+
+func (o *memORM) MarkBroadcastsUnconsumed(_ context.Context, fromBlock int64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, b := range o.broadcasts {
+		if int64(b.blockNumber) >= fromBlock {
+			b.consumed = false
+		}
+	}
+	return nil
+}
+
+func (o *memORM) SetPendingMinBlock(_ context.Context, blockNum *int64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.pendingMin = blockNum
+	return nil
+}
+
+func (o *memORM) GetPendingMinBlock(context.Context) (*int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.pendingMin, nil
+}
+
+func (o *memORM) Reinitialize(ctx context.Context) (*int64, error) {
+	return o.GetPendingMinBlock(ctx)
+}
+
+func (o *memORM) WithDataSource(sqlutil.DataSource) log.ORM { return o }
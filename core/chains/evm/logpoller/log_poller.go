@@ -33,6 +33,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
+	httypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker/types"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	ubig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
 )
@@ -127,6 +128,10 @@ type logPoller struct {
 	cachedAddresses []common.Address
 	cachedEventSigs []common.Hash
 
+	// reorgHint is the deepest reorg reported by the HeadTracker since the last time LogPoller handled a reorg.
+	reorgMu   sync.Mutex
+	reorgHint *httypes.ReorgEvent
+
 	replayStart    chan int64
 	replayComplete chan error
 	stopCh         services.StopChan
@@ -964,7 +969,10 @@ func (lp *logPoller) getCurrentBlockMaybeHandleReorg(ctx context.Context, curren
 		// There can be another reorg while we're finding the LCA.
 		// That is ok, since we'll detect it on the next iteration.
 		// Since we go currentBlock by currentBlock for unfinalized logs, the mismatch starts at currentBlockNumber - 1.
-		blockAfterLCA, err2 := lp.findBlockAfterLCA(ctx, currentBlock, expectedParent.FinalizedBlockNumber)
+		blockAfterLCA, err2 := lp.blockAfterLCAFromReorgHint(ctx, currentBlock, expectedParent.FinalizedBlockNumber)
+		if blockAfterLCA == nil && err2 == nil {
+			blockAfterLCA, err2 = lp.findBlockAfterLCA(ctx, currentBlock, expectedParent.FinalizedBlockNumber)
+		}
 		if err2 != nil {
 			lp.lggr.Warnw("Unable to find LCA after reorg, retrying", "err", err2)
 			return nil, pkgerrors.New("Unable to find LCA after reorg, retrying")
@@ -1105,6 +1113,56 @@ func (lp *logPoller) latestBlocks(ctx context.Context) (*evmtypes.Head, int64, e
 	return latest, finalizedBN, nil
 }
 
+// OnReorg conforms to ReorgTrackable. The reorg reported by the HeadTracker is used as a hint on the next
+// reorg handling, to avoid walking back the chain block by block to find the LCA.
+func (lp *logPoller) OnReorg(_ context.Context, event httypes.ReorgEvent) {
+	lp.reorgMu.Lock()
+	defer lp.reorgMu.Unlock()
+	if lp.reorgHint == nil || event.CommonAncestorNumber < lp.reorgHint.CommonAncestorNumber {
+		lp.reorgHint = &event
+	}
+}
+
+// blockAfterLCAFromReorgHint uses the reorg reported by the HeadTracker to find the block after LCA.
+// Returns nil block without error if there is no hint or the hint does not match the state of our db,
+// in which case LCA must be found by findBlockAfterLCA.
+func (lp *logPoller) blockAfterLCAFromReorgHint(ctx context.Context, current *evmtypes.Head, latestFinalizedBlockNumber int64) (*evmtypes.Head, error) {
+	lp.reorgMu.Lock()
+	hint := lp.reorgHint
+	lp.reorgHint = nil
+	lp.reorgMu.Unlock()
+
+	if hint == nil || hint.CommonAncestorNumber >= current.Number || hint.CommonAncestorNumber < latestFinalizedBlockNumber {
+		return nil, nil
+	}
+	ourAncestor, err := lp.orm.SelectBlockByNumber(ctx, hint.CommonAncestorNumber)
+	if err != nil {
+		if pkgerrors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if ourAncestor.BlockHash != hint.CommonAncestorHash {
+		lp.lggr.Debugw("Reorg hint does not match saved block, searching for LCA", "commonAncestorNumber", hint.CommonAncestorNumber,
+			"commonAncestorHash", hint.CommonAncestorHash, "savedHash", ourAncestor.BlockHash)
+		return nil, nil
+	}
+
+	blockAfterLCA := current
+	if hint.CommonAncestorNumber+1 != current.Number {
+		blockAfterLCA, err = lp.ec.HeadByNumber(ctx, big.NewInt(hint.CommonAncestorNumber+1))
+		if err != nil {
+			return nil, err
+		}
+	}
+	// chain might have been reorged again since the hint was produced
+	if blockAfterLCA == nil || blockAfterLCA.ParentHash != hint.CommonAncestorHash {
+		return nil, nil
+	}
+	lp.lggr.Debugw("Found LCA using HeadTracker reorg hint", "commonAncestorNumber", hint.CommonAncestorNumber, "reorgDepth", hint.Depth)
+	return blockAfterLCA, nil
+}
+
 // Find the first place where our chain and their chain have the same block,
 // that block number is the LCA. Return the block after that, where we want to resume polling.
 func (lp *logPoller) findBlockAfterLCA(ctx context.Context, current *evmtypes.Head, latestFinalizedBlockNumber int64) (*evmtypes.Head, error) {
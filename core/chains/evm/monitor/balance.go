@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net/http"
 	"sync"
 	"time"
 
@@ -15,13 +16,17 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	evmclient "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
 	httypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	httputil "github.com/smartcontractkit/chainlink/v2/core/utils/http"
 )
 
 type (
@@ -43,6 +48,12 @@ type (
 		ethBalances    map[gethCommon.Address]*assets.Eth
 		ethBalancesMtx sync.RWMutex
 		sleeperTask    *utils.SleeperTask
+
+		cfg        config.EVM
+		txm        txmgr.TxManager
+		httpClient *http.Client
+		alerts     *lowBalanceAlerts
+		topUps     *topUpLedger
 	}
 
 	NullBalanceMonitor struct{}
@@ -50,8 +61,10 @@ type (
 
 var _ BalanceMonitor = (*balanceMonitor)(nil)
 
-// NewBalanceMonitor returns a new balanceMonitor
-func NewBalanceMonitor(ethClient evmclient.Client, ethKeyStore keystore.Eth, lggr logger.Logger) *balanceMonitor {
+// NewBalanceMonitor returns a new balanceMonitor.
+// Keys falling below their configured low balance threshold are alerted on and, if enabled, topped up via txm. The
+// top-ups already sent are read from ds, which is only used when top-ups are enabled.
+func NewBalanceMonitor(ds sqlutil.DataSource, ethClient evmclient.Client, ethKeyStore keystore.Eth, cfg config.EVM, txm txmgr.TxManager, lggr logger.Logger) *balanceMonitor {
 	chainId := ethClient.ConfiguredChainID()
 	bm := &balanceMonitor{
 		ethClient:   ethClient,
@@ -59,6 +72,11 @@ func NewBalanceMonitor(ethClient evmclient.Client, ethKeyStore keystore.Eth, lgg
 		chainIDStr:  chainId.String(),
 		ethKeyStore: ethKeyStore,
 		ethBalances: make(map[gethCommon.Address]*assets.Eth),
+		cfg:         cfg,
+		txm:         txm,
+		httpClient:  httputil.NewUnrestrictedHTTPClient(),
+		alerts:      newLowBalanceAlerts(),
+		topUps:      newTopUpLedger(ds, chainId),
 	}
 	bm.Service, bm.eng = services.Config{
 		Name:  "BalanceMonitor",
@@ -89,7 +107,7 @@ func (bm *balanceMonitor) OnNewLongestChain(_ context.Context, _ *evmtypes.Head)
 	}
 }
 
-func (bm *balanceMonitor) updateBalance(ethBal assets.Eth, address gethCommon.Address) {
+func (bm *balanceMonitor) updateBalance(ctx context.Context, ethBal assets.Eth, address gethCommon.Address) {
 	bm.promUpdateEthBalance(&ethBal, address)
 
 	bm.ethBalancesMtx.Lock()
@@ -105,12 +123,11 @@ func (bm *balanceMonitor) updateBalance(ethBal assets.Eth, address gethCommon.Ad
 
 	if oldBal == nil {
 		lgr.Infof("ETH balance for %s: %s", address.Hex(), ethBal.String())
-		return
-	}
-
-	if ethBal.Cmp(oldBal) != 0 {
+	} else if ethBal.Cmp(oldBal) != 0 {
 		lgr.Infof("New ETH balance for %s: %s", address.Hex(), ethBal.String())
 	}
+
+	bm.checkLowBalance(ctx, &ethBal, address)
 }
 
 func (bm *balanceMonitor) GetEthBalance(address gethCommon.Address) *assets.Eth {
@@ -184,7 +201,7 @@ func (w *worker) checkAccountBalance(ctx context.Context, address gethCommon.Add
 		)
 	} else {
 		ethBal := assets.Eth(*bal)
-		w.bm.updateBalance(ethBal, address)
+		w.bm.updateBalance(ctx, ethBal, address)
 	}
 }
 
@@ -2,7 +2,10 @@ package monitor_test
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -14,15 +17,23 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	txmgrcommon "github.com/smartcontractkit/chainlink/v2/common/txmgr"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	evmclimocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
 	ksmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/keystore/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/monitor"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
+	txmmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 )
 
 var nilBigInt *big.Int
@@ -44,7 +55,7 @@ func TestBalanceMonitor_Start(t *testing.T) {
 			Return([]common.Address{k0Addr, k1Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(nil, ethClient, ethKeyStore, testutils.NewTestChainScopedConfig(t, nil).EVM(), nil, logger.Test(t))
 
 		k0bal := big.NewInt(42)
 		k1bal := big.NewInt(43)
@@ -71,7 +82,7 @@ func TestBalanceMonitor_Start(t *testing.T) {
 			Return([]common.Address{k0Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(nil, ethClient, ethKeyStore, testutils.NewTestChainScopedConfig(t, nil).EVM(), nil, logger.Test(t))
 		k0bal := big.NewInt(42)
 
 		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(k0bal, nil)
@@ -90,7 +101,7 @@ func TestBalanceMonitor_Start(t *testing.T) {
 			Return([]common.Address{k0Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(nil, ethClient, ethKeyStore, testutils.NewTestChainScopedConfig(t, nil).EVM(), nil, logger.Test(t))
 		ctxCancelledAwaiter := testutils.NewAwaiter()
 
 		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Run(func(args mock.Arguments) {
@@ -119,7 +130,7 @@ func TestBalanceMonitor_Start(t *testing.T) {
 			Return([]common.Address{k0Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(nil, ethClient, ethKeyStore, testutils.NewTestChainScopedConfig(t, nil).EVM(), nil, logger.Test(t))
 
 		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).
 			Once().
@@ -144,7 +155,7 @@ func TestBalanceMonitor_OnNewLongestChain_UpdatesBalance(t *testing.T) {
 			Return([]common.Address{k0Addr, k1Addr}, nil)
 		ethClient := newEthClientMock(t)
 
-		bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+		bm := monitor.NewBalanceMonitor(nil, ethClient, ethKeyStore, testutils.NewTestChainScopedConfig(t, nil).EVM(), nil, logger.Test(t))
 		k0bal := big.NewInt(42)
 		// Deliberately larger than a 64 bit unsigned integer to test overflow
 		k1bal := big.NewInt(0)
@@ -193,7 +204,7 @@ func TestBalanceMonitor_FewerRPCCallsWhenBehind(t *testing.T) {
 
 	ethClient := newEthClientMock(t)
 
-	bm := monitor.NewBalanceMonitor(ethClient, ethKeyStore, logger.Test(t))
+	bm := monitor.NewBalanceMonitor(nil, ethClient, ethKeyStore, testutils.NewTestChainScopedConfig(t, nil).EVM(), nil, logger.Test(t))
 	ethClient.On("BalanceAt", mock.Anything, mock.Anything, mock.Anything).
 		Once().
 		Return(big.NewInt(1), nil)
@@ -230,6 +241,121 @@ func TestBalanceMonitor_FewerRPCCallsWhenBehind(t *testing.T) {
 	assert.LessOrEqual(t, callCount.Load(), int32(1))
 }
 
+func TestBalanceMonitor_LowBalance(t *testing.T) {
+	t.Parallel()
+
+	fundingAddr := testutils.NewAddress()
+	k0Addr := testutils.NewAddress()
+
+	newConfig := func(t *testing.T, webhookURL string, topUp bool) config.EVM {
+		return testutils.NewTestChainScopedConfig(t, func(c *toml.EVMConfig) {
+			c.BalanceMonitor.LowBalanceThreshold = assets.NewWeiI(100)
+			if webhookURL != "" {
+				c.BalanceMonitor.AlertWebhookURL = commonconfig.MustParseURL(webhookURL)
+			}
+			c.BalanceMonitor.TopUp.Enabled = ptr(topUp)
+			c.BalanceMonitor.TopUp.FundingAddress = ptr(types.EIP55AddressFromAddress(fundingAddr))
+			c.BalanceMonitor.TopUp.Amount = assets.NewWeiI(50)
+			c.BalanceMonitor.TopUp.DailyLimit = assets.NewWeiI(50)
+		}).EVM()
+	}
+
+	t.Run("alerts via webhook and health report, and recovers", func(t *testing.T) {
+		alerts := make(chan monitor.LowBalanceAlert, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var alert monitor.LowBalanceAlert
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+			alerts <- alert
+		}))
+		t.Cleanup(srv.Close)
+
+		ethKeyStore := ksmocks.NewEth(t)
+		ethKeyStore.On("EnabledAddressesForChain", mock.Anything, mock.Anything).
+			Return([]common.Address{k0Addr}, nil)
+		ethClient := newEthClientMock(t)
+
+		bm := monitor.NewBalanceMonitor(nil, ethClient, ethKeyStore, newConfig(t, srv.URL, false), nil, logger.Test(t))
+		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(big.NewInt(99), nil)
+		servicetest.Run(t, bm)
+
+		select {
+		case alert := <-alerts:
+			assert.Equal(t, k0Addr.Hex(), alert.Address)
+			assert.Equal(t, "99", alert.Balance)
+			assert.Equal(t, "100", alert.Threshold)
+		case <-time.After(tests.WaitTimeout(t)):
+			t.Fatal("timed out waiting for alert")
+		}
+		require.Error(t, bm.HealthReport()[bm.Name()])
+
+		// no repeated alert while still low
+		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(big.NewInt(98), nil)
+		bm.OnNewLongestChain(tests.Context(t), testutils.Head(0))
+		<-bm.WorkDone()
+		assert.Empty(t, alerts)
+
+		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(big.NewInt(100), nil)
+		bm.OnNewLongestChain(tests.Context(t), testutils.Head(1))
+		<-bm.WorkDone()
+		require.NoError(t, bm.HealthReport()[bm.Name()])
+	})
+
+	t.Run("tops up low keys up to the daily limit", func(t *testing.T) {
+		k1Addr := testutils.NewAddress()
+		ethKeyStore := ksmocks.NewEth(t)
+		ethKeyStore.On("EnabledAddressesForChain", mock.Anything, mock.Anything).
+			Return([]common.Address{fundingAddr, k0Addr, k1Addr}, nil)
+		ethClient := newEthClientMock(t)
+		txm := txmmocks.NewMockEvmTxManager(t)
+		db := pgtest.NewSqlxDB(t)
+
+		bm := monitor.NewBalanceMonitor(db, ethClient, ethKeyStore, newConfig(t, "", true), txm, logger.Test(t))
+		ethClient.On("BalanceAt", mock.Anything, fundingAddr, nilBigInt).Return(big.NewInt(1), nil)
+		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Return(big.NewInt(1), nil)
+		ethClient.On("BalanceAt", mock.Anything, k1Addr, nilBigInt).Return(big.NewInt(1), nil)
+
+		// funding key is never topped up, and the daily limit only allows a single top-up
+		txm.On("SendNativeToken", mock.Anything, big.NewInt(0), fundingAddr, mock.MatchedBy(func(to common.Address) bool {
+			return to == k0Addr || to == k1Addr
+		}), *big.NewInt(50), mock.Anything).Once().Return(txmgr.Tx{ID: 1}, nil)
+
+		servicetest.Run(t, bm)
+
+		bm.OnNewLongestChain(tests.Context(t), testutils.Head(0))
+		<-bm.WorkDone()
+	})
+
+	t.Run("counts the top-ups sent before a restart toward the daily limit", func(t *testing.T) {
+		ethKeyStore := ksmocks.NewEth(t)
+		ethKeyStore.On("EnabledAddressesForChain", mock.Anything, mock.Anything).
+			Return([]common.Address{k0Addr}, nil)
+		ethClient := newEthClientMock(t)
+		ethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Return(big.NewInt(1), nil)
+		// no top-up is expected
+		txm := txmmocks.NewMockEvmTxManager(t)
+		db := pgtest.NewSqlxDB(t)
+		txStore := txmgr.NewTxStore(db, logger.Test(t))
+		require.NoError(t, txStore.InsertTx(tests.Context(t), &txmgr.Tx{
+			FromAddress:    fundingAddr,
+			ToAddress:      k0Addr,
+			EncodedPayload: []byte{},
+			Value:          *big.NewInt(50),
+			FeeLimit:       21000,
+			State:          txmgrcommon.TxUnstarted,
+			ChainID:        big.NewInt(0),
+			CreatedAt:      time.Now().Add(-time.Hour),
+		}))
+
+		bm := monitor.NewBalanceMonitor(db, ethClient, ethKeyStore, newConfig(t, "", true), txm, logger.Test(t))
+		servicetest.Run(t, bm)
+
+		bm.OnNewLongestChain(tests.Context(t), testutils.Head(0))
+		<-bm.WorkDone()
+	})
+}
+
+func ptr[T any](v T) *T { return &v }
+
 func Test_ApproximateFloat64(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,290 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	ubig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
+)
+
+const (
+	// topUpCooldown is the minimum time between two top-ups of the same key, giving the previous top-up time to confirm.
+	topUpCooldown = 15 * time.Minute
+	// topUpWindow is the rolling window over which TopUp.DailyLimit is enforced.
+	topUpWindow = 24 * time.Hour
+	// alertTimeout bounds the post of a low balance alert to BalanceMonitor.AlertWebhookURL.
+	alertTimeout = 10 * time.Second
+)
+
+var (
+	promLowBalanceAlerts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "balance_monitor_low_balance_alerts",
+			Help: "The number of times an account was detected to have fallen below its low balance threshold",
+		},
+		[]string{"account", "evmChainID"},
+	)
+	promTopUps = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "balance_monitor_top_ups",
+			Help: "The number of top-up transactions sent to accounts below their low balance threshold",
+		},
+		[]string{"account", "evmChainID"},
+	)
+	promTopUpsSkipped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "balance_monitor_top_ups_skipped",
+			Help: "The number of top-ups that were not sent because they would exceed the daily limit",
+		},
+		[]string{"account", "evmChainID"},
+	)
+)
+
+// LowBalanceAlert is the JSON payload posted to BalanceMonitor.AlertWebhookURL.
+type LowBalanceAlert struct {
+	EVMChainID string    `json:"evmChainID"`
+	Address    string    `json:"address"`
+	Balance    string    `json:"balance"`
+	Threshold  string    `json:"threshold"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+// lowBalanceAlerts tracks which keys are currently below their threshold, so that alerts fire once per incident.
+type lowBalanceAlerts struct {
+	mu  sync.Mutex
+	low map[gethCommon.Address]struct{}
+}
+
+func newLowBalanceAlerts() *lowBalanceAlerts {
+	return &lowBalanceAlerts{low: make(map[gethCommon.Address]struct{})}
+}
+
+// set marks address as low (or recovered) and reports whether the state changed.
+func (a *lowBalanceAlerts) set(address gethCommon.Address, low bool) (changed bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, wasLow := a.low[address]
+	if low {
+		a.low[address] = struct{}{}
+	} else {
+		delete(a.low, address)
+	}
+	return wasLow != low
+}
+
+type topUp struct {
+	id     uint64
+	at     time.Time
+	amount *big.Int
+}
+
+// topUpLedger tracks top-ups sent in the current window. It is loaded from the txes table before the first top-up,
+// so that the top-ups sent before the node restarted count toward the daily limit.
+type topUpLedger struct {
+	ds      sqlutil.DataSource
+	chainID *big.Int
+
+	mu      sync.Mutex
+	loaded  bool
+	nextID  uint64
+	sent    []topUp
+	lastFor map[gethCommon.Address]time.Time
+}
+
+func newTopUpLedger(ds sqlutil.DataSource, chainID *big.Int) *topUpLedger {
+	return &topUpLedger{ds: ds, chainID: chainID, lastFor: make(map[gethCommon.Address]time.Time)}
+}
+
+// load records the top-ups sent from funding in the window ending at now, unless already loaded. Any transfer of the
+// native token from the funding key counts as a top-up, except the ones that failed before being broadcast.
+func (l *topUpLedger) load(ctx context.Context, funding gethCommon.Address, now time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.loaded {
+		return nil
+	}
+	var rows []struct {
+		ToAddress gethCommon.Address `db:"to_address"`
+		Value     assets.Eth         `db:"value"`
+		CreatedAt time.Time          `db:"created_at"`
+	}
+	err := l.ds.SelectContext(ctx, &rows, `SELECT to_address, value, created_at FROM evm.txes
+WHERE evm_chain_id = $1 AND from_address = $2 AND value > 0 AND octet_length(encoded_payload) = 0 AND state <> 'fatal_error' AND created_at > $3
+ORDER BY created_at`, ubig.New(l.chainID), funding, now.Add(-topUpWindow))
+	if err != nil {
+		return fmt.Errorf("failed to load sent top-ups: %w", err)
+	}
+	for _, row := range rows {
+		l.sent = append(l.sent, topUp{id: l.nextID, at: row.CreatedAt, amount: row.Value.ToInt()})
+		l.nextID++
+		l.lastFor[row.ToAddress] = row.CreatedAt
+	}
+	l.loaded = true
+	return nil
+}
+
+var (
+	errTopUpCooldown   = fmt.Errorf("key was topped up less than %s ago", topUpCooldown)
+	errTopUpDailyLimit = errors.New("top-up would exceed the daily limit")
+)
+
+// reserve records a top-up of amount to address at now, if allowed by the cooldown and the daily limit.
+// The returned release func must be called if the top-up could not be sent.
+func (l *topUpLedger) reserve(address gethCommon.Address, amount, limit *big.Int, now time.Time) (release func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if last, ok := l.lastFor[address]; ok && now.Sub(last) < topUpCooldown {
+		return nil, errTopUpCooldown
+	}
+
+	total := new(big.Int).Set(amount)
+	kept := l.sent[:0]
+	for _, t := range l.sent {
+		if now.Sub(t.at) < topUpWindow {
+			kept = append(kept, t)
+			total.Add(total, t.amount)
+		}
+	}
+	l.sent = kept
+	if total.Cmp(limit) > 0 {
+		return nil, errTopUpDailyLimit
+	}
+
+	prevLast, hadLast := l.lastFor[address]
+	id := l.nextID
+	l.nextID++
+	l.sent = append(l.sent, topUp{id: id, at: now, amount: amount})
+	l.lastFor[address] = now
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i := range l.sent {
+			if l.sent[i].id == id {
+				l.sent = append(l.sent[:i], l.sent[i+1:]...)
+				break
+			}
+		}
+		if hadLast {
+			l.lastFor[address] = prevLast
+		} else {
+			delete(l.lastFor, address)
+		}
+	}, nil
+}
+
+func lowBalanceCondition(address gethCommon.Address) string {
+	return "LowBalance:" + address.Hex()
+}
+
+// checkLowBalance alerts on keys below their low balance threshold and tops them up if enabled.
+func (bm *balanceMonitor) checkLowBalance(ctx context.Context, balance *assets.Eth, address gethCommon.Address) {
+	threshold := bm.cfg.BalanceMonitor().LowBalanceThresholdKey(address)
+	if threshold == nil || threshold.IsZero() || balance.ToInt().Cmp(threshold.ToInt()) >= 0 {
+		if bm.alerts.set(address, false) {
+			bm.eng.Infow("BalanceMonitor: balance recovered above low balance threshold", "address", address.Hex(), "balance", balance.String())
+			bm.eng.ClearHealthCond(lowBalanceCondition(address))
+		}
+		return
+	}
+
+	if bm.alerts.set(address, true) {
+		bm.eng.Criticalw(fmt.Sprintf("BalanceMonitor: balance for %s is below the low balance threshold of %s", address.Hex(), threshold.String()),
+			"address", address.Hex(), "balance", balance.String(), "threshold", threshold.String())
+		bm.eng.SetHealthCond(lowBalanceCondition(address), fmt.Errorf("balance %s is below the low balance threshold of %s", balance.String(), threshold.String()))
+		promLowBalanceAlerts.WithLabelValues(address.Hex(), bm.chainIDStr).Inc()
+		alert := LowBalanceAlert{
+			EVMChainID: bm.chainIDStr,
+			Address:    address.Hex(),
+			Balance:    balance.ToInt().String(),
+			Threshold:  threshold.ToInt().String(),
+			DetectedAt: time.Now(),
+		}
+		// the webhook is posted in the background, so that a slow endpoint doesn't hold up the balance checks
+		bm.eng.Go(func(ctx context.Context) {
+			ctx, cancel := context.WithTimeout(ctx, alertTimeout)
+			defer cancel()
+			if err := bm.postAlert(ctx, alert); err != nil {
+				bm.eng.Errorw("BalanceMonitor: failed to post low balance alert", "address", alert.Address, "err", err)
+			}
+		})
+	}
+
+	bm.topUp(ctx, address)
+}
+
+func (bm *balanceMonitor) postAlert(ctx context.Context, alert LowBalanceAlert) error {
+	webhookURL := bm.cfg.BalanceMonitor().AlertWebhookURL()
+	if webhookURL == nil {
+		return nil
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := bm.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (bm *balanceMonitor) topUp(ctx context.Context, address gethCommon.Address) {
+	topUpCfg := bm.cfg.BalanceMonitor().TopUp()
+	if !topUpCfg.Enabled() || bm.txm == nil {
+		return
+	}
+	funding := topUpCfg.FundingAddress().Address()
+	if address == funding {
+		return
+	}
+	lggr := bm.eng.With("address", address.Hex(), "fundingAddress", funding.Hex(), "amount", topUpCfg.Amount().String())
+
+	amount := topUpCfg.Amount().ToInt()
+	if err := bm.topUps.load(ctx, funding, time.Now()); err != nil {
+		lggr.Errorw("BalanceMonitor: not topping up key", "err", err)
+		return
+	}
+	release, err := bm.topUps.reserve(address, amount, topUpCfg.DailyLimit().ToInt(), time.Now())
+	if err != nil {
+		if errors.Is(err, errTopUpDailyLimit) {
+			lggr.Errorw("BalanceMonitor: not topping up key", "dailyLimit", topUpCfg.DailyLimit().String(), "err", err)
+			promTopUpsSkipped.WithLabelValues(address.Hex(), bm.chainIDStr).Inc()
+		} else {
+			lggr.Debugw("BalanceMonitor: not topping up key", "err", err)
+		}
+		return
+	}
+
+	etx, err := bm.txm.SendNativeToken(ctx, bm.chainID, funding, address, *amount, bm.cfg.GasEstimator().LimitTransfer())
+	if err != nil {
+		release()
+		lggr.Errorw("BalanceMonitor: failed to send top-up transaction", "err", err)
+		return
+	}
+	lggr.Infow("BalanceMonitor: sent top-up transaction", "txID", etx.ID)
+	promTopUps.WithLabelValues(address.Hex(), bm.chainIDStr).Inc()
+}
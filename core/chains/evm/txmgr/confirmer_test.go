@@ -32,6 +32,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas"
 	gasmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	httypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/keystore"
 	ksmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/keystore/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/testutils"
@@ -412,6 +413,51 @@ func TestEthConfirmer_CheckForConfirmation(t *testing.T) {
 	})
 }
 
+func TestEthConfirmer_ProcessReorgedBlocks(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	txStore := cltest.NewTestTxStore(t, db)
+	ethClient := testutils.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
+	ec := newEthConfirmer(t, txStore, ethClient, cfg, evmcfg, ethKeyStore, nil)
+	ctx := tests.Context(t)
+
+	// the new chain replaced blocks 100 and 101
+	h99 := &evmtypes.Head{Hash: testutils.NewHash(), Number: 99}
+	h100 := &evmtypes.Head{Hash: testutils.NewHash(), Number: 100}
+	h100.Parent.Store(h99)
+	head := &evmtypes.Head{Hash: testutils.NewHash(), Number: 101}
+	head.Parent.Store(h100)
+	reorg := httypes.ReorgEvent{Depth: 2, OldHeadNumber: 101, NewHeadHash: head.Hash, NewHeadNumber: 101, CommonAncestorHash: h99.Hash, CommonAncestorNumber: 99}
+
+	// confirmed in a block of the old chain
+	reorgedTx := mustInsertConfirmedEthTxWithReceipt(t, txStore, fromAddress, 0, 100)
+	// confirmed in a block that is still part of the chain
+	canonicalTx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 1, 100, fromAddress)
+	mustInsertEthReceipt(t, txStore, 100, h100.Hash, canonicalTx.TxAttempts[0].Hash)
+	// confirmed before the common ancestor
+	olderTx := mustInsertConfirmedEthTxWithReceipt(t, txStore, fromAddress, 2, 99)
+
+	require.NoError(t, ec.ProcessReorgedBlocks(ctx, reorg, head))
+
+	etx, err := txStore.FindTxWithAttempts(ctx, reorgedTx.ID)
+	require.NoError(t, err)
+	require.Equal(t, txmgrcommon.TxUnconfirmed, etx.State)
+	require.Equal(t, txmgrtypes.TxAttemptInProgress, etx.TxAttempts[0].State)
+	require.Empty(t, etx.TxAttempts[0].Receipts)
+
+	for _, id := range []int64{canonicalTx.ID, olderTx.ID} {
+		etx, err = txStore.FindTxWithAttempts(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, txmgrcommon.TxConfirmed, etx.State)
+		require.Len(t, etx.TxAttempts[0].Receipts, 1)
+	}
+}
+
 func TestEthConfirmer_FindTxsRequiringRebroadcast(t *testing.T) {
 	t.Parallel()
 
@@ -49,6 +49,7 @@ type EvmTxStore interface {
 	FindTxesPendingCallback(ctx context.Context, latest, finalized int64, chainID *big.Int) (receiptsPlus []ReceiptPlus, err error)
 	FindTxesByIDs(ctx context.Context, etxIDs []int64, chainID *big.Int) (etxs []*Tx, err error)
 	SaveFetchedReceipts(ctx context.Context, r []*evmtypes.Receipt) (err error)
+	SaveRevertReason(ctx context.Context, r RevertReason) error
 	UpdateTxStatesToFinalizedUsingTxHashes(ctx context.Context, txHashes []common.Hash, chainID *big.Int) error
 }
 
@@ -62,6 +63,7 @@ type TxStoreWebApi interface {
 	FindTxAttempt(ctx context.Context, hash common.Hash) (*TxAttempt, error)
 	FindTxWithAttempts(ctx context.Context, etxID int64) (etx Tx, err error)
 	FindTxsByStateAndFromAddresses(ctx context.Context, addresses []common.Address, state txmgrtypes.TxState, chainID *big.Int) (txs []*Tx, err error)
+	FindRevertReason(ctx context.Context, hash common.Hash) (*RevertReason, error)
 }
 
 type TestEvmTxStore interface {
@@ -1100,6 +1102,47 @@ func (o *evmTxStore) DeleteReceiptByTxHash(ctx context.Context, txHash common.Ha
 	return err
 }
 
+// SaveRevertReason saves the revert reason of a reverted transaction attempt, replacing any previous one.
+func (o *evmTxStore) SaveRevertReason(ctx context.Context, r RevertReason) error {
+	var cancel context.CancelFunc
+	ctx, cancel = o.stopCh.Ctx(ctx)
+	defer cancel()
+	_, err := o.q.ExecContext(ctx, `INSERT INTO evm.tx_revert_reasons (tx_hash, evm_chain_id, reason, revert_data, source, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (tx_hash) DO UPDATE SET reason = EXCLUDED.reason, revert_data = EXCLUDED.revert_data, source = EXCLUDED.source, created_at = EXCLUDED.created_at`,
+		r.TxHash, r.EVMChainID, r.Reason, r.Data, r.Source, r.CreatedAt)
+	return pkgerrors.Wrap(err, "SaveRevertReason failed")
+}
+
+type dbRevertReason struct {
+	TxHash     common.Hash        `db:"tx_hash"`
+	EVMChainID ubig.Big           `db:"evm_chain_id"`
+	Reason     string             `db:"reason"`
+	Data       []byte             `db:"revert_data"`
+	Source     RevertReasonSource `db:"source"`
+	CreatedAt  time.Time          `db:"created_at"`
+}
+
+// FindRevertReason returns the revert reason of the transaction attempt with the given hash, or sql.ErrNoRows if
+// there is none.
+func (o *evmTxStore) FindRevertReason(ctx context.Context, hash common.Hash) (*RevertReason, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = o.stopCh.Ctx(ctx)
+	defer cancel()
+	var r dbRevertReason
+	if err := o.q.GetContext(ctx, &r, `SELECT * FROM evm.tx_revert_reasons WHERE tx_hash = $1`, hash); err != nil {
+		return nil, err
+	}
+	return &RevertReason{
+		TxHash:     r.TxHash,
+		EVMChainID: r.EVMChainID,
+		Reason:     r.Reason,
+		Data:       r.Data,
+		Source:     r.Source,
+		CreatedAt:  r.CreatedAt,
+	}, nil
+}
+
 func (o *evmTxStore) UpdateTxsForRebroadcast(ctx context.Context, etxIDs []int64, attemptIDs []int64) error {
 	var cancel context.CancelFunc
 	ctx, cancel = o.stopCh.Ctx(ctx)
@@ -2012,6 +2055,32 @@ func (o *evmTxStore) FindReorgOrIncludedTxs(ctx context.Context, fromAddress com
 	return
 }
 
+func (o *evmTxStore) FindConfirmedTxsInBlockRange(ctx context.Context, fromBlock, toBlock int64, chainID *big.Int) (etxs []*Tx, err error) {
+	var cancel context.CancelFunc
+	ctx, cancel = o.stopCh.Ctx(ctx)
+	defer cancel()
+	err = o.Transact(ctx, true, func(orm *evmTxStore) error {
+		var dbEtxs []DbEthTx
+		query := `SELECT * FROM evm.txes WHERE state IN ('confirmed', 'confirmed_missing_receipt') AND evm_chain_id = $3 AND EXISTS (
+			SELECT 1 FROM evm.tx_attempts JOIN evm.receipts ON evm.receipts.tx_hash = evm.tx_attempts.hash
+			WHERE evm.tx_attempts.eth_tx_id = evm.txes.id AND evm.receipts.block_number BETWEEN $1 AND $2
+		)`
+		if err = orm.q.SelectContext(ctx, &dbEtxs, query, fromBlock, toBlock, chainID.String()); err != nil {
+			return fmt.Errorf("failed to load evm.txes: %w", err)
+		}
+		etxs = make([]*Tx, len(dbEtxs))
+		dbEthTxsToEvmEthTxPtrs(dbEtxs, etxs)
+		if err = orm.LoadTxesAttempts(ctx, etxs); err != nil {
+			return fmt.Errorf("failed to load evm.tx_attempts: %w", err)
+		}
+		if err = orm.loadEthTxesAttemptsWithPartialReceipts(ctx, etxs); err != nil {
+			return fmt.Errorf("failed to load partial evm.receipts: %w", err)
+		}
+		return nil
+	})
+	return
+}
+
 func (o *evmTxStore) UpdateTxConfirmed(ctx context.Context, etxIDs []int64) error {
 	var cancel context.CancelFunc
 	ctx, cancel = o.stopCh.Ctx(ctx)
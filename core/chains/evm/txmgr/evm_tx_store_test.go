@@ -27,6 +27,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
+	ubig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
@@ -1867,6 +1868,34 @@ func TestORM_UpdateTxStatesToFinalizedUsingTxHashes(t *testing.T) {
 	})
 }
 
+func TestORM_FindConfirmedTxsInBlockRange(t *testing.T) {
+	t.Parallel()
+
+	ctx := tests.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	txStore := cltest.NewTestTxStore(t, db)
+	kst := cltest.NewKeyStore(t, db)
+	_, fromAddress := cltest.MustInsertRandomKey(t, kst.Eth())
+
+	mustInsertConfirmedEthTxWithReceipt(t, txStore, fromAddress, 0, 99)
+	etx1 := mustInsertConfirmedEthTxWithReceipt(t, txStore, fromAddress, 1, 100)
+	etx2 := mustInsertConfirmedEthTxWithReceipt(t, txStore, fromAddress, 2, 101)
+	mustInsertConfirmedEthTxWithReceipt(t, txStore, fromAddress, 3, 102)
+	// Unconfirmed can't be in a block
+	mustInsertUnconfirmedEthTxWithAttemptState(t, txStore, 4, fromAddress, txmgrtypes.TxAttemptBroadcast)
+
+	etxs, err := txStore.FindConfirmedTxsInBlockRange(ctx, 100, 101, testutils.FixtureChainID)
+	require.NoError(t, err)
+	require.Len(t, etxs, 2)
+	ids := []int64{etxs[0].ID, etxs[1].ID}
+	require.ElementsMatch(t, []int64{etx1.ID, etx2.ID}, ids)
+	require.Len(t, etxs[0].TxAttempts[0].Receipts, 1)
+
+	etxs, err = txStore.FindConfirmedTxsInBlockRange(ctx, 100, 101, big.NewInt(0))
+	require.NoError(t, err)
+	require.Empty(t, etxs)
+}
+
 func TestORM_FindReorgOrIncludedTxs(t *testing.T) {
 	t.Parallel()
 
@@ -2008,6 +2037,43 @@ func TestORM_DeleteReceiptsByTxHash(t *testing.T) {
 	require.Len(t, etx2.TxAttempts[0].Receipts, 1)
 }
 
+func TestORM_SaveRevertReason(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	txStore := cltest.NewTestTxStore(t, db)
+	ctx := tests.Context(t)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
+
+	etx := mustInsertConfirmedEthTxWithReceipt(t, txStore, fromAddress, 0, 100)
+	txHash := etx.TxAttempts[0].Hash
+
+	_, err := txStore.FindRevertReason(ctx, txHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rr := txmgr.RevertReason{
+		TxHash:     txHash,
+		EVMChainID: *ubig.New(testutils.FixtureChainID),
+		Reason:     "execution reverted",
+		Source:     txmgr.RevertReasonSourceCall,
+		CreatedAt:  time.Now(),
+	}
+	require.NoError(t, txStore.SaveRevertReason(ctx, rr))
+
+	// saving again overwrites the previous revert reason
+	rr.Reason, rr.Data, rr.Source = "not allowed", []byte{1, 2, 3}, txmgr.RevertReasonSourceTrace
+	require.NoError(t, txStore.SaveRevertReason(ctx, rr))
+
+	found, err := txStore.FindRevertReason(ctx, txHash)
+	require.NoError(t, err)
+	assert.Equal(t, txHash, found.TxHash)
+	assert.Equal(t, "not allowed", found.Reason)
+	assert.Equal(t, []byte{1, 2, 3}, found.Data)
+	assert.Equal(t, txmgr.RevertReasonSourceTrace, found.Source)
+	assert.Equal(t, testutils.FixtureChainID.String(), found.EVMChainID.String())
+}
+
 func mustInsertTerminallyStuckTxWithAttempt(t *testing.T, txStore txmgr.TestEvmTxStore, fromAddress common.Address, nonceInt int64, broadcastBeforeBlockNum int64) txmgr.Tx {
 	ctx := tests.Context(t)
 	broadcast := time.Now()
@@ -68,6 +68,7 @@ type finalizerTxStore interface {
 	FindTxesByIDs(ctx context.Context, etxIDs []int64, chainID *big.Int) (etxs []*Tx, err error)
 	PreloadTxes(ctx context.Context, attempts []TxAttempt) error
 	SaveFetchedReceipts(ctx context.Context, r []*evmtypes.Receipt) (err error)
+	SaveRevertReason(ctx context.Context, r RevertReason) error
 	UpdateTxCallbackCompleted(ctx context.Context, pipelineTaskRunID uuid.UUID, chainID *big.Int) error
 	UpdateTxFatalErrorAndDeleteAttempts(ctx context.Context, etx *Tx) error
 	UpdateTxStatesToFinalizedUsingTxHashes(ctx context.Context, txHashes []common.Hash, chainID *big.Int) error
@@ -79,6 +80,11 @@ type finalizerChainClient interface {
 	CallContract(ctx context.Context, a TxAttempt, blockNumber *big.Int) (rpcErr fmt.Stringer, extractErr error)
 }
 
+type finalizerRevertReasonConfig interface {
+	Enabled() bool
+	Trace() bool
+}
+
 type finalizerHeadTracker interface {
 	LatestAndFinalizedBlock(ctx context.Context) (latest, finalized *evmtypes.Head, err error)
 }
@@ -92,6 +98,7 @@ type evmFinalizer struct {
 	chainID           *big.Int
 	rpcBatchSize      int
 	forwardersEnabled bool
+	revertReasonCfg   finalizerRevertReasonConfig
 
 	txStore     finalizerTxStore
 	client      finalizerChainClient
@@ -110,6 +117,7 @@ func NewEvmFinalizer(
 	chainID *big.Int,
 	rpcBatchSize uint32,
 	forwardersEnabled bool,
+	revertReasonCfg finalizerRevertReasonConfig, // nil disables revert reason lookups
 	txStore finalizerTxStore,
 	client finalizerChainClient,
 	headTracker finalizerHeadTracker,
@@ -120,6 +128,7 @@ func NewEvmFinalizer(
 		chainID:           chainID,
 		rpcBatchSize:      int(rpcBatchSize),
 		forwardersEnabled: forwardersEnabled,
+		revertReasonCfg:   revertReasonCfg,
 		txStore:           txStore,
 		client:            client,
 		headTracker:       headTracker,
@@ -413,6 +422,7 @@ func (f *evmFinalizer) FetchAndStoreReceipts(ctx context.Context, head, latestFi
 			errorList = append(errorList, err)
 			continue
 		}
+		f.storeRevertReasons(ctx, batch, receipts)
 	}
 	if len(errorList) > 0 {
 		return errors.Join(errorList...)
@@ -497,14 +507,17 @@ func (f *evmFinalizer) validateReceipt(ctx context.Context, receipt *evmtypes.Re
 	}
 
 	if receipt.GetStatus() == 0 {
-		if receipt.GetRevertReason() != nil {
-			l.Warnw("transaction reverted on-chain", "hash", receipt.GetTxHash(), "revertReason", *receipt.GetRevertReason())
-		} else {
-			rpcError, errExtract := f.client.CallContract(ctx, attempt, receipt.GetBlockNumber())
-			if errExtract == nil {
-				l.Warnw("transaction reverted on-chain", "hash", receipt.GetTxHash(), "rpcError", rpcError.String())
+		// If enabled, the revert reason is looked up, logged and stored once the receipt is saved
+		if !f.revertReasonsEnabled() {
+			if receipt.GetRevertReason() != nil {
+				l.Warnw("transaction reverted on-chain", "hash", receipt.GetTxHash(), "revertReason", *receipt.GetRevertReason())
 			} else {
-				l.Warnw("transaction reverted on-chain unable to extract revert reason", "hash", receipt.GetTxHash(), "err", errExtract)
+				rpcError, errExtract := f.client.CallContract(ctx, attempt, receipt.GetBlockNumber())
+				if errExtract == nil {
+					l.Warnw("transaction reverted on-chain", "hash", receipt.GetTxHash(), "rpcError", rpcError.String())
+				} else {
+					l.Warnw("transaction reverted on-chain unable to extract revert reason", "hash", receipt.GetTxHash(), "err", errExtract)
+				}
 			}
 		}
 		// This might increment more than once e.g. in case of re-orgs going back and forth we might re-fetch the same receipt
@@ -60,7 +60,7 @@ func TestFinalizer_MarkTxFinalized(t *testing.T) {
 	head.Parent.Store(h99)
 
 	t.Run("returns not finalized for tx with receipt newer than finalized block", func(t *testing.T) {
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		servicetest.Run(t, finalizer)
 
 		idempotencyKey := uuid.New().String()
@@ -90,7 +90,7 @@ func TestFinalizer_MarkTxFinalized(t *testing.T) {
 	})
 
 	t.Run("returns not finalized for tx with receipt re-org'd out and deletes stale receipt", func(t *testing.T) {
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		servicetest.Run(t, finalizer)
 
 		idempotencyKey := uuid.New().String()
@@ -122,7 +122,7 @@ func TestFinalizer_MarkTxFinalized(t *testing.T) {
 	})
 
 	t.Run("returns finalized for tx with receipt in a finalized block", func(t *testing.T) {
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		servicetest.Run(t, finalizer)
 
 		idempotencyKey := uuid.New().String()
@@ -152,7 +152,7 @@ func TestFinalizer_MarkTxFinalized(t *testing.T) {
 	})
 
 	t.Run("returns finalized for tx with receipt older than block history depth", func(t *testing.T) {
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		servicetest.Run(t, finalizer)
 
 		idempotencyKey := uuid.New().String()
@@ -220,7 +220,7 @@ func TestFinalizer_MarkTxFinalized(t *testing.T) {
 	})
 
 	t.Run("returns error if failed to retrieve latest head in headtracker", func(t *testing.T) {
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		servicetest.Run(t, finalizer)
 
 		ethClient.On("HeadByNumber", mock.Anything, mock.Anything).Return(nil, errors.New("failed to get latest head")).Once()
@@ -229,7 +229,7 @@ func TestFinalizer_MarkTxFinalized(t *testing.T) {
 	})
 
 	t.Run("returns error if failed to calculate latest finalized head in headtracker", func(t *testing.T) {
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		servicetest.Run(t, finalizer)
 
 		ethClient.On("HeadByNumber", mock.Anything, mock.Anything).Return(head, nil).Once()
@@ -284,7 +284,7 @@ func TestFinalizer_ResumePendingRuns(t *testing.T) {
 
 	t.Run("doesn't process task runs that are not suspended (possibly already previously resumed)", func(t *testing.T) {
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		finalizer.SetResumeCallback(func(context.Context, uuid.UUID, interface{}, error) error {
 			t.Fatal("No value expected")
 			return nil
@@ -306,7 +306,7 @@ func TestFinalizer_ResumePendingRuns(t *testing.T) {
 
 	t.Run("doesn't process task runs where the receipt is younger than minConfirmations", func(t *testing.T) {
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		finalizer.SetResumeCallback(func(context.Context, uuid.UUID, interface{}, error) error {
 			t.Fatal("No value expected")
 			return nil
@@ -330,7 +330,7 @@ func TestFinalizer_ResumePendingRuns(t *testing.T) {
 		nonce := evmtypes.Nonce(3)
 		var err error
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		finalizer.SetResumeCallback(func(ctx context.Context, id uuid.UUID, value interface{}, thisErr error) error {
 			err = thisErr
 			ch <- value
@@ -384,7 +384,7 @@ func TestFinalizer_ResumePendingRuns(t *testing.T) {
 		ch := make(chan data)
 		nonce := evmtypes.Nonce(4)
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		finalizer.SetResumeCallback(func(ctx context.Context, id uuid.UUID, value interface{}, err error) error {
 			ch <- data{value, err}
 			return nil
@@ -432,7 +432,7 @@ func TestFinalizer_ResumePendingRuns(t *testing.T) {
 	t.Run("does not mark callback complete if callback fails", func(t *testing.T) {
 		nonce := evmtypes.Nonce(5)
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		finalizer.SetResumeCallback(func(ctx context.Context, id uuid.UUID, value interface{}, err error) error {
 			return errors.New("error")
 		})
@@ -481,7 +481,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, config.EVM().RPCDefaultBatchSize(), false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, config.EVM().RPCDefaultBatchSize(), false, nil, txStore, txmClient, ht)
 
 		mustInsertFatalErrorEthTx(t, txStore, fromAddress)
 		mustInsertInProgressEthTx(t, txStore, 0, fromAddress)
@@ -500,7 +500,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		// Insert confirmed transaction without receipt
 		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, head.Number, fromAddress)
 		// Transaction not confirmed yet, receipt is nil
@@ -527,7 +527,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		// Insert confirmed transaction without receipt
 		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, head.Number, fromAddress)
 		txmReceipt := evmtypes.Receipt{
@@ -560,7 +560,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		// Insert confirmed transaction without receipt
 		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, head.Number, fromAddress)
 		txmReceipt := evmtypes.Receipt{
@@ -594,7 +594,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		// Insert confirmed transaction without receipt
 		etx1 := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, head.Number, fromAddress)
 		// Insert confirmed transaction without receipt
@@ -649,7 +649,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		// Insert confirmed transaction without receipt
 		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, head.Number, fromAddress)
 		attempt1 := etx.TxAttempts[0]
@@ -702,7 +702,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		// Insert confirmed transaction without receipt
 		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, head.Number, fromAddress)
 		receipt := evmtypes.Receipt{
@@ -734,7 +734,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		// Insert confirmed transaction without receipt
 		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, head.Number, fromAddress)
 		// NOTE: This should never happen, but we shouldn't panic regardless
@@ -767,7 +767,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 		// Insert confirmed transaction without receipt
 		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, head.Number, fromAddress)
 		attempt := etx.TxAttempts[0]
@@ -814,7 +814,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, true, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, true, nil, txStore, txmClient, ht)
 
 		// Insert confirmed transaction without receipt
 		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, latestFinalizedHead.Number, fromAddress)
@@ -849,7 +849,7 @@ func TestFinalizer_FetchAndStoreReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, true, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, true, nil, txStore, txmClient, ht)
 
 		// Insert confirmed transaction without receipt
 		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, latestFinalizedHead.Number, fromAddress)
@@ -897,7 +897,7 @@ func TestFinalizer_FetchAndStoreReceipts_batching(t *testing.T) {
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
 		rpcBatchSize := uint32(2)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 
 		// Insert confirmed transaction without receipt
 		etx := mustInsertConfirmedEthTx(t, txStore, 0, fromAddress)
@@ -946,7 +946,7 @@ func TestFinalizer_FetchAndStoreReceipts_batching(t *testing.T) {
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
 		rpcBatchSize := uint32(1)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, rpcBatchSize, false, nil, txStore, txmClient, ht)
 
 		// Insert confirmed transactions without receipts
 		etx1 := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, head.Number, fromAddress)
@@ -1007,7 +1007,7 @@ func TestFinalizer_FetchAndStoreReceipts_HandlesNonFwdTxsWithForwardingEnabled(t
 	txStore := cltest.NewTestTxStore(t, db)
 	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 	_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-	finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, txStore, txmClient, ht)
+	finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, nil, txStore, txmClient, ht)
 
 	// tx is not forwarded and doesn't have meta set. Confirmer should handle nil meta values
 	etx := mustInsertConfirmedEthTx(t, txStore, 0, fromAddress)
@@ -1063,7 +1063,7 @@ func TestFinalizer_ProcessOldTxsWithoutReceipts(t *testing.T) {
 	t.Run("does nothing if no old transactions found", func(t *testing.T) {
 		db := pgtest.NewSqlxDB(t)
 		txStore := cltest.NewTestTxStore(t, db)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, nil, txStore, txmClient, ht)
 		require.NoError(t, finalizer.ProcessOldTxsWithoutReceipts(ctx, []int64{}, head, latestFinalizedHead))
 	})
 
@@ -1072,7 +1072,7 @@ func TestFinalizer_ProcessOldTxsWithoutReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, nil, txStore, txmClient, ht)
 
 		// Insert confirmed transaction without receipt
 		etx1 := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, latestFinalizedHead.Number, fromAddress)
@@ -1099,7 +1099,7 @@ func TestFinalizer_ProcessOldTxsWithoutReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, nil, txStore, txmClient, ht)
 		finalizer.SetResumeCallback(func(context.Context, uuid.UUID, interface{}, error) error { return nil })
 
 		// Insert confirmed transaction with pending task run
@@ -1134,7 +1134,7 @@ func TestFinalizer_ProcessOldTxsWithoutReceipts(t *testing.T) {
 		txStore := cltest.NewTestTxStore(t, db)
 		ethKeyStore := cltest.NewKeyStore(t, db).Eth()
 		_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore)
-		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, txStore, txmClient, ht)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, true, nil, txStore, txmClient, ht)
 		finalizer.SetResumeCallback(func(context.Context, uuid.UUID, interface{}, error) error { return errors.New("failure") })
 
 		// Insert confirmed transaction with pending task run
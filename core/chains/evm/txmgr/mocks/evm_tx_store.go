@@ -612,6 +612,67 @@ func (_c *EvmTxStore_FindConfirmedTxesReceipts_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// FindConfirmedTxsInBlockRange provides a mock function with given fields: ctx, fromBlock, toBlock, chainID
+func (_m *EvmTxStore) FindConfirmedTxsInBlockRange(ctx context.Context, fromBlock int64, toBlock int64, chainID *big.Int) ([]*types.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], error) {
+	ret := _m.Called(ctx, fromBlock, toBlock, chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindConfirmedTxsInBlockRange")
+	}
+
+	var r0 []*types.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *big.Int) ([]*types.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], error)); ok {
+		return rf(ctx, fromBlock, toBlock, chainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *big.Int) []*types.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee]); ok {
+		r0 = rf(ctx, fromBlock, toBlock, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, *big.Int) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvmTxStore_FindConfirmedTxsInBlockRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindConfirmedTxsInBlockRange'
+type EvmTxStore_FindConfirmedTxsInBlockRange_Call struct {
+	*mock.Call
+}
+
+// FindConfirmedTxsInBlockRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock int64
+//   - toBlock int64
+//   - chainID *big.Int
+func (_e *EvmTxStore_Expecter) FindConfirmedTxsInBlockRange(ctx interface{}, fromBlock interface{}, toBlock interface{}, chainID interface{}) *EvmTxStore_FindConfirmedTxsInBlockRange_Call {
+	return &EvmTxStore_FindConfirmedTxsInBlockRange_Call{Call: _e.mock.On("FindConfirmedTxsInBlockRange", ctx, fromBlock, toBlock, chainID)}
+}
+
+func (_c *EvmTxStore_FindConfirmedTxsInBlockRange_Call) Run(run func(ctx context.Context, fromBlock int64, toBlock int64, chainID *big.Int)) *EvmTxStore_FindConfirmedTxsInBlockRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *EvmTxStore_FindConfirmedTxsInBlockRange_Call) Return(etxs []*types.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) *EvmTxStore_FindConfirmedTxsInBlockRange_Call {
+	_c.Call.Return(etxs, err)
+	return _c
+}
+
+func (_c *EvmTxStore_FindConfirmedTxsInBlockRange_Call) RunAndReturn(run func(context.Context, int64, int64, *big.Int) ([]*types.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], error)) *EvmTxStore_FindConfirmedTxsInBlockRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindEarliestUnconfirmedBroadcastTime provides a mock function with given fields: ctx, chainID
 func (_m *EvmTxStore) FindEarliestUnconfirmedBroadcastTime(ctx context.Context, chainID *big.Int) (null.Time, error) {
 	ret := _m.Called(ctx, chainID)
@@ -914,6 +975,65 @@ func (_c *EvmTxStore_FindReorgOrIncludedTxs_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// FindRevertReason provides a mock function with given fields: ctx, hash
+func (_m *EvmTxStore) FindRevertReason(ctx context.Context, hash common.Hash) (*txmgr.RevertReason, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRevertReason")
+	}
+
+	var r0 *txmgr.RevertReason
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*txmgr.RevertReason, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *txmgr.RevertReason); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*txmgr.RevertReason)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvmTxStore_FindRevertReason_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRevertReason'
+type EvmTxStore_FindRevertReason_Call struct {
+	*mock.Call
+}
+
+// FindRevertReason is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash common.Hash
+func (_e *EvmTxStore_Expecter) FindRevertReason(ctx interface{}, hash interface{}) *EvmTxStore_FindRevertReason_Call {
+	return &EvmTxStore_FindRevertReason_Call{Call: _e.mock.On("FindRevertReason", ctx, hash)}
+}
+
+func (_c *EvmTxStore_FindRevertReason_Call) Run(run func(ctx context.Context, hash common.Hash)) *EvmTxStore_FindRevertReason_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *EvmTxStore_FindRevertReason_Call) Return(_a0 *txmgr.RevertReason, _a1 error) *EvmTxStore_FindRevertReason_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EvmTxStore_FindRevertReason_Call) RunAndReturn(run func(context.Context, common.Hash) (*txmgr.RevertReason, error)) *EvmTxStore_FindRevertReason_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindTxAttempt provides a mock function with given fields: ctx, hash
 func (_m *EvmTxStore) FindTxAttempt(ctx context.Context, hash common.Hash) (*txmgr.TxAttempt, error) {
 	ret := _m.Called(ctx, hash)
@@ -2679,6 +2799,53 @@ func (_c *EvmTxStore_SaveReplacementInProgressAttempt_Call) RunAndReturn(run fun
 	return _c
 }
 
+// SaveRevertReason provides a mock function with given fields: ctx, r
+func (_m *EvmTxStore) SaveRevertReason(ctx context.Context, r txmgr.RevertReason) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRevertReason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, txmgr.RevertReason) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvmTxStore_SaveRevertReason_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveRevertReason'
+type EvmTxStore_SaveRevertReason_Call struct {
+	*mock.Call
+}
+
+// SaveRevertReason is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r txmgr.RevertReason
+func (_e *EvmTxStore_Expecter) SaveRevertReason(ctx interface{}, r interface{}) *EvmTxStore_SaveRevertReason_Call {
+	return &EvmTxStore_SaveRevertReason_Call{Call: _e.mock.On("SaveRevertReason", ctx, r)}
+}
+
+func (_c *EvmTxStore_SaveRevertReason_Call) Run(run func(ctx context.Context, r txmgr.RevertReason)) *EvmTxStore_SaveRevertReason_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(txmgr.RevertReason))
+	})
+	return _c
+}
+
+func (_c *EvmTxStore_SaveRevertReason_Call) Return(_a0 error) *EvmTxStore_SaveRevertReason_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EvmTxStore_SaveRevertReason_Call) RunAndReturn(run func(context.Context, txmgr.RevertReason) error) *EvmTxStore_SaveRevertReason_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveSentAttempt provides a mock function with given fields: ctx, timeout, attempt, broadcastAt
 func (_m *EvmTxStore) SaveSentAttempt(ctx context.Context, timeout time.Duration, attempt *types.TxAttempt[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], broadcastAt time.Time) error {
 	ret := _m.Called(ctx, timeout, attempt, broadcastAt)
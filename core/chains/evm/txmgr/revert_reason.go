@@ -0,0 +1,290 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	ubig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/functions/generated/functions_coordinator"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/functions/generated/functions_router"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/i_automation_registry_master_wrapper_2_3"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/i_keeper_registry_master_wrapper_2_1"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/offchain_aggregator_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/vrf_coordinator_v2_5"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/forwarder"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/operatorforwarder/generated/authorized_forwarder"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/operatorforwarder/generated/operator"
+)
+
+// RevertReasonSource is how the revert reason of a transaction was looked up.
+type RevertReasonSource string
+
+const (
+	// RevertReasonSourceReceipt is a revert reason included in the receipt by the RPC (e.g. Hedera)
+	RevertReasonSourceReceipt RevertReasonSource = "receipt"
+	// RevertReasonSourceTrace is a revert reason found with debug_traceTransaction
+	RevertReasonSourceTrace RevertReasonSource = "trace"
+	// RevertReasonSourceCall is a revert reason found by replaying the transaction with eth_call
+	RevertReasonSourceCall RevertReasonSource = "call"
+)
+
+// RevertReason is the human-readable reason a transaction reverted on-chain.
+type RevertReason struct {
+	TxHash     common.Hash
+	EVMChainID ubig.Big
+	// Reason is the decoded revert reason, or the RPC error message if the revert data could not be decoded.
+	Reason string
+	// Data is the raw revert data, if any.
+	Data      []byte
+	Source    RevertReasonSource
+	CreatedAt time.Time
+}
+
+// gethwrapperErrorABIs are the contracts the node commonly sends transactions to, whose custom errors are decoded by default.
+var gethwrapperErrorABIs = []string{
+	authorized_forwarder.AuthorizedForwarderMetaData.ABI,
+	operator.OperatorMetaData.ABI,
+	offchain_aggregator_wrapper.OffchainAggregatorMetaData.ABI,
+	vrf_coordinator_v2.VRFCoordinatorV2MetaData.ABI,
+	vrf_coordinator_v2_5.VRFCoordinatorV25MetaData.ABI,
+	i_keeper_registry_master_wrapper_2_1.IKeeperRegistryMasterMetaData.ABI,
+	i_automation_registry_master_wrapper_2_3.IAutomationRegistryMaster23MetaData.ABI,
+	functions_router.FunctionsRouterMetaData.ABI,
+	functions_coordinator.FunctionsCoordinatorMetaData.ABI,
+	forwarder.KeystoneForwarderMetaData.ABI,
+}
+
+// RevertReasonDecoder decodes revert data into a human-readable reason. Besides Error(string) and Panic(uint256),
+// custom errors are decoded using the ABIs added to the decoder.
+type RevertReasonDecoder struct {
+	errors map[[4]byte]abi.Error
+}
+
+// NewRevertReasonDecoder returns a decoder for the custom errors in abis.
+func NewRevertReasonDecoder(abis ...string) (*RevertReasonDecoder, error) {
+	d := &RevertReasonDecoder{errors: make(map[[4]byte]abi.Error)}
+	for _, a := range abis {
+		if err := d.AddABI(a); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+var defaultRevertReasonDecoder = sync.OnceValues(func() (*RevertReasonDecoder, error) {
+	return NewRevertReasonDecoder(gethwrapperErrorABIs...)
+})
+
+// DefaultRevertReasonDecoder returns a decoder for the custom errors of the contracts in core/gethwrappers that
+// the node commonly sends transactions to.
+func DefaultRevertReasonDecoder() *RevertReasonDecoder {
+	d, err := defaultRevertReasonDecoder()
+	if err != nil {
+		// the ABIs are generated, so this can only happen if a wrapper is broken
+		panic(fmt.Errorf("invalid gethwrapper ABI: %w", err))
+	}
+	return d
+}
+
+// AddABI adds the custom errors of the JSON ABI abiJSON to the decoder.
+func (d *RevertReasonDecoder) AddABI(abiJSON string) error {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	for _, e := range parsed.Errors {
+		d.errors[[4]byte(e.ID[:4])] = e
+	}
+	return nil
+}
+
+// With returns a copy of the decoder that also decodes the custom errors in abis. Invalid ABIs are skipped.
+func (d *RevertReasonDecoder) With(abis ...string) *RevertReasonDecoder {
+	c := &RevertReasonDecoder{errors: make(map[[4]byte]abi.Error, len(d.errors))}
+	for k, v := range d.errors {
+		c.errors[k] = v
+	}
+	for _, a := range abis {
+		_ = c.AddABI(a)
+	}
+	return c
+}
+
+// Decode returns the revert reason encoded in data, and whether it could be decoded.
+func (d *RevertReasonDecoder) Decode(data []byte) (string, bool) {
+	if len(data) < 4 {
+		return "", false
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason, true
+	}
+	e, ok := d.errors[[4]byte(data[:4])]
+	if !ok {
+		return "", false
+	}
+	values, err := e.Inputs.Unpack(data[4:])
+	if err != nil {
+		return "", false
+	}
+	args := make([]string, len(values))
+	for i, v := range values {
+		args[i] = fmt.Sprintf("%s=%v", e.Inputs[i].Name, formatRevertArg(v))
+	}
+	return fmt.Sprintf("%s(%s)", e.Name, strings.Join(args, ", ")), true
+}
+
+func formatRevertArg(v any) any {
+	switch t := v.(type) {
+	case []byte:
+		return hexutil.Encode(t)
+	case [32]byte:
+		return hexutil.Encode(t[:])
+	}
+	return v
+}
+
+// ParseRevertData extracts the revert data from the data field of a JSON-RPC error. Some RPCs prefix it with "Reverted ".
+func ParseRevertData(errData any) ([]byte, bool) {
+	s, ok := errData.(string)
+	if !ok {
+		return nil, false
+	}
+	s = strings.TrimSpace(strings.TrimPrefix(s, "Reverted"))
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// callFrame is the result of debug_traceTransaction using the callTracer.
+type callFrame struct {
+	Output       hexutil.Bytes `json:"output"`
+	Error        string        `json:"error"`
+	RevertReason string        `json:"revertReason"`
+	Calls        []callFrame   `json:"calls"`
+}
+
+// revertData returns the revert data of the frame, falling back to the first reverted sub call.
+func (f *callFrame) revertData() []byte {
+	if len(f.Output) > 0 {
+		return f.Output
+	}
+	for i := range f.Calls {
+		if f.Calls[i].Error != "" {
+			if data := f.Calls[i].revertData(); len(data) > 0 {
+				return data
+			}
+		}
+	}
+	return nil
+}
+
+// lookupRevertReason finds out why the transaction of receipt reverted, either from the receipt, by tracing it,
+// or by replaying it with eth_call on the block it was included in.
+func (f *evmFinalizer) lookupRevertReason(ctx context.Context, receipt *evmtypes.Receipt, attempt TxAttempt) (*RevertReason, error) {
+	rr := &RevertReason{
+		TxHash:     receipt.TxHash,
+		EVMChainID: *ubig.New(f.chainID),
+		CreatedAt:  time.Now(),
+	}
+	if reason := receipt.GetRevertReason(); reason != nil {
+		rr.Reason, rr.Source = *reason, RevertReasonSourceReceipt
+		return rr, nil
+	}
+
+	var errs error
+	if f.revertReasonCfg.Trace() {
+		var frame callFrame
+		elems := []rpc.BatchElem{{
+			Method: "debug_traceTransaction",
+			Args:   []any{receipt.TxHash, map[string]any{"tracer": "callTracer"}},
+			Result: &frame,
+		}}
+		err := f.client.BatchCallContext(ctx, elems)
+		if err == nil {
+			err = elems[0].Error
+		}
+		if err == nil {
+			rr.Data, rr.Source = frame.revertData(), RevertReasonSourceTrace
+			rr.Reason = f.decodeRevertReason(rr.Data, frame.RevertReason, frame.Error)
+			return rr, nil
+		}
+		errs = errors.Join(errs, fmt.Errorf("debug_traceTransaction failed: %w", err))
+	}
+
+	rpcErr, err := f.client.CallContract(ctx, attempt, receipt.GetBlockNumber())
+	if err != nil {
+		return nil, errors.Join(errs, fmt.Errorf("eth_call replay failed: %w", err))
+	}
+	rr.Source = RevertReasonSourceCall
+	var message string
+	if dataErr, ok := rpcErr.(rpc.DataError); ok {
+		rr.Data, _ = ParseRevertData(dataErr.ErrorData())
+		message = dataErr.Error()
+	}
+	rr.Reason = f.decodeRevertReason(rr.Data, message, rpcErr.String())
+	return rr, nil
+}
+
+// decodeRevertReason decodes data, falling back to the first non-empty message.
+func (f *evmFinalizer) decodeRevertReason(data []byte, messages ...string) string {
+	if reason, ok := DefaultRevertReasonDecoder().Decode(data); ok {
+		return reason
+	}
+	for _, m := range messages {
+		if m != "" {
+			return m
+		}
+	}
+	if len(data) > 0 {
+		return hexutil.Encode(data)
+	}
+	return "execution reverted"
+}
+
+func (f *evmFinalizer) revertReasonsEnabled() bool {
+	return f.revertReasonCfg != nil && f.revertReasonCfg.Enabled()
+}
+
+// storeRevertReasons looks up and saves the revert reasons of the reverted receipts.
+// Failures are only logged, as the revert reason is informational.
+func (f *evmFinalizer) storeRevertReasons(ctx context.Context, attempts []TxAttempt, receipts []*evmtypes.Receipt) {
+	if !f.revertReasonsEnabled() {
+		return
+	}
+	attemptsByHash := make(map[common.Hash]TxAttempt, len(attempts))
+	for _, a := range attempts {
+		attemptsByHash[a.Hash] = a
+	}
+	for _, receipt := range receipts {
+		if receipt.GetStatus() != 0 {
+			continue
+		}
+		attempt, ok := attemptsByHash[receipt.TxHash]
+		if !ok {
+			continue
+		}
+		l := attempt.Tx.GetLogger(f.lggr).With("txHash", receipt.TxHash, "txID", attempt.TxID)
+		rr, err := f.lookupRevertReason(ctx, receipt, attempt)
+		if err != nil {
+			l.Warnw("Failed to look up revert reason", "err", err)
+			continue
+		}
+		l.Warnw("transaction reverted on-chain", "revertReason", rr.Reason, "source", rr.Source)
+		if err := f.txStore.SaveRevertReason(ctx, *rr); err != nil {
+			l.Errorw("Failed to save revert reason", "err", err)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package txmgr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
+	evmclmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr/mocks"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/forwarder"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+)
+
+const customErrorABI = `[{"inputs":[{"internalType":"address","name":"sender","type":"address"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"Unauthorized","type":"error"}]`
+
+func mustEncodeError(t *testing.T, abiJSON string, name string, args ...any) []byte {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	require.NoError(t, err)
+	e, ok := parsed.Errors[name]
+	require.True(t, ok)
+	packed, err := e.Inputs.Pack(args...)
+	require.NoError(t, err)
+	return append(e.ID[:4:4], packed...)
+}
+
+func mustEncodeRevert(t *testing.T, reason string) []byte {
+	typ, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: typ}}.Pack(reason)
+	require.NoError(t, err)
+	return append(hexutil.MustDecode("0x08c379a0"), packed...)
+}
+
+func TestRevertReasonDecoder(t *testing.T) {
+	t.Parallel()
+
+	sender := testutils.NewAddress()
+	for _, tt := range []struct {
+		name    string
+		decoder *txmgr.RevertReasonDecoder
+		data    []byte
+		exp     string
+		ok      bool
+	}{
+		{"Error(string)", txmgr.DefaultRevertReasonDecoder(), mustEncodeRevert(t, "not allowed"), "not allowed", true},
+		{"Panic(uint256)", txmgr.DefaultRevertReasonDecoder(), append(hexutil.MustDecode("0x4e487b71"), common32(0x11)...), "arithmetic underflow or overflow", true},
+		{"gethwrapper error", txmgr.DefaultRevertReasonDecoder(),
+			mustEncodeError(t, forwarder.KeystoneForwarderMetaData.ABI, "ExcessSigners", big.NewInt(32), big.NewInt(31)),
+			"ExcessSigners(numSigners=32, maxSigners=31)", true},
+		{"job error", txmgr.DefaultRevertReasonDecoder().With(customErrorABI),
+			mustEncodeError(t, customErrorABI, "Unauthorized", sender, []byte{1, 2}),
+			"Unauthorized(sender=" + sender.Hex() + ", data=0x0102)", true},
+		{"unknown error", txmgr.DefaultRevertReasonDecoder(), mustEncodeError(t, customErrorABI, "Unauthorized", sender, []byte{}), "", false},
+		{"short data", txmgr.DefaultRevertReasonDecoder(), []byte{1, 2}, "", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := tt.decoder.Decode(tt.data)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.exp, reason)
+		})
+	}
+
+	t.Run("With does not modify the original decoder", func(t *testing.T) {
+		_, ok := txmgr.DefaultRevertReasonDecoder().Decode(mustEncodeError(t, customErrorABI, "Unauthorized", sender, []byte{}))
+		assert.False(t, ok)
+	})
+}
+
+func common32(n byte) []byte {
+	b := make([]byte, 32)
+	b[31] = n
+	return b
+}
+
+func TestParseRevertData(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		in  any
+		exp []byte
+		ok  bool
+	}{
+		{"0x0102", []byte{1, 2}, true},
+		{"Reverted 0x0102", []byte{1, 2}, true},
+		{"execution reverted", nil, false},
+		{map[string]any{"data": "0x01"}, nil, false},
+	} {
+		data, ok := txmgr.ParseRevertData(tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+		assert.Equal(t, tt.exp, data, tt.in)
+	}
+}
+
+type testRevertReasonConfig struct {
+	trace bool
+}
+
+func (testRevertReasonConfig) Enabled() bool { return true }
+func (c testRevertReasonConfig) Trace() bool { return c.trace }
+
+func TestFinalizer_FetchAndStoreReceipts_RevertReasons(t *testing.T) {
+	t.Parallel()
+	ctx := tests.Context(t)
+
+	head := &evmtypes.Head{Hash: utils.NewHash(), Number: 100}
+	latestFinalizedHead := &evmtypes.Head{Hash: utils.NewHash(), Number: 99}
+	head.Parent.Store(latestFinalizedHead)
+
+	// fetchAndStoreReceipts fetches a reverted receipt for a single attempt and returns the saved revert reason
+	fetchAndStoreReceipts := func(t *testing.T, trace bool, ethClient *evmclmocks.Client) txmgr.RevertReason {
+		txmClient := txmgr.NewEvmTxmClient(ethClient, nil)
+		txStore := mocks.NewEvmTxStore(t)
+		ht := headtracker.NewSimulatedHeadTracker(ethClient, true, 0)
+		finalizer := txmgr.NewEvmFinalizer(logger.Test(t), testutils.FixtureChainID, 1, false, testRevertReasonConfig{trace: trace}, txStore, txmClient, ht)
+
+		attempt := txmgr.TxAttempt{
+			ID:   1,
+			TxID: 1,
+			Hash: revertedTxHash,
+			Tx:   txmgr.Tx{ID: 1, FromAddress: testutils.NewAddress(), ToAddress: testutils.NewAddress()},
+		}
+		receipt := &evmtypes.Receipt{TxHash: attempt.Hash, BlockHash: utils.NewHash(), BlockNumber: big.NewInt(head.Number), Status: 0}
+		txStore.On("FindAttemptsRequiringReceiptFetch", mock.Anything, testutils.FixtureChainID).Return([]txmgr.TxAttempt{attempt}, nil).Once()
+		ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+			return len(b) == 1 && cltest.BatchElemMatchesParams(b[0], attempt.Hash, "eth_getTransactionReceipt")
+		})).Return(nil).Run(func(args mock.Arguments) {
+			elems := args.Get(1).([]rpc.BatchElem)
+			*elems[0].Result.(*evmtypes.Receipt) = *receipt
+		}).Once()
+		txStore.On("SaveFetchedReceipts", mock.Anything, []*evmtypes.Receipt{receipt}).Return(nil).Once()
+		var saved txmgr.RevertReason
+		txStore.On("SaveRevertReason", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(txmgr.RevertReason)
+		}).Once()
+
+		require.NoError(t, finalizer.FetchAndStoreReceipts(ctx, head, latestFinalizedHead))
+		assert.Equal(t, attempt.Hash, saved.TxHash)
+		return saved
+	}
+
+	t.Run("decodes the output of debug_traceTransaction", func(t *testing.T) {
+		ethClient := testutils.NewEthClientMockWithDefaultChain(t)
+		data := mustEncodeRevert(t, "not allowed")
+		ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+			return len(b) == 1 && b[0].Method == "debug_traceTransaction" && b[0].Args[0] == revertedTxHash
+		})).Return(nil).Run(func(args mock.Arguments) {
+			elems := args.Get(1).([]rpc.BatchElem)
+			frame := fmt.Sprintf(`{"error":"execution reverted","calls":[{"error":"execution reverted","output":%q}]}`, hexutil.Encode(data))
+			require.NoError(t, json.Unmarshal([]byte(frame), elems[0].Result))
+		}).Once()
+
+		rr := fetchAndStoreReceipts(t, true, ethClient)
+		assert.Equal(t, "not allowed", rr.Reason)
+		assert.Equal(t, data, rr.Data)
+		assert.Equal(t, txmgr.RevertReasonSourceTrace, rr.Source)
+	})
+
+	t.Run("falls back to eth_call if tracing fails", func(t *testing.T) {
+		ethClient := testutils.NewEthClientMockWithDefaultChain(t)
+		ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+			return len(b) == 1 && b[0].Method == "debug_traceTransaction"
+		})).Return(nil).Run(func(args mock.Arguments) {
+			elems := args.Get(1).([]rpc.BatchElem)
+			elems[0].Error = errors.New("the method debug_traceTransaction does not exist/is not available")
+		}).Once()
+		data := mustEncodeError(t, forwarder.KeystoneForwarderMetaData.ABI, "ExcessSigners", big.NewInt(32), big.NewInt(31))
+		ethClient.On("CallContract", mock.Anything, mock.Anything, big.NewInt(head.Number)).
+			Return(nil, client.JsonError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(data)}).Once()
+
+		rr := fetchAndStoreReceipts(t, true, ethClient)
+		assert.Equal(t, "ExcessSigners(numSigners=32, maxSigners=31)", rr.Reason)
+		assert.Equal(t, data, rr.Data)
+		assert.Equal(t, txmgr.RevertReasonSourceCall, rr.Source)
+	})
+
+	t.Run("uses the RPC error message if the revert data is unknown", func(t *testing.T) {
+		ethClient := testutils.NewEthClientMockWithDefaultChain(t)
+		ethClient.On("CallContract", mock.Anything, mock.Anything, big.NewInt(head.Number)).
+			Return(nil, client.JsonError{Code: 3, Message: "execution reverted: custom error 0xdeadbeef", Data: "0xdeadbeef"}).Once()
+
+		rr := fetchAndStoreReceipts(t, false, ethClient)
+		assert.Equal(t, "execution reverted: custom error 0xdeadbeef", rr.Reason)
+		assert.Equal(t, hexutil.MustDecode("0xdeadbeef"), rr.Data)
+		assert.Equal(t, txmgr.RevertReasonSourceCall, rr.Source)
+	})
+}
+
+var revertedTxHash = utils.NewHash()
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -44,6 +46,7 @@ import (
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
 	ubig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/authorized_receiver"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
@@ -322,6 +325,13 @@ func TestTxm_CreateTransaction(t *testing.T) {
 		fwdr, err := form.CreateForwarder(tests.Context(t), fwdrAddr, ubig.Big(cltest.FixtureChainID))
 		require.NoError(t, err)
 		require.Equal(t, fwdr.Address, fwdrAddr)
+		receiverABI, err := authorized_receiver.AuthorizedReceiverMetaData.GetAbi()
+		require.NoError(t, err)
+		senders, err := receiverABI.Methods["getAuthorizedSenders"].Outputs.Pack([]common.Address{fromAddress})
+		require.NoError(t, err)
+		ethClient.On("CallContract", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return msg.To != nil && *msg.To == fwdrAddr
+		}), mock.Anything).Return(senders, nil).Once()
 
 		etx, err := txm.CreateTransaction(tests.Context(t), txmgr.TxRequest{
 			FromAddress:      fromAddress,
@@ -343,6 +353,26 @@ func TestTxm_CreateTransaction(t *testing.T) {
 		require.Equal(t, etx.ToAddress.String(), fwdrAddr.String())
 	})
 
+	t.Run("fails when the forwarder authorization check fails", func(t *testing.T) {
+		pgtest.MustExec(t, db, `DELETE FROM evm.txes`)
+		evmConfig.MaxQueued = uint64(1)
+		fwdrAddr := testutils.NewAddress()
+		ethClient.On("CallContract", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return msg.To != nil && *msg.To == fwdrAddr
+		}), mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := txm.CreateTransaction(tests.Context(t), txmgr.TxRequest{
+			FromAddress:      fromAddress,
+			ToAddress:        toAddress,
+			EncodedPayload:   payload,
+			FeeLimit:         gasLimit,
+			ForwarderAddress: fwdrAddr,
+			Strategy:         txmgrcommon.NewSendEveryStrategy(),
+		})
+		require.ErrorContains(t, err, "failed to check if sender is authorized on forwarder")
+		cltest.AssertCount(t, db, "evm.txes", 0)
+	})
+
 	t.Run("insert Tx successfully with a IdempotencyKey", func(t *testing.T) {
 		evmConfig.MaxQueued = uint64(3)
 		id := uuid.New()
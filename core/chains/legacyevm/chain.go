@@ -240,7 +240,9 @@ func newChain(ctx context.Context, cfg *evmconfig.ChainScoped, nodes []*toml.Nod
 				BackupPollerBlockDelay:   int64(cfg.EVM().BackupLogPollerBlockDelay()),
 				ClientErrors:             cfg.EVM().NodePool().Errors(),
 			}
-			logPoller = logpoller.NewLogPoller(logpoller.NewObservedORM(chainID, opts.DS, l), client, l, headTracker, lpOpts)
+			lp := logpoller.NewLogPoller(logpoller.NewObservedORM(chainID, opts.DS, l), client, l, headTracker, lpOpts)
+			headBroadcaster.SubscribeReorgs(lp)
+			logPoller = lp
 		}
 	}
 
@@ -251,6 +253,7 @@ func newChain(ctx context.Context, cfg *evmconfig.ChainScoped, nodes []*toml.Nod
 	}
 
 	headBroadcaster.Subscribe(txm)
+	headBroadcaster.SubscribeReorgs(txm)
 
 	// Highest seen head height is used as part of the start of LogBroadcaster backfill range
 	highestSeenHead, err := headSaver.LatestHeadFromDB(ctx)
@@ -260,7 +263,7 @@ func newChain(ctx context.Context, cfg *evmconfig.ChainScoped, nodes []*toml.Nod
 
 	var balanceMonitor monitor.BalanceMonitor
 	if opts.AppConfig.EVMRPCEnabled() && cfg.EVM().BalanceMonitor().Enabled() {
-		balanceMonitor = monitor.NewBalanceMonitor(client, opts.KeyStore, l)
+		balanceMonitor = monitor.NewBalanceMonitor(opts.DS, client, opts.KeyStore, cfg.EVM(), txm, l)
 		headBroadcaster.Subscribe(balanceMonitor)
 	}
 
@@ -271,7 +274,14 @@ func newChain(ctx context.Context, cfg *evmconfig.ChainScoped, nodes []*toml.Nod
 		logBroadcaster = &log.NullBroadcaster{ErrMsg: fmt.Sprintf("LogBroadcaster disabled for chain %d", chainID)}
 	} else if opts.GenLogBroadcaster == nil {
 		logORM := log.NewORM(opts.DS, *chainID)
-		logBroadcaster = log.NewBroadcaster(logORM, client, cfg.EVM(), l, highestSeenHead, opts.MailMon)
+		if cfg.EVM().LogBroadcasterUseLogPoller() {
+			if logPoller == logpoller.LogPollerDisabled {
+				return nil, fmt.Errorf("LogBroadcasterUseLogPoller requires the LogPoller to be enabled for chain %s", chainID.String())
+			}
+			logBroadcaster = log.NewLogPollerBroadcaster(logORM, logPoller, *chainID, cfg.EVM(), l, opts.MailMon)
+		} else {
+			logBroadcaster = log.NewBroadcaster(logORM, client, cfg.EVM(), l, highestSeenHead, opts.MailMon)
+		}
 	} else {
 		logBroadcaster = opts.GenLogBroadcaster(chainID)
 	}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"errors"
 	"cmp"
 	"fmt"
 	"net/url"
@@ -316,6 +315,16 @@ func NewApp(s *Shell) *cli.App {
 			Usage:       "Commands for managing forwarder addresses.",
 			Subcommands: initFowardersSubCmds(s),
 		},
+		{
+			Name:        "workflows",
+			Usage:       "Commands for inspecting workflows",
+			Subcommands: initWorkflowsSubCmds(s),
+		},
+		{
+			Name:        "capabilities",
+			Usage:       "Commands for inspecting the capabilities of the node",
+			Subcommands: initCapabilitiesSubCmds(s),
+		},
 		{
 			Name:  "help-all",
 			Usage: "Shows a list of all commands and sub-commands",
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initCapabilitiesSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List the capabilities of the capabilities registry of the node, local and remote",
+			Action: s.ListCapabilities,
+		},
+		{
+			Name:   "remote",
+			Usage:  "List the shims of remote capabilities, i.e. the trigger publishers and subscribers, and the executable servers and clients, with their peers, pending requests and counters",
+			Action: s.ListRemoteCapabilityShims,
+		},
+	}
+}
+
+type CapabilityPresenter struct {
+	JAID
+	presenters.CapabilityResource
+}
+
+var capabilityHeaders = []string{"ID", "Type", "Local", "DON ID", "DON Members", "Description"}
+
+// ToRow presents the CapabilityResource as a slice of strings.
+func (p *CapabilityPresenter) ToRow() []string {
+	var donID string
+	if p.DonID != nil {
+		donID = fmt.Sprint(*p.DonID)
+	}
+	return []string{
+		p.GetID(),
+		p.CapabilityType,
+		fmt.Sprint(p.IsLocal),
+		donID,
+		fmt.Sprint(len(p.DonMembers)),
+		p.Description,
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *CapabilityPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable(capabilityHeaders)
+	table.Append(p.ToRow())
+	render("Capability", table)
+	return nil
+}
+
+// CapabilityPresenters implements TableRenderer for a slice of CapabilityPresenter.
+type CapabilityPresenters []CapabilityPresenter
+
+// RenderTable implements TableRenderer
+func (ps CapabilityPresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable(capabilityHeaders)
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+	render("Capabilities", table)
+	return nil
+}
+
+type RemoteCapabilityShimPresenter struct {
+	JAID
+	presenters.RemoteCapabilityShimResource
+}
+
+var remoteCapabilityShimHeaders = []string{"Kind", "Capability ID", "DON ID", "Peers", "Registrations", "Pending Requests", "Messages Received", "Errors"}
+
+// ToRow presents the RemoteCapabilityShimResource as a slice of strings.
+func (p *RemoteCapabilityShimPresenter) ToRow() []string {
+	return []string{
+		p.Kind,
+		p.CapabilityID,
+		fmt.Sprint(p.DonID),
+		strings.Join(p.Peers, "\n"),
+		fmt.Sprint(p.Registrations),
+		fmt.Sprint(p.PendingRequests),
+		fmt.Sprint(p.MessagesReceived),
+		fmt.Sprint(p.Errors),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *RemoteCapabilityShimPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable(remoteCapabilityShimHeaders)
+	table.Append(p.ToRow())
+	render("Remote Capability Shim", table)
+	return nil
+}
+
+// RemoteCapabilityShimPresenters implements TableRenderer for a slice of RemoteCapabilityShimPresenter.
+type RemoteCapabilityShimPresenters []RemoteCapabilityShimPresenter
+
+// RenderTable implements TableRenderer
+func (ps RemoteCapabilityShimPresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable(remoteCapabilityShimHeaders)
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+	render("Remote Capability Shims", table)
+	return nil
+}
+
+// ListCapabilities lists the capabilities of the capabilities registry
+func (s *Shell) ListCapabilities(_ *cli.Context) (err error) {
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/capabilities")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &CapabilityPresenters{})
+}
+
+// ListRemoteCapabilityShims lists the shims of remote capabilities
+func (s *Shell) ListRemoteCapabilityShims(_ *cli.Context) (err error) {
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/capabilities/remote")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &RemoteCapabilityShimPresenters{})
+}
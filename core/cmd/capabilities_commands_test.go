@@ -0,0 +1,71 @@
+package cmd_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestCapabilityPresenters_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		id     = "streams-trigger@1.0.0"
+		donID  = uint32(2)
+		buffer = bytes.NewBufferString("")
+		r      = cmd.RendererTable{Writer: buffer}
+	)
+
+	ps := cmd.CapabilityPresenters{{
+		JAID: cmd.NewJAID(id),
+		CapabilityResource: presenters.CapabilityResource{
+			JAID:           presenters.NewJAID(id),
+			CapabilityType: "trigger",
+			Description:    "Remote Trigger",
+			DonID:          &donID,
+			DonMembers:     []string{"12D3KooWBCF1XT5Wi8FzfgNCqRL76Swv8TRU3TiD4QiJm8NMNX7N"},
+		},
+	}}
+	require.NoError(t, ps.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, id)
+	assert.Contains(t, output, "trigger")
+	assert.Contains(t, output, "Remote Trigger")
+}
+
+func TestRemoteCapabilityShimPresenters_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		capabilityID = "write_ethereum-testnet-sepolia@1.0.0"
+		peer         = "12D3KooWBCF1XT5Wi8FzfgNCqRL76Swv8TRU3TiD4QiJm8NMNX7N"
+		buffer       = bytes.NewBufferString("")
+		r            = cmd.RendererTable{Writer: buffer}
+	)
+
+	ps := cmd.RemoteCapabilityShimPresenters{{
+		JAID: cmd.NewJAID("executable-client/3/" + capabilityID),
+		RemoteCapabilityShimResource: presenters.RemoteCapabilityShimResource{
+			Kind:             "executable-client",
+			CapabilityID:     capabilityID,
+			DonID:            3,
+			Peers:            []string{peer},
+			PendingRequests:  4,
+			MessagesReceived: 1234,
+			Errors:           5,
+		},
+	}}
+	require.NoError(t, ps.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "executable-client")
+	assert.Contains(t, output, capabilityID)
+	assert.Contains(t, output, peer)
+	assert.Contains(t, output, "1234")
+}
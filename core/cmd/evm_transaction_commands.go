@@ -73,13 +73,19 @@ type EthTxPresenter struct {
 
 // RenderTable implements TableRenderer
 func (p *EthTxPresenter) RenderTable(rt RendererTable) error {
-	table := rt.newTable([]string{"From", "Nonce", "To", "State"})
-	table.Append([]string{
+	headers := []string{"From", "Nonce", "To", "State"}
+	row := []string{
 		p.From.Hex(),
 		p.Nonce,
 		p.To.Hex(),
 		fmt.Sprint(p.State),
-	})
+	}
+	if p.RevertReason != "" {
+		headers = append(headers, "Revert Reason")
+		row = append(row, p.RevertReason)
+	}
+	table := rt.newTable(headers)
+	table.Append(row)
 
 	render(fmt.Sprintf("Ethereum Transaction %v", p.Hash.Hex()), table)
 	return nil
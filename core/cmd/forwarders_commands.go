@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"strings"
 	"time"
 
 	gethCommon "github.com/ethereum/go-ethereum/common"
@@ -46,6 +47,11 @@ func initFowardersSubCmds(s *Shell) []cli.Command {
 			Usage:  "Delete a forwarder address",
 			Action: s.DeleteForwarder,
 		},
+		{
+			Name:   "verify",
+			Usage:  "Verify on-chain that the node's keys are authorized senders of each tracked forwarder",
+			Action: s.VerifyForwarders,
+		},
 	}
 }
 
@@ -92,11 +98,62 @@ func (ps EVMForwarderPresenters) RenderTable(rt RendererTable) error {
 	return nil
 }
 
+type EVMForwarderStatusPresenter struct {
+	JAID
+	presenters.EVMForwarderStatusResource
+}
+
+var evmFwdStatusHeaders = []string{"ID", "Address", "Chain ID", "Authorized Keys", "Unauthorized Keys", "Error"}
+
+// ToRow presents the EVMForwarderStatusResource as a slice of strings.
+func (p *EVMForwarderStatusPresenter) ToRow() []string {
+	return []string{
+		p.GetID(),
+		p.Address.String(),
+		p.EVMChainID.ToInt().String(),
+		joinAddresses(p.AuthorizedKeys),
+		joinAddresses(p.UnauthorizedKeys),
+		p.Error,
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *EVMForwarderStatusPresenter) RenderTable(rt RendererTable) error {
+	renderList(evmFwdStatusHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return nil
+}
+
+// EVMForwarderStatusPresenters implements TableRenderer for a slice of EVMForwarderStatusPresenter.
+type EVMForwarderStatusPresenters []EVMForwarderStatusPresenter
+
+// RenderTable implements TableRenderer
+func (ps EVMForwarderStatusPresenters) RenderTable(rt RendererTable) error {
+	var rows [][]string
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+	renderList(evmFwdStatusHeaders, rows, rt.Writer)
+	return nil
+}
+
+func joinAddresses(addrs []gethCommon.Address) string {
+	strs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		strs[i] = addr.String()
+	}
+	return strings.Join(strs, ", ")
+}
+
 // ListForwarders list all forwarder addresses tracked by node
 func (s *Shell) ListForwarders(c *cli.Context) (err error) {
 	return s.getPage("/v2/nodes/evm/forwarders", c.Int("page"), &EVMForwarderPresenters{})
 }
 
+// VerifyForwarders checks the authorized senders of all forwarders tracked by node.
+func (s *Shell) VerifyForwarders(c *cli.Context) (err error) {
+	return s.getPage("/v2/nodes/evm/forwarders/verify", c.Int("page"), &EVMForwarderStatusPresenters{})
+}
+
 // DeleteForwarder deletes forwarder address from node db by id.
 func (s *Shell) DeleteForwarder(c *cli.Context) (err error) {
 	if !c.Args().Present() {
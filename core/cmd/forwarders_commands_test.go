@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
@@ -62,6 +63,42 @@ func TestEVMForwarderPresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, createdAt.Format(time.RFC3339))
 }
 
+func TestEVMForwarderStatusPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		id           = "ID:"
+		address      = utils.RandomAddress()
+		authorized   = utils.RandomAddress()
+		unauthorized = utils.RandomAddress()
+		evmChainID   = big.NewI(4)
+		buffer       = bytes.NewBufferString("")
+		r            = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.EVMForwarderStatusPresenter{
+		EVMForwarderStatusResource: presenters.EVMForwarderStatusResource{
+			JAID:             presenters.NewJAID(id),
+			Address:          address,
+			EVMChainID:       *evmChainID,
+			AuthorizedKeys:   []common.Address{authorized},
+			UnauthorizedKeys: []common.Address{unauthorized},
+			VerifiedAt:       time.Now(),
+			Error:            "some error",
+		},
+	}
+
+	require.NoError(t, cmd.EVMForwarderStatusPresenters{p}.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, id)
+	assert.Contains(t, output, address.String())
+	assert.Contains(t, output, evmChainID.ToInt().String())
+	assert.Contains(t, output, authorized.String())
+	assert.Contains(t, output, unauthorized.String())
+	assert.Contains(t, output, "some error")
+}
+
 func TestShell_TrackEVMForwarder(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initWorkflowsSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "executions",
+			Usage: "Commands for inspecting the executions of a workflow",
+			Subcommands: []cli.Command{
+				{
+					Name:   "list",
+					Usage:  "List the executions of the workflow <workflowID> in descending order",
+					Action: s.ListWorkflowExecutions,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "page",
+							Usage: "page of results to display",
+						},
+						cli.StringFlag{
+							Name:  "status",
+							Usage: "only list executions with this status, options: [started, errored, timeout, completed, completed_early_exit, rate_limited]",
+						},
+					},
+				},
+				{
+					Name:   "show",
+					Usage:  "Show the status, inputs, outputs, errors and timing of each step of the execution <executionID> of the workflow <workflowID>",
+					Action: s.ShowWorkflowExecution,
+				},
+			},
+		},
+		{
+			Name:  "dead-letters",
+			Usage: "Commands for the executions of a workflow that errored after a step exhausted its retries",
+			Subcommands: []cli.Command{
+				{
+					Name:   "list",
+					Usage:  "List the dead-lettered executions of the workflow <workflowID> in descending order",
+					Action: s.ListWorkflowDeadLetters,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "page",
+							Usage: "page of results to display",
+						},
+					},
+				},
+				{
+					Name:   "redrive",
+					Usage:  "Resume the dead-lettered execution <executionID> of the workflow <workflowID> from its failed steps, once the underlying capability recovered",
+					Action: s.RedriveWorkflowExecution,
+				},
+			},
+		},
+		{
+			Name:   "simulate",
+			Usage:  "Run a workflow locally against mock capabilities, and print the trace of its executions",
+			Action: s.SimulateWorkflow,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "spec",
+					Usage: "path to a YAML workflow spec",
+				},
+				cli.StringFlag{
+					Name:  "wasm",
+					Usage: "path to a WASM workflow binary, optionally brotli compressed (.br)",
+				},
+				cli.StringFlag{
+					Name:  "config",
+					Usage: "path to the config of the WASM workflow",
+				},
+				cli.StringFlag{
+					Name:  "events",
+					Usage: `(required) path to a JSON file with the list of trigger events, each one starting an execution, e.g. [{"trigger": "streams-trigger@1.0.0", "outputs": {...}}]`,
+				},
+				cli.StringFlag{
+					Name:  "outputs",
+					Usage: `path to a JSON file with the outputs of action and consensus capabilities by ID, e.g. {"offchain_reporting@1.0.0": {...}}; steps of other capabilities output their inputs`,
+				},
+				cli.StringFlag{
+					Name:  "secrets",
+					Usage: "path to a JSON file with the secrets of the workflow by name",
+				},
+				cli.DurationFlag{
+					Name:  "timeout",
+					Usage: "maximum duration of each execution",
+					Value: time.Minute,
+				},
+			},
+		},
+	}
+}
+
+type WorkflowExecutionPresenter struct {
+	JAID
+	presenters.WorkflowExecutionResource
+}
+
+var workflowExecutionHeaders = []string{"ID", "Status", "Created At", "Finished At", "Duration"}
+
+// ToRow presents the WorkflowExecutionResource as a slice of strings.
+func (p *WorkflowExecutionPresenter) ToRow() []string {
+	var duration string
+	if p.CreatedAt != nil && p.FinishedAt != nil {
+		duration = p.FinishedAt.Sub(*p.CreatedAt).String()
+	}
+	return []string{
+		p.GetID(),
+		p.Status,
+		formatOptionalTime(p.CreatedAt),
+		formatOptionalTime(p.FinishedAt),
+		duration,
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *WorkflowExecutionPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable(workflowExecutionHeaders)
+	table.Append(p.ToRow())
+	render(fmt.Sprintf("Execution of Workflow %s", p.WorkflowID), table)
+
+	steps := rt.newTable([]string{"Ref", "Status", "Updated At", "Inputs", "Outputs", "Error"})
+	steps.SetAutoWrapText(false)
+	for _, step := range p.Steps {
+		steps.Append([]string{
+			step.Ref,
+			step.Status,
+			formatOptionalTime(step.UpdatedAt),
+			formatStepValue(step.Inputs),
+			formatStepValue(step.Outputs),
+			step.Error,
+		})
+	}
+	render("Steps", steps)
+	return nil
+}
+
+// WorkflowExecutionPresenters implements TableRenderer for a slice of WorkflowExecutionPresenter.
+type WorkflowExecutionPresenters []WorkflowExecutionPresenter
+
+// RenderTable implements TableRenderer
+func (ps WorkflowExecutionPresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable(workflowExecutionHeaders)
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+	render("Workflow Executions", table)
+	return nil
+}
+
+type WorkflowDeadLetterPresenter struct {
+	JAID
+	presenters.WorkflowDeadLetterResource
+}
+
+var workflowDeadLetterHeaders = []string{"Execution ID", "Step", "Attempts", "Error", "Created At", "Re-drive Requested At"}
+
+// ToRow presents the WorkflowDeadLetterResource as a slice of strings.
+func (p *WorkflowDeadLetterPresenter) ToRow() []string {
+	return []string{
+		p.GetID(),
+		p.StepRef,
+		fmt.Sprint(p.Attempts),
+		p.Error,
+		formatOptionalTime(p.CreatedAt),
+		formatOptionalTime(p.RedriveRequestedAt),
+	}
+}
+
+// WorkflowDeadLetterPresenters implements TableRenderer for a slice of WorkflowDeadLetterPresenter.
+type WorkflowDeadLetterPresenters []WorkflowDeadLetterPresenter
+
+// RenderTable implements TableRenderer
+func (ps WorkflowDeadLetterPresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable(workflowDeadLetterHeaders)
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+	render("Workflow Dead Letters", table)
+	return nil
+}
+
+// SimulateWorkflow runs a workflow in-process against mock capabilities, and prints the steps of each execution.
+func (s *Shell) SimulateWorkflow(c *cli.Context) error {
+	ctx := s.ctx()
+
+	var spec job.WorkflowSpec
+	switch specPath, wasmPath := c.String("spec"), c.String("wasm"); {
+	case specPath != "" && wasmPath != "":
+		return s.errorOut(errors.New("only one of --spec and --wasm can be used"))
+	case specPath != "":
+		b, err := os.ReadFile(specPath)
+		if err != nil {
+			return s.errorOut(fmt.Errorf("failed to read workflow spec: %w", err))
+		}
+		spec = job.WorkflowSpec{Workflow: string(b), SpecType: job.YamlSpec}
+	case wasmPath != "":
+		config := c.String("config")
+		if config == "" {
+			// the workflow has an empty config
+			config = os.DevNull
+		}
+		spec = job.WorkflowSpec{Workflow: wasmPath, Config: config, SpecType: job.WASMFile}
+	default:
+		return s.errorOut(errors.New("must pass the workflow with --spec or --wasm"))
+	}
+	if _, err := spec.SDKSpec(ctx); err != nil {
+		return s.errorOut(fmt.Errorf("invalid workflow: %w", err))
+	}
+
+	if c.String("events") == "" {
+		return s.errorOut(errors.New("must pass the trigger events with --events"))
+	}
+	cfg := workflows.SimulatorConfig{
+		Lggr:    s.Logger,
+		Spec:    spec,
+		Timeout: c.Duration("timeout"),
+	}
+	if err := readJSONFile(c.String("events"), &cfg.Events); err != nil {
+		return s.errorOut(fmt.Errorf("failed to read events: %w", err))
+	}
+	if path := c.String("outputs"); path != "" {
+		if err := readJSONFile(path, &cfg.Outputs); err != nil {
+			return s.errorOut(fmt.Errorf("failed to read outputs: %w", err))
+		}
+	}
+	if path := c.String("secrets"); path != "" {
+		if err := readJSONFile(path, &cfg.Secrets); err != nil {
+			return s.errorOut(fmt.Errorf("failed to read secrets: %w", err))
+		}
+	}
+
+	executions, simErr := workflows.Simulate(ctx, cfg)
+	// render the executions that finished even if the simulation failed
+	for _, execution := range executions {
+		p := WorkflowExecutionPresenter{
+			JAID:                      NewJAID(execution.ExecutionID),
+			WorkflowExecutionResource: presenters.NewWorkflowExecutionResource(execution),
+		}
+		if err := s.Render(&p); err != nil {
+			return s.errorOut(err)
+		}
+	}
+	return s.errorOut(simErr)
+}
+
+func readJSONFile(path string, v any) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatStepValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// ListWorkflowExecutions lists the executions of a workflow, optionally filtered by status
+func (s *Shell) ListWorkflowExecutions(c *cli.Context) error {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the ID of the workflow"))
+	}
+	uri := "/v2/workflows/" + url.PathEscape(c.Args().First()) + "/executions"
+	if status := c.String("status"); status != "" {
+		uri += "?" + url.Values{"status": {status}}.Encode()
+	}
+	return s.getPage(uri, c.Int("page"), &WorkflowExecutionPresenters{})
+}
+
+// ShowWorkflowExecution shows the steps of an execution of a workflow
+func (s *Shell) ShowWorkflowExecution(c *cli.Context) (err error) {
+	if c.NArg() != 2 {
+		return s.errorOut(errors.New("must pass the ID of the workflow and the ID of the execution"))
+	}
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/workflows/"+url.PathEscape(c.Args().Get(0))+"/executions/"+url.PathEscape(c.Args().Get(1)))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &WorkflowExecutionPresenter{})
+}
+
+// ListWorkflowDeadLetters lists the executions of a workflow that errored after a step exhausted its retries
+func (s *Shell) ListWorkflowDeadLetters(c *cli.Context) error {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the ID of the workflow"))
+	}
+	return s.getPage("/v2/workflows/"+url.PathEscape(c.Args().First())+"/dead_letters", c.Int("page"), &WorkflowDeadLetterPresenters{})
+}
+
+// RedriveWorkflowExecution requests a dead-lettered execution of a workflow to be resumed from its failed steps
+func (s *Shell) RedriveWorkflowExecution(c *cli.Context) (err error) {
+	if c.NArg() != 2 {
+		return s.errorOut(errors.New("must pass the ID of the workflow and the ID of the execution"))
+	}
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/workflows/"+url.PathEscape(c.Args().Get(0))+"/dead_letters/"+url.PathEscape(c.Args().Get(1))+"/redrive", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &WorkflowExecutionPresenter{}, "Re-drive requested, the execution will be resumed by the workflow engine")
+}
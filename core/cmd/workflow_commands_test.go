@@ -0,0 +1,104 @@
+package cmd_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestWorkflowExecutionPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		id         = "ea6a1ed2ba8d28d7a79d5bde1e2d3d5a2ae0c37b8d23a4c7e3f79cbbf1d9c7d1"
+		workflowID = "15c631d295ef5e32deb99a10ee6804bc4af13855687559d7ff6552ac6dbb2ce0"
+		createdAt  = time.Now()
+		finishedAt = createdAt.Add(1500 * time.Millisecond)
+		buffer     = bytes.NewBufferString("")
+		r          = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.WorkflowExecutionPresenter{
+		JAID: cmd.NewJAID(id),
+		WorkflowExecutionResource: presenters.WorkflowExecutionResource{
+			JAID:       presenters.NewJAID(id),
+			WorkflowID: workflowID,
+			Status:     "completed",
+			CreatedAt:  &createdAt,
+			UpdatedAt:  &finishedAt,
+			FinishedAt: &finishedAt,
+			Steps: []presenters.WorkflowExecutionStepResource{
+				{
+					Ref:       "trigger",
+					Status:    "completed",
+					Outputs:   map[string]any{"price": "100"},
+					UpdatedAt: &createdAt,
+				},
+				{
+					Ref:       "write",
+					Status:    "errored",
+					Inputs:    map[string]any{"report": "0x01"},
+					Error:     "failed to transmit",
+					UpdatedAt: &finishedAt,
+				},
+			},
+		},
+	}
+
+	// Render a single resource
+	require.NoError(t, p.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, id)
+	assert.Contains(t, output, "1.5s")
+	assert.Contains(t, output, createdAt.Format(time.RFC3339))
+	assert.Contains(t, output, `{"price":"100"}`)
+	assert.Contains(t, output, `{"report":"0x01"}`)
+	assert.Contains(t, output, "failed to transmit")
+
+	// Render many resources
+	buffer.Reset()
+	ps := cmd.WorkflowExecutionPresenters{p}
+	require.NoError(t, ps.RenderTable(r))
+
+	output = buffer.String()
+	assert.Contains(t, output, id)
+	assert.Contains(t, output, "completed")
+	assert.NotContains(t, output, "failed to transmit")
+}
+
+func TestWorkflowDeadLetterPresenters_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		id        = "ea6a1ed2ba8d28d7a79d5bde1e2d3d5a2ae0c37b8d23a4c7e3f79cbbf1d9c7d1"
+		createdAt = time.Now()
+		buffer    = bytes.NewBufferString("")
+		r         = cmd.RendererTable{Writer: buffer}
+	)
+
+	ps := cmd.WorkflowDeadLetterPresenters{{
+		JAID: cmd.NewJAID(id),
+		WorkflowDeadLetterResource: presenters.WorkflowDeadLetterResource{
+			JAID:       presenters.NewJAID(id),
+			WorkflowID: "15c631d295ef5e32deb99a10ee6804bc4af13855687559d7ff6552ac6dbb2ce0",
+			StepRef:    "write",
+			Error:      "rpc unavailable",
+			Attempts:   4,
+			CreatedAt:  &createdAt,
+		},
+	}}
+	require.NoError(t, ps.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, id)
+	assert.Contains(t, output, "write")
+	assert.Contains(t, output, "rpc unavailable")
+	assert.Contains(t, output, createdAt.Format(time.RFC3339))
+}
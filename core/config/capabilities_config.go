@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/types"
 )
 
@@ -16,6 +18,45 @@ type CapabilitiesWorkflowRegistry interface {
 	NetworkID() string
 	ChainID() string
 	RelayID() types.RelayID
+	LocalArtifactsDir() string
+	IPFSGatewayURL() string
+}
+
+type CapabilitiesWorkflowExecutions interface {
+	ReaperInterval() time.Duration
+	ReaperThreshold() time.Duration
+	FailedReaperThreshold() time.Duration
+	MaxExecutionsPerWorkflow() uint32
+	ReaperBatchSize() uint32
+	StaleExecutionThreshold() time.Duration
+	Overrides() []WorkflowExecutionsOverride
+}
+
+type WorkflowExecutionsOverride interface {
+	WorkflowID() string
+	ReaperThreshold() time.Duration
+	FailedReaperThreshold() time.Duration
+	MaxExecutionsPerWorkflow() uint32
+}
+
+type WorkflowQuota interface {
+	ExecutionsPerMinute() uint32
+	ConcurrentExecutions() uint32
+	ComputeTimePerMinute() time.Duration
+	FetchCallsPerMinute() uint32
+	FuelPerExecution() uint64
+	MemoryMBsPerExecution() uint32
+	FetchCallsPerExecution() uint32
+}
+
+type WorkflowOwnerQuota interface {
+	WorkflowQuota
+	Overrides() []WorkflowOwnerQuotaOverride
+}
+
+type WorkflowOwnerQuotaOverride interface {
+	Owner() string
+	WorkflowQuota
 }
 
 type GatewayConnector interface {
@@ -26,11 +67,13 @@ type GatewayConnector interface {
 	WSHandshakeTimeoutMillis() uint32
 	AuthMinChallengeLen() int
 	AuthTimestampToleranceSec() uint32
+	AllowGatewayAuthenticatedSenders() bool
 }
 
 type ConnectorGateway interface {
 	ID() string
 	URL() string
+	Weight() uint32
 }
 
 type Capabilities interface {
@@ -38,5 +81,8 @@ type Capabilities interface {
 	Dispatcher() Dispatcher
 	ExternalRegistry() CapabilitiesExternalRegistry
 	WorkflowRegistry() CapabilitiesWorkflowRegistry
+	WorkflowExecutions() CapabilitiesWorkflowExecutions
+	WorkflowOwnerQuotas() WorkflowOwnerQuota
+	WorkflowQuotas() WorkflowQuota
 	GatewayConnector() GatewayConnector
 }
@@ -1,5 +1,11 @@
 package config
 
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
 type DispatcherRateLimit interface {
 	GlobalRPS() float64
 	GlobalBurst() int
@@ -10,5 +16,8 @@ type DispatcherRateLimit interface {
 type Dispatcher interface {
 	SupportedVersion() int
 	ReceiverBufferSize() int
+	BatchPeriod() time.Duration
+	MaxBatchSize() int
+	CompressionThreshold() utils.FileSize
 	RateLimit() DispatcherRateLimit
 }
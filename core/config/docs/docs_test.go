@@ -54,7 +54,8 @@ func TestDoc(t *testing.T) {
 		// clean up KeySpecific as a special case
 		require.Equal(t, 1, len(docDefaults.KeySpecific))
 		ks := evmcfg.KeySpecific{Key: new(types.EIP55Address),
-			GasEstimator: evmcfg.KeySpecificGasEstimator{PriceMax: new(assets.Wei)}}
+			GasEstimator:   evmcfg.KeySpecificGasEstimator{PriceMax: new(assets.Wei)},
+			BalanceMonitor: evmcfg.KeySpecificBalanceMonitor{LowBalanceThreshold: new(assets.Wei)}}
 		require.Equal(t, ks, docDefaults.KeySpecific[0])
 		docDefaults.KeySpecific = nil
 
@@ -92,6 +93,12 @@ func TestDoc(t *testing.T) {
 		docDefaults.Workflow.GasLimitDefault = &gasLimitDefault
 		docDefaults.NodePool.Errors = evmcfg.ClientErrors{}
 
+		// BalanceMonitor.TopUp configs are only set if the feature is enabled
+		docDefaults.BalanceMonitor.AlertWebhookURL = nil
+		docDefaults.BalanceMonitor.TopUp.FundingAddress = nil
+		docDefaults.BalanceMonitor.TopUp.Amount = nil
+		docDefaults.BalanceMonitor.TopUp.DailyLimit = nil
+
 		// Transactions.AutoPurge configs are only set if the feature is enabled
 		docDefaults.Transactions.AutoPurge.DetectionApiUrl = nil
 		docDefaults.Transactions.AutoPurge.Threshold = nil
@@ -1452,9 +1452,11 @@ func (r *ExternalRegistry) setFrom(f *ExternalRegistry) {
 }
 
 type WorkflowRegistry struct {
-	Address   *string
-	NetworkID *string
-	ChainID   *string
+	Address           *string
+	NetworkID         *string
+	ChainID           *string
+	LocalArtifactsDir *string
+	IPFSGatewayURL    *string
 }
 
 func (r *WorkflowRegistry) setFrom(f *WorkflowRegistry) {
@@ -1469,12 +1471,166 @@ func (r *WorkflowRegistry) setFrom(f *WorkflowRegistry) {
 	if f.ChainID != nil {
 		r.ChainID = f.ChainID
 	}
+
+	if f.LocalArtifactsDir != nil {
+		r.LocalArtifactsDir = f.LocalArtifactsDir
+	}
+
+	if f.IPFSGatewayURL != nil {
+		r.IPFSGatewayURL = f.IPFSGatewayURL
+	}
+}
+
+type WorkflowExecutions struct {
+	ReaperInterval           *commonconfig.Duration
+	ReaperThreshold          *commonconfig.Duration
+	FailedReaperThreshold    *commonconfig.Duration
+	MaxExecutionsPerWorkflow *uint32
+	ReaperBatchSize          *uint32
+	StaleExecutionThreshold  *commonconfig.Duration
+	Overrides                []WorkflowExecutionsOverride `toml:",omitempty"`
+}
+
+func (w *WorkflowExecutions) setFrom(f *WorkflowExecutions) {
+	if f.ReaperInterval != nil {
+		w.ReaperInterval = f.ReaperInterval
+	}
+	if f.ReaperThreshold != nil {
+		w.ReaperThreshold = f.ReaperThreshold
+	}
+	if f.FailedReaperThreshold != nil {
+		w.FailedReaperThreshold = f.FailedReaperThreshold
+	}
+	if f.MaxExecutionsPerWorkflow != nil {
+		w.MaxExecutionsPerWorkflow = f.MaxExecutionsPerWorkflow
+	}
+	if f.ReaperBatchSize != nil {
+		w.ReaperBatchSize = f.ReaperBatchSize
+	}
+	if f.StaleExecutionThreshold != nil {
+		w.StaleExecutionThreshold = f.StaleExecutionThreshold
+	}
+	if f.Overrides != nil {
+		w.Overrides = f.Overrides
+	}
+}
+
+func (w *WorkflowExecutions) ValidateConfig() (err error) {
+	if w.ReaperBatchSize != nil && *w.ReaperBatchSize == 0 {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "ReaperBatchSize", Value: *w.ReaperBatchSize, Msg: "must be greater than zero"})
+	}
+	if w.ReaperThreshold != nil && w.FailedReaperThreshold != nil && w.FailedReaperThreshold.Duration() < w.ReaperThreshold.Duration() {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "FailedReaperThreshold", Value: w.FailedReaperThreshold.String(), Msg: "must not be less than ReaperThreshold"})
+	}
+	workflowIDs := make(map[string]struct{}, len(w.Overrides))
+	for i, o := range w.Overrides {
+		if o.WorkflowID == nil || *o.WorkflowID == "" {
+			err = multierr.Append(err, configutils.ErrMissing{Name: fmt.Sprintf("Overrides[%d].WorkflowID", i), Msg: "required for retention overrides"})
+			continue
+		}
+		if _, ok := workflowIDs[*o.WorkflowID]; ok {
+			err = multierr.Append(err, configutils.ErrInvalid{Name: fmt.Sprintf("Overrides[%d].WorkflowID", i), Value: *o.WorkflowID, Msg: "duplicate workflow ID"})
+		}
+		workflowIDs[*o.WorkflowID] = struct{}{}
+		if o.ReaperThreshold != nil && o.FailedReaperThreshold != nil && o.FailedReaperThreshold.Duration() < o.ReaperThreshold.Duration() {
+			err = multierr.Append(err, configutils.ErrInvalid{Name: fmt.Sprintf("Overrides[%d].FailedReaperThreshold", i), Value: o.FailedReaperThreshold.String(), Msg: "must not be less than ReaperThreshold"})
+		}
+	}
+	return
+}
+
+// WorkflowExecutionsOverride overrides the retention of the executions of a single workflow. Unset fields fall back to
+// the WorkflowExecutions values.
+type WorkflowExecutionsOverride struct {
+	WorkflowID               *string
+	ReaperThreshold          *commonconfig.Duration
+	FailedReaperThreshold    *commonconfig.Duration
+	MaxExecutionsPerWorkflow *uint32
+}
+
+type WorkflowQuota struct {
+	ExecutionsPerMinute    *uint32
+	ConcurrentExecutions   *uint32
+	ComputeTimePerMinute   *commonconfig.Duration
+	FetchCallsPerMinute    *uint32
+	FuelPerExecution       *uint64
+	MemoryMBsPerExecution  *uint32
+	FetchCallsPerExecution *uint32
+}
+
+func (w *WorkflowQuota) setFrom(f *WorkflowQuota) {
+	if f.ExecutionsPerMinute != nil {
+		w.ExecutionsPerMinute = f.ExecutionsPerMinute
+	}
+	if f.ConcurrentExecutions != nil {
+		w.ConcurrentExecutions = f.ConcurrentExecutions
+	}
+	if f.ComputeTimePerMinute != nil {
+		w.ComputeTimePerMinute = f.ComputeTimePerMinute
+	}
+	if f.FetchCallsPerMinute != nil {
+		w.FetchCallsPerMinute = f.FetchCallsPerMinute
+	}
+	if f.FuelPerExecution != nil {
+		w.FuelPerExecution = f.FuelPerExecution
+	}
+	if f.MemoryMBsPerExecution != nil {
+		w.MemoryMBsPerExecution = f.MemoryMBsPerExecution
+	}
+	if f.FetchCallsPerExecution != nil {
+		w.FetchCallsPerExecution = f.FetchCallsPerExecution
+	}
+}
+
+// WorkflowOwnerQuota is the quota of every workflow owner, which can be overridden for specific owners.
+type WorkflowOwnerQuota struct {
+	WorkflowQuota
+	Overrides []WorkflowOwnerQuotaOverride `toml:",omitempty"`
+}
+
+func (w *WorkflowOwnerQuota) setFrom(f *WorkflowOwnerQuota) {
+	w.WorkflowQuota.setFrom(&f.WorkflowQuota)
+	if f.Overrides != nil {
+		w.Overrides = f.Overrides
+	}
+}
+
+func (w *WorkflowOwnerQuota) ValidateConfig() (err error) {
+	owners := make(map[string]struct{}, len(w.Overrides))
+	for i, o := range w.Overrides {
+		if o.Owner == nil || *o.Owner == "" {
+			err = multierr.Append(err, configutils.ErrMissing{Name: fmt.Sprintf("Overrides[%d].Owner", i), Msg: "required for quota overrides"})
+			continue
+		}
+		owner := strings.TrimPrefix(strings.ToLower(*o.Owner), "0x")
+		if _, ok := owners[owner]; ok {
+			err = multierr.Append(err, configutils.ErrInvalid{Name: fmt.Sprintf("Overrides[%d].Owner", i), Value: *o.Owner, Msg: "duplicate owner"})
+		}
+		owners[owner] = struct{}{}
+	}
+	return
+}
+
+// OverrideQuota returns the quota of the owner of the override, whose unset fields fall back to the quota of every
+// owner.
+func (w *WorkflowOwnerQuota) OverrideQuota(o WorkflowOwnerQuotaOverride) WorkflowQuota {
+	q := w.WorkflowQuota
+	q.setFrom(&o.WorkflowQuota)
+	return q
+}
+
+type WorkflowOwnerQuotaOverride struct {
+	Owner *string
+	WorkflowQuota
 }
 
 type Dispatcher struct {
-	SupportedVersion   *int
-	ReceiverBufferSize *int
-	RateLimit          DispatcherRateLimit
+	SupportedVersion     *int
+	ReceiverBufferSize   *int
+	BatchPeriod          *commonconfig.Duration
+	MaxBatchSize         *int
+	CompressionThreshold *utils.FileSize
+	RateLimit            DispatcherRateLimit
 }
 
 func (d *Dispatcher) setFrom(f *Dispatcher) {
@@ -1487,6 +1643,26 @@ func (d *Dispatcher) setFrom(f *Dispatcher) {
 	if f.SupportedVersion != nil {
 		d.SupportedVersion = f.SupportedVersion
 	}
+
+	if f.BatchPeriod != nil {
+		d.BatchPeriod = f.BatchPeriod
+	}
+
+	if f.MaxBatchSize != nil {
+		d.MaxBatchSize = f.MaxBatchSize
+	}
+
+	if f.CompressionThreshold != nil {
+		d.CompressionThreshold = f.CompressionThreshold
+	}
+}
+
+func (d *Dispatcher) ValidateConfig() (err error) {
+	if d.MaxBatchSize != nil && d.RateLimit.PerSenderBurst != nil && *d.MaxBatchSize > *d.RateLimit.PerSenderBurst {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "MaxBatchSize", Value: *d.MaxBatchSize,
+			Msg: fmt.Sprintf("must be less than or equal to RateLimit.PerSenderBurst (%d), as the messages of a batch are rate limited one by one", *d.RateLimit.PerSenderBurst)})
+	}
+	return
 }
 
 type DispatcherRateLimit struct {
@@ -1512,13 +1688,14 @@ func (drl *DispatcherRateLimit) setFrom(f *DispatcherRateLimit) {
 }
 
 type GatewayConnector struct {
-	ChainIDForNodeKey         *string
-	NodeAddress               *string
-	DonID                     *string
-	Gateways                  []ConnectorGateway
-	WSHandshakeTimeoutMillis  *uint32
-	AuthMinChallengeLen       *int
-	AuthTimestampToleranceSec *uint32
+	ChainIDForNodeKey                *string
+	NodeAddress                      *string
+	DonID                            *string
+	Gateways                         []ConnectorGateway
+	WSHandshakeTimeoutMillis         *uint32
+	AuthMinChallengeLen              *int
+	AuthTimestampToleranceSec        *uint32
+	AllowGatewayAuthenticatedSenders *bool
 }
 
 func (r *GatewayConnector) setFrom(f *GatewayConnector) {
@@ -1549,25 +1726,36 @@ func (r *GatewayConnector) setFrom(f *GatewayConnector) {
 	if f.AuthTimestampToleranceSec != nil {
 		r.AuthTimestampToleranceSec = f.AuthTimestampToleranceSec
 	}
+
+	if f.AllowGatewayAuthenticatedSenders != nil {
+		r.AllowGatewayAuthenticatedSenders = f.AllowGatewayAuthenticatedSenders
+	}
 }
 
 type ConnectorGateway struct {
-	ID  *string
-	URL *string
+	ID     *string
+	URL    *string
+	Weight *uint32
 }
 
 type Capabilities struct {
-	Peering          P2P              `toml:",omitempty"`
-	Dispatcher       Dispatcher       `toml:",omitempty"`
-	ExternalRegistry ExternalRegistry `toml:",omitempty"`
-	WorkflowRegistry WorkflowRegistry `toml:",omitempty"`
-	GatewayConnector GatewayConnector `toml:",omitempty"`
+	Peering             P2P                `toml:",omitempty"`
+	Dispatcher          Dispatcher         `toml:",omitempty"`
+	ExternalRegistry    ExternalRegistry   `toml:",omitempty"`
+	WorkflowRegistry    WorkflowRegistry   `toml:",omitempty"`
+	WorkflowExecutions  WorkflowExecutions `toml:",omitempty"`
+	WorkflowOwnerQuotas WorkflowOwnerQuota `toml:",omitempty"`
+	WorkflowQuotas      WorkflowQuota      `toml:",omitempty"`
+	GatewayConnector    GatewayConnector   `toml:",omitempty"`
 }
 
 func (c *Capabilities) setFrom(f *Capabilities) {
 	c.Peering.setFrom(&f.Peering)
 	c.ExternalRegistry.setFrom(&f.ExternalRegistry)
 	c.WorkflowRegistry.setFrom(&f.WorkflowRegistry)
+	c.WorkflowExecutions.setFrom(&f.WorkflowExecutions)
+	c.WorkflowOwnerQuotas.setFrom(&f.WorkflowOwnerQuotas)
+	c.WorkflowQuotas.setFrom(&f.WorkflowQuotas)
 	c.Dispatcher.setFrom(&f.Dispatcher)
 	c.GatewayConnector.setFrom(&f.GatewayConnector)
 }
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -579,3 +580,110 @@ func TestMercuryTLS_ValidateTLSCertPath(t *testing.T) {
 
 // ptr is a utility function for converting a value to a pointer to the value.
 func ptr[T any](t T) *T { return &t }
+
+func TestWorkflowExecutions_ValidateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    WorkflowExecutions
+		wantErr   bool
+		errSubStr string
+	}{
+		{
+			name: "valid",
+			config: WorkflowExecutions{
+				ReaperThreshold:       commonconfig.MustNewDuration(3 * time.Hour),
+				FailedReaperThreshold: commonconfig.MustNewDuration(24 * time.Hour),
+				ReaperBatchSize:       ptr[uint32](3000),
+			},
+		},
+		{
+			name:      "zero batch size",
+			config:    WorkflowExecutions{ReaperBatchSize: ptr[uint32](0)},
+			wantErr:   true,
+			errSubStr: "ReaperBatchSize: invalid value (0): must be greater than zero",
+		},
+		{
+			name: "failed executions kept for less time than completed ones",
+			config: WorkflowExecutions{
+				ReaperThreshold:       commonconfig.MustNewDuration(3 * time.Hour),
+				FailedReaperThreshold: commonconfig.MustNewDuration(time.Hour),
+			},
+			wantErr:   true,
+			errSubStr: "FailedReaperThreshold: invalid value (1h0m0s): must not be less than ReaperThreshold",
+		},
+		{
+			name: "override without workflow ID",
+			config: WorkflowExecutions{
+				Overrides: []WorkflowExecutionsOverride{{ReaperThreshold: commonconfig.MustNewDuration(time.Hour)}},
+			},
+			wantErr:   true,
+			errSubStr: "Overrides[0].WorkflowID: missing: required for retention overrides",
+		},
+		{
+			name: "duplicate override",
+			config: WorkflowExecutions{
+				Overrides: []WorkflowExecutionsOverride{{WorkflowID: ptr("wf")}, {WorkflowID: ptr("wf")}},
+			},
+			wantErr:   true,
+			errSubStr: "Overrides[1].WorkflowID: invalid value (wf): duplicate workflow ID",
+		},
+		{
+			name: "override keeping failed executions for less time than completed ones",
+			config: WorkflowExecutions{
+				Overrides: []WorkflowExecutionsOverride{{
+					WorkflowID:            ptr("wf"),
+					ReaperThreshold:       commonconfig.MustNewDuration(3 * time.Hour),
+					FailedReaperThreshold: commonconfig.MustNewDuration(time.Hour),
+				}},
+			},
+			wantErr:   true,
+			errSubStr: "Overrides[0].FailedReaperThreshold: invalid value (1h0m0s): must not be less than ReaperThreshold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.ValidateConfig()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, tt.errSubStr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkflowOwnerQuota_ValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	valid := WorkflowOwnerQuota{Overrides: []WorkflowOwnerQuotaOverride{{Owner: ptr("0xAA")}, {Owner: ptr("0xbb")}}}
+	assert.NoError(t, valid.ValidateConfig())
+
+	missing := WorkflowOwnerQuota{Overrides: []WorkflowOwnerQuotaOverride{{}}}
+	assert.ErrorContains(t, missing.ValidateConfig(), "Overrides[0].Owner: missing: required for quota overrides")
+
+	// owners are case and prefix insensitive
+	duplicate := WorkflowOwnerQuota{Overrides: []WorkflowOwnerQuotaOverride{{Owner: ptr("0xAA")}, {Owner: ptr("aa")}}}
+	assert.ErrorContains(t, duplicate.ValidateConfig(), "Overrides[1].Owner: invalid value (aa): duplicate owner")
+}
+
+func TestDispatcher_ValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	valid := Dispatcher{MaxBatchSize: ptr(50), RateLimit: DispatcherRateLimit{PerSenderBurst: ptr(50)}}
+	assert.NoError(t, valid.ValidateConfig())
+
+	// a batch larger than the burst of its sender could never be received in full
+	tooLarge := Dispatcher{MaxBatchSize: ptr(51), RateLimit: DispatcherRateLimit{PerSenderBurst: ptr(50)}}
+	assert.ErrorContains(t, tooLarge.ValidateConfig(), "MaxBatchSize: invalid value (51): must be less than or equal to RateLimit.PerSenderBurst (50)")
+}
+
+func TestWorkflowOwnerQuota_OverrideQuota(t *testing.T) {
+	t.Parallel()
+
+	q := WorkflowOwnerQuota{WorkflowQuota: WorkflowQuota{ExecutionsPerMinute: ptr[uint32](10), FuelPerExecution: ptr[uint64](1000)}}
+	got := q.OverrideQuota(WorkflowOwnerQuotaOverride{Owner: ptr("0xAA"), WorkflowQuota: WorkflowQuota{ExecutionsPerMinute: ptr[uint32](100)}})
+	assert.Equal(t, uint32(100), *got.ExecutionsPerMinute)
+	assert.Equal(t, uint64(1000), *got.FuelPerExecution)
+	assert.Equal(t, uint32(10), *q.ExecutionsPerMinute)
+}
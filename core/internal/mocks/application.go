@@ -9,6 +9,8 @@ import (
 
 	bridges "github.com/smartcontractkit/chainlink/v2/core/bridges"
 
+	capabilities "github.com/smartcontractkit/chainlink/v2/core/capabilities"
+
 	chainlink "github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 
 	context "context"
@@ -31,12 +33,16 @@ import (
 
 	plugins "github.com/smartcontractkit/chainlink/v2/plugins"
 
+	remotetypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types"
+
 	services "github.com/smartcontractkit/chainlink/v2/core/services"
 
 	sessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
 
 	sqlutil "github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 
+	store "github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+
 	txmgr "github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 
 	types "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
@@ -497,6 +503,53 @@ func (_c *Application_GetAuditLogger_Call) RunAndReturn(run func() audit.AuditLo
 	return _c
 }
 
+// GetCapabilitiesRegistry provides a mock function with given fields:
+func (_m *Application) GetCapabilitiesRegistry() *capabilities.Registry {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCapabilitiesRegistry")
+	}
+
+	var r0 *capabilities.Registry
+	if rf, ok := ret.Get(0).(func() *capabilities.Registry); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*capabilities.Registry)
+		}
+	}
+
+	return r0
+}
+
+// Application_GetCapabilitiesRegistry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCapabilitiesRegistry'
+type Application_GetCapabilitiesRegistry_Call struct {
+	*mock.Call
+}
+
+// GetCapabilitiesRegistry is a helper method to define mock.On call
+func (_e *Application_Expecter) GetCapabilitiesRegistry() *Application_GetCapabilitiesRegistry_Call {
+	return &Application_GetCapabilitiesRegistry_Call{Call: _e.mock.On("GetCapabilitiesRegistry")}
+}
+
+func (_c *Application_GetCapabilitiesRegistry_Call) Run(run func()) *Application_GetCapabilitiesRegistry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_GetCapabilitiesRegistry_Call) Return(_a0 *capabilities.Registry) *Application_GetCapabilitiesRegistry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_GetCapabilitiesRegistry_Call) RunAndReturn(run func() *capabilities.Registry) *Application_GetCapabilitiesRegistry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetConfig provides a mock function with given fields:
 func (_m *Application) GetConfig() chainlink.GeneralConfig {
 	ret := _m.Called()
@@ -967,6 +1020,53 @@ func (_c *Application_GetRelayers_Call) RunAndReturn(run func() chainlink.Relaye
 	return _c
 }
 
+// GetRemoteCapabilityShims provides a mock function with given fields:
+func (_m *Application) GetRemoteCapabilityShims() []remotetypes.ShimStats {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRemoteCapabilityShims")
+	}
+
+	var r0 []remotetypes.ShimStats
+	if rf, ok := ret.Get(0).(func() []remotetypes.ShimStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]remotetypes.ShimStats)
+		}
+	}
+
+	return r0
+}
+
+// Application_GetRemoteCapabilityShims_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRemoteCapabilityShims'
+type Application_GetRemoteCapabilityShims_Call struct {
+	*mock.Call
+}
+
+// GetRemoteCapabilityShims is a helper method to define mock.On call
+func (_e *Application_Expecter) GetRemoteCapabilityShims() *Application_GetRemoteCapabilityShims_Call {
+	return &Application_GetRemoteCapabilityShims_Call{Call: _e.mock.On("GetRemoteCapabilityShims")}
+}
+
+func (_c *Application_GetRemoteCapabilityShims_Call) Run(run func()) *Application_GetRemoteCapabilityShims_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_GetRemoteCapabilityShims_Call) Return(_a0 []remotetypes.ShimStats) *Application_GetRemoteCapabilityShims_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_GetRemoteCapabilityShims_Call) RunAndReturn(run func() []remotetypes.ShimStats) *Application_GetRemoteCapabilityShims_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetWebAuthnConfiguration provides a mock function with given fields:
 func (_m *Application) GetWebAuthnConfiguration() sessions.WebAuthnConfiguration {
 	ret := _m.Called()
@@ -1676,6 +1776,53 @@ func (_c *Application_WakeSessionReaper_Call) RunAndReturn(run func()) *Applicat
 	return _c
 }
 
+// WorkflowORM provides a mock function with given fields:
+func (_m *Application) WorkflowORM() store.Store {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WorkflowORM")
+	}
+
+	var r0 store.Store
+	if rf, ok := ret.Get(0).(func() store.Store); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Store)
+		}
+	}
+
+	return r0
+}
+
+// Application_WorkflowORM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WorkflowORM'
+type Application_WorkflowORM_Call struct {
+	*mock.Call
+}
+
+// WorkflowORM is a helper method to define mock.On call
+func (_e *Application_Expecter) WorkflowORM() *Application_WorkflowORM_Call {
+	return &Application_WorkflowORM_Call{Call: _e.mock.On("WorkflowORM")}
+}
+
+func (_c *Application_WorkflowORM_Call) Run(run func()) *Application_WorkflowORM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_WorkflowORM_Call) Return(_a0 store.Store) *Application_WorkflowORM_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_WorkflowORM_Call) RunAndReturn(run func() store.Store) *Application_WorkflowORM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewApplication creates a new instance of Application. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApplication(t interface {
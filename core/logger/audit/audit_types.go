@@ -88,6 +88,8 @@ const (
 	JobErrorDismissed EventID = "JOB_ERROR_DISMISSED"
 	JobRunSet         EventID = "JOB_RUN_SET"
 
+	WorkflowExecutionRedriven EventID = "WORKFLOW_EXECUTION_REDRIVEN"
+
 	EnvNoncriticalEnvDumped EventID = "ENV_NONCRITICAL_ENV_DUMPED"
 
 	UnauthedRunResumed EventID = "UNAUTHED_RUN_RESUMED"
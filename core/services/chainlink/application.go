@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"path/filepath"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -71,6 +72,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/quotas"
 	workflowstore "github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/syncer"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
@@ -108,6 +110,10 @@ type Application interface {
 	BasicAdminUsersORM() sessions.BasicAdminUsersORM
 	AuthenticationProvider() sessions.AuthenticationProvider
 	TxmStorageService() txmgr.EvmTxStore
+	WorkflowORM() workflowstore.Store
+	GetCapabilitiesRegistry() *capabilities.Registry
+	// GetRemoteCapabilityShims returns the shims of remote capabilities, or none if the capabilities registry isn't configured.
+	GetRemoteCapabilityShims() []remotetypes.ShimStats
 	AddJobV2(ctx context.Context, job *job.Job) error
 	DeleteJob(ctx context.Context, jobID int32) error
 	RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta jsonserializable.JSONSerializable) (int64, error)
@@ -146,6 +152,9 @@ type ChainlinkApplication struct {
 	localAdminUsersORM       sessions.BasicAdminUsersORM
 	authenticationProvider   sessions.AuthenticationProvider
 	txmStorageService        txmgr.EvmTxStore
+	workflowORM              workflowstore.Store
+	capabilitiesRegistry     *capabilities.Registry
+	capabilitiesShimLister   capabilities.ShimLister
 	FeedsService             feeds.Service
 	webhookJobRunner         webhook.JobRunner
 	Config                   GeneralConfig
@@ -214,6 +223,9 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		opts.CapabilitiesRegistry = capabilities.NewRegistry(globalLogger)
 	}
 
+	// workflowLimiter enforces the quotas of the workflows run by all the workflow engines of the node
+	workflowLimiter := quotas.NewLimiterFromConfig(cfg.Capabilities().WorkflowOwnerQuotas(), cfg.Capabilities().WorkflowQuotas(), clockwork.NewRealClock())
+
 	var gatewayConnectorWrapper *gatewayconnector.ServiceWrapper
 	if cfg.Capabilities().GatewayConnector().DonID() != "" {
 		globalLogger.Debugw("Creating GatewayConnector wrapper", "donID", cfg.Capabilities().GatewayConnector().DonID())
@@ -226,6 +238,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 	}
 
 	var externalPeerWrapper p2ptypes.PeerWrapper
+	var capabilitiesShimLister capabilities.ShimLister
 	if cfg.Capabilities().Peering().Enabled() {
 		var dispatcher remotetypes.Dispatcher
 		if opts.CapabilitiesDispatcher == nil {
@@ -279,6 +292,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 				workflowDonNotifier,
 			)
 			registrySyncer.AddLauncher(wfLauncher)
+			capabilitiesShimLister = wfLauncher
 
 			srvcs = append(srvcs, wfLauncher, registrySyncer)
 
@@ -302,10 +316,18 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 
 				lggr := globalLogger.Named("WorkflowRegistrySyncer")
 				fetcher := syncer.NewFetcherService(lggr, gatewayConnectorWrapper)
+				artifactFetchers := []syncer.ArtifactFetcher{fetcher}
+				if dir := cfg.Capabilities().WorkflowRegistry().LocalArtifactsDir(); dir != "" {
+					artifactFetchers = append(artifactFetchers, syncer.NewLocalFetcher(dir))
+				}
+				if gatewayURL := cfg.Capabilities().WorkflowRegistry().IPFSGatewayURL(); gatewayURL != "" {
+					artifactFetchers = append(artifactFetchers, syncer.NewIPFSFetcher(gatewayURL, fetcher.Fetch))
+				}
+				artifactCache := syncer.NewArtifactCache(lggr, filepath.Join(cfg.RootDir(), "workflows", "artifacts"), 0, clockwork.NewRealClock())
 
 				eventHandler := syncer.NewEventHandler(lggr, syncer.NewWorkflowRegistryDS(opts.DS, globalLogger),
-					fetcher.Fetch, workflowstore.NewDBStore(opts.DS, lggr, clockwork.NewRealClock()), opts.CapabilitiesRegistry,
-					custmsg.NewLabeler(), clockwork.NewRealClock(), keys[0])
+					syncer.NewMultiFetcher(artifactFetchers...).Fetch, workflowstore.NewDBStore(opts.DS, lggr, clockwork.NewRealClock()), opts.CapabilitiesRegistry,
+					custmsg.NewLabeler(), clockwork.NewRealClock(), keys[0], syncer.WithLimiter(workflowLimiter), syncer.WithArtifactCache(artifactCache))
 
 				loader := syncer.NewWorkflowRegistryContractLoader(lggr, cfg.Capabilities().WorkflowRegistry().Address(), func(ctx context.Context, bytes []byte) (syncer.ContractReader, error) {
 					return relayer.NewContractReader(ctx, bytes)
@@ -446,7 +468,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		streamRegistry = streams.NewRegistry(globalLogger, pipelineRunner)
 		workflowORM    = workflowstore.NewDBStore(opts.DS, globalLogger, clockwork.NewRealClock())
 	)
-	srvcs = append(srvcs, workflowORM)
+	srvcs = append(srvcs, workflowORM, workflowstore.NewReaper(opts.DS, cfg.Capabilities().WorkflowExecutions(), globalLogger, clockwork.NewRealClock()))
 
 	promReporter := headreporter.NewPrometheusReporter(opts.DS, legacyEVMChains)
 	chainIDs := make([]*big.Int, legacyEVMChains.Len())
@@ -525,6 +547,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		globalLogger,
 		opts.CapabilitiesRegistry,
 		workflowORM,
+		workflowLimiter,
 	)
 
 	// Flux monitor requires ethereum just to boot, silence errors with a null delegate
@@ -569,6 +592,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		keyStore,
 		peerWrapper,
 		opts.NewOracleFactoryFn,
+		workflowLimiter,
 	)
 
 	if cfg.OCR().Enabled() {
@@ -699,6 +723,9 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		localAdminUsersORM:       localAdminUsersORM,
 		authenticationProvider:   authenticationProvider,
 		txmStorageService:        txmORM,
+		workflowORM:              workflowORM,
+		capabilitiesRegistry:     opts.CapabilitiesRegistry,
+		capabilitiesShimLister:   capabilitiesShimLister,
 		FeedsService:             feedsService,
 		Config:                   cfg,
 		webhookJobRunner:         webhookJobRunner,
@@ -897,6 +924,21 @@ func (app *ChainlinkApplication) TxmStorageService() txmgr.EvmTxStore {
 	return app.txmStorageService
 }
 
+func (app *ChainlinkApplication) WorkflowORM() workflowstore.Store {
+	return app.workflowORM
+}
+
+func (app *ChainlinkApplication) GetCapabilitiesRegistry() *capabilities.Registry {
+	return app.capabilitiesRegistry
+}
+
+func (app *ChainlinkApplication) GetRemoteCapabilityShims() []remotetypes.ShimStats {
+	if app.capabilitiesShimLister == nil {
+		return nil
+	}
+	return app.capabilitiesShimLister.Shims()
+}
+
 func (app *ChainlinkApplication) GetExternalInitiatorManager() webhook.ExternalInitiatorManager {
 	return app.ExternalInitiatorManager
 }
@@ -293,6 +293,22 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ValidateConfig returns an error if the Config is not valid for use, as-is, including settings which depend on
+// each other across sections.
+func (c *Config) ValidateConfig() (err error) {
+	err = c.Core.ValidateConfig()
+
+	if c.Feature.LogPoller == nil || !*c.Feature.LogPoller {
+		for i, ec := range c.EVM {
+			if ec.IsEnabled() && ec.LogBroadcasterUseLogPoller != nil && *ec.LogBroadcasterUseLogPoller {
+				err = multierr.Append(err, config.ErrInvalid{Name: fmt.Sprintf("EVM.%d.LogBroadcasterUseLogPoller", i), Value: true,
+					Msg: "requires Feature.LogPoller to be enabled"})
+			}
+		}
+	}
+	return
+}
+
 // setDefaults initializes unset fields with default values.
 func (c *Config) setDefaults() {
 	core := docs.CoreDefaults()
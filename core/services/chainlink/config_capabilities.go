@@ -1,9 +1,12 @@
 package chainlink
 
 import (
+	"time"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/types"
 	"github.com/smartcontractkit/chainlink/v2/core/config"
 	"github.com/smartcontractkit/chainlink/v2/core/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
 var _ config.Capabilities = (*capabilitiesConfig)(nil)
@@ -28,6 +31,20 @@ func (c *capabilitiesConfig) WorkflowRegistry() config.CapabilitiesWorkflowRegis
 	}
 }
 
+func (c *capabilitiesConfig) WorkflowExecutions() config.CapabilitiesWorkflowExecutions {
+	return &capabilitiesWorkflowExecutions{
+		c: c.c.WorkflowExecutions,
+	}
+}
+
+func (c *capabilitiesConfig) WorkflowOwnerQuotas() config.WorkflowOwnerQuota {
+	return &workflowOwnerQuota{workflowQuota: workflowQuota{c: c.c.WorkflowOwnerQuotas.WorkflowQuota}, c: c.c.WorkflowOwnerQuotas}
+}
+
+func (c *capabilitiesConfig) WorkflowQuotas() config.WorkflowQuota {
+	return &workflowQuota{c: c.c.WorkflowQuotas}
+}
+
 func (c *capabilitiesConfig) Dispatcher() config.Dispatcher {
 	return &dispatcher{d: c.c.Dispatcher}
 }
@@ -44,6 +61,18 @@ func (d *dispatcher) ReceiverBufferSize() int {
 	return *d.d.ReceiverBufferSize
 }
 
+func (d *dispatcher) BatchPeriod() time.Duration {
+	return d.d.BatchPeriod.Duration()
+}
+
+func (d *dispatcher) MaxBatchSize() int {
+	return *d.d.MaxBatchSize
+}
+
+func (d *dispatcher) CompressionThreshold() utils.FileSize {
+	return *d.d.CompressionThreshold
+}
+
 func (d *dispatcher) RateLimit() config.DispatcherRateLimit {
 	return &dispatcherRateLimit{r: d.d.RateLimit}
 }
@@ -114,6 +143,134 @@ func (c *capabilitiesWorkflowRegistry) Address() string {
 	return *c.c.Address
 }
 
+func (c *capabilitiesWorkflowRegistry) LocalArtifactsDir() string {
+	return *c.c.LocalArtifactsDir
+}
+
+func (c *capabilitiesWorkflowRegistry) IPFSGatewayURL() string {
+	return *c.c.IPFSGatewayURL
+}
+
+type capabilitiesWorkflowExecutions struct {
+	c toml.WorkflowExecutions
+}
+
+func (c *capabilitiesWorkflowExecutions) ReaperInterval() time.Duration {
+	return c.c.ReaperInterval.Duration()
+}
+
+func (c *capabilitiesWorkflowExecutions) ReaperThreshold() time.Duration {
+	return c.c.ReaperThreshold.Duration()
+}
+
+func (c *capabilitiesWorkflowExecutions) FailedReaperThreshold() time.Duration {
+	return c.c.FailedReaperThreshold.Duration()
+}
+
+func (c *capabilitiesWorkflowExecutions) MaxExecutionsPerWorkflow() uint32 {
+	return *c.c.MaxExecutionsPerWorkflow
+}
+
+func (c *capabilitiesWorkflowExecutions) ReaperBatchSize() uint32 {
+	return *c.c.ReaperBatchSize
+}
+
+func (c *capabilitiesWorkflowExecutions) StaleExecutionThreshold() time.Duration {
+	return c.c.StaleExecutionThreshold.Duration()
+}
+
+func (c *capabilitiesWorkflowExecutions) Overrides() []config.WorkflowExecutionsOverride {
+	t := make([]config.WorkflowExecutionsOverride, len(c.c.Overrides))
+	for index, element := range c.c.Overrides {
+		t[index] = &workflowExecutionsOverride{c: element, defaults: c}
+	}
+	return t
+}
+
+type workflowExecutionsOverride struct {
+	c        toml.WorkflowExecutionsOverride
+	defaults *capabilitiesWorkflowExecutions
+}
+
+func (o *workflowExecutionsOverride) WorkflowID() string {
+	return *o.c.WorkflowID
+}
+
+func (o *workflowExecutionsOverride) ReaperThreshold() time.Duration {
+	if o.c.ReaperThreshold == nil {
+		return o.defaults.ReaperThreshold()
+	}
+	return o.c.ReaperThreshold.Duration()
+}
+
+func (o *workflowExecutionsOverride) FailedReaperThreshold() time.Duration {
+	if o.c.FailedReaperThreshold == nil {
+		return o.defaults.FailedReaperThreshold()
+	}
+	return o.c.FailedReaperThreshold.Duration()
+}
+
+func (o *workflowExecutionsOverride) MaxExecutionsPerWorkflow() uint32 {
+	if o.c.MaxExecutionsPerWorkflow == nil {
+		return o.defaults.MaxExecutionsPerWorkflow()
+	}
+	return *o.c.MaxExecutionsPerWorkflow
+}
+
+type workflowQuota struct {
+	c toml.WorkflowQuota
+}
+
+func (w *workflowQuota) ExecutionsPerMinute() uint32 {
+	return *w.c.ExecutionsPerMinute
+}
+
+func (w *workflowQuota) ConcurrentExecutions() uint32 {
+	return *w.c.ConcurrentExecutions
+}
+
+func (w *workflowQuota) ComputeTimePerMinute() time.Duration {
+	return w.c.ComputeTimePerMinute.Duration()
+}
+
+func (w *workflowQuota) FetchCallsPerMinute() uint32 {
+	return *w.c.FetchCallsPerMinute
+}
+
+func (w *workflowQuota) FuelPerExecution() uint64 {
+	return *w.c.FuelPerExecution
+}
+
+func (w *workflowQuota) MemoryMBsPerExecution() uint32 {
+	return *w.c.MemoryMBsPerExecution
+}
+
+func (w *workflowQuota) FetchCallsPerExecution() uint32 {
+	return *w.c.FetchCallsPerExecution
+}
+
+type workflowOwnerQuota struct {
+	workflowQuota
+	c toml.WorkflowOwnerQuota
+}
+
+func (w *workflowOwnerQuota) Overrides() []config.WorkflowOwnerQuotaOverride {
+	t := make([]config.WorkflowOwnerQuotaOverride, len(w.c.Overrides))
+	for index, element := range w.c.Overrides {
+		t[index] = &workflowOwnerQuotaOverride{workflowQuota: workflowQuota{c: w.c.OverrideQuota(element)}, owner: *element.Owner}
+	}
+	return t
+}
+
+type workflowOwnerQuotaOverride struct {
+	workflowQuota
+	owner string
+}
+
+func (o *workflowOwnerQuotaOverride) Owner() string {
+	return o.owner
+}
+
 type gatewayConnector struct {
 	c toml.GatewayConnector
 }
@@ -149,6 +306,10 @@ func (c *gatewayConnector) AuthTimestampToleranceSec() uint32 {
 	return *c.c.AuthTimestampToleranceSec
 }
 
+func (c *gatewayConnector) AllowGatewayAuthenticatedSenders() bool {
+	return *c.c.AllowGatewayAuthenticatedSenders
+}
+
 type connectorGateway struct {
 	c toml.ConnectorGateway
 }
@@ -160,3 +321,10 @@ func (c *connectorGateway) ID() string {
 func (c *connectorGateway) URL() string {
 	return *c.c.URL
 }
+
+func (c *connectorGateway) Weight() uint32 {
+	if c.c.Weight == nil {
+		return 0
+	}
+	return *c.c.Weight
+}
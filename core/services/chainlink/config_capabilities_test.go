@@ -43,4 +43,43 @@ func TestCapabilitiesConfig(t *testing.T) {
 	assert.Equal(t, time.Minute, v2.DeltaDial().Duration())
 	assert.Equal(t, 2*time.Second, v2.DeltaReconcile().Duration())
 	assert.Equal(t, []string{"foo", "bar"}, v2.ListenAddresses())
+
+	we := cfg.Capabilities().WorkflowExecutions()
+	assert.Equal(t, time.Minute, we.ReaperInterval())
+	assert.Equal(t, 6*time.Hour, we.ReaperThreshold())
+	assert.Equal(t, 72*time.Hour, we.FailedReaperThreshold())
+	assert.Equal(t, uint32(100), we.MaxExecutionsPerWorkflow())
+	assert.Equal(t, uint32(500), we.ReaperBatchSize())
+	assert.Equal(t, 48*time.Hour, we.StaleExecutionThreshold())
+	require.Len(t, we.Overrides(), 1)
+	override := we.Overrides()[0]
+	assert.Equal(t, "15c631d295ef5e32deb99a10ee6804bc4af1385568f9b3363f6552ac6dbb2cef", override.WorkflowID())
+	assert.Equal(t, 168*time.Hour, override.ReaperThreshold())
+	assert.Equal(t, 336*time.Hour, override.FailedReaperThreshold())
+	assert.Equal(t, uint32(10000), override.MaxExecutionsPerWorkflow())
+
+	oq := cfg.Capabilities().WorkflowOwnerQuotas()
+	assert.Equal(t, uint32(600), oq.ExecutionsPerMinute())
+	assert.Equal(t, uint32(100), oq.ConcurrentExecutions())
+	assert.Equal(t, 5*time.Minute, oq.ComputeTimePerMinute())
+	assert.Equal(t, uint32(1000), oq.FetchCallsPerMinute())
+	assert.Equal(t, uint64(1_000_000_000), oq.FuelPerExecution())
+	assert.Equal(t, uint32(256), oq.MemoryMBsPerExecution())
+	assert.Equal(t, uint32(10), oq.FetchCallsPerExecution())
+	require.Len(t, oq.Overrides(), 1)
+	oo := oq.Overrides()[0]
+	assert.Equal(t, "0x00000000000000000000000000000000000000aa", oo.Owner())
+	assert.Equal(t, uint32(6000), oo.ExecutionsPerMinute())
+	assert.Equal(t, time.Hour, oo.ComputeTimePerMinute())
+	assert.Equal(t, uint64(10_000_000_000), oo.FuelPerExecution())
+	assert.Equal(t, uint32(20), oo.FetchCallsPerExecution())
+
+	wq := cfg.Capabilities().WorkflowQuotas()
+	assert.Equal(t, uint32(60), wq.ExecutionsPerMinute())
+	assert.Equal(t, uint32(10), wq.ConcurrentExecutions())
+	assert.Equal(t, time.Minute, wq.ComputeTimePerMinute())
+	assert.Equal(t, uint32(100), wq.FetchCallsPerMinute())
+	assert.Equal(t, uint64(100_000_000), wq.FuelPerExecution())
+	assert.Equal(t, uint32(128), wq.MemoryMBsPerExecution())
+	assert.Equal(t, uint32(5), wq.FetchCallsPerExecution())
 }
@@ -495,13 +495,64 @@ func TestConfig_Marshal(t *testing.T) {
 			NetworkID: ptr("evm"),
 		},
 		WorkflowRegistry: toml.WorkflowRegistry{
-			Address:   ptr(""),
-			ChainID:   ptr("1"),
-			NetworkID: ptr("evm"),
+			Address:           ptr(""),
+			ChainID:           ptr("1"),
+			NetworkID:         ptr("evm"),
+			LocalArtifactsDir: ptr("/var/lib/chainlink/workflows"),
+			IPFSGatewayURL:    ptr("https://ipfs.example.com"),
+		},
+		WorkflowExecutions: toml.WorkflowExecutions{
+			ReaperInterval:           commoncfg.MustNewDuration(time.Minute),
+			ReaperThreshold:          commoncfg.MustNewDuration(6 * time.Hour),
+			FailedReaperThreshold:    commoncfg.MustNewDuration(72 * time.Hour),
+			MaxExecutionsPerWorkflow: ptr[uint32](100),
+			ReaperBatchSize:          ptr[uint32](500),
+			StaleExecutionThreshold:  commoncfg.MustNewDuration(48 * time.Hour),
+			Overrides: []toml.WorkflowExecutionsOverride{{
+				WorkflowID:               ptr("15c631d295ef5e32deb99a10ee6804bc4af1385568f9b3363f6552ac6dbb2cef"),
+				ReaperThreshold:          commoncfg.MustNewDuration(168 * time.Hour),
+				FailedReaperThreshold:    commoncfg.MustNewDuration(336 * time.Hour),
+				MaxExecutionsPerWorkflow: ptr[uint32](10000),
+			}},
+		},
+		WorkflowOwnerQuotas: toml.WorkflowOwnerQuota{
+			WorkflowQuota: toml.WorkflowQuota{
+				ExecutionsPerMinute:    ptr[uint32](600),
+				ConcurrentExecutions:   ptr[uint32](100),
+				ComputeTimePerMinute:   commoncfg.MustNewDuration(5 * time.Minute),
+				FetchCallsPerMinute:    ptr[uint32](1000),
+				FuelPerExecution:       ptr[uint64](1_000_000_000),
+				MemoryMBsPerExecution:  ptr[uint32](256),
+				FetchCallsPerExecution: ptr[uint32](10),
+			},
+			Overrides: []toml.WorkflowOwnerQuotaOverride{{
+				Owner: ptr("0x00000000000000000000000000000000000000aa"),
+				WorkflowQuota: toml.WorkflowQuota{
+					ExecutionsPerMinute:    ptr[uint32](6000),
+					ConcurrentExecutions:   ptr[uint32](1000),
+					ComputeTimePerMinute:   commoncfg.MustNewDuration(time.Hour),
+					FetchCallsPerMinute:    ptr[uint32](10000),
+					FuelPerExecution:       ptr[uint64](10_000_000_000),
+					MemoryMBsPerExecution:  ptr[uint32](512),
+					FetchCallsPerExecution: ptr[uint32](20),
+				},
+			}},
+		},
+		WorkflowQuotas: toml.WorkflowQuota{
+			ExecutionsPerMinute:    ptr[uint32](60),
+			ConcurrentExecutions:   ptr[uint32](10),
+			ComputeTimePerMinute:   commoncfg.MustNewDuration(time.Minute),
+			FetchCallsPerMinute:    ptr[uint32](100),
+			FuelPerExecution:       ptr[uint64](100_000_000),
+			MemoryMBsPerExecution:  ptr[uint32](128),
+			FetchCallsPerExecution: ptr[uint32](5),
 		},
 		Dispatcher: toml.Dispatcher{
-			SupportedVersion:   ptr(1),
-			ReceiverBufferSize: ptr(10000),
+			SupportedVersion:     ptr(1),
+			ReceiverBufferSize:   ptr(10000),
+			BatchPeriod:          commoncfg.MustNewDuration(100 * time.Millisecond),
+			MaxBatchSize:         ptr(50),
+			CompressionThreshold: ptr(utils.FileSize(4 * utils.KB)),
 			RateLimit: toml.DispatcherRateLimit{
 				GlobalRPS:      ptr(800.0),
 				GlobalBurst:    ptr(1000),
@@ -510,14 +561,15 @@ func TestConfig_Marshal(t *testing.T) {
 			},
 		},
 		GatewayConnector: toml.GatewayConnector{
-			ChainIDForNodeKey:         ptr("11155111"),
-			NodeAddress:               ptr("0x68902d681c28119f9b2531473a417088bf008e59"),
-			DonID:                     ptr("example_don"),
-			WSHandshakeTimeoutMillis:  ptr[uint32](100),
-			AuthMinChallengeLen:       ptr[int](10),
-			AuthTimestampToleranceSec: ptr[uint32](10),
+			ChainIDForNodeKey:                ptr("11155111"),
+			NodeAddress:                      ptr("0x68902d681c28119f9b2531473a417088bf008e59"),
+			DonID:                            ptr("example_don"),
+			WSHandshakeTimeoutMillis:         ptr[uint32](100),
+			AuthMinChallengeLen:              ptr[int](10),
+			AuthTimestampToleranceSec:        ptr[uint32](10),
+			AllowGatewayAuthenticatedSenders: ptr(true),
 			Gateways: []toml.ConnectorGateway{
-				{ID: ptr("example_gateway"), URL: ptr("wss://localhost:8081/node")},
+				{ID: ptr("example_gateway"), URL: ptr("wss://localhost:8081/node"), Weight: ptr[uint32](1)},
 			},
 		},
 	}
@@ -577,7 +629,15 @@ func TestConfig_Marshal(t *testing.T) {
 			Chain: evmcfg.Chain{
 				AutoCreateKey: ptr(false),
 				BalanceMonitor: evmcfg.BalanceMonitor{
-					Enabled: ptr(true),
+					Enabled:             ptr(true),
+					LowBalanceThreshold: assets.NewWeiI(1_000_000_000_000_000),
+					AlertWebhookURL:     mustURL("https://alerts.example.com/chainlink"),
+					TopUp: evmcfg.BalanceMonitorTopUp{
+						Enabled:        ptr(true),
+						FundingAddress: mustAddress("0x538aAaB4ea120b2bC2fe5D296852D948F07D849e"),
+						Amount:         assets.NewWeiI(100_000_000_000_000_000),
+						DailyLimit:     assets.NewWeiI(1_000_000_000_000_000_000),
+					},
 				},
 				BlockBackfillDepth:   ptr[uint32](100),
 				BlockBackfillSkip:    ptr(true),
@@ -634,6 +694,9 @@ func TestConfig_Marshal(t *testing.T) {
 						GasEstimator: evmcfg.KeySpecificGasEstimator{
 							PriceMax: assets.NewWei(mustHexToBig(t, "FFFFFFFFFFFFFFFFFFFFFFFF")),
 						},
+						BalanceMonitor: evmcfg.KeySpecificBalanceMonitor{
+							LowBalanceThreshold: assets.NewWeiI(500_000_000_000_000_000),
+						},
 					},
 				},
 
@@ -649,6 +712,7 @@ func TestConfig_Marshal(t *testing.T) {
 				NoNewHeadsThreshold:          &minute,
 				OperatorFactoryAddress:       mustAddress("0xa5B85635Be42F21f94F28034B7DA440EeFF0F418"),
 				LogBroadcasterEnabled:        ptr(true),
+				LogBroadcasterUseLogPoller:   ptr(false),
 				RPCDefaultBatchSize:          ptr[uint32](17),
 				RPCBlockQueryDelay:           ptr[uint16](10),
 				NoNewFinalizedHeadsThreshold: &hour,
@@ -663,6 +727,10 @@ func TestConfig_Marshal(t *testing.T) {
 					AutoPurge: evmcfg.AutoPurgeConfig{
 						Enabled: ptr(false),
 					},
+					RevertReason: evmcfg.RevertReasonConfig{
+						Enabled: ptr(true),
+						Trace:   ptr(true),
+					},
 				},
 
 				HeadTracker: evmcfg.HeadTracker{
@@ -1112,6 +1180,7 @@ NonceAutoSync = true
 NoNewHeadsThreshold = '1m0s'
 OperatorFactoryAddress = '0xa5B85635Be42F21f94F28034B7DA440EeFF0F418'
 LogBroadcasterEnabled = true
+LogBroadcasterUseLogPoller = false
 RPCDefaultBatchSize = 17
 RPCBlockQueryDelay = 10
 FinalizedBlockOffset = 16
@@ -1128,8 +1197,20 @@ ResendAfterThreshold = '1h0m0s'
 [EVM.Transactions.AutoPurge]
 Enabled = false
 
+[EVM.Transactions.RevertReason]
+Enabled = true
+Trace = true
+
 [EVM.BalanceMonitor]
 Enabled = true
+LowBalanceThreshold = '1 milli'
+AlertWebhookURL = 'https://alerts.example.com/chainlink'
+
+[EVM.BalanceMonitor.TopUp]
+Enabled = true
+FundingAddress = '0x538aAaB4ea120b2bC2fe5D296852D948F07D849e'
+Amount = '100 milli'
+DailyLimit = '1 ether'
 
 [EVM.GasEstimator]
 Mode = 'SuggestedPrice'
@@ -1183,6 +1264,9 @@ Key = '0x2a3e23c6f242F5345320814aC8a1b4E58707D292'
 [EVM.KeySpecific.GasEstimator]
 PriceMax = '79.228162514264337593543950335 gether'
 
+[EVM.KeySpecific.BalanceMonitor]
+LowBalanceThreshold = '500 milli'
+
 [EVM.NodePool]
 PollFailureThreshold = 5
 PollInterval = '1m0s'
@@ -1536,6 +1620,19 @@ func TestConfig_Validate(t *testing.T) {
 		- 0: 2 errors:
 			- Enabled: invalid value (1): expected bool
 			- ChainID: missing: required for all chains`},
+		{name: "log-poller-broadcaster", toml: `
+[[EVM]]
+ChainID = '1'
+LogBroadcasterUseLogPoller = true
+LogPollInterval = '0s'
+
+[[EVM.Nodes]]
+Name = 'foo'
+WSURL = 'wss://foo.bar'
+HTTPURL = 'https://foo.bar'
+`, exp: `invalid configuration: 2 errors:
+	- EVM.0.LogBroadcasterUseLogPoller: invalid value (true): requires Feature.LogPoller to be enabled
+	- EVM.0.LogPollInterval: invalid value (0s): must be greater than 0 when LogBroadcasterUseLogPoller is enabled`},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			var c Config
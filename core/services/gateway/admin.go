@@ -0,0 +1,185 @@
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+const (
+	maxAdminRequestBytes = 1_000_000
+	adminReadTimeout     = 10 * time.Second
+)
+
+// ErrUnknownDON is returned when updating or describing a DON that the gateway doesn't serve.
+var ErrUnknownDON = errors.New("unknown DON ID")
+
+// DONUpdate changes the members and/or the handler config of a DON. Nil fields are left unchanged.
+type DONUpdate struct {
+	Members       []config.NodeConfig
+	HandlerConfig json.RawMessage
+}
+
+// DONDescription is the current configuration of a DON, as served by the admin API.
+type DONDescription struct {
+	DonId         string
+	HandlerName   string
+	HandlerConfig json.RawMessage
+	Members       []config.NodeConfig
+	F             int
+}
+
+// DONUpdater changes the configuration of the DONs of a running gateway. Updates are runtime-only: they are not
+// persisted to the gateway job spec, so they are lost when the job or the node restarts and must be applied to the
+// job spec as well to be kept.
+type DONUpdater interface {
+	// DescribeDON returns the current configuration of the DON.
+	DescribeDON(donID string) (DONDescription, error)
+	// UpdateDON applies the update to the DON. Connections to the nodes staying in the DON are kept alive, and the
+	// handler of the DON is only replaced when its config changes.
+	UpdateDON(ctx context.Context, donID string, update DONUpdate) error
+}
+
+// adminServer serves the admin API of the gateway, authenticating admins by API keys:
+//
+//	GET   /dons/{don}  returns the DONDescription of the DON
+//	PATCH /dons/{don}  applies the DONUpdate of the body to the DON and returns its new DONDescription
+type adminServer struct {
+	services.StateMachine
+	config   *config.AdminServerConfig
+	keys     map[[sha256.Size]byte]struct{}
+	updater  DONUpdater
+	listener net.Listener
+	server   *http.Server
+	doneCh   chan struct{}
+	lggr     logger.Logger
+}
+
+var _ job.ServiceCtx = (*adminServer)(nil)
+
+func newAdminServer(cfg *config.AdminServerConfig, updater DONUpdater, lggr logger.Logger) (*adminServer, error) {
+	if len(cfg.APIKeyHashes) == 0 {
+		return nil, errors.New("no API key hashes")
+	}
+	keys := make(map[[sha256.Size]byte]struct{}, len(cfg.APIKeyHashes))
+	for _, keyHash := range cfg.APIKeyHashes {
+		hash, err := parseKeyHash(keyHash)
+		if err != nil {
+			return nil, err
+		}
+		keys[hash] = struct{}{}
+	}
+	s := &adminServer{
+		config:  cfg,
+		keys:    keys,
+		updater: updater,
+		doneCh:  make(chan struct{}),
+		lggr:    lggr.Named("AdminServer"),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /dons/{don}", s.authenticated(s.handleDescribeDON))
+	mux.Handle("PATCH /dons/{don}", s.authenticated(s.handleUpdateDON))
+	s.server = &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: adminReadTimeout,
+	}
+	return s, nil
+}
+
+func (s *adminServer) authenticated(handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(APIKeyHeader)
+		if _, ok := s.keys[sha256.Sum256([]byte(key))]; key == "" || !ok {
+			s.writeResponse(w, http.StatusUnauthorized, restError{Error: "invalid API key"})
+			return
+		}
+		handler(w, r)
+	})
+}
+
+func (s *adminServer) handleDescribeDON(w http.ResponseWriter, r *http.Request) {
+	description, err := s.updater.DescribeDON(r.PathValue("don"))
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.writeResponse(w, http.StatusOK, description)
+}
+
+func (s *adminServer) handleUpdateDON(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxAdminRequestBytes))
+	if err != nil {
+		s.writeResponse(w, http.StatusBadRequest, restError{Error: err.Error()})
+		return
+	}
+	var update DONUpdate
+	if err = json.Unmarshal(body, &update); err != nil {
+		s.writeResponse(w, http.StatusBadRequest, restError{Error: fmt.Sprintf("invalid update: %v", err)})
+		return
+	}
+	donID := r.PathValue("don")
+	if err = s.updater.UpdateDON(r.Context(), donID, update); err != nil {
+		s.lggr.Errorw("failed to update DON", "donID", donID, "err", err)
+		s.writeError(w, err)
+		return
+	}
+	s.handleDescribeDON(w, r)
+}
+
+func (s *adminServer) writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrUnknownDON) {
+		s.writeResponse(w, http.StatusNotFound, restError{Error: err.Error()})
+		return
+	}
+	s.writeResponse(w, http.StatusBadRequest, restError{Error: err.Error()})
+}
+
+func (s *adminServer) writeResponse(w http.ResponseWriter, httpStatusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.lggr.Error("error when writing response", err)
+	}
+}
+
+func (s *adminServer) GetPort() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *adminServer) Start(ctx context.Context) error {
+	return s.StartOnce("GatewayAdminServer", func() (err error) {
+		s.lggr.Info("starting gateway admin server")
+		s.listener, err = net.Listen("tcp", s.server.Addr)
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := s.server.Serve(s.listener); err != http.ErrServerClosed {
+				s.lggr.Error("gateway admin server closed with error:", err)
+			}
+			close(s.doneCh)
+		}()
+		return nil
+	})
+}
+
+func (s *adminServer) Close() error {
+	return s.StopOnce("GatewayAdminServer", func() (err error) {
+		s.lggr.Info("closing gateway admin server")
+		err = s.server.Shutdown(context.Background())
+		<-s.doneCh
+		return
+	})
+}
@@ -0,0 +1,218 @@
+package gateway_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
+	handler_mocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/mocks"
+)
+
+const testAdminAPIKey = "admin-key"
+
+func newAdminGateway(t *testing.T) gateway.Gateway {
+	cfg := parseTOMLConfig(t, buildConfig(`
+[[dons]]
+DonId = "my_don"
+HandlerName = "dummy"
+F = 1
+
+[[dons.Members]]
+Name = "node one"
+Address = "0x0001020304050607080900010203040506070809"
+`))
+	cfg.AdminServerConfig = &config.AdminServerConfig{APIKeyHashes: []string{hashAPIKey(testAdminAPIKey)}}
+	lggr := logger.TestLogger(t)
+	gw, err := gateway.NewGatewayFromConfig(cfg, gateway.NewHandlerFactory(nil, nil, nil, lggr), lggr)
+	require.NoError(t, err)
+	servicetest.Run(t, gw)
+	return gw
+}
+
+func sendAdminRequest(t *testing.T, gw gateway.Gateway, method string, donID string, apiKey string, body string) (int, []byte) {
+	url := fmt.Sprintf("http://localhost:%d/dons/%s", gw.GetAdminPort(), donID)
+	req, err := http.NewRequestWithContext(testutils.Context(t), method, url, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.Header.Set(gateway.APIKeyHeader, apiKey)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, respBody
+}
+
+func TestGateway_NewGatewayFromConfig_InvalidAdminServerConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := parseTOMLConfig(t, buildConfig(""))
+	cfg.AdminServerConfig = &config.AdminServerConfig{APIKeyHashes: []string{"not_a_hash"}}
+	lggr := logger.TestLogger(t)
+	_, err := gateway.NewGatewayFromConfig(cfg, gateway.NewHandlerFactory(nil, nil, nil, lggr), lggr)
+	require.ErrorContains(t, err, "invalid admin server config")
+}
+
+func TestGateway_AdminServer_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	gw := newAdminGateway(t)
+	statusCode, _ := sendAdminRequest(t, gw, http.MethodGet, "my_don", "", "")
+	require.Equal(t, http.StatusUnauthorized, statusCode)
+	statusCode, _ = sendAdminRequest(t, gw, http.MethodPatch, "my_don", "wrong-key", `{"Members":[]}`)
+	require.Equal(t, http.StatusUnauthorized, statusCode)
+}
+
+func TestGateway_AdminServer_DescribeDON(t *testing.T) {
+	t.Parallel()
+
+	gw := newAdminGateway(t)
+	statusCode, body := sendAdminRequest(t, gw, http.MethodGet, "my_don", testAdminAPIKey, "")
+	require.Equal(t, http.StatusOK, statusCode)
+	var description gateway.DONDescription
+	require.NoError(t, json.Unmarshal(body, &description))
+	require.Equal(t, "my_don", description.DonId)
+	require.Equal(t, "dummy", description.HandlerName)
+	require.Equal(t, 1, description.F)
+	require.Equal(t, []config.NodeConfig{{Name: "node one", Address: "0x0001020304050607080900010203040506070809"}}, description.Members)
+
+	statusCode, _ = sendAdminRequest(t, gw, http.MethodGet, "unknown_don", testAdminAPIKey, "")
+	require.Equal(t, http.StatusNotFound, statusCode)
+}
+
+func TestGateway_AdminServer_UpdateDON(t *testing.T) {
+	t.Parallel()
+
+	gw := newAdminGateway(t)
+	update := `{"Members":[{"Name":"node two","Address":"0x00000000000000000000000000000000000000AA"}],"HandlerConfig":{"foo":"bar"}}`
+	statusCode, body := sendAdminRequest(t, gw, http.MethodPatch, "my_don", testAdminAPIKey, update)
+	require.Equal(t, http.StatusOK, statusCode, string(body))
+	var description gateway.DONDescription
+	require.NoError(t, json.Unmarshal(body, &description))
+	require.Equal(t, []config.NodeConfig{{Name: "node two", Address: "0x00000000000000000000000000000000000000aa"}}, description.Members)
+	require.JSONEq(t, `{"foo":"bar"}`, string(description.HandlerConfig))
+	require.Equal(t, 1, description.F)
+
+	// unchanged members
+	statusCode, body = sendAdminRequest(t, gw, http.MethodPatch, "my_don", testAdminAPIKey, `{"HandlerConfig":{}}`)
+	require.Equal(t, http.StatusOK, statusCode, string(body))
+	require.NoError(t, json.Unmarshal(body, &description))
+	require.Len(t, description.Members, 1)
+
+	statusCode, _ = sendAdminRequest(t, gw, http.MethodPatch, "my_don", testAdminAPIKey, `{"Members":[{"Name":"bad","Address":"0xnot_an_address"}]}`)
+	require.Equal(t, http.StatusBadRequest, statusCode)
+	statusCode, _ = sendAdminRequest(t, gw, http.MethodPatch, "my_don", testAdminAPIKey, `{{`)
+	require.Equal(t, http.StatusBadRequest, statusCode)
+	statusCode, _ = sendAdminRequest(t, gw, http.MethodPatch, "unknown_don", testAdminAPIKey, `{}`)
+	require.Equal(t, http.StatusNotFound, statusCode)
+}
+
+type testHandlerFactory struct {
+	handlers chan handlers.Handler
+}
+
+func (f *testHandlerFactory) NewHandler(gateway.HandlerType, json.RawMessage, *config.DONConfig, handlers.DON) (handlers.Handler, error) {
+	return <-f.handlers, nil
+}
+
+func TestGateway_UpdateDON_DrainsPendingRequests(t *testing.T) {
+	t.Parallel()
+
+	cfg := parseTOMLConfig(t, buildConfig(`
+[[dons]]
+DonId = "my_don"
+HandlerName = "mock"
+`))
+	oldHandler, newHandler := handler_mocks.NewHandler(t), handler_mocks.NewHandler(t)
+	for _, handler := range []*handler_mocks.Handler{oldHandler, newHandler} {
+		handler.On("Start", mock.Anything).Return(nil)
+	}
+	closed := make(chan struct{})
+	oldHandler.On("Close").Return(nil).Run(func(mock.Arguments) { close(closed) })
+	newHandler.On("Close").Return(nil)
+	callbackChs := make(chan chan<- handlers.UserCallbackPayload, 1)
+	oldHandler.On("HandleUserMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		callbackChs <- args.Get(2).(chan<- handlers.UserCallbackPayload)
+	})
+	newHandler.On("HandleUserMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		msg := args.Get(1).(*api.Message)
+		args.Get(2).(chan<- handlers.UserCallbackPayload) <- handlers.UserCallbackPayload{Msg: msg, ErrCode: api.NoError}
+	})
+	factory := &testHandlerFactory{handlers: make(chan handlers.Handler, 2)}
+	factory.handlers <- oldHandler
+	factory.handlers <- newHandler
+	lggr := logger.TestLogger(t)
+	gw, err := gateway.NewGatewayFromConfig(cfg, factory, lggr)
+	require.NoError(t, err)
+	servicetest.Run(t, gw)
+	ctx := testutils.Context(t)
+
+	responses := make(chan int, 1)
+	go func() {
+		_, statusCode := gw.ProcessRequest(ctx, newSignedRequest(t, "abcd", "request", "my_don", []byte{}))
+		responses <- statusCode
+	}()
+	callbackCh := <-callbackChs
+
+	// the new handler serves requests at once, while the old one is drained in the background
+	require.NoError(t, gw.(gateway.DONUpdater).UpdateDON(ctx, "my_don", gateway.DONUpdate{HandlerConfig: json.RawMessage(`{}`)}))
+	_, statusCode := gw.ProcessRequest(ctx, newSignedRequest(t, "efgh", "request", "my_don", []byte{}))
+	require.Equal(t, http.StatusOK, statusCode)
+	select {
+	case <-closed:
+		t.Fatal("old handler closed with a pending request")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	callbackCh <- handlers.UserCallbackPayload{Msg: &api.Message{Body: api.MessageBody{MessageId: "abcd", DonId: "my_don"}}, ErrCode: api.NoError}
+	require.Equal(t, http.StatusOK, <-responses)
+	select {
+	case <-closed:
+	case <-ctx.Done():
+		t.Fatal("old handler not closed after draining")
+	}
+}
+
+func TestGateway_UpdateDON_MembersOnlyKeepsHandler(t *testing.T) {
+	t.Parallel()
+
+	cfg := parseTOMLConfig(t, buildConfig(`
+[[dons]]
+DonId = "my_don"
+HandlerName = "mock"
+`))
+	handler := handler_mocks.NewHandler(t)
+	handler.On("Start", mock.Anything).Return(nil)
+	handler.On("Close").Return(nil)
+	factory := &testHandlerFactory{handlers: make(chan handlers.Handler, 1)}
+	factory.handlers <- handler
+	lggr := logger.TestLogger(t)
+	gw, err := gateway.NewGatewayFromConfig(cfg, factory, lggr)
+	require.NoError(t, err)
+	servicetest.Run(t, gw)
+	ctx := testutils.Context(t)
+
+	// a second handler would be created by a rebuild
+	factory.handlers <- handler_mocks.NewHandler(t)
+	members := []config.NodeConfig{{Name: "node one", Address: "0x0001020304050607080900010203040506070809"}}
+	updater := gw.(gateway.DONUpdater)
+	require.NoError(t, updater.UpdateDON(ctx, "my_don", gateway.DONUpdate{Members: members}))
+	require.Len(t, factory.handlers, 1)
+
+	description, err := updater.DescribeDON("my_don")
+	require.NoError(t, err)
+	require.Equal(t, members, description.Members)
+}
@@ -33,6 +33,9 @@ const (
 type Message struct {
 	Signature string      `json:"signature"`
 	Body      MessageBody `json:"body"`
+	// AuthenticatedSender is set by Gateways on the user messages they authenticated with other credentials than
+	// a signature, e.g. an API key or a JWT. Nodes only trust it if they are configured to.
+	AuthenticatedSender string `json:"authenticated_sender,omitempty"`
 }
 
 type MessageBody struct {
@@ -48,12 +51,26 @@ type MessageBody struct {
 }
 
 func (m *Message) Validate() error {
-	if m == nil {
-		return errors.New("nil message")
+	if err := m.ValidateBody(); err != nil {
+		return err
 	}
 	if len(m.Signature) != MessageSignatureHexEncodedLen {
 		return errors.New("invalid hex-encoded signature length")
 	}
+	signerBytes, err := m.ExtractSigner()
+	if err != nil {
+		return err
+	}
+	m.Body.Sender = utils.StringToHex(string(signerBytes))
+	return nil
+}
+
+// ValidateBody validates the fields of the message body, but not the signature, e.g. of messages authenticated by
+// a Gateway.
+func (m *Message) ValidateBody() error {
+	if m == nil {
+		return errors.New("nil message")
+	}
 	if len(m.Body.MessageId) == 0 || len(m.Body.MessageId) > MessageIdMaxLen {
 		return errors.New("invalid message ID length")
 	}
@@ -75,11 +92,6 @@ func (m *Message) Validate() error {
 	if len(m.Body.Receiver) != 0 && len(m.Body.Receiver) != MessageReceiverLen {
 		return errors.New("invalid Receiver length")
 	}
-	signerBytes, err := m.ExtractSigner()
-	if err != nil {
-		return err
-	}
-	m.Body.Sender = utils.StringToHex(string(signerBytes))
 	return nil
 }
 
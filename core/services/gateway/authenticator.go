@@ -0,0 +1,295 @@
+package gateway
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jonboulle/clockwork"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
+)
+
+// APIKeyHeader is the header of the API keys of users.
+const APIKeyHeader = "X-API-Key"
+
+// ErrNoCredentials is returned by authenticators when the request carries none of the credentials they accept.
+var ErrNoCredentials = errors.New("missing credentials")
+
+// Authenticator authenticates the unsigned user messages of a DON with other credentials than signatures.
+type Authenticator interface {
+	// Authenticate returns the sender identity mapped to the credentials of the request header.
+	Authenticate(header http.Header) (sender string, err error)
+}
+
+// NewAuthenticatorFromConfig returns an authenticator accepting the credentials configured for a DON, or nil if no
+// credentials are configured, in which case only signed messages are accepted.
+func NewAuthenticatorFromConfig(cfg config.AuthConfig, clock clockwork.Clock) (Authenticator, error) {
+	var authenticators multiAuthenticator
+	if len(cfg.APIKeys) > 0 {
+		a, err := newAPIKeyAuthenticator(cfg.APIKeys)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API keys: %w", err)
+		}
+		authenticators = append(authenticators, a)
+	}
+	if cfg.JWT != nil {
+		a, err := newJWTAuthenticator(*cfg.JWT, clock)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT config: %w", err)
+		}
+		authenticators = append(authenticators, a)
+	}
+	if len(authenticators) == 0 {
+		return nil, nil
+	}
+	return authenticators, nil
+}
+
+// multiAuthenticator authenticates requests with the first authenticator whose credentials they carry.
+type multiAuthenticator []Authenticator
+
+func (m multiAuthenticator) Authenticate(header http.Header) (string, error) {
+	for _, a := range m {
+		sender, err := a.Authenticate(header)
+		if !errors.Is(err, ErrNoCredentials) {
+			return sender, err
+		}
+	}
+	return "", ErrNoCredentials
+}
+
+func normalizeSender(sender string) (string, error) {
+	if !common.IsHexAddress(sender) {
+		return "", fmt.Errorf("invalid sender %q, must be a hex-encoded address", sender)
+	}
+	return strings.ToLower(common.HexToAddress(sender).Hex()), nil
+}
+
+// apiKeyAuthenticator authenticates users by static API keys. Only the hashes of the keys are configured.
+type apiKeyAuthenticator struct {
+	senders map[[sha256.Size]byte]string
+}
+
+func newAPIKeyAuthenticator(keys []config.APIKeyConfig) (*apiKeyAuthenticator, error) {
+	a := &apiKeyAuthenticator{senders: make(map[[sha256.Size]byte]string, len(keys))}
+	for _, key := range keys {
+		hash, err := parseKeyHash(key.KeyHash)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := a.senders[hash]; ok {
+			return nil, fmt.Errorf("duplicate key hash %q", key.KeyHash)
+		}
+		sender, err := normalizeSender(key.Sender)
+		if err != nil {
+			return nil, err
+		}
+		a.senders[hash] = sender
+	}
+	return a, nil
+}
+
+func parseKeyHash(keyHash string) ([sha256.Size]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(keyHash, "0x"))
+	if err != nil || len(b) != sha256.Size {
+		return [sha256.Size]byte{}, fmt.Errorf("invalid key hash %q, must be a hex-encoded SHA-256 hash", keyHash)
+	}
+	return [sha256.Size]byte(b), nil
+}
+
+func (a *apiKeyAuthenticator) Authenticate(header http.Header) (string, error) {
+	key := header.Get(APIKeyHeader)
+	if key == "" {
+		return "", ErrNoCredentials
+	}
+	sender, ok := a.senders[sha256.Sum256([]byte(key))]
+	if !ok {
+		return "", errors.New("invalid API key")
+	}
+	return sender, nil
+}
+
+// jwtAuthenticator authenticates users by JWTs signed by one of the keys of a local JWKS file. The file is read
+// once, when the gateway is created.
+type jwtAuthenticator struct {
+	keys    map[string]jwk
+	senders map[string]string
+	parser  *jwt.Parser
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+
+	publicKey crypto.PublicKey
+}
+
+func newJWTAuthenticator(cfg config.JWTAuthConfig, clock clockwork.Clock) (*jwtAuthenticator, error) {
+	b, err := os.ReadFile(cfg.JWKSPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.Unmarshal(b, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+	if len(jwks.Keys) == 0 {
+		return nil, errors.New("JWKS has no keys")
+	}
+	keys := make(map[string]jwk, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if key.publicKey, err = key.parsePublicKey(); err != nil {
+			return nil, fmt.Errorf("invalid JWK %q: %w", key.Kid, err)
+		}
+		if _, ok := keys[key.Kid]; ok {
+			return nil, fmt.Errorf("duplicate JWK %q", key.Kid)
+		}
+		keys[key.Kid] = key
+	}
+
+	if len(cfg.Senders) == 0 {
+		return nil, errors.New("no senders")
+	}
+	senders := make(map[string]string, len(cfg.Senders))
+	for subject, sender := range cfg.Senders {
+		if senders[subject], err = normalizeSender(sender); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(clock.Now),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	return &jwtAuthenticator{keys: keys, senders: senders, parser: jwt.NewParser(opts...)}, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(header http.Header) (string, error) {
+	token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return "", ErrNoCredentials
+	}
+	var claims jwt.RegisteredClaims
+	if _, err := a.parser.ParseWithClaims(token, &claims, a.key); err != nil {
+		return "", fmt.Errorf("invalid JWT: %w", err)
+	}
+	sender, ok := a.senders[claims.Subject]
+	if !ok {
+		return "", fmt.Errorf("unknown JWT subject %q", claims.Subject)
+	}
+	return sender, nil
+}
+
+// key returns the key verifying the token: the one with its key ID or, without key ID, the only key of the JWKS.
+func (a *jwtAuthenticator) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := a.keys[kid]
+	if !ok && kid == "" && len(a.keys) == 1 {
+		for _, k := range a.keys {
+			key, ok = k, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if key.Alg != "" && key.Alg != token.Method.Alg() {
+		return nil, fmt.Errorf("key %q is not for %s", kid, token.Method.Alg())
+	}
+	return key.publicKey, nil
+}
+
+func (k jwk) parsePublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y: %w", err)
+		}
+		//nolint:staticcheck // IsOnCurve is the only way to validate the coordinates of a JWK
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid x")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,192 @@
+package gateway_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
+)
+
+const (
+	testSender1 = "0x0001020304050607080900010203040506070809"
+	testSender2 = "0x00000000000000000000000000000000000000aa"
+)
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func apiKeyHeader(key string) http.Header {
+	header := http.Header{}
+	header.Set(gateway.APIKeyHeader, key)
+	return header
+}
+
+func TestAuthenticator_APIKeys(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := gateway.NewAuthenticatorFromConfig(config.AuthConfig{
+		APIKeys: []config.APIKeyConfig{
+			{KeyHash: hashAPIKey("key-1"), Sender: testSender1},
+			{KeyHash: "0x" + hashAPIKey("key-2"), Sender: "0x00000000000000000000000000000000000000AA"},
+		},
+	}, clockwork.NewFakeClock())
+	require.NoError(t, err)
+
+	for key, expected := range map[string]string{"key-1": testSender1, "key-2": testSender2} {
+		sender, err := authenticator.Authenticate(apiKeyHeader(key))
+		require.NoError(t, err)
+		assert.Equal(t, expected, sender)
+	}
+
+	_, err = authenticator.Authenticate(apiKeyHeader("key-3"))
+	require.ErrorContains(t, err, "invalid API key")
+	_, err = authenticator.Authenticate(http.Header{})
+	require.ErrorIs(t, err, gateway.ErrNoCredentials)
+}
+
+func TestAuthenticator_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	jwksPath := writeJWKS(t, map[string]any{"kid": "key-1", "kty": "OKP", "crv": "Ed25519", "x": base64.RawURLEncoding.EncodeToString(make([]byte, ed25519.PublicKeySize))})
+
+	for name, cfg := range map[string]config.AuthConfig{
+		"invalid key hash":   {APIKeys: []config.APIKeyConfig{{KeyHash: "abcd", Sender: testSender1}}},
+		"duplicate key":      {APIKeys: []config.APIKeyConfig{{KeyHash: hashAPIKey("key"), Sender: testSender1}, {KeyHash: hashAPIKey("key"), Sender: testSender2}}},
+		"invalid sender":     {APIKeys: []config.APIKeyConfig{{KeyHash: hashAPIKey("key"), Sender: "backend-service"}}},
+		"missing JWKS":       {JWT: &config.JWTAuthConfig{JWKSPath: filepath.Join(t.TempDir(), "jwks.json"), Senders: map[string]string{"service": testSender1}}},
+		"no JWT senders":     {JWT: &config.JWTAuthConfig{JWKSPath: jwksPath}},
+		"invalid JWT sender": {JWT: &config.JWTAuthConfig{JWKSPath: jwksPath, Senders: map[string]string{"service": "0x01"}}},
+	} {
+		_, err := gateway.NewAuthenticatorFromConfig(cfg, clockwork.NewFakeClock())
+		require.Error(t, err, name)
+	}
+
+	authenticator, err := gateway.NewAuthenticatorFromConfig(config.AuthConfig{}, clockwork.NewFakeClock())
+	require.NoError(t, err)
+	require.Nil(t, authenticator)
+}
+
+func writeJWKS(t *testing.T, keys ...map[string]any) string {
+	b, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, b, 0600))
+	return path
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func TestAuthenticator_JWT(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwksPath := writeJWKS(t,
+		map[string]any{"kid": "rsa", "kty": "RSA", "alg": "RS256", "use": "sig", "n": encodeBigInt(rsaKey.N), "e": encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+		map[string]any{"kid": "ec", "kty": "EC", "crv": "P-256", "x": encodeBigInt(ecKey.X), "y": encodeBigInt(ecKey.Y)},
+		map[string]any{"kid": "ed", "kty": "OKP", "crv": "Ed25519", "x": base64.RawURLEncoding.EncodeToString(edPublicKey)},
+	)
+	clock := clockwork.NewFakeClockAt(time.Unix(1700000000, 0))
+	authenticator, err := gateway.NewAuthenticatorFromConfig(config.AuthConfig{
+		JWT: &config.JWTAuthConfig{
+			JWKSPath: jwksPath,
+			Issuer:   "https://issuer.example.com",
+			Audience: "gateway",
+			Senders:  map[string]string{"service-1": testSender1, "service-2": testSender2},
+		},
+	}, clock)
+	require.NoError(t, err)
+
+	newToken := func(method jwt.SigningMethod, kid string, key crypto.Signer, claims jwt.RegisteredClaims) http.Header {
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return http.Header{"Authorization": {"Bearer " + signed}}
+	}
+	validClaims := func(subject string) jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    "https://issuer.example.com",
+			Audience:  jwt.ClaimStrings{"gateway"},
+			ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Minute)),
+		}
+	}
+
+	t.Run("valid tokens", func(t *testing.T) {
+		sender, err := authenticator.Authenticate(newToken(jwt.SigningMethodRS256, "rsa", rsaKey, validClaims("service-1")))
+		require.NoError(t, err)
+		assert.Equal(t, testSender1, sender)
+
+		sender, err = authenticator.Authenticate(newToken(jwt.SigningMethodES256, "ec", ecKey, validClaims("service-2")))
+		require.NoError(t, err)
+		assert.Equal(t, testSender2, sender)
+
+		sender, err = authenticator.Authenticate(newToken(jwt.SigningMethodEdDSA, "ed", edKey, validClaims("service-1")))
+		require.NoError(t, err)
+		assert.Equal(t, testSender1, sender)
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		expired := validClaims("service-1")
+		expired.ExpiresAt = jwt.NewNumericDate(clock.Now().Add(-time.Minute))
+		noExpiry := validClaims("service-1")
+		noExpiry.ExpiresAt = nil
+		otherAudience := validClaims("service-1")
+		otherAudience.Audience = jwt.ClaimStrings{"other"}
+		otherIssuer := validClaims("service-1")
+		otherIssuer.Issuer = "https://other.example.com"
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		for name, header := range map[string]http.Header{
+			"expired":         newToken(jwt.SigningMethodRS256, "rsa", rsaKey, expired),
+			"no expiry":       newToken(jwt.SigningMethodRS256, "rsa", rsaKey, noExpiry),
+			"other audience":  newToken(jwt.SigningMethodRS256, "rsa", rsaKey, otherAudience),
+			"other issuer":    newToken(jwt.SigningMethodRS256, "rsa", rsaKey, otherIssuer),
+			"unknown subject": newToken(jwt.SigningMethodRS256, "rsa", rsaKey, validClaims("service-3")),
+			"unknown key":     newToken(jwt.SigningMethodRS256, "other", rsaKey, validClaims("service-1")),
+			"wrong signer":    newToken(jwt.SigningMethodRS256, "rsa", otherKey, validClaims("service-1")),
+			"wrong algorithm": newToken(jwt.SigningMethodRS512, "rsa", rsaKey, validClaims("service-1")),
+			"malformed":       {"Authorization": {"Bearer abc.def.ghi"}},
+		} {
+			_, err := authenticator.Authenticate(header)
+			require.Error(t, err, name)
+			require.NotErrorIs(t, err, gateway.ErrNoCredentials, name)
+		}
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		_, err := authenticator.Authenticate(http.Header{"Authorization": {"Basic dXNlcjpwYXNz"}})
+		require.ErrorIs(t, err, gateway.ErrNoCredentials)
+	})
+}
@@ -12,7 +12,17 @@ type GatewayConfig struct {
 	ConnectionManagerConfig ConnectionManagerConfig
 	// HTTPClientConfig is configuration for outbound HTTP calls to external endpoints
 	HTTPClientConfig gw_net.HTTPClientConfig
-	Dons             []DONConfig
+	// AdminServerConfig enables the admin API changing the members and handler configs of DONs without restarting
+	// the gateway, if set.
+	AdminServerConfig *AdminServerConfig
+	Dons              []DONConfig
+}
+
+type AdminServerConfig struct {
+	Host string
+	Port uint16
+	// APIKeyHashes are the hex-encoded SHA-256 hashes of the API keys of admins, in the "X-API-Key" header.
+	APIKeyHashes []string
 }
 
 type ConnectionManagerConfig struct {
@@ -28,6 +38,33 @@ type DONConfig struct {
 	HandlerConfig json.RawMessage
 	Members       []NodeConfig
 	F             int
+	// Auth configures the authentication of unsigned user messages. Signed messages are always accepted.
+	Auth AuthConfig
+}
+
+// AuthConfig configures the credentials accepted instead of signatures, each one mapped to the sender identity
+// passed to handlers. Sender identities are hex-encoded addresses, like the signers of signed messages.
+type AuthConfig struct {
+	JWT     *JWTAuthConfig
+	APIKeys []APIKeyConfig
+}
+
+// JWTAuthConfig configures the authentication of users by JWTs, in the "Authorization: Bearer" header.
+type JWTAuthConfig struct {
+	// JWKSPath is the path to a local JWKS file with the public keys verifying the JWTs.
+	JWKSPath string
+	// Issuer and Audience are required to match the claims of the JWTs, if set.
+	Issuer   string
+	Audience string
+	// Senders maps the subjects of the JWTs to sender identities. Other subjects are rejected.
+	Senders map[string]string
+}
+
+// APIKeyConfig configures a static API key, in the "X-API-Key" header.
+type APIKeyConfig struct {
+	// KeyHash is the hex-encoded SHA-256 hash of the API key, so that keys aren't stored in job specs.
+	KeyHash string
+	Sender  string
 }
 
 type NodeConfig struct {
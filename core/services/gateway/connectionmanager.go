@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -57,9 +59,11 @@ type connectionManager struct {
 func (m *connectionManager) HealthReport() map[string]error {
 	hr := map[string]error{m.Name(): m.Healthy()}
 	for _, d := range m.dons {
+		d.mu.RLock()
 		for _, n := range d.nodes {
 			services.CopyHealth(hr, n.conn.HealthReport())
 		}
+		d.mu.RUnlock()
 	}
 	return hr
 }
@@ -67,18 +71,45 @@ func (m *connectionManager) HealthReport() map[string]error {
 func (m *connectionManager) Name() string { return m.lggr.Name() }
 
 type donConnectionManager struct {
-	donConfig  *config.DONConfig
-	nodes      map[string]*nodeState
-	handler    handlers.Handler
+	donConfig *config.DONConfig
+	nodes     map[string]*nodeState
+	members   []config.NodeConfig
+	handler   handlers.Handler
+	// draining are the replaced handlers, which still receive the node messages of the requests pending in them
+	draining   []handlers.Handler
+	mu         sync.RWMutex // guards nodes, members and handlers, which change with the updates of the DON
 	codec      api.Codec
 	closeWait  sync.WaitGroup
 	shutdownCh services.StopChan
+	started    bool
 	lggr       logger.Logger
 }
 
 type nodeState struct {
 	name string
 	conn network.WSConnectionWrapper
+	// stopCh is closed when the node is removed from the DON
+	stopCh services.StopChan
+}
+
+// withName returns a copy of the node state with the given name, sharing its connection.
+func (n *nodeState) withName(name string) *nodeState {
+	return &nodeState{name: name, conn: n.conn, stopCh: n.stopCh}
+}
+
+// stop stops the read loop of the node and closes its connection.
+func (n *nodeState) stop() {
+	close(n.stopCh)
+	n.conn.Close()
+}
+
+func (n *nodeState) removed() bool {
+	select {
+	case <-n.stopCh:
+		return true
+	default:
+		return false
+	}
 }
 
 // immutable
@@ -101,26 +132,19 @@ func NewConnectionManager(gwConfig *config.GatewayConfig, clock clockwork.Clock,
 		if ok {
 			return nil, fmt.Errorf("duplicate DON ID %s", donConfig.DonId)
 		}
+		if err := validateMembers(donConfig.Members); err != nil {
+			return nil, fmt.Errorf("%w in DON %s", err, donConfig.DonId)
+		}
 		nodes := make(map[string]*nodeState)
 		for _, nodeConfig := range donConfig.Members {
 			nodeAddress := strings.ToLower(nodeConfig.Address)
-			_, ok := nodes[nodeAddress]
-			if ok {
-				return nil, fmt.Errorf("duplicate node address %s in DON %s", nodeAddress, donConfig.DonId)
-			}
-			connWrapper := network.NewWSConnectionWrapper(lggr)
-			if connWrapper == nil {
-				return nil, fmt.Errorf("error creating WSConnectionWrapper for node %s", nodeAddress)
-			}
-			nodes[nodeAddress] = &nodeState{
-				name: nodeConfig.Name,
-				conn: connWrapper,
-			}
+			nodes[nodeAddress] = newNodeState(nodeConfig.Name, lggr)
 		}
 		dons[donConfig.DonId] = &donConnectionManager{
 			donConfig:  &donConfig,
 			codec:      codec,
 			nodes:      nodes,
+			members:    normalizedMembers(donConfig.Members),
 			shutdownCh: make(chan struct{}),
 			lggr:       lggr.Named("DONConnectionManager." + donConfig.DonId),
 		}
@@ -137,6 +161,27 @@ func NewConnectionManager(gwConfig *config.GatewayConfig, clock clockwork.Clock,
 	return connMgr, nil
 }
 
+// validateMembers returns an error if the members of a DON have duplicate addresses.
+func validateMembers(members []config.NodeConfig) error {
+	addresses := make(map[string]struct{}, len(members))
+	for _, nodeConfig := range members {
+		nodeAddress := strings.ToLower(nodeConfig.Address)
+		if _, ok := addresses[nodeAddress]; ok {
+			return fmt.Errorf("duplicate node address %s", nodeAddress)
+		}
+		addresses[nodeAddress] = struct{}{}
+	}
+	return nil
+}
+
+func newNodeState(name string, lggr logger.Logger) *nodeState {
+	return &nodeState{
+		name:   name,
+		conn:   network.NewWSConnectionWrapper(lggr),
+		stopCh: make(services.StopChan),
+	}
+}
+
 func (m *connectionManager) DONConnectionManager(donId string) *donConnectionManager {
 	return m.dons[donId]
 }
@@ -145,13 +190,15 @@ func (m *connectionManager) Start(ctx context.Context) error {
 	return m.StartOnce("ConnectionManager", func() error {
 		m.lggr.Info("starting connection manager")
 		for _, donConnMgr := range m.dons {
-			donConnMgr.closeWait.Add(len(donConnMgr.nodes))
+			donConnMgr.mu.Lock()
 			for nodeAddress, nodeState := range donConnMgr.nodes {
-				if err := nodeState.conn.Start(ctx); err != nil {
+				if err := donConnMgr.startNode(ctx, nodeAddress, nodeState); err != nil {
+					donConnMgr.mu.Unlock()
 					return err
 				}
-				go donConnMgr.readLoop(nodeAddress, nodeState)
 			}
+			donConnMgr.started = true
+			donConnMgr.mu.Unlock()
 			donConnMgr.closeWait.Add(1)
 			go donConnMgr.keepaliveLoop(m.config.HeartbeatIntervalSec)
 		}
@@ -165,9 +212,11 @@ func (m *connectionManager) Close() error {
 		err = multierr.Combine(err, m.wsServer.Close())
 		for _, donConnMgr := range m.dons {
 			close(donConnMgr.shutdownCh)
+			donConnMgr.mu.RLock()
 			for _, nodeState := range donConnMgr.nodes {
 				nodeState.conn.Close()
 			}
+			donConnMgr.mu.RUnlock()
 		}
 		for _, donConnMgr := range m.dons {
 			donConnMgr.closeWait.Wait()
@@ -187,7 +236,9 @@ func (m *connectionManager) StartHandshake(authHeader []byte) (attemptId string,
 	if !ok {
 		return "", nil, network.ErrAuthInvalidDonId
 	}
+	donConnMgr.mu.RLock()
 	nodeState, ok := donConnMgr.nodes[nodeAddress]
+	donConnMgr.mu.RUnlock()
 	if !ok {
 		return "", nil, network.ErrAuthInvalidNode
 	}
@@ -234,6 +285,9 @@ func (m *connectionManager) FinalizeHandshake(attemptId string, response []byte,
 	if err != nil || attempt.nodeAddress != "0x"+hex.EncodeToString(signer) {
 		return network.ErrChallengeInvalidSignature
 	}
+	if attempt.nodeState.removed() {
+		return network.ErrAuthInvalidNode
+	}
 	if conn != nil {
 		conn.SetPongHandler(func(data string) error {
 			m.lggr.Debugw("received keepalive pong from node", "nodeAddress", attempt.nodeAddress)
@@ -257,9 +311,108 @@ func (m *connectionManager) GetPort() int {
 }
 
 func (m *donConnectionManager) SetHandler(handler handlers.Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.handler = handler
 }
 
+// ReplaceHandler sets the handler of the DON. The replaced handler keeps receiving the node messages, which answer
+// the requests pending in it, until release is called.
+func (m *donConnectionManager) ReplaceHandler(handler handlers.Handler) (release func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	old := m.handler
+	m.handler = handler
+	if old == nil {
+		return func() {}
+	}
+	m.draining = append(m.draining, old)
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.draining = slices.DeleteFunc(m.draining, func(h handlers.Handler) bool { return h == old })
+	}
+}
+
+// getHandlers returns the handler of the DON, followed by the replaced handlers being drained.
+func (m *donConnectionManager) getHandlers() (handlers.Handler, []handlers.Handler) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.handler, slices.Clone(m.draining)
+}
+
+// Members returns the current members of the DON, with lower-case addresses.
+func (m *donConnectionManager) Members() []config.NodeConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.members)
+}
+
+func normalizedMembers(members []config.NodeConfig) []config.NodeConfig {
+	normalized := make([]config.NodeConfig, len(members))
+	for i, nodeConfig := range members {
+		normalized[i] = config.NodeConfig{Name: nodeConfig.Name, Address: strings.ToLower(nodeConfig.Address)}
+	}
+	return normalized
+}
+
+// SetMembers changes the members of the DON. Connections to the nodes staying in the DON are kept open, the nodes
+// removed from the DON are disconnected and the nodes added to the DON can connect. If a node fails to start, the
+// members are unchanged.
+func (m *donConnectionManager) SetMembers(ctx context.Context, members []config.NodeConfig) error {
+	if err := validateMembers(members); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nodes := make(map[string]*nodeState, len(members))
+	var added []*nodeState
+	for _, nodeConfig := range members {
+		nodeAddress := strings.ToLower(nodeConfig.Address)
+		if nodeState, ok := m.nodes[nodeAddress]; ok {
+			// the node state is replaced rather than renamed, as it is read without the lock once looked up
+			nodes[nodeAddress] = nodeState.withName(nodeConfig.Name)
+			continue
+		}
+		nodeState := newNodeState(nodeConfig.Name, m.lggr)
+		if m.started {
+			if err := m.startNode(ctx, nodeAddress, nodeState); err != nil {
+				for _, addedState := range added {
+					addedState.stop()
+				}
+				return fmt.Errorf("failed to start node %s: %w", nodeAddress, err)
+			}
+		}
+		added = append(added, nodeState)
+		nodes[nodeAddress] = nodeState
+	}
+	for nodeAddress, nodeState := range nodes {
+		if _, ok := m.nodes[nodeAddress]; !ok {
+			m.lggr.Infow("added node", "nodeAddress", nodeAddress, "name", nodeState.name)
+		}
+	}
+	for nodeAddress, nodeState := range m.nodes {
+		if _, ok := nodes[nodeAddress]; !ok {
+			nodeState.stop()
+			m.lggr.Infow("removed node", "nodeAddress", nodeAddress, "name", nodeState.name)
+		}
+	}
+	m.nodes = nodes
+	m.members = normalizedMembers(members)
+	return nil
+}
+
+// startNode starts the connection of the node and its read loop. Must be called with mu held.
+func (m *donConnectionManager) startNode(ctx context.Context, nodeAddress string, nodeState *nodeState) error {
+	if err := nodeState.conn.Start(ctx); err != nil {
+		return err
+	}
+	m.closeWait.Add(1)
+	go m.readLoop(nodeAddress, nodeState)
+	return nil
+}
+
 func (m *donConnectionManager) SendToNode(ctx context.Context, nodeAddress string, msg *api.Message) error {
 	if msg == nil {
 		return errors.New("nil message")
@@ -268,7 +421,9 @@ func (m *donConnectionManager) SendToNode(ctx context.Context, nodeAddress strin
 	if err != nil {
 		return fmt.Errorf("error encoding request for node %s: %v", nodeAddress, err)
 	}
+	m.mu.RLock()
 	nodeState := m.nodes[nodeAddress]
+	m.mu.RUnlock()
 	if nodeState == nil {
 		return fmt.Errorf("node %s not found", nodeAddress)
 	}
@@ -282,6 +437,9 @@ func (m *donConnectionManager) readLoop(nodeAddress string, nodeState *nodeState
 		case <-m.shutdownCh:
 			m.closeWait.Done()
 			return
+		case <-nodeState.stopCh:
+			m.closeWait.Done()
+			return
 		case item := <-nodeState.conn.ReadChannel():
 			msg, err := m.codec.DecodeResponse(item.Data)
 			if err != nil {
@@ -296,10 +454,17 @@ func (m *donConnectionManager) readLoop(nodeAddress string, nodeState *nodeState
 				m.lggr.Errorw("message sender mismatch when reading from node", "nodeAddress", nodeAddress, "sender", msg.Body.Sender)
 				break
 			}
-			err = m.handler.HandleNodeMessage(ctx, msg, nodeAddress)
+			handler, draining := m.getHandlers()
+			err = handler.HandleNodeMessage(ctx, msg, nodeAddress)
 			if err != nil {
 				m.lggr.Error("error when calling HandleNodeMessage ", err)
 			}
+			for _, drainingHandler := range draining {
+				// the message answers a request pending in at most one of the handlers, the others reject it
+				if err = drainingHandler.HandleNodeMessage(ctx, msg, nodeAddress); err != nil {
+					m.lggr.Debugw("replaced handler rejected node message", "nodeAddress", nodeAddress, "err", err)
+				}
+			}
 		}
 	}
 }
@@ -323,14 +488,17 @@ func (m *donConnectionManager) keepaliveLoop(intervalSec uint32) {
 			return
 		case <-keepaliveTicker.C:
 			errorCount := 0
-			for nodeAddress, nodeState := range m.nodes {
+			m.mu.RLock()
+			nodes := maps.Clone(m.nodes)
+			m.mu.RUnlock()
+			for nodeAddress, nodeState := range nodes {
 				err := nodeState.conn.Write(ctx, websocket.PingMessage, []byte{})
 				if err != nil {
 					m.lggr.Debugw("unable to send keepalive ping to node", "nodeAddress", nodeAddress, "name", nodeState.name, "donID", m.donConfig.DonId, "err", err)
 					errorCount++
 				}
 			}
-			promKeepalivesSent.WithLabelValues(m.donConfig.DonId).Set(float64(len(m.nodes) - errorCount))
+			promKeepalivesSent.WithLabelValues(m.donConfig.DonId).Set(float64(len(nodes) - errorCount))
 			m.lggr.Infow("sent keepalive pings to nodes", "donID", m.donConfig.DonId, "errCount", errorCount)
 		}
 	}
@@ -3,6 +3,7 @@ package gateway_test
 import (
 	"crypto/ecdsa"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/jonboulle/clockwork"
@@ -242,3 +243,50 @@ func TestConnectionManager_CleanStartClose(t *testing.T) {
 	err = mgr.Close()
 	require.NoError(t, err)
 }
+
+func TestConnectionManager_SetMembers(t *testing.T) {
+	t.Parallel()
+
+	cfg, nodes := newTestConfig(t, 2)
+	newNode := gc.NewTestNodes(t, 1)[0]
+	clock := clockwork.NewFakeClock()
+	mgr, err := gateway.NewConnectionManager(cfg, clock, logger.TestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, mgr.Start(testutils.Context(t)))
+	t.Cleanup(func() { require.NoError(t, mgr.Close()) })
+
+	donMgr := mgr.DONConnectionManager("my_don_1")
+	err = donMgr.SetMembers(testutils.Context(t), []config.NodeConfig{
+		{Name: "node_1", Address: nodes[1].Address},
+		{Name: "node_1_again", Address: nodes[1].Address},
+	})
+	require.ErrorContains(t, err, "duplicate node address")
+
+	require.NoError(t, donMgr.SetMembers(testutils.Context(t), []config.NodeConfig{
+		{Name: "node_1", Address: nodes[1].Address},
+		{Name: "new_node", Address: newNode.Address},
+	}))
+	require.Equal(t, []config.NodeConfig{
+		{Name: "node_1", Address: strings.ToLower(nodes[1].Address)},
+		{Name: "new_node", Address: strings.ToLower(newNode.Address)},
+	}, donMgr.Members())
+
+	authHeaderElems := network.AuthHeaderElems{
+		Timestamp: uint32(clock.Now().Unix()),
+		DonId:     "my_don_1",
+		GatewayId: "my_gateway_no_3",
+	}
+	// removed node
+	_, _, err = mgr.StartHandshake(signAndPackAuthHeader(t, &authHeaderElems, nodes[0].PrivateKey))
+	require.ErrorIs(t, err, network.ErrAuthInvalidNode)
+	require.ErrorContains(t, donMgr.SendToNode(testutils.Context(t), nodes[0].Address, &api.Message{}), "not found")
+
+	// kept and added nodes
+	for _, node := range []gc.TestNode{nodes[1], newNode} {
+		attemptId, challenge, err := mgr.StartHandshake(signAndPackAuthHeader(t, &authHeaderElems, node.PrivateKey))
+		require.NoError(t, err)
+		response, err := gc.SignData(node.PrivateKey, challenge)
+		require.NoError(t, err)
+		require.NoError(t, mgr.FinalizeHandshake(attemptId, response, nil))
+	}
+}
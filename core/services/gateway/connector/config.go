@@ -11,9 +11,16 @@ type ConnectorConfig struct {
 	WsClientConfig            network.WebSocketClientConfig
 	AuthMinChallengeLen       int
 	AuthTimestampToleranceSec uint32
+	// AllowGatewayAuthenticatedSenders accepts unsigned user messages from Gateways, with the sender they
+	// authenticated by other credentials than a signature, e.g. an API key or a JWT.
+	AllowGatewayAuthenticatedSenders bool
+	// LatencyProbeIntervalSec is the interval of the pings measuring the latency of the Gateways, 10s if unset.
+	LatencyProbeIntervalSec uint32
 }
 
 type ConnectorGatewayConfig struct {
 	Id  string
 	URL string
+	// Weight is the relative share of the messages sent to the Gateway by SendToAnyGateway, 1 if unset.
+	Weight uint32
 }
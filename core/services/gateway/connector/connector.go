@@ -1,13 +1,20 @@
 package connector
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/websocket"
 	"github.com/jonboulle/clockwork"
 
@@ -31,6 +38,14 @@ type GatewayConnector interface {
 	SendToGateway(ctx context.Context, gatewayID string, msg *api.Message) error
 	// SignAndSendToGateway signs the message and sends the message to the specified gateway
 	SignAndSendToGateway(ctx context.Context, gatewayID string, msg *api.MessageBody) error
+	// SendToAnyGateway takes a signed message as argument and sends it to one of the connected gateways, picked at
+	// random by their weight, health and latency, failing over to the others if sending fails. It returns the ID of
+	// the gateway the message was sent to.
+	SendToAnyGateway(ctx context.Context, msg *api.Message) (gatewayID string, err error)
+	// SignAndSendToAnyGateway signs the message and sends it like SendToAnyGateway
+	SignAndSendToAnyGateway(ctx context.Context, msg *api.MessageBody) (gatewayID string, err error)
+	// GatewayStats returns the health and latency of each gateway, by ID
+	GatewayStats() map[string]GatewayStats
 	// GatewayIDs returns the list of Gateway IDs
 	GatewayIDs() []string
 	// DonID returns the DON ID
@@ -78,19 +93,47 @@ func (c *gatewayConnector) HealthReport() map[string]error {
 
 func (c *gatewayConnector) Name() string { return c.lggr.Name() }
 
-type gatewayState struct {
-	// signal channel is closed once the gateway is connected
-	signalCh chan struct{}
+// GatewayStats is the health and latency of a gateway, as tracked by the connector.
+type GatewayStats struct {
+	Connected bool
+	// Latency is the moving average of the round trips of pings to the gateway, 0 until measured.
+	Latency time.Duration
+	// ConsecutiveFailures counts the failed sends to the gateway since the last successful one or reconnection.
+	ConsecutiveFailures int
+}
 
+type gatewayState struct {
 	conn     network.WSConnectionWrapper
 	config   ConnectorGatewayConfig
 	url      *url.URL
 	wsClient network.WebSocketClient
+
+	mu sync.Mutex
+	// signal channel is closed once the gateway is connected
+	signalCh chan struct{}
+	stats    GatewayStats
 }
 
 // A gatewayState is connected when the signal channel is closed
 func (gs *gatewayState) signal() {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
 	close(gs.signalCh)
+	gs.stats.Connected = true
+	gs.stats.ConsecutiveFailures = 0
+}
+
+func (gs *gatewayState) disconnected() {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	gs.signalCh = make(chan struct{})
+	gs.stats.Connected = false
+}
+
+func (gs *gatewayState) signalChannel() <-chan struct{} {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	return gs.signalCh
 }
 
 // awaitConn blocks until the gateway is connected or the context is done
@@ -98,11 +141,47 @@ func (gs *gatewayState) awaitConn(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
 		return fmt.Errorf("await connection failed: %w", ctx.Err())
-	case <-gs.signalCh:
+	case <-gs.signalChannel():
 		return nil
 	}
 }
 
+func (gs *gatewayState) getStats() GatewayStats {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	return gs.stats
+}
+
+// recordSend updates the consecutive failures with the result of a send.
+func (gs *gatewayState) recordSend(err error) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if err != nil {
+		gs.stats.ConsecutiveFailures++
+	} else {
+		gs.stats.ConsecutiveFailures = 0
+	}
+}
+
+// recordLatency adds the round trip of a ping to the moving average of the latency.
+func (gs *gatewayState) recordLatency(roundTrip time.Duration) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if gs.stats.Latency == 0 {
+		gs.stats.Latency = roundTrip
+		return
+	}
+	gs.stats.Latency = time.Duration(latencySmoothing*float64(roundTrip) + (1-latencySmoothing)*float64(gs.stats.Latency))
+}
+
+const (
+	defaultLatencyProbeInterval = 10 * time.Second
+	// latencySmoothing is the weight of the latest round trip in the moving average of the latency
+	latencySmoothing = 0.2
+)
+
+var errNoConnectedGateway = errors.New("no connected gateway")
+
 func NewGatewayConnector(config *ConnectorConfig, signer Signer, clock clockwork.Clock, lggr logger.Logger) (GatewayConnector, error) {
 	if config == nil || signer == nil || clock == nil || lggr == nil {
 		return nil, errors.New("nil dependency")
@@ -194,11 +273,23 @@ func (c *gatewayConnector) SendToGateway(ctx context.Context, gatewayID string,
 }
 
 func (c *gatewayConnector) SignAndSendToGateway(ctx context.Context, gatewayID string, body *api.MessageBody) error {
-	signature, err := c.signer.Sign(api.GetRawMessageBody(body)...)
+	msg, err := c.signMessage(body)
 	if err != nil {
 		return err
 	}
-	msg := &api.Message{
+	err = c.SendToGateway(ctx, gatewayID, msg)
+	if err != nil {
+		return fmt.Errorf("failed to send message to gateway %s: %v", gatewayID, err)
+	}
+	return nil
+}
+
+func (c *gatewayConnector) signMessage(body *api.MessageBody) (*api.Message, error) {
+	signature, err := c.signer.Sign(api.GetRawMessageBody(body)...)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Message{
 		Body: api.MessageBody{
 			MessageId: body.MessageId,
 			DonId:     body.DonId,
@@ -208,13 +299,117 @@ func (c *gatewayConnector) SignAndSendToGateway(ctx context.Context, gatewayID s
 			Sender:    utils.StringToHex(string(c.nodeAddress)),
 		},
 		Signature: utils.StringToHex(string(signature)),
+	}, nil
+}
+
+func (c *gatewayConnector) SendToAnyGateway(ctx context.Context, msg *api.Message) (string, error) {
+	data, err := c.codec.EncodeResponse(msg)
+	if err != nil {
+		return "", fmt.Errorf("error encoding message: %w", err)
+	}
+	gateways := c.selectionOrder()
+	if len(gateways) == 0 {
+		gateway, err := c.awaitAnyConnection(ctx)
+		if err != nil {
+			return "", err
+		}
+		gateways = []*gatewayState{gateway}
+	}
+	var errs error
+	for _, gateway := range gateways {
+		err = gateway.conn.Write(ctx, websocket.BinaryMessage, data)
+		gateway.recordSend(err)
+		if err == nil {
+			return gateway.config.Id, nil
+		}
+		c.lggr.Warnw("failed to send message to gateway, failing over", "id", gateway.config.Id, "err", err)
+		errs = errors.Join(errs, fmt.Errorf("gateway %s: %w", gateway.config.Id, err))
+		if ctx.Err() != nil {
+			break
+		}
 	}
+	return "", fmt.Errorf("failed to send message to any gateway: %w", errs)
+}
 
-	err = c.SendToGateway(ctx, gatewayID, msg)
+func (c *gatewayConnector) SignAndSendToAnyGateway(ctx context.Context, body *api.MessageBody) (string, error) {
+	msg, err := c.signMessage(body)
 	if err != nil {
-		return fmt.Errorf("failed to send message to gateway %s: %v", gatewayID, err)
+		return "", err
 	}
-	return nil
+	return c.SendToAnyGateway(ctx, msg)
+}
+
+// selectionOrder returns the connected gateways in a weighted random order, in which SendToAnyGateway tries them.
+func (c *gatewayConnector) selectionOrder() []*gatewayState {
+	type candidate struct {
+		gateway *gatewayState
+		stats   GatewayStats
+		key     float64
+	}
+	var candidates []candidate
+	var minLatency time.Duration
+	for _, gateway := range c.gateways {
+		stats := gateway.getStats()
+		if !stats.Connected {
+			continue
+		}
+		if stats.Latency > 0 && (minLatency == 0 || stats.Latency < minLatency) {
+			minLatency = stats.Latency
+		}
+		candidates = append(candidates, candidate{gateway: gateway, stats: stats})
+	}
+	// weighted random sampling without replacement, by Efraimidis and Spirakis
+	for i := range candidates {
+		weight := selectionWeight(candidates[i].gateway.config.Weight, candidates[i].stats, minLatency)
+		candidates[i].key = math.Pow(rand.Float64(), 1/weight)
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int { return cmp.Compare(b.key, a.key) })
+	gateways := make([]*gatewayState, len(candidates))
+	for i, candidate := range candidates {
+		gateways[i] = candidate.gateway
+	}
+	return gateways
+}
+
+// selectionWeight returns the weight of a connected gateway in the selection: its configured weight, scaled down by
+// its latency relative to the fastest gateway, and halved by each of its consecutive failures.
+func selectionWeight(weight uint32, stats GatewayStats, minLatency time.Duration) float64 {
+	w := float64(max(weight, 1))
+	if stats.Latency > 0 && minLatency > 0 {
+		w *= float64(minLatency) / float64(stats.Latency)
+	}
+	return w / math.Pow(2, float64(min(stats.ConsecutiveFailures, 32)))
+}
+
+// awaitAnyConnection blocks until one of the gateways is connected or the context is done
+func (c *gatewayConnector) awaitAnyConnection(ctx context.Context) (*gatewayState, error) {
+	if len(c.gateways) == 0 {
+		return nil, errNoConnectedGateway
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	connectedCh := make(chan *gatewayState, len(c.gateways))
+	for _, gateway := range c.gateways {
+		go func() {
+			if gateway.awaitConn(ctx) == nil {
+				connectedCh <- gateway
+			}
+		}()
+	}
+	select {
+	case gateway := <-connectedCh:
+		return gateway, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %w", errNoConnectedGateway, ctx.Err())
+	}
+}
+
+func (c *gatewayConnector) GatewayStats() map[string]GatewayStats {
+	stats := make(map[string]GatewayStats, len(c.gateways))
+	for id, gateway := range c.gateways {
+		stats[id] = gateway.getStats()
+	}
+	return stats
 }
 
 func (c *gatewayConnector) GatewayIDs() []string {
@@ -244,8 +439,8 @@ func (c *gatewayConnector) readLoop(gatewayState *gatewayState) {
 				c.lggr.Errorw("parse error when reading from Gateway", "id", gatewayState.config.Id, "err", err)
 				break
 			}
-			if err = msg.Validate(); err != nil {
-				c.lggr.Errorw("failed to validate message signature", "id", gatewayState.config.Id, "err", err)
+			if err = c.validateMessage(msg); err != nil {
+				c.lggr.Errorw("failed to validate message", "id", gatewayState.config.Id, "err", err)
 				break
 			}
 			handler, exists := c.handlers[msg.Body.Method]
@@ -258,6 +453,25 @@ func (c *gatewayConnector) readLoop(gatewayState *gatewayState) {
 	}
 }
 
+// validateMessage validates the message and sets its sender: the signer of signed messages or, if allowed, the
+// sender authenticated by the Gateway for unsigned messages.
+func (c *gatewayConnector) validateMessage(msg *api.Message) error {
+	if msg.Signature != "" || msg.AuthenticatedSender == "" {
+		return msg.Validate()
+	}
+	if !c.config.AllowGatewayAuthenticatedSenders {
+		return errors.New("unsigned message with a sender authenticated by the Gateway, which is not allowed")
+	}
+	if err := msg.ValidateBody(); err != nil {
+		return err
+	}
+	if !common.IsHexAddress(msg.AuthenticatedSender) {
+		return errors.New("invalid authenticated sender")
+	}
+	msg.Body.Sender = strings.ToLower(msg.AuthenticatedSender)
+	return nil
+}
+
 func (c *gatewayConnector) reconnectLoop(gatewayState *gatewayState) {
 	redialBackoff := utils.NewRedialBackoff()
 	ctx, cancel := c.shutdownCh.NewCtx()
@@ -269,6 +483,10 @@ func (c *gatewayConnector) reconnectLoop(gatewayState *gatewayState) {
 			c.lggr.Errorw("connection error", "url", gatewayState.url, "err", err)
 		} else {
 			c.lggr.Infow("connected successfully", "url", gatewayState.url)
+			conn.SetPongHandler(func(data string) error {
+				c.recordPong(gatewayState, data)
+				return nil
+			})
 			closeCh := gatewayState.conn.Reset(conn)
 			gatewayState.signal()
 			<-closeCh
@@ -278,7 +496,7 @@ func (c *gatewayConnector) reconnectLoop(gatewayState *gatewayState) {
 			redialBackoff = utils.NewRedialBackoff()
 
 			// reset signal channel
-			gatewayState.signalCh = make(chan struct{})
+			gatewayState.disconnected()
 		}
 		select {
 		case <-c.shutdownCh:
@@ -290,6 +508,43 @@ func (c *gatewayConnector) reconnectLoop(gatewayState *gatewayState) {
 	}
 }
 
+// latencyProbeLoop periodically pings the gateway. Its pongs echo the time of the pings, to measure their round trip.
+func (c *gatewayConnector) latencyProbeLoop(gatewayState *gatewayState) {
+	defer c.closeWait.Done()
+	ctx, cancel := c.shutdownCh.NewCtx()
+	defer cancel()
+
+	interval := defaultLatencyProbeInterval
+	if c.config.LatencyProbeIntervalSec > 0 {
+		interval = time.Duration(c.config.LatencyProbeIntervalSec) * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-ticker.C:
+			if !gatewayState.getStats().Connected {
+				break
+			}
+			payload := strconv.FormatInt(time.Now().UnixNano(), 10)
+			if err := gatewayState.conn.Write(ctx, websocket.PingMessage, []byte(payload)); err != nil {
+				c.lggr.Debugw("unable to send latency probe to gateway", "id", gatewayState.config.Id, "err", err)
+			}
+		}
+	}
+}
+
+func (c *gatewayConnector) recordPong(gatewayState *gatewayState, data string) {
+	sentAt, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		c.lggr.Debugw("unexpected pong from gateway", "id", gatewayState.config.Id, "data", data)
+		return
+	}
+	gatewayState.recordLatency(time.Since(time.Unix(0, sentAt)))
+}
+
 func (c *gatewayConnector) Start(ctx context.Context) error {
 	return c.StartOnce("GatewayConnector", func() error {
 		c.lggr.Info("starting gateway connector")
@@ -298,9 +553,10 @@ func (c *gatewayConnector) Start(ctx context.Context) error {
 			if err := gatewayState.conn.Start(ctx); err != nil {
 				return err
 			}
-			c.closeWait.Add(2)
+			c.closeWait.Add(3)
 			go c.readLoop(gatewayState)
 			go c.reconnectLoop(gatewayState)
+			go c.latencyProbeLoop(gatewayState)
 		}
 		return nil
 	})
@@ -0,0 +1,173 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/network"
+)
+
+func TestGatewayConnector_ValidateMessage(t *testing.T) {
+	t.Parallel()
+
+	const sender = "0x0001020304050607080900010203040506070809"
+	newMessage := func() *api.Message {
+		return &api.Message{
+			Body:                api.MessageBody{MessageId: "abcd", Method: "request", DonId: "testDON", Sender: "0x00000000000000000000000000000000000000aa"},
+			AuthenticatedSender: sender,
+		}
+	}
+
+	t.Run("not allowed", func(t *testing.T) {
+		c := &gatewayConnector{config: &ConnectorConfig{}}
+		require.ErrorContains(t, c.validateMessage(newMessage()), "not allowed")
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		c := &gatewayConnector{config: &ConnectorConfig{AllowGatewayAuthenticatedSenders: true}}
+		msg := newMessage()
+		require.NoError(t, c.validateMessage(msg))
+		require.Equal(t, sender, msg.Body.Sender)
+
+		msg = newMessage()
+		msg.AuthenticatedSender = "backend-service"
+		require.ErrorContains(t, c.validateMessage(msg), "invalid authenticated sender")
+	})
+
+	t.Run("signed messages are verified", func(t *testing.T) {
+		c := &gatewayConnector{config: &ConnectorConfig{AllowGatewayAuthenticatedSenders: true}}
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		msg := newMessage()
+		require.NoError(t, msg.Sign(privateKey))
+		require.NoError(t, c.validateMessage(msg))
+		require.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), common.HexToAddress(msg.Body.Sender))
+
+		msg.Signature = "0x1234"
+		require.Error(t, c.validateMessage(msg))
+	})
+}
+
+// fakeConn counts the writes to a gateway, which fail with err if set.
+type fakeConn struct {
+	network.WSConnectionWrapper
+	writes atomic.Int32
+	err    error
+}
+
+func (f *fakeConn) Write(ctx context.Context, msgType int, data []byte) error {
+	f.writes.Add(1)
+	return f.err
+}
+
+func newFakeGateway(id string, weight uint32, connected bool, writeErr error) (*gatewayState, *fakeConn) {
+	conn := &fakeConn{err: writeErr}
+	gs := &gatewayState{conn: conn, config: ConnectorGatewayConfig{Id: id, Weight: weight}, signalCh: make(chan struct{})}
+	if connected {
+		gs.signal()
+	}
+	return gs, conn
+}
+
+func newFakeConnector(t *testing.T, gateways ...*gatewayState) *gatewayConnector {
+	c := &gatewayConnector{config: &ConnectorConfig{}, codec: &api.JsonRPCCodec{}, gateways: make(map[string]*gatewayState), lggr: logger.TestLogger(t)}
+	for _, gs := range gateways {
+		c.gateways[gs.config.Id] = gs
+	}
+	return c
+}
+
+func TestGatewayConnector_SelectionWeight(t *testing.T) {
+	t.Parallel()
+
+	require.InDelta(t, 1.0, selectionWeight(0, GatewayStats{}, 0), 1e-9)
+	require.InDelta(t, 3.0, selectionWeight(3, GatewayStats{}, 0), 1e-9)
+	require.InDelta(t, 3.0, selectionWeight(3, GatewayStats{Latency: 10 * time.Millisecond}, 10*time.Millisecond), 1e-9)
+	require.InDelta(t, 1.5, selectionWeight(3, GatewayStats{Latency: 20 * time.Millisecond}, 10*time.Millisecond), 1e-9)
+	require.InDelta(t, 0.75, selectionWeight(3, GatewayStats{ConsecutiveFailures: 2}, 0), 1e-9)
+}
+
+func TestGatewayConnector_SendToAnyGateway(t *testing.T) {
+	t.Parallel()
+
+	msg := &api.Message{Body: api.MessageBody{MessageId: "abcd", Method: "request", DonId: "testDON"}}
+
+	t.Run("fails over to healthy gateways", func(t *testing.T) {
+		down, downConn := newFakeGateway("down", 1, true, errors.New("broken pipe"))
+		up, upConn := newFakeGateway("up", 1, true, nil)
+		disconnected, disconnectedConn := newFakeGateway("disconnected", 100, false, nil)
+		c := newFakeConnector(t, down, up, disconnected)
+
+		for i := 0; i < 10; i++ {
+			gatewayID, err := c.SendToAnyGateway(testutils.Context(t), msg)
+			require.NoError(t, err)
+			require.Equal(t, "up", gatewayID)
+		}
+		require.Equal(t, int32(10), upConn.writes.Load())
+		require.Zero(t, disconnectedConn.writes.Load())
+		require.Equal(t, int(downConn.writes.Load()), c.GatewayStats()["down"].ConsecutiveFailures)
+		require.Zero(t, c.GatewayStats()["up"].ConsecutiveFailures)
+	})
+
+	t.Run("all gateways fail", func(t *testing.T) {
+		a, _ := newFakeGateway("a", 1, true, errors.New("broken pipe"))
+		b, _ := newFakeGateway("b", 1, true, errors.New("connection reset"))
+		c := newFakeConnector(t, a, b)
+
+		_, err := c.SendToAnyGateway(testutils.Context(t), msg)
+		require.ErrorContains(t, err, "broken pipe")
+		require.ErrorContains(t, err, "connection reset")
+	})
+
+	t.Run("prefers heavier gateways", func(t *testing.T) {
+		light, _ := newFakeGateway("light", 1, true, nil)
+		heavy, _ := newFakeGateway("heavy", 1000, true, nil)
+		c := newFakeConnector(t, light, heavy)
+
+		heavyCount := 0
+		for i := 0; i < 100; i++ {
+			gatewayID, err := c.SendToAnyGateway(testutils.Context(t), msg)
+			require.NoError(t, err)
+			if gatewayID == "heavy" {
+				heavyCount++
+			}
+		}
+		require.Greater(t, heavyCount, 90)
+	})
+
+	t.Run("awaits a connection", func(t *testing.T) {
+		gs, conn := newFakeGateway("late", 1, false, nil)
+		c := newFakeConnector(t, gs)
+
+		ctx, cancel := context.WithTimeout(testutils.Context(t), 10*time.Millisecond)
+		defer cancel()
+		_, err := c.SendToAnyGateway(ctx, msg)
+		require.ErrorIs(t, err, errNoConnectedGateway)
+
+		time.AfterFunc(10*time.Millisecond, gs.signal)
+		gatewayID, err := c.SendToAnyGateway(testutils.Context(t), msg)
+		require.NoError(t, err)
+		require.Equal(t, "late", gatewayID)
+		require.Equal(t, int32(1), conn.writes.Load())
+	})
+}
+
+func TestGatewayConnector_RecordLatency(t *testing.T) {
+	t.Parallel()
+
+	gs, _ := newFakeGateway("gw", 1, true, nil)
+	gs.recordLatency(100 * time.Millisecond)
+	require.Equal(t, 100*time.Millisecond, gs.getStats().Latency)
+	gs.recordLatency(200 * time.Millisecond)
+	require.Equal(t, 120*time.Millisecond, gs.getStats().Latency)
+}
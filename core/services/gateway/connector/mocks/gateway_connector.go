@@ -316,6 +316,53 @@ func (_c *GatewayConnector_GatewayIDs_Call) RunAndReturn(run func() []string) *G
 	return _c
 }
 
+// GatewayStats provides a mock function with given fields:
+func (_m *GatewayConnector) GatewayStats() map[string]connector.GatewayStats {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GatewayStats")
+	}
+
+	var r0 map[string]connector.GatewayStats
+	if rf, ok := ret.Get(0).(func() map[string]connector.GatewayStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]connector.GatewayStats)
+		}
+	}
+
+	return r0
+}
+
+// GatewayConnector_GatewayStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GatewayStats'
+type GatewayConnector_GatewayStats_Call struct {
+	*mock.Call
+}
+
+// GatewayStats is a helper method to define mock.On call
+func (_e *GatewayConnector_Expecter) GatewayStats() *GatewayConnector_GatewayStats_Call {
+	return &GatewayConnector_GatewayStats_Call{Call: _e.mock.On("GatewayStats")}
+}
+
+func (_c *GatewayConnector_GatewayStats_Call) Run(run func()) *GatewayConnector_GatewayStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *GatewayConnector_GatewayStats_Call) Return(_a0 map[string]connector.GatewayStats) *GatewayConnector_GatewayStats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GatewayConnector_GatewayStats_Call) RunAndReturn(run func() map[string]connector.GatewayStats) *GatewayConnector_GatewayStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HealthReport provides a mock function with given fields:
 func (_m *GatewayConnector) HealthReport() map[string]error {
 	ret := _m.Called()
@@ -511,6 +558,63 @@ func (_c *GatewayConnector_Ready_Call) RunAndReturn(run func() error) *GatewayCo
 	return _c
 }
 
+// SendToAnyGateway provides a mock function with given fields: ctx, msg
+func (_m *GatewayConnector) SendToAnyGateway(ctx context.Context, msg *api.Message) (string, error) {
+	ret := _m.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendToAnyGateway")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.Message) (string, error)); ok {
+		return rf(ctx, msg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.Message) string); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.Message) error); ok {
+		r1 = rf(ctx, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GatewayConnector_SendToAnyGateway_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendToAnyGateway'
+type GatewayConnector_SendToAnyGateway_Call struct {
+	*mock.Call
+}
+
+// SendToAnyGateway is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg *api.Message
+func (_e *GatewayConnector_Expecter) SendToAnyGateway(ctx interface{}, msg interface{}) *GatewayConnector_SendToAnyGateway_Call {
+	return &GatewayConnector_SendToAnyGateway_Call{Call: _e.mock.On("SendToAnyGateway", ctx, msg)}
+}
+
+func (_c *GatewayConnector_SendToAnyGateway_Call) Run(run func(ctx context.Context, msg *api.Message)) *GatewayConnector_SendToAnyGateway_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*api.Message))
+	})
+	return _c
+}
+
+func (_c *GatewayConnector_SendToAnyGateway_Call) Return(_a0 string, _a1 error) *GatewayConnector_SendToAnyGateway_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GatewayConnector_SendToAnyGateway_Call) RunAndReturn(run func(context.Context, *api.Message) (string, error)) *GatewayConnector_SendToAnyGateway_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendToGateway provides a mock function with given fields: ctx, gatewayID, msg
 func (_m *GatewayConnector) SendToGateway(ctx context.Context, gatewayID string, msg *api.Message) error {
 	ret := _m.Called(ctx, gatewayID, msg)
@@ -559,6 +663,63 @@ func (_c *GatewayConnector_SendToGateway_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// SignAndSendToAnyGateway provides a mock function with given fields: ctx, msg
+func (_m *GatewayConnector) SignAndSendToAnyGateway(ctx context.Context, msg *api.MessageBody) (string, error) {
+	ret := _m.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignAndSendToAnyGateway")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.MessageBody) (string, error)); ok {
+		return rf(ctx, msg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.MessageBody) string); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.MessageBody) error); ok {
+		r1 = rf(ctx, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GatewayConnector_SignAndSendToAnyGateway_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignAndSendToAnyGateway'
+type GatewayConnector_SignAndSendToAnyGateway_Call struct {
+	*mock.Call
+}
+
+// SignAndSendToAnyGateway is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg *api.MessageBody
+func (_e *GatewayConnector_Expecter) SignAndSendToAnyGateway(ctx interface{}, msg interface{}) *GatewayConnector_SignAndSendToAnyGateway_Call {
+	return &GatewayConnector_SignAndSendToAnyGateway_Call{Call: _e.mock.On("SignAndSendToAnyGateway", ctx, msg)}
+}
+
+func (_c *GatewayConnector_SignAndSendToAnyGateway_Call) Run(run func(ctx context.Context, msg *api.MessageBody)) *GatewayConnector_SignAndSendToAnyGateway_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*api.MessageBody))
+	})
+	return _c
+}
+
+func (_c *GatewayConnector_SignAndSendToAnyGateway_Call) Return(_a0 string, _a1 error) *GatewayConnector_SignAndSendToAnyGateway_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GatewayConnector_SignAndSendToAnyGateway_Call) RunAndReturn(run func(context.Context, *api.MessageBody) (string, error)) *GatewayConnector_SignAndSendToAnyGateway_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SignAndSendToGateway provides a mock function with given fields: ctx, gatewayID, msg
 func (_m *GatewayConnector) SignAndSendToGateway(ctx context.Context, gatewayID string, msg *api.MessageBody) error {
 	ret := _m.Called(ctx, gatewayID, msg)
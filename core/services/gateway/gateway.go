@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"go.uber.org/multierr"
 
@@ -34,6 +35,8 @@ type Gateway interface {
 
 	GetUserPort() int
 	GetNodePort() int
+	// GetAdminPort returns the port of the admin API, or 0 if it is disabled.
+	GetAdminPort() int
 }
 
 type HandlerType = string
@@ -45,23 +48,41 @@ type HandlerFactory interface {
 type gateway struct {
 	services.StateMachine
 
-	codec      api.Codec
-	httpServer gw_net.HttpServer
+	codec          api.Codec
+	httpServer     gw_net.HttpServer
+	authenticators map[string]Authenticator
+	connMgr        ConnectionManager
+	lggr           logger.Logger
+
+	// mu guards handlers, donConfigs and inFlight, which change with the updates of the admin API
+	mu         sync.RWMutex
 	handlers   map[string]handlers.Handler
-	connMgr    ConnectionManager
-	lggr       logger.Logger
+	donConfigs map[string]*config.DONConfig
+	// inFlight counts the user messages pending in the current handler of each DON, which are awaited before a
+	// replaced handler is closed
+	inFlight map[string]*sync.WaitGroup
+	// drains tracks the replaced handlers being drained in the background
+	drains sync.WaitGroup
+	// updateMu serializes the updates of DONs
+	updateMu       sync.Mutex
+	handlerFactory HandlerFactory
+	adminServer    *adminServer
 }
 
-func NewGatewayFromConfig(config *config.GatewayConfig, handlerFactory HandlerFactory, lggr logger.Logger) (Gateway, error) {
+var _ DONUpdater = (*gateway)(nil)
+
+func NewGatewayFromConfig(gwConfig *config.GatewayConfig, handlerFactory HandlerFactory, lggr logger.Logger) (Gateway, error) {
 	codec := &api.JsonRPCCodec{}
-	httpServer := gw_net.NewHttpServer(&config.UserServerConfig, lggr)
-	connMgr, err := NewConnectionManager(config, clockwork.NewRealClock(), lggr)
+	httpServer := gw_net.NewHttpServer(&gwConfig.UserServerConfig, lggr)
+	connMgr, err := NewConnectionManager(gwConfig, clockwork.NewRealClock(), lggr)
 	if err != nil {
 		return nil, err
 	}
 
 	handlerMap := make(map[string]handlers.Handler)
-	for _, donConfig := range config.Dons {
+	donConfigs := make(map[string]*config.DONConfig)
+	authenticators := make(map[string]Authenticator)
+	for _, donConfig := range gwConfig.Dons {
 		donConfig := donConfig
 		_, ok := handlerMap[donConfig.DonId]
 		if ok {
@@ -71,29 +92,65 @@ func NewGatewayFromConfig(config *config.GatewayConfig, handlerFactory HandlerFa
 		if donConnMgr == nil {
 			return nil, fmt.Errorf("connection manager ID %s not found", donConfig.DonId)
 		}
-		for idx, nodeConfig := range donConfig.Members {
-			donConfig.Members[idx].Address = strings.ToLower(nodeConfig.Address)
-			if !common.IsHexAddress(nodeConfig.Address) {
-				return nil, fmt.Errorf("invalid node address %s", nodeConfig.Address)
-			}
+		if err = normalizeMembers(donConfig.Members); err != nil {
+			return nil, err
 		}
 		handler, err := handlerFactory.NewHandler(donConfig.HandlerName, donConfig.HandlerConfig, &donConfig, donConnMgr)
 		if err != nil {
 			return nil, err
 		}
 		handlerMap[donConfig.DonId] = handler
+		donConfigs[donConfig.DonId] = &donConfig
 		donConnMgr.SetHandler(handler)
+		authenticator, err := NewAuthenticatorFromConfig(donConfig.Auth, clockwork.NewRealClock())
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth config of DON %s: %w", donConfig.DonId, err)
+		}
+		if authenticator != nil {
+			authenticators[donConfig.DonId] = authenticator
+		}
 	}
-	return NewGateway(codec, httpServer, handlerMap, connMgr, lggr), nil
+	gw := newGateway(codec, httpServer, handlerMap, authenticators, connMgr, lggr)
+	gw.donConfigs = donConfigs
+	gw.handlerFactory = handlerFactory
+	if gwConfig.AdminServerConfig != nil {
+		gw.adminServer, err = newAdminServer(gwConfig.AdminServerConfig, gw, lggr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid admin server config: %w", err)
+		}
+	}
+	return gw, nil
+}
+
+// normalizeMembers lower-cases the addresses of the members of a DON, which must be hex addresses.
+func normalizeMembers(members []config.NodeConfig) error {
+	for idx, nodeConfig := range members {
+		members[idx].Address = strings.ToLower(nodeConfig.Address)
+		if !common.IsHexAddress(nodeConfig.Address) {
+			return fmt.Errorf("invalid node address %s", nodeConfig.Address)
+		}
+	}
+	return nil
+}
+
+// NewGateway returns a gateway routing user messages to the handlers of their DON. Signed messages are always
+// accepted, unsigned messages only for the DONs with an authenticator, which determines their sender.
+func NewGateway(codec api.Codec, httpServer gw_net.HttpServer, handlers map[string]handlers.Handler, authenticators map[string]Authenticator, connMgr ConnectionManager, lggr logger.Logger) Gateway {
+	return newGateway(codec, httpServer, handlers, authenticators, connMgr, lggr)
 }
 
-func NewGateway(codec api.Codec, httpServer gw_net.HttpServer, handlers map[string]handlers.Handler, connMgr ConnectionManager, lggr logger.Logger) Gateway {
+func newGateway(codec api.Codec, httpServer gw_net.HttpServer, handlers map[string]handlers.Handler, authenticators map[string]Authenticator, connMgr ConnectionManager, lggr logger.Logger) *gateway {
 	gw := &gateway{
-		codec:      codec,
-		httpServer: httpServer,
-		handlers:   handlers,
-		connMgr:    connMgr,
-		lggr:       lggr.Named("Gateway"),
+		codec:          codec,
+		httpServer:     httpServer,
+		handlers:       handlers,
+		authenticators: authenticators,
+		connMgr:        connMgr,
+		inFlight:       make(map[string]*sync.WaitGroup, len(handlers)),
+		lggr:           lggr.Named("Gateway"),
+	}
+	for donID := range handlers {
+		gw.inFlight[donID] = &sync.WaitGroup{}
 	}
 	httpServer.SetHTTPRequestHandler(gw)
 	return gw
@@ -102,26 +159,42 @@ func NewGateway(codec api.Codec, httpServer gw_net.HttpServer, handlers map[stri
 func (g *gateway) Start(ctx context.Context) error {
 	return g.StartOnce("Gateway", func() error {
 		g.lggr.Info("starting gateway")
+		g.mu.RLock()
 		for _, handler := range g.handlers {
 			if err := handler.Start(ctx); err != nil {
+				g.mu.RUnlock()
 				return err
 			}
 		}
+		g.mu.RUnlock()
 		if err := g.connMgr.Start(ctx); err != nil {
 			return err
 		}
-		return g.httpServer.Start(ctx)
+		if err := g.httpServer.Start(ctx); err != nil {
+			return err
+		}
+		if g.adminServer != nil {
+			return g.adminServer.Start(ctx)
+		}
+		return nil
 	})
 }
 
 func (g *gateway) Close() error {
 	return g.StopOnce("Gateway", func() (err error) {
 		g.lggr.Info("closing gateway")
+		// the admin server awaits the updates in progress
+		if g.adminServer != nil {
+			err = multierr.Combine(err, g.adminServer.Close())
+		}
 		err = multierr.Combine(err, g.httpServer.Close())
 		err = multierr.Combine(err, g.connMgr.Close())
+		g.drains.Wait()
+		g.mu.RLock()
 		for _, handler := range g.handlers {
 			err = multierr.Combine(err, handler.Close())
 		}
+		g.mu.RUnlock()
 		return
 	})
 }
@@ -136,38 +209,78 @@ func (g *gateway) ProcessRequest(ctx context.Context, rawRequest []byte) (rawRes
 	if msg == nil {
 		return newError(g.codec, "", api.UserMessageParseError, "nil message")
 	}
-	if err = msg.Validate(); err != nil {
-		return newError(g.codec, msg.Body.MessageId, api.UserMessageParseError, err.Error())
+	response, errCode, errMsg := g.processMessage(ctx, msg)
+	if errCode != api.NoError {
+		return newError(g.codec, msg.Body.MessageId, errCode, errMsg)
+	}
+	// encode
+	rawResponse, err = g.codec.EncodeResponse(response)
+	if err != nil {
+		return newError(g.codec, msg.Body.MessageId, api.NodeReponseEncodingError, "")
+	}
+	promRequest.WithLabelValues(api.NoError.String()).Inc()
+	return rawResponse, api.ToHttpErrorCode(api.NoError)
+}
+
+// processMessage sends the user message to the handler of its DON and awaits its response.
+func (g *gateway) processMessage(ctx context.Context, msg *api.Message) (*api.Message, api.ErrorCode, string) {
+	if errCode, err := g.authenticate(ctx, msg); err != nil {
+		return nil, errCode, err.Error()
 	}
 	// find correct handler
+	g.mu.RLock()
 	handler, ok := g.handlers[msg.Body.DonId]
 	if !ok {
-		return newError(g.codec, msg.Body.MessageId, api.UnsupportedDONIdError, "unsupported DON ID")
+		g.mu.RUnlock()
+		return nil, api.UnsupportedDONIdError, "unsupported DON ID"
 	}
+	inFlight := g.inFlight[msg.Body.DonId]
+	inFlight.Add(1)
+	g.mu.RUnlock()
+	defer inFlight.Done()
 	// send to the handler
 	responseCh := make(chan handlers.UserCallbackPayload, 1)
-	err = handler.HandleUserMessage(ctx, msg, responseCh)
-	if err != nil {
-		return newError(g.codec, msg.Body.MessageId, api.HandlerError, err.Error())
+	if err := handler.HandleUserMessage(ctx, msg, responseCh); err != nil {
+		return nil, api.HandlerError, err.Error()
 	}
 	// await response
 	var response handlers.UserCallbackPayload
 	select {
 	case <-ctx.Done():
-		return newError(g.codec, msg.Body.MessageId, api.RequestTimeoutError, "handler timeout")
+		return nil, api.RequestTimeoutError, "handler timeout"
 	case response = <-responseCh:
 		break
 	}
 	if response.ErrCode != api.NoError {
-		return newError(g.codec, msg.Body.MessageId, response.ErrCode, response.ErrMsg)
+		return nil, response.ErrCode, response.ErrMsg
 	}
-	// encode
-	rawResponse, err = g.codec.EncodeResponse(response.Msg)
+	return response.Msg, api.NoError, ""
+}
+
+// authenticate validates the message and sets its sender: the signer of signed messages, or the sender identity
+// mapped to the credentials of the request for unsigned messages.
+func (g *gateway) authenticate(ctx context.Context, msg *api.Message) (api.ErrorCode, error) {
+	// only the gateway can vouch for senders
+	msg.AuthenticatedSender = ""
+	authenticator, ok := g.authenticators[msg.Body.DonId]
+	if msg.Signature != "" || !ok {
+		if err := msg.Validate(); err != nil {
+			return api.UserMessageParseError, err
+		}
+		return api.NoError, nil
+	}
+
+	if err := msg.ValidateBody(); err != nil {
+		return api.UserMessageParseError, err
+	}
+	sender, err := authenticator.Authenticate(gw_net.RequestHeaderFromContext(ctx))
 	if err != nil {
-		return newError(g.codec, msg.Body.MessageId, api.NodeReponseEncodingError, "")
+		g.lggr.Debugw("failed to authenticate user message", "donID", msg.Body.DonId, "messageID", msg.Body.MessageId, "err", err)
+		return api.UnauthorizedError, err
 	}
-	promRequest.WithLabelValues(api.NoError.String()).Inc()
-	return rawResponse, api.ToHttpErrorCode(api.NoError)
+	msg.Body.Sender = sender
+	msg.AuthenticatedSender = sender
+	return api.NoError, nil
 }
 
 func newError(codec api.Codec, id string, errCode api.ErrorCode, errMsg string) ([]byte, int) {
@@ -188,3 +301,98 @@ func (g *gateway) GetUserPort() int {
 func (g *gateway) GetNodePort() int {
 	return g.connMgr.GetPort()
 }
+
+func (g *gateway) GetAdminPort() int {
+	if g.adminServer == nil {
+		return 0
+	}
+	return g.adminServer.GetPort()
+}
+
+func (g *gateway) DescribeDON(donID string) (DONDescription, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	donConfig, ok := g.donConfigs[donID]
+	if !ok {
+		return DONDescription{}, fmt.Errorf("%w %s", ErrUnknownDON, donID)
+	}
+	return DONDescription{
+		DonId:         donConfig.DonId,
+		HandlerName:   donConfig.HandlerName,
+		HandlerConfig: donConfig.HandlerConfig,
+		Members:       donConfig.Members,
+		F:             donConfig.F,
+	}, nil
+}
+
+// UpdateDON changes the members of the connection manager of the DON and, if the handler config is updated, replaces
+// the handler of the DON by a new one. New requests are sent to the new handler as soon as it's started, while the old
+// handler is closed in the background, once the requests pending in it are responded to or time out. Updates that
+// only change the members keep the handler, and so its state, such as its rate limiters. Updates are not persisted:
+// the gateway job spec is unchanged and restarting the job reverts them.
+func (g *gateway) UpdateDON(ctx context.Context, donID string, update DONUpdate) error {
+	g.updateMu.Lock()
+	defer g.updateMu.Unlock()
+
+	g.mu.RLock()
+	donConfig, ok := g.donConfigs[donID]
+	g.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w %s", ErrUnknownDON, donID)
+	}
+	newConfig := *donConfig
+	if update.Members != nil {
+		newConfig.Members = append([]config.NodeConfig(nil), update.Members...)
+		if err := normalizeMembers(newConfig.Members); err != nil {
+			return err
+		}
+		if err := validateMembers(newConfig.Members); err != nil {
+			return err
+		}
+	}
+	donConnMgr := g.connMgr.DONConnectionManager(donID)
+	if update.HandlerConfig == nil {
+		// handlers read the members from the connection manager
+		if err := donConnMgr.SetMembers(ctx, newConfig.Members); err != nil {
+			return err
+		}
+		g.mu.Lock()
+		g.donConfigs[donID] = &newConfig
+		g.mu.Unlock()
+		g.lggr.Infow("updated DON members", "donID", donID, "nMembers", len(newConfig.Members))
+		return nil
+	}
+	newConfig.HandlerConfig = update.HandlerConfig
+
+	handler, err := g.handlerFactory.NewHandler(newConfig.HandlerName, newConfig.HandlerConfig, &newConfig, donConnMgr)
+	if err != nil {
+		return err
+	}
+	if err = handler.Start(ctx); err != nil {
+		return multierr.Combine(err, handler.Close())
+	}
+	// the members change before the handler, so that the new handler can reach all of them
+	if err = donConnMgr.SetMembers(ctx, newConfig.Members); err != nil {
+		return multierr.Combine(err, handler.Close())
+	}
+	release := donConnMgr.ReplaceHandler(handler)
+	g.mu.Lock()
+	oldHandler, oldInFlight := g.handlers[donID], g.inFlight[donID]
+	g.handlers[donID] = handler
+	g.donConfigs[donID] = &newConfig
+	g.inFlight[donID] = &sync.WaitGroup{}
+	g.mu.Unlock()
+
+	// the requests pending in the old handler are bounded by the request timeout of the user server
+	g.drains.Add(1)
+	go func() {
+		defer g.drains.Done()
+		oldInFlight.Wait()
+		release()
+		if err := oldHandler.Close(); err != nil {
+			g.lggr.Errorw("failed to close replaced handler", "donID", donID, "err", err)
+		}
+	}()
+	g.lggr.Infow("updated DON", "donID", donID, "nMembers", len(newConfig.Members))
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jonboulle/clockwork"
 	"github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -20,6 +21,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
 	handler_mocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/mocks"
+	gw_net "github.com/smartcontractkit/chainlink/v2/core/services/gateway/network"
 	net_mocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/network/mocks"
 )
 
@@ -149,7 +151,7 @@ func newGatewayWithMockHandler(t *testing.T) (gateway.Gateway, *handler_mocks.Ha
 	handlers := map[string]handlers.Handler{
 		"testDON": handler,
 	}
-	gw := gateway.NewGateway(&api.JsonRPCCodec{}, httpServer, handlers, nil, logger.TestLogger(t))
+	gw := gateway.NewGateway(&api.JsonRPCCodec{}, httpServer, handlers, nil, nil, logger.TestLogger(t))
 	return gw, handler
 }
 
@@ -245,3 +247,82 @@ func TestGateway_ProcessRequest_HandlerError(t *testing.T) {
 	requireJsonRPCError(t, response, "abcd", -32600, "failure")
 	require.Equal(t, 400, statusCode)
 }
+
+func TestGateway_NewGatewayFromConfig_InvalidAuthConfig(t *testing.T) {
+	t.Parallel()
+
+	tomlConfig := buildConfig(`
+[[dons]]
+DonId = "my_don"
+HandlerName = "dummy"
+
+[[dons.Auth.APIKeys]]
+KeyHash = "abcd"
+Sender = "0x0001020304050607080900010203040506070809"
+`)
+
+	lggr := logger.TestLogger(t)
+	_, err := gateway.NewGatewayFromConfig(parseTOMLConfig(t, tomlConfig), gateway.NewHandlerFactory(nil, nil, nil, lggr), lggr)
+	require.ErrorContains(t, err, "invalid auth config of DON my_don")
+}
+
+func TestGateway_ProcessRequest_Authenticated(t *testing.T) {
+	t.Parallel()
+
+	httpServer := net_mocks.NewHttpServer(t)
+	httpServer.On("SetHTTPRequestHandler", mock.Anything).Return(nil)
+	handler := handler_mocks.NewHandler(t)
+	authenticator, err := gateway.NewAuthenticatorFromConfig(config.AuthConfig{
+		APIKeys: []config.APIKeyConfig{{KeyHash: hashAPIKey("key-1"), Sender: testSender1}},
+	}, clockwork.NewFakeClock())
+	require.NoError(t, err)
+	gw := gateway.NewGateway(&api.JsonRPCCodec{}, httpServer, map[string]handlers.Handler{"testDON": handler}, map[string]gateway.Authenticator{"testDON": authenticator}, nil, logger.TestLogger(t))
+
+	handler.On("HandleUserMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		msg := args.Get(1).(*api.Message)
+		require.Equal(t, testSender1, msg.Body.Sender)
+		require.Equal(t, testSender1, msg.AuthenticatedSender)
+		callbackCh := args.Get(2).(chan<- handlers.UserCallbackPayload)
+		callbackCh <- handlers.UserCallbackPayload{Msg: msg, ErrCode: api.NoError, ErrMsg: ""}
+	})
+
+	codec := api.JsonRPCCodec{}
+	unsignedRequest, err := codec.EncodeRequest(&api.Message{
+		Body:                api.MessageBody{MessageId: "abcd", Method: "request", DonId: "testDON", Sender: testSender2},
+		AuthenticatedSender: testSender2,
+	})
+	require.NoError(t, err)
+
+	t.Run("API key", func(t *testing.T) {
+		ctx := gw_net.WithRequestHeader(testutils.Context(t), apiKeyHeader("key-1"))
+		_, statusCode := gw.ProcessRequest(ctx, unsignedRequest)
+		require.Equal(t, 200, statusCode)
+	})
+
+	t.Run("invalid API key", func(t *testing.T) {
+		ctx := gw_net.WithRequestHeader(testutils.Context(t), apiKeyHeader("key-2"))
+		response, statusCode := gw.ProcessRequest(ctx, unsignedRequest)
+		requireJsonRPCError(t, response, "abcd", -32600, "invalid API key")
+		require.Equal(t, 401, statusCode)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		response, statusCode := gw.ProcessRequest(testutils.Context(t), unsignedRequest)
+		requireJsonRPCError(t, response, "abcd", -32600, "missing credentials")
+		require.Equal(t, 401, statusCode)
+	})
+
+	t.Run("signed request", func(t *testing.T) {
+		handler := handler_mocks.NewHandler(t)
+		gw := gateway.NewGateway(&api.JsonRPCCodec{}, httpServer, map[string]handlers.Handler{"testDON": handler}, map[string]gateway.Authenticator{"testDON": authenticator}, nil, logger.TestLogger(t))
+		handler.On("HandleUserMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*api.Message)
+			require.Empty(t, msg.AuthenticatedSender)
+			callbackCh := args.Get(2).(chan<- handlers.UserCallbackPayload)
+			callbackCh <- handlers.UserCallbackPayload{Msg: msg, ErrCode: api.NoError, ErrMsg: ""}
+		})
+
+		_, statusCode := gw.ProcessRequest(testutils.Context(t), newSignedRequest(t, "abcd", "request", "testDON", []byte{}))
+		require.Equal(t, 200, statusCode)
+	})
+}
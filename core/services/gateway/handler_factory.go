@@ -45,7 +45,7 @@ func (hf *handlerFactory) NewHandler(handlerType HandlerType, handlerConfig json
 	case DummyHandlerType:
 		return handlers.NewDummyHandler(donConfig, don, hf.lggr)
 	case WebAPICapabilitiesType:
-		return capabilities.NewHandler(handlerConfig, donConfig, don, hf.httpClient, hf.lggr)
+		return capabilities.NewHandler(handlerConfig, donConfig, don, hf.httpClient, hf.ds, hf.lggr)
 	default:
 		return nil, fmt.Errorf("unsupported handler type %s", handlerType)
 	}
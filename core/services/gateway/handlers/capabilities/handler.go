@@ -3,12 +3,15 @@ package capabilities
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"go.uber.org/multierr"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/webapi/webapicap"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
@@ -34,13 +37,15 @@ type handler struct {
 	mu              sync.Mutex
 	lggr            logger.Logger
 	httpClient      network.HTTPClient
-	nodeRateLimiter *common.RateLimiter
+	nodeRateLimiter common.Limiter
 	wg              sync.WaitGroup
 }
 
 type HandlerConfig struct {
 	NodeRateLimiter         common.RateLimiterConfig `json:"nodeRateLimiter"`
 	MaxAllowedMessageAgeSec uint                     `json:"maxAllowedMessageAgeSec"`
+	// SharedState shares rate limits with the other Gateways of the DON using the same database
+	SharedState bool `json:"sharedState"`
 }
 
 type savedCallback struct {
@@ -49,14 +54,23 @@ type savedCallback struct {
 }
 
 var _ handlers.Handler = (*handler)(nil)
+var _ handlers.MethodDescriber = (*handler)(nil)
 
-func NewHandler(handlerConfig json.RawMessage, donConfig *config.DONConfig, don handlers.DON, httpClient network.HTTPClient, lggr logger.Logger) (*handler, error) {
+func NewHandler(handlerConfig json.RawMessage, donConfig *config.DONConfig, don handlers.DON, httpClient network.HTTPClient, ds sqlutil.DataSource, lggr logger.Logger) (*handler, error) {
 	var cfg HandlerConfig
 	err := json.Unmarshal(handlerConfig, &cfg)
 	if err != nil {
 		return nil, err
 	}
-	nodeRateLimiter, err := common.NewRateLimiter(cfg.NodeRateLimiter)
+	var nodeRateLimiter common.Limiter
+	if cfg.SharedState {
+		if ds == nil {
+			return nil, errors.New("shared state requires a database")
+		}
+		nodeRateLimiter, err = common.NewSharedRateLimiter(cfg.NodeRateLimiter, common.NewORM(ds), donConfig.DonId, "node", lggr)
+	} else {
+		nodeRateLimiter, err = common.NewRateLimiter(cfg.NodeRateLimiter)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +134,7 @@ func (h *handler) handleWebAPITriggerMessage(ctx context.Context, msg *api.Messa
 
 func (h *handler) handleWebAPIOutgoingMessage(ctx context.Context, msg *api.Message, nodeAddr string) error {
 	h.lggr.Debugw("handling webAPI outgoing message", "messageId", msg.Body.MessageId, "nodeAddr", nodeAddr)
-	if !h.nodeRateLimiter.Allow(nodeAddr) {
+	if !h.nodeRateLimiter.AllowContext(ctx, nodeAddr) {
 		return fmt.Errorf("rate limit exceeded for node %s", nodeAddr)
 	}
 	var payload Request
@@ -204,6 +218,12 @@ func (h *handler) Close() error {
 	return nil
 }
 
+func (h *handler) Methods() []handlers.MethodDescription {
+	return []handlers.MethodDescription{
+		{Name: MethodWebAPITrigger, Description: "Fires the Web API triggers of the workflows of the DON.", Request: webapicap.TriggerRequestPayload{}, Response: TriggerResponsePayload{}},
+	}
+}
+
 func (h *handler) HandleUserMessage(ctx context.Context, msg *api.Message, callbackCh chan<- handlers.UserCallbackPayload) error {
 	h.mu.Lock()
 	h.savedCallbacks[msg.Body.MessageId] = &savedCallback{msg.Body.MessageId, callbackCh}
@@ -240,7 +260,7 @@ func (h *handler) HandleUserMessage(ctx context.Context, msg *api.Message, callb
 	}
 
 	// Send to all nodes.
-	for _, member := range h.donConfig.Members {
+	for _, member := range don.Members() {
 		err = multierr.Combine(err, don.SendToNode(ctx, member.Address, msg))
 	}
 	return err
@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
 	gwcommon "github.com/smartcontractkit/chainlink/v2/core/services/gateway/common"
@@ -67,11 +68,22 @@ func setupHandler(t *testing.T) (*handler, *mocks.HTTPClient, *handlermocks.DON,
 			Address: n.Address,
 		})
 	}
-	handler, err := NewHandler(json.RawMessage(cfgBytes), donConfig, don, httpClient, lggr)
+	don.On("Members").Return(donConfig.Members).Maybe()
+	handler, err := NewHandler(json.RawMessage(cfgBytes), donConfig, don, httpClient, nil, lggr)
 	require.NoError(t, err)
 	return handler, httpClient, don, nodes
 }
 
+func TestNewHandler_SharedState(t *testing.T) {
+	handlerConfig := json.RawMessage(`{"sharedState": true, "nodeRateLimiter": {"globalRPS": 1, "globalBurst": 1, "perSenderRPS": 1, "perSenderBurst": 1}}`)
+	_, err := NewHandler(handlerConfig, &config.DONConfig{}, nil, nil, nil, logger.TestLogger(t))
+	require.ErrorContains(t, err, "shared state requires a database")
+
+	handler, err := NewHandler(handlerConfig, &config.DONConfig{DonId: "don1"}, nil, nil, pgtest.NewSqlxDB(t), logger.TestLogger(t))
+	require.NoError(t, err)
+	require.IsType(t, &common.SharedRateLimiter{}, handler.nodeRateLimiter)
+}
+
 func TestHandler_SendHTTPMessageToClient(t *testing.T) {
 	handler, httpClient, don, nodes := setupHandler(t)
 	ctx := testutils.Context(t)
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+)
+
+// ORM persists the state shared by the Gateways of a DON, e.g. replicas behind a load balancer, so that they enforce
+// rate limits and reject duplicate requests together.
+type ORM interface {
+	// TakeToken takes a token from the bucket of the limiter of the DON for the sender, which is refilled at rps
+	// tokens per second up to burst tokens. It returns false if the bucket is empty.
+	TakeToken(ctx context.Context, donID, limiter, sender string, rps float64, burst int) (bool, error)
+	// DeleteIdleBuckets deletes the buckets of the limiter of the DON which have not been updated for idle.
+	DeleteIdleBuckets(ctx context.Context, donID, limiter string, idle time.Duration) (int64, error)
+	// ReserveRequest reserves the message ID of the sender in the DON for ttl. It returns false if the message ID
+	// is already reserved.
+	ReserveRequest(ctx context.Context, donID, sender, messageID string, ttl time.Duration) (bool, error)
+	// ReleaseRequest releases the message ID of the sender in the DON.
+	ReleaseRequest(ctx context.Context, donID, sender, messageID string) error
+	// DeleteExpiredRequests deletes the expired reservations of message IDs of all DONs.
+	DeleteExpiredRequests(ctx context.Context) (int64, error)
+}
+
+type orm struct {
+	ds sqlutil.DataSource
+}
+
+var _ ORM = (*orm)(nil)
+
+func NewORM(ds sqlutil.DataSource) ORM {
+	return &orm{ds: ds}
+}
+
+func (o *orm) TakeToken(ctx context.Context, donID, limiter, sender string, rps float64, burst int) (bool, error) {
+	// The bucket is refilled with the tokens accrued since it was last updated, then a token is taken, all under the
+	// lock of the row so that concurrent Gateways never take the same token.
+	const stmt = `
+		INSERT INTO gateway_rate_limit_buckets AS b (don_id, limiter, sender, tokens, updated_at)
+		VALUES ($1, $2, $3, $4::double precision - 1, NOW())
+		ON CONFLICT (don_id, limiter, sender) DO UPDATE SET
+			tokens = LEAST($4::double precision, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at)::double precision * $5::double precision) - 1,
+			updated_at = NOW()
+		WHERE LEAST($4::double precision, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at)::double precision * $5::double precision) >= 1
+		RETURNING tokens;`
+	var tokens float64
+	err := o.ds.GetContext(ctx, &tokens, stmt, donID, limiter, sender, burst, rps)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (o *orm) DeleteIdleBuckets(ctx context.Context, donID, limiter string, idle time.Duration) (int64, error) {
+	const stmt = `
+		DELETE FROM gateway_rate_limit_buckets
+		WHERE don_id = $1 AND limiter = $2 AND updated_at <= NOW() - $3::double precision * INTERVAL '1 millisecond';`
+	res, err := o.ds.ExecContext(ctx, stmt, donID, limiter, idle.Milliseconds())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (o *orm) ReserveRequest(ctx context.Context, donID, sender, messageID string, ttl time.Duration) (bool, error) {
+	const stmt = `
+		INSERT INTO gateway_pending_requests AS r (don_id, sender, message_id, expires_at)
+		VALUES ($1, $2, $3, NOW() + $4::double precision * INTERVAL '1 millisecond')
+		ON CONFLICT (don_id, sender, message_id) DO UPDATE SET expires_at = EXCLUDED.expires_at
+		WHERE r.expires_at <= NOW();`
+	res, err := o.ds.ExecContext(ctx, stmt, donID, sender, messageID, ttl.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (o *orm) ReleaseRequest(ctx context.Context, donID, sender, messageID string) error {
+	const stmt = `DELETE FROM gateway_pending_requests WHERE don_id = $1 AND sender = $2 AND message_id = $3;`
+	_, err := o.ds.ExecContext(ctx, stmt, donID, sender, messageID)
+	return err
+}
+
+func (o *orm) DeleteExpiredRequests(ctx context.Context) (int64, error) {
+	res, err := o.ds.ExecContext(ctx, `DELETE FROM gateway_pending_requests WHERE expires_at <= NOW();`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
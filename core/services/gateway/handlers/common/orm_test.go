@@ -0,0 +1,107 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
+)
+
+func TestORM_TakeToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	orm := common.NewORM(pgtest.NewSqlxDB(t))
+
+	for i := 0; i < 2; i++ {
+		allowed, err := orm.TakeToken(ctx, "don1", "user", "0x1234", 0.001, 2)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+	allowed, err := orm.TakeToken(ctx, "don1", "user", "0x1234", 0.001, 2)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	// buckets of other senders, limiters and DONs are independent
+	for _, key := range [][3]string{{"don1", "user", "0x5678"}, {"don1", "node", "0x1234"}, {"don2", "user", "0x1234"}} {
+		allowed, err = orm.TakeToken(ctx, key[0], key[1], key[2], 0.001, 2)
+		require.NoError(t, err)
+		assert.True(t, allowed, key)
+	}
+
+	// buckets are refilled over time
+	allowed, err = orm.TakeToken(ctx, "don3", "user", "0x1234", 1000, 1)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	time.Sleep(10 * time.Millisecond)
+	allowed, err = orm.TakeToken(ctx, "don3", "user", "0x1234", 1000, 1)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestORM_DeleteIdleBuckets(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	orm := common.NewORM(pgtest.NewSqlxDB(t))
+
+	for _, key := range [][3]string{{"don1", "user", "0x1234"}, {"don1", "user", "0x5678"}, {"don1", "node", "0x1234"}, {"don2", "user", "0x1234"}} {
+		_, err := orm.TakeToken(ctx, key[0], key[1], key[2], 0.001, 2)
+		require.NoError(t, err)
+	}
+
+	deleted, err := orm.DeleteIdleBuckets(ctx, "don1", "user", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	// only the buckets of the limiter of the DON are deleted
+	deleted, err = orm.DeleteIdleBuckets(ctx, "don1", "user", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	// deleted buckets are full again
+	for i := 0; i < 2; i++ {
+		allowed, err := orm.TakeToken(ctx, "don1", "user", "0x1234", 0.001, 2)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+}
+
+func TestORM_ReserveRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	orm := common.NewORM(pgtest.NewSqlxDB(t))
+
+	reserved, err := orm.ReserveRequest(ctx, "don1", "0x1234", "abcd", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	reserved, err = orm.ReserveRequest(ctx, "don1", "0x1234", "abcd", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	reserved, err = orm.ReserveRequest(ctx, "don2", "0x1234", "abcd", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	require.NoError(t, orm.ReleaseRequest(ctx, "don1", "0x1234", "abcd"))
+	reserved, err = orm.ReserveRequest(ctx, "don1", "0x1234", "abcd", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	// expired reservations can be reserved again
+	reserved, err = orm.ReserveRequest(ctx, "don1", "0x1234", "efgh", 0)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	reserved, err = orm.ReserveRequest(ctx, "don1", "0x1234", "efgh", 0)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	deleted, err := orm.DeleteExpiredRequests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
@@ -1,12 +1,20 @@
 package common
 
 import (
+	"context"
 	"errors"
 	"sync"
 
 	"golang.org/x/time/rate"
 )
 
+// Limiter limits the rate of requests, both globally and per sender.
+type Limiter interface {
+	AllowContext(ctx context.Context, sender string) bool
+}
+
+var _ Limiter = (*RateLimiter)(nil)
+
 // Wrapper around Go's rate.Limiter that supports both global and a per-sender rate limiting.
 type RateLimiter struct {
 	global    *rate.Limiter
@@ -47,3 +55,7 @@ func (rl *RateLimiter) Allow(sender string) bool {
 
 	return senderLimiter.Allow() && rl.global.Allow()
 }
+
+func (rl *RateLimiter) AllowContext(_ context.Context, sender string) bool {
+	return rl.Allow(sender)
+}
@@ -0,0 +1,127 @@
+package common
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	// globalBucketPrefix prefixes the senders of the buckets of the global limit, which no sender can collide with,
+	// as they are hex addresses.
+	globalBucketPrefix = "global/"
+	// maxGlobalShards is the maximum number of buckets the global limit is split across.
+	maxGlobalShards = 8
+	// minPruneInterval is the minimum interval between deletions of idle buckets.
+	minPruneInterval = time.Minute
+)
+
+// SharedRateLimiter enforces the limits of a RateLimiterConfig across all the Gateways of a DON sharing a database.
+// The global limit is split evenly across several buckets, which are taken from starting at a random one, so that
+// concurrent requests mostly lock different rows. Tokens refill per bucket, so a fraction of a token may be left
+// unused in every bucket.
+// If the database is unavailable, requests are limited in-process until it is available again.
+type SharedRateLimiter struct {
+	orm          ORM
+	donID        string
+	name         string
+	config       RateLimiterConfig
+	globalShards int
+	idle         time.Duration
+	fallback     *RateLimiter
+	lggr         logger.Logger
+
+	mu         sync.Mutex
+	lastPruned time.Time
+}
+
+var _ Limiter = (*SharedRateLimiter)(nil)
+
+// NewSharedRateLimiter returns a limiter of the DON whose buckets are stored with the ORM. The name distinguishes the
+// buckets of the limiters of the same DON, e.g. of users and of nodes.
+func NewSharedRateLimiter(config RateLimiterConfig, orm ORM, donID string, name string, lggr logger.Logger) (*SharedRateLimiter, error) {
+	fallback, err := NewRateLimiter(config)
+	if err != nil {
+		return nil, err
+	}
+	rl := &SharedRateLimiter{
+		orm:          orm,
+		donID:        donID,
+		name:         name,
+		config:       config,
+		globalShards: min(maxGlobalShards, config.GlobalBurst),
+		fallback:     fallback,
+		lggr:         lggr.Named("SharedRateLimiter"),
+	}
+	// a bucket refilled for longer than it takes to fill it up is full, like a new one, so it can be deleted
+	globalRPS, globalBurst := rl.globalShard(0)
+	rl.idle = max(refillDuration(config.PerSenderRPS, config.PerSenderBurst), refillDuration(globalRPS, globalBurst))
+	return rl, nil
+}
+
+func refillDuration(rps float64, burst int) time.Duration {
+	return time.Duration(float64(burst) / rps * float64(time.Second))
+}
+
+func (rl *SharedRateLimiter) AllowContext(ctx context.Context, sender string) bool {
+	rl.pruneIdle(ctx)
+	allowed, err := rl.orm.TakeToken(ctx, rl.donID, rl.name, sender, rl.config.PerSenderRPS, rl.config.PerSenderBurst)
+	if err == nil && allowed {
+		allowed, err = rl.takeGlobalToken(ctx)
+	}
+	if err != nil {
+		rl.lggr.Errorw("failed to take token from shared bucket, limiting in-process", "donID", rl.donID, "limiter", rl.name, "sender", sender, "err", err)
+		return rl.fallback.Allow(sender)
+	}
+	return allowed
+}
+
+// takeGlobalToken takes a token from the first bucket of the global limit which is not empty, starting at a random
+// one. It returns false if all the buckets are empty.
+func (rl *SharedRateLimiter) takeGlobalToken(ctx context.Context) (bool, error) {
+	start := rand.Intn(rl.globalShards) //nolint:gosec // shards only spread the load of the global limit
+	for i := 0; i < rl.globalShards; i++ {
+		shard := (start + i) % rl.globalShards
+		rps, burst := rl.globalShard(shard)
+		allowed, err := rl.orm.TakeToken(ctx, rl.donID, rl.name, globalBucketPrefix+strconv.Itoa(shard), rps, burst)
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+	return false, nil
+}
+
+// globalShard returns the rate and burst of the bucket of the global limit with the given index. The burst is split
+// so that the bursts of all buckets add up to the global burst.
+func (rl *SharedRateLimiter) globalShard(shard int) (float64, int) {
+	burst := rl.config.GlobalBurst / rl.globalShards
+	if shard < rl.config.GlobalBurst%rl.globalShards {
+		burst++
+	}
+	return rl.config.GlobalRPS / float64(rl.globalShards), burst
+}
+
+// pruneIdle deletes the buckets of the limiter which are full again, at most once per minute, or per the time it
+// takes to fill up a bucket if longer.
+func (rl *SharedRateLimiter) pruneIdle(ctx context.Context) {
+	rl.mu.Lock()
+	if time.Since(rl.lastPruned) < max(rl.idle, minPruneInterval) {
+		rl.mu.Unlock()
+		return
+	}
+	rl.lastPruned = time.Now()
+	rl.mu.Unlock()
+
+	n, err := rl.orm.DeleteIdleBuckets(ctx, rl.donID, rl.name, rl.idle)
+	if err != nil {
+		rl.lggr.Errorw("failed to delete idle buckets", "donID", rl.donID, "limiter", rl.name, "err", err)
+		return
+	}
+	if n > 0 {
+		rl.lggr.Debugw("deleted idle buckets", "donID", rl.donID, "limiter", rl.name, "count", n)
+	}
+}
@@ -0,0 +1,211 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
+)
+
+// memoryORM is a local stand-in for the database shared by Gateways.
+type memoryORM struct {
+	mu       sync.Mutex
+	clock    clockwork.Clock
+	buckets  map[[3]string]*memoryBucket
+	requests map[[3]string]time.Time
+	err      error
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+var _ common.ORM = (*memoryORM)(nil)
+
+func newMemoryORM(clock clockwork.Clock) *memoryORM {
+	return &memoryORM{clock: clock, buckets: make(map[[3]string]*memoryBucket), requests: make(map[[3]string]time.Time)}
+}
+
+func (o *memoryORM) setErr(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.err = err
+}
+
+func (o *memoryORM) TakeToken(_ context.Context, donID, limiter, sender string, rps float64, burst int) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return false, o.err
+	}
+	now := o.clock.Now()
+	key := [3]string{donID, limiter, sender}
+	b, ok := o.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(burst), updatedAt: now}
+		o.buckets[key] = b
+	}
+	tokens := math.Min(float64(burst), b.tokens+now.Sub(b.updatedAt).Seconds()*rps)
+	if tokens < 1 {
+		return false, nil
+	}
+	b.tokens, b.updatedAt = tokens-1, now
+	return true, nil
+}
+
+func (o *memoryORM) DeleteIdleBuckets(_ context.Context, donID, limiter string, idle time.Duration) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return 0, o.err
+	}
+	var n int64
+	for key, b := range o.buckets {
+		if key[0] == donID && key[1] == limiter && !b.updatedAt.After(o.clock.Now().Add(-idle)) {
+			delete(o.buckets, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (o *memoryORM) bucketCount() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.buckets)
+}
+
+func (o *memoryORM) ReserveRequest(_ context.Context, donID, sender, messageID string, ttl time.Duration) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return false, o.err
+	}
+	key := [3]string{donID, sender, messageID}
+	if expiresAt, ok := o.requests[key]; ok && expiresAt.After(o.clock.Now()) {
+		return false, nil
+	}
+	o.requests[key] = o.clock.Now().Add(ttl)
+	return true, nil
+}
+
+func (o *memoryORM) ReleaseRequest(_ context.Context, donID, sender, messageID string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return o.err
+	}
+	delete(o.requests, [3]string{donID, sender, messageID})
+	return nil
+}
+
+func (o *memoryORM) DeleteExpiredRequests(context.Context) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return 0, o.err
+	}
+	var n int64
+	for key, expiresAt := range o.requests {
+		if !expiresAt.After(o.clock.Now()) {
+			delete(o.requests, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	clock := clockwork.NewFakeClock()
+	orm := newMemoryORM(clock)
+	// the global limit is split across 3 buckets refilled at 1 RPS each
+	config := common.RateLimiterConfig{
+		GlobalRPS:      3.0,
+		GlobalBurst:    3,
+		PerSenderRPS:   1.0,
+		PerSenderBurst: 2,
+	}
+	// two Gateways of the same DON
+	rl1, err := common.NewSharedRateLimiter(config, orm, "don1", "user", logger.TestLogger(t))
+	require.NoError(t, err)
+	rl2, err := common.NewSharedRateLimiter(config, orm, "don1", "user", logger.TestLogger(t))
+	require.NoError(t, err)
+
+	require.True(t, rl1.AllowContext(ctx, "user1"))
+	require.True(t, rl2.AllowContext(ctx, "user1"))
+	require.False(t, rl1.AllowContext(ctx, "user1"))
+	require.False(t, rl2.AllowContext(ctx, "user1"))
+	require.True(t, rl2.AllowContext(ctx, "user2"))
+	require.False(t, rl1.AllowContext(ctx, "user3"))
+
+	// other DONs and limiters have their own buckets
+	rl3, err := common.NewSharedRateLimiter(config, orm, "don2", "user", logger.TestLogger(t))
+	require.NoError(t, err)
+	require.True(t, rl3.AllowContext(ctx, "user1"))
+	rl4, err := common.NewSharedRateLimiter(config, orm, "don1", "node", logger.TestLogger(t))
+	require.NoError(t, err)
+	require.True(t, rl4.AllowContext(ctx, "user1"))
+
+	clock.Advance(time.Second)
+	require.True(t, rl1.AllowContext(ctx, "user1"))
+	require.False(t, rl2.AllowContext(ctx, "user1"))
+
+	t.Run("database unavailable", func(t *testing.T) {
+		orm.setErr(errors.New("connection refused"))
+		rl, err := common.NewSharedRateLimiter(config, orm, "don1", "user", logger.TestLogger(t))
+		require.NoError(t, err)
+		require.True(t, rl.AllowContext(ctx, "user1"))
+		require.True(t, rl.AllowContext(ctx, "user1"))
+		require.False(t, rl.AllowContext(ctx, "user1"))
+	})
+
+	_, err = common.NewSharedRateLimiter(common.RateLimiterConfig{}, orm, "don1", "user", logger.TestLogger(t))
+	require.Error(t, err)
+}
+
+func TestSharedRateLimiter_PrunesIdleBuckets(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	clock := clockwork.NewFakeClock()
+	orm := newMemoryORM(clock)
+	config := common.RateLimiterConfig{
+		GlobalRPS:      100.0,
+		GlobalBurst:    1,
+		PerSenderRPS:   1.0,
+		PerSenderBurst: 1,
+	}
+	rl, err := common.NewSharedRateLimiter(config, orm, "don1", "user", logger.TestLogger(t))
+	require.NoError(t, err)
+	other, err := common.NewSharedRateLimiter(config, orm, "don1", "node", logger.TestLogger(t))
+	require.NoError(t, err)
+
+	require.True(t, rl.AllowContext(ctx, "user1"))
+	require.True(t, other.AllowContext(ctx, "user1"))
+	require.Equal(t, 4, orm.bucketCount())
+
+	// the first request pruned nothing and the next prune is due in a minute
+	clock.Advance(2 * time.Second)
+	require.True(t, rl.AllowContext(ctx, "user2"))
+	require.Equal(t, 5, orm.bucketCount())
+
+	// the buckets of the limiter idle for longer than it takes to fill them up are deleted by a new Gateway
+	rl2, err := common.NewSharedRateLimiter(config, orm, "don1", "user", logger.TestLogger(t))
+	require.NoError(t, err)
+	clock.Advance(2 * time.Second)
+	require.True(t, rl2.AllowContext(ctx, "user3"))
+	require.Equal(t, 4, orm.bucketCount())
+}
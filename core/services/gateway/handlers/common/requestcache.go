@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
@@ -15,7 +16,7 @@ import (
 // Additionally, each request has a timeout, after which the netry will be removed from the cache and an error sent to the callback channel.
 // All methods are thread-safe.
 type RequestCache[T any] interface {
-	NewRequest(request *api.Message, callbackCh chan<- handlers.UserCallbackPayload, responseData *T) error
+	NewRequest(ctx context.Context, request *api.Message, callbackCh chan<- handlers.UserCallbackPayload, responseData *T) error
 	ProcessResponse(response *api.Message, process ResponseProcessor[T]) error
 }
 
@@ -46,7 +47,7 @@ func NewRequestCache[T any](timeout time.Duration, maxCacheSize uint32) RequestC
 	return &requestCache[T]{cache: make(map[globalId]*pendingRequest[T]), timeout: timeout, maxCacheSize: maxCacheSize}
 }
 
-func (c *requestCache[T]) NewRequest(request *api.Message, callbackCh chan<- handlers.UserCallbackPayload, responseData *T) error {
+func (c *requestCache[T]) NewRequest(_ context.Context, request *api.Message, callbackCh chan<- handlers.UserCallbackPayload, responseData *T) error {
 	if request == nil {
 		return errors.New("request is nil")
 	}
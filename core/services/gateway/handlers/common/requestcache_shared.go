@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
+)
+
+// sharedRequestCache is a RequestCache rejecting the requests whose message IDs are pending in any Gateway of the DON
+// sharing a database. The state of requests stays in the Gateway they were sent to, which is the one receiving the
+// responses of nodes.
+// Message IDs are reserved until the timeout of their requests, even once the requests are complete. If the database
+// is unavailable, duplicate requests are only rejected in-process until it is available again.
+type sharedRequestCache[T any] struct {
+	local   RequestCache[T]
+	orm     ORM
+	donID   string
+	timeout time.Duration
+	lggr    logger.Logger
+
+	mu         sync.Mutex
+	lastPruned time.Time
+}
+
+func NewSharedRequestCache[T any](timeout time.Duration, maxCacheSize uint32, orm ORM, donID string, lggr logger.Logger) RequestCache[T] {
+	return &sharedRequestCache[T]{
+		local:   NewRequestCache[T](timeout, maxCacheSize),
+		orm:     orm,
+		donID:   donID,
+		timeout: timeout,
+		lggr:    lggr.Named("SharedRequestCache"),
+	}
+}
+
+func (c *sharedRequestCache[T]) NewRequest(ctx context.Context, request *api.Message, callbackCh chan<- handlers.UserCallbackPayload, responseData *T) error {
+	if request == nil {
+		return errors.New("request is nil")
+	}
+	c.pruneExpired(ctx)
+	reserved, err := c.orm.ReserveRequest(ctx, c.donID, request.Body.Sender, request.Body.MessageId, c.timeout)
+	if err != nil {
+		c.lggr.Errorw("failed to reserve message ID, deduplicating in-process", "sender", request.Body.Sender, "messageID", request.Body.MessageId, "err", err)
+	} else if !reserved {
+		return errors.New("request already exists")
+	}
+	if err = c.local.NewRequest(ctx, request, callbackCh, responseData); err != nil {
+		if reserved {
+			if rerr := c.orm.ReleaseRequest(ctx, c.donID, request.Body.Sender, request.Body.MessageId); rerr != nil {
+				c.lggr.Errorw("failed to release message ID", "sender", request.Body.Sender, "messageID", request.Body.MessageId, "err", rerr)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *sharedRequestCache[T]) ProcessResponse(response *api.Message, process ResponseProcessor[T]) error {
+	return c.local.ProcessResponse(response, process)
+}
+
+// pruneExpired deletes the expired reservations, at most once per timeout.
+func (c *sharedRequestCache[T]) pruneExpired(ctx context.Context) {
+	c.mu.Lock()
+	if time.Since(c.lastPruned) < c.timeout {
+		c.mu.Unlock()
+		return
+	}
+	c.lastPruned = time.Now()
+	c.mu.Unlock()
+
+	n, err := c.orm.DeleteExpiredRequests(ctx)
+	if err != nil {
+		c.lggr.Errorw("failed to delete expired message IDs", "err", err)
+		return
+	}
+	if n > 0 {
+		c.lggr.Debugw("deleted expired message IDs", "count", n)
+	}
+}
@@ -0,0 +1,67 @@
+package common_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
+)
+
+func TestSharedRequestCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	clock := clockwork.NewFakeClock()
+	orm := newMemoryORM(clock)
+	// two Gateways of the same DON
+	cache1 := common.NewSharedRequestCache[requestState](time.Hour, 1, orm, "don1", logger.TestLogger(t))
+	cache2 := common.NewSharedRequestCache[requestState](time.Hour, 1, orm, "don1", logger.TestLogger(t))
+
+	req := &api.Message{Body: api.MessageBody{MessageId: "aa", Sender: "0x1234"}}
+	require.NoError(t, cache1.NewRequest(ctx, req, make(chan handlers.UserCallbackPayload, 1), &requestState{}))
+	require.ErrorContains(t, cache2.NewRequest(ctx, req, make(chan handlers.UserCallbackPayload, 1), &requestState{}), "request already exists")
+
+	// other DONs have their own message IDs
+	cache3 := common.NewSharedRequestCache[requestState](time.Hour, 1, orm, "don2", logger.TestLogger(t))
+	require.NoError(t, cache3.NewRequest(ctx, req, make(chan handlers.UserCallbackPayload, 1), &requestState{}))
+
+	// responses are processed by the Gateway of the request
+	callbackCh := make(chan handlers.UserCallbackPayload, 1)
+	req2 := &api.Message{Body: api.MessageBody{MessageId: "bb", Sender: "0x1234"}}
+	require.NoError(t, cache2.NewRequest(ctx, req2, callbackCh, &requestState{}))
+	resp := &api.Message{Body: api.MessageBody{MessageId: "bb", Receiver: "0x1234"}}
+	require.Error(t, cache1.ProcessResponse(resp, func(*api.Message, *requestState) (*handlers.UserCallbackPayload, *requestState, error) {
+		return &handlers.UserCallbackPayload{Msg: resp}, nil, nil
+	}))
+	require.NoError(t, cache2.ProcessResponse(resp, func(*api.Message, *requestState) (*handlers.UserCallbackPayload, *requestState, error) {
+		return &handlers.UserCallbackPayload{Msg: resp}, nil, nil
+	}))
+	require.Equal(t, resp, (<-callbackCh).Msg)
+
+	// message IDs of requests rejected by the Gateway are released
+	req3 := &api.Message{Body: api.MessageBody{MessageId: "cc", Sender: "0x1234"}}
+	require.ErrorContains(t, cache1.NewRequest(ctx, req3, make(chan handlers.UserCallbackPayload, 1), &requestState{}), "request cache is full")
+	require.NoError(t, cache2.NewRequest(ctx, req3, make(chan handlers.UserCallbackPayload, 1), &requestState{}))
+
+	// message IDs are reserved until the timeout of their requests
+	clock.Advance(time.Hour)
+	require.NoError(t, cache3.ProcessResponse(&api.Message{Body: api.MessageBody{MessageId: "aa", Receiver: "0x1234"}}, func(*api.Message, *requestState) (*handlers.UserCallbackPayload, *requestState, error) {
+		return &handlers.UserCallbackPayload{Msg: req}, nil, nil
+	}))
+	require.NoError(t, cache3.NewRequest(ctx, req, make(chan handlers.UserCallbackPayload, 1), &requestState{}))
+
+	t.Run("database unavailable", func(t *testing.T) {
+		orm.setErr(errors.New("connection refused"))
+		cache := common.NewSharedRequestCache[requestState](time.Hour, 10, orm, "don1", logger.TestLogger(t))
+		require.NoError(t, cache.NewRequest(ctx, req, make(chan handlers.UserCallbackPayload, 1), &requestState{}))
+		require.ErrorContains(t, cache.NewRequest(ctx, req, make(chan handlers.UserCallbackPayload, 1), &requestState{}), "request already exists")
+	})
+}
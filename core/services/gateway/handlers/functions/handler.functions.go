@@ -71,6 +71,8 @@ type FunctionsHandlerConfig struct {
 	MaxPendingRequests         uint32                `json:"maxPendingRequests"`
 	RequestTimeoutMillis       int64                 `json:"requestTimeoutMillis"`
 	AllowedHeartbeatInitiators []string              `json:"allowedHeartbeatInitiators"`
+	// SharedState shares rate limits and pending message IDs with the other Gateways of the DON using the same database
+	SharedState bool `json:"sharedState"`
 }
 
 type functionsHandler struct {
@@ -83,8 +85,8 @@ type functionsHandler struct {
 	allowlist                  fallow.OnchainAllowlist
 	subscriptions              fsub.OnchainSubscriptions
 	minimumBalance             *assets.Link
-	userRateLimiter            *hc.RateLimiter
-	nodeRateLimiter            *hc.RateLimiter
+	userRateLimiter            hc.Limiter
+	nodeRateLimiter            hc.Limiter
 	allowedHeartbeatInitiators map[string]struct{}
 	chStop                     services.StopChan
 	lggr                       logger.Logger
@@ -98,6 +100,7 @@ type PendingRequest struct {
 }
 
 var _ handlers.Handler = (*functionsHandler)(nil)
+var _ handlers.MethodDescriber = (*functionsHandler)(nil)
 
 func NewFunctionsHandlerFromConfig(handlerConfig json.RawMessage, donConfig *config.DONConfig, don handlers.DON, legacyChains legacyevm.LegacyChainContainer, ds sqlutil.DataSource, lggr logger.Logger) (handlers.Handler, error) {
 	var cfg FunctionsHandlerConfig
@@ -122,15 +125,22 @@ func NewFunctionsHandlerFromConfig(handlerConfig json.RawMessage, donConfig *con
 			return nil, err2
 		}
 	}
-	var userRateLimiter, nodeRateLimiter *hc.RateLimiter
+	var sharedStateORM hc.ORM
+	if cfg.SharedState {
+		if ds == nil {
+			return nil, errors.New("shared state requires a database")
+		}
+		sharedStateORM = hc.NewORM(ds)
+	}
+	var userRateLimiter, nodeRateLimiter hc.Limiter
 	if cfg.UserRateLimiter != nil {
-		userRateLimiter, err = hc.NewRateLimiter(*cfg.UserRateLimiter)
+		userRateLimiter, err = newRateLimiter(*cfg.UserRateLimiter, sharedStateORM, donConfig.DonId, "user", lggr)
 		if err != nil {
 			return nil, err
 		}
 	}
 	if cfg.NodeRateLimiter != nil {
-		nodeRateLimiter, err = hc.NewRateLimiter(*cfg.NodeRateLimiter)
+		nodeRateLimiter, err = newRateLimiter(*cfg.NodeRateLimiter, sharedStateORM, donConfig.DonId, "node", lggr)
 		if err != nil {
 			return nil, err
 		}
@@ -156,10 +166,21 @@ func NewFunctionsHandlerFromConfig(handlerConfig json.RawMessage, donConfig *con
 	for _, initiator := range cfg.AllowedHeartbeatInitiators {
 		allowedHeartbeatInitiators[strings.ToLower(initiator)] = struct{}{}
 	}
-	pendingRequestsCache := hc.NewRequestCache[PendingRequest](time.Millisecond*time.Duration(cfg.RequestTimeoutMillis), cfg.MaxPendingRequests)
+	requestTimeout := time.Millisecond * time.Duration(cfg.RequestTimeoutMillis)
+	pendingRequestsCache := hc.NewRequestCache[PendingRequest](requestTimeout, cfg.MaxPendingRequests)
+	if sharedStateORM != nil {
+		pendingRequestsCache = hc.NewSharedRequestCache[PendingRequest](requestTimeout, cfg.MaxPendingRequests, sharedStateORM, donConfig.DonId, lggr)
+	}
 	return NewFunctionsHandler(cfg, donConfig, don, pendingRequestsCache, allowlist, subscriptions, cfg.MinimumSubscriptionBalance, userRateLimiter, nodeRateLimiter, allowedHeartbeatInitiators, lggr), nil
 }
 
+func newRateLimiter(cfg hc.RateLimiterConfig, sharedStateORM hc.ORM, donID string, name string, lggr logger.Logger) (hc.Limiter, error) {
+	if sharedStateORM != nil {
+		return hc.NewSharedRateLimiter(cfg, sharedStateORM, donID, name, lggr)
+	}
+	return hc.NewRateLimiter(cfg)
+}
+
 func NewFunctionsHandler(
 	cfg FunctionsHandlerConfig,
 	donConfig *config.DONConfig,
@@ -168,8 +189,8 @@ func NewFunctionsHandler(
 	allowlist fallow.OnchainAllowlist,
 	subscriptions fsub.OnchainSubscriptions,
 	minimumBalance *assets.Link,
-	userRateLimiter *hc.RateLimiter,
-	nodeRateLimiter *hc.RateLimiter,
+	userRateLimiter hc.Limiter,
+	nodeRateLimiter hc.Limiter,
 	allowedHeartbeatInitiators map[string]struct{},
 	lggr logger.Logger) handlers.Handler {
 	return &functionsHandler{
@@ -195,7 +216,7 @@ func (h *functionsHandler) HandleUserMessage(ctx context.Context, msg *api.Messa
 		promHandlerError.WithLabelValues(h.donConfig.DonId, ErrNotAllowlisted.Error()).Inc()
 		return ErrNotAllowlisted
 	}
-	if h.userRateLimiter != nil && !h.userRateLimiter.Allow(msg.Body.Sender) {
+	if h.userRateLimiter != nil && !h.userRateLimiter.AllowContext(ctx, msg.Body.Sender) {
 		h.lggr.Debugw("rate-limited", "sender", msg.Body.Sender)
 		promHandlerError.WithLabelValues(h.donConfig.DonId, ErrRateLimited.Error()).Inc()
 		return ErrRateLimited
@@ -230,16 +251,24 @@ func (h *functionsHandler) HandleUserMessage(ctx context.Context, msg *api.Messa
 	}
 }
 
+func (h *functionsHandler) Methods() []handlers.MethodDescription {
+	return []handlers.MethodDescription{
+		{Name: MethodSecretsSet, Description: "Stores encrypted secrets in a slot of the DON.", Request: SecretsSetRequest{}, Response: CombinedResponse{}},
+		{Name: MethodSecretsList, Description: "Lists the secrets of the sender stored in the DON.", Response: CombinedResponse{}},
+		{Name: MethodHeartbeat, Description: "Sends a heartbeat request to the DON, only accepted from the allowed initiators.", Response: CombinedResponse{}},
+	}
+}
+
 func (h *functionsHandler) handleRequest(ctx context.Context, msg *api.Message, callbackCh chan<- handlers.UserCallbackPayload) error {
 	h.lggr.Debugw("handleRequest: processing message", "sender", msg.Body.Sender, "messageId", msg.Body.MessageId)
-	err := h.pendingRequests.NewRequest(msg, callbackCh, &PendingRequest{request: msg, responses: make(map[string]*api.Message)})
+	err := h.pendingRequests.NewRequest(ctx, msg, callbackCh, &PendingRequest{request: msg, responses: make(map[string]*api.Message)})
 	if err != nil {
 		h.lggr.Warnw("handleRequest: error adding new request", "sender", msg.Body.Sender, "err", err)
 		promHandlerError.WithLabelValues(h.donConfig.DonId, err.Error()).Inc()
 		return err
 	}
 	// Send to all nodes.
-	for _, member := range h.donConfig.Members {
+	for _, member := range h.don.Members() {
 		err := h.don.SendToNode(ctx, member.Address, msg)
 		if err != nil {
 			h.lggr.Debugw("handleRequest: failed to send to a node", "node", member.Address, "err", err)
@@ -250,7 +279,7 @@ func (h *functionsHandler) handleRequest(ctx context.Context, msg *api.Message,
 
 func (h *functionsHandler) HandleNodeMessage(ctx context.Context, msg *api.Message, nodeAddr string) error {
 	h.lggr.Debugw("HandleNodeMessage: processing message", "nodeAddr", nodeAddr, "receiver", msg.Body.Receiver, "id", msg.Body.MessageId)
-	if h.nodeRateLimiter != nil && !h.nodeRateLimiter.Allow(nodeAddr) {
+	if h.nodeRateLimiter != nil && !h.nodeRateLimiter.AllowContext(ctx, nodeAddr) {
 		h.lggr.Debugw("rate-limited", "sender", nodeAddr)
 		return errors.New("rate-limited")
 	}
@@ -290,7 +319,7 @@ func (h *functionsHandler) processSecretsResponse(response *api.Message, respons
 		}
 	} else {
 		responseData.errors = append(responseData.errors, response)
-		if len(responseData.errors) >= len(h.donConfig.Members)-h.donConfig.F {
+		if len(responseData.errors) >= len(h.don.Members())-h.donConfig.F {
 			// return error to the user
 			callbackPayload, err := newSecretsResponse(responseData.request, false, responseData.errors)
 			return callbackPayload, responseData, err
@@ -15,6 +15,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/assets"
 	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
 	gc "github.com/smartcontractkit/chainlink/v2/core/services/gateway/common"
@@ -42,6 +43,7 @@ func newFunctionsHandlerForATestDON(t *testing.T, nodes []gc.TestNode, requestTi
 	}
 
 	don := handlers_mocks.NewDON(t)
+	don.On("Members").Return(donConfig.Members).Maybe()
 	allowlist := allowlist_mocks.NewOnchainAllowlist(t)
 	subscriptions := subscriptions_mocks.NewOnchainSubscriptions(t)
 	minBalance := assets.NewLinkFromJuels(100)
@@ -102,6 +104,18 @@ func TestFunctionsHandler_CleanStartAndClose(t *testing.T) {
 	servicetest.Run(t, handler)
 }
 
+func TestFunctionsHandler_SharedState(t *testing.T) {
+	t.Parallel()
+
+	handlerConfig := json.RawMessage(`{"sharedState": true, "userRateLimiter": {"globalRPS": 1, "globalBurst": 1, "perSenderRPS": 1, "perSenderBurst": 1}}`)
+	_, err := functions.NewFunctionsHandlerFromConfig(handlerConfig, &config.DONConfig{}, nil, nil, nil, logger.TestLogger(t))
+	require.ErrorContains(t, err, "shared state requires a database")
+
+	handler, err := functions.NewFunctionsHandlerFromConfig(handlerConfig, &config.DONConfig{DonId: "don1"}, nil, nil, pgtest.NewSqlxDB(t), logger.TestLogger(t))
+	require.NoError(t, err)
+	servicetest.Run(t, handler)
+}
+
 func TestFunctionsHandler_HandleUserMessage_SecretsSet(t *testing.T) {
 	t.Parallel()
 
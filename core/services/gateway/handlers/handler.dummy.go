@@ -44,7 +44,7 @@ func (d *dummyHandler) HandleUserMessage(ctx context.Context, msg *api.Message,
 
 	var err error
 	// Send to all nodes.
-	for _, member := range d.donConfig.Members {
+	for _, member := range don.Members() {
 		err = multierr.Combine(err, don.SendToNode(ctx, member.Address, msg))
 	}
 	return err
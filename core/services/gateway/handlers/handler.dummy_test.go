@@ -15,6 +15,7 @@ import (
 
 type testConnManager struct {
 	handler     handlers.Handler
+	members     []config.NodeConfig
 	sendCounter int
 }
 
@@ -22,6 +23,10 @@ func (m *testConnManager) SetHandler(handler handlers.Handler) {
 	m.handler = handler
 }
 
+func (m *testConnManager) Members() []config.NodeConfig {
+	return m.members
+}
+
 func (m *testConnManager) SendToNode(ctx context.Context, nodeAddress string, msg *api.Message) error {
 	m.sendCounter++
 	return nil
@@ -37,7 +42,7 @@ func TestDummyHandler_BasicFlow(t *testing.T) {
 		},
 	}
 
-	connMgr := testConnManager{}
+	connMgr := testConnManager{members: config.Members}
 	handler, err := handlers.NewDummyHandler(&config, &connMgr, logger.TestLogger(t))
 	require.NoError(t, err)
 	connMgr.SetHandler(handler)
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 )
 
@@ -36,8 +37,25 @@ type Handler interface {
 	HandleNodeMessage(ctx context.Context, msg *api.Message, nodeAddr string) error
 }
 
+// MethodDescriber is implemented by handlers describing the methods of their user messages, e.g. in the OpenAPI
+// documents of the REST endpoint of the Gateway.
+type MethodDescriber interface {
+	Methods() []MethodDescription
+}
+
+// MethodDescription describes a method of user messages. Request and Response are values of the types of the
+// payloads of the request and of the response, from which their JSON schemas are generated. Nil means any payload.
+type MethodDescription struct {
+	Name        string
+	Description string
+	Request     any
+	Response    any
+}
+
 // Representation of a DON from a Handler's perspective.
 type DON interface {
 	// Thread-safe
 	SendToNode(ctx context.Context, nodeAddress string, msg *api.Message) error
+	// Members returns the current members of the DON, which change with the updates of the admin API. Thread-safe.
+	Members() []config.NodeConfig
 }
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	api "github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	config "github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -23,6 +24,53 @@ func (_m *DON) EXPECT() *DON_Expecter {
 	return &DON_Expecter{mock: &_m.Mock}
 }
 
+// Members provides a mock function with given fields:
+func (_m *DON) Members() []config.NodeConfig {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Members")
+	}
+
+	var r0 []config.NodeConfig
+	if rf, ok := ret.Get(0).(func() []config.NodeConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]config.NodeConfig)
+		}
+	}
+
+	return r0
+}
+
+// DON_Members_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Members'
+type DON_Members_Call struct {
+	*mock.Call
+}
+
+// Members is a helper method to define mock.On call
+func (_e *DON_Expecter) Members() *DON_Members_Call {
+	return &DON_Members_Call{Call: _e.mock.On("Members")}
+}
+
+func (_c *DON_Members_Call) Run(run func()) *DON_Members_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DON_Members_Call) Return(_a0 []config.NodeConfig) *DON_Members_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DON_Members_Call) RunAndReturn(run func() []config.NodeConfig) *DON_Members_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendToNode provides a mock function with given fields: ctx, nodeAddress, msg
 func (_m *DON) SendToNode(ctx context.Context, nodeAddress string, msg *api.Message) error {
 	ret := _m.Called(ctx, nodeAddress, msg)
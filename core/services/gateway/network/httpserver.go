@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
@@ -25,6 +26,25 @@ type HttpServer interface {
 
 type HTTPRequestHandler interface {
 	ProcessRequest(ctx context.Context, rawRequest []byte) (rawResponse []byte, httpStatusCode int)
+
+	// ProcessRESTRequest processes a user message of the REST endpoint, whose body is the payload of the message.
+	ProcessRESTRequest(ctx context.Context, donID string, method string, rawPayload []byte) (rawResponse []byte, httpStatusCode int)
+
+	// OpenAPIDocument returns the OpenAPI document of the REST endpoint of the DON, served under basePath.
+	OpenAPIDocument(donID string, basePath string) ([]byte, error)
+}
+
+type requestHeaderKey struct{}
+
+// WithRequestHeader returns a context carrying the header of the HTTP request, e.g. for its credentials.
+func WithRequestHeader(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, requestHeaderKey{}, header)
+}
+
+// RequestHeaderFromContext returns the header of the HTTP request carried by the context, if any.
+func RequestHeaderFromContext(ctx context.Context) http.Header {
+	header, _ := ctx.Value(requestHeaderKey{}).(http.Header)
+	return header
 }
 
 type HTTPServerConfig struct {
@@ -39,6 +59,9 @@ type HTTPServerConfig struct {
 	WriteTimeoutMillis   uint32
 	RequestTimeoutMillis uint32
 	MaxRequestBytes      int64
+	// RESTPathPrefix enables the REST endpoint of user messages at POST <prefix>/dons/{don}/methods/{method}, and
+	// its OpenAPI documents at GET <prefix>/dons/{don}/openapi.json, if set.
+	RESTPathPrefix string
 }
 
 type httpServer struct {
@@ -55,6 +78,8 @@ type httpServer struct {
 const (
 	HealthCheckPath     = "/health"
 	HealthCheckResponse = "OK"
+
+	restContentType = "application/json"
 )
 
 func NewHttpServer(config *HTTPServerConfig, lggr logger.Logger) HttpServer {
@@ -68,6 +93,10 @@ func NewHttpServer(config *HTTPServerConfig, lggr logger.Logger) HttpServer {
 	mux := http.NewServeMux()
 	mux.Handle(config.Path, http.HandlerFunc(server.handleRequest))
 	mux.Handle(HealthCheckPath, http.HandlerFunc(server.handleHealthCheck))
+	if prefix := strings.TrimSuffix(config.RESTPathPrefix, "/"); prefix != "" {
+		mux.Handle("POST "+prefix+"/dons/{don}/methods/{method}", http.HandlerFunc(server.handleRESTRequest))
+		mux.Handle("GET "+prefix+"/dons/{don}/openapi.json", http.HandlerFunc(server.handleOpenAPIDocument))
+	}
 	server.server = &http.Server{
 		Addr:              fmt.Sprintf("%s:%d", config.Host, config.Port),
 		Handler:           mux,
@@ -96,12 +125,8 @@ func (s *httpServer) handleRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	requestCtx := r.Context()
-	if s.config.RequestTimeoutMillis > 0 {
-		var cancel context.CancelFunc
-		requestCtx, cancel = context.WithTimeout(requestCtx, time.Duration(s.config.RequestTimeoutMillis)*time.Millisecond)
-		defer cancel()
-	}
+	requestCtx, cancel := s.newRequestContext(r)
+	defer cancel()
 	rawResponse, httpStatusCode := s.handler.ProcessRequest(requestCtx, rawMessage)
 
 	w.Header().Set("Content-Type", s.config.ContentTypeHeader)
@@ -112,6 +137,51 @@ func (s *httpServer) handleRequest(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *httpServer) handleRESTRequest(w http.ResponseWriter, r *http.Request) {
+	source := http.MaxBytesReader(nil, r.Body, s.config.MaxRequestBytes)
+	rawPayload, err := io.ReadAll(source)
+	if err != nil {
+		s.lggr.Error("error reading request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	requestCtx, cancel := s.newRequestContext(r)
+	defer cancel()
+	rawResponse, httpStatusCode := s.handler.ProcessRESTRequest(requestCtx, r.PathValue("don"), r.PathValue("method"), rawPayload)
+
+	w.Header().Set("Content-Type", restContentType)
+	w.WriteHeader(httpStatusCode)
+	_, err = w.Write(rawResponse)
+	if err != nil {
+		s.lggr.Error("error when writing response", err)
+	}
+}
+
+func (s *httpServer) handleOpenAPIDocument(w http.ResponseWriter, r *http.Request) {
+	document, err := s.handler.OpenAPIDocument(r.PathValue("don"), strings.TrimSuffix(s.config.RESTPathPrefix, "/"))
+	if err != nil {
+		s.lggr.Debugw("error generating OpenAPI document", "donID", r.PathValue("don"), "err", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", restContentType)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(document)
+	if err != nil {
+		s.lggr.Error("error when writing response", err)
+	}
+}
+
+// newRequestContext returns the context of processing the request, carrying its header.
+func (s *httpServer) newRequestContext(r *http.Request) (context.Context, context.CancelFunc) {
+	requestCtx := WithRequestHeader(r.Context(), r.Header)
+	if s.config.RequestTimeoutMillis > 0 {
+		return context.WithTimeout(requestCtx, time.Duration(s.config.RequestTimeoutMillis)*time.Millisecond)
+	}
+	return context.WithCancel(requestCtx)
+}
+
 func (s *httpServer) SetHTTPRequestHandler(handler HTTPRequestHandler) {
 	s.handler = handler
 }
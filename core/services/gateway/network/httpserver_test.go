@@ -2,6 +2,8 @@ package network_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,8 +20,9 @@ import (
 )
 
 const (
-	HTTPTestHost = "localhost"
-	HTTPTestPath = "/test_path"
+	HTTPTestHost     = "localhost"
+	HTTPTestPath     = "/test_path"
+	HTTPTestRESTPath = "/rest"
 )
 
 func startNewServer(t *testing.T, maxRequestBytes int64, readTimeoutMillis uint32) (server network.HttpServer, handler *mocks.HTTPRequestHandler, url string) {
@@ -33,6 +36,7 @@ func startNewServer(t *testing.T, maxRequestBytes int64, readTimeoutMillis uint3
 		WriteTimeoutMillis:   10_000,
 		RequestTimeoutMillis: 10_000,
 		MaxRequestBytes:      maxRequestBytes,
+		RESTPathPrefix:       HTTPTestRESTPath + "/",
 	}
 
 	handler = mocks.NewHTTPRequestHandler(t)
@@ -90,3 +94,51 @@ func TestHTTPServer_HandleHealthCheck(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 	require.Equal(t, []byte(network.HealthCheckResponse), respBytes)
 }
+
+func TestHTTPServer_HandleRESTRequest(t *testing.T) {
+	t.Parallel()
+	server, handler, url := startNewServer(t, 100_000, 100_000)
+	defer server.Close()
+
+	handler.On("ProcessRESTRequest", mock.Anything, "don1", "method1", []byte(`{"a":1}`)).Return([]byte(`{"b":2}`), 200).Run(func(args mock.Arguments) {
+		header := network.RequestHeaderFromContext(args.Get(0).(context.Context))
+		require.Equal(t, "abcd", header.Get("X-Message-Id"))
+	})
+
+	url = strings.Replace(url, HTTPTestPath, HTTPTestRESTPath+"/dons/don1/methods/method1", 1)
+	req, err := http.NewRequestWithContext(testutils.Context(t), "POST", url, bytes.NewBufferString(`{"a":1}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Message-Id", "abcd")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	require.Equal(t, `{"b":2}`, string(respBytes))
+
+	// only POST requests are accepted
+	req, err = http.NewRequestWithContext(testutils.Context(t), "GET", url, nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestHTTPServer_HandleOpenAPIDocument(t *testing.T) {
+	t.Parallel()
+	server, handler, url := startNewServer(t, 100_000, 100_000)
+	defer server.Close()
+
+	handler.On("OpenAPIDocument", "don1", HTTPTestRESTPath).Return([]byte(`{"openapi":"3.1.0"}`), nil)
+	handler.On("OpenAPIDocument", "don2", HTTPTestRESTPath).Return(nil, errors.New("unsupported DON ID"))
+
+	for don, expectedStatus := range map[string]int{"don1": http.StatusOK, "don2": http.StatusNotFound} {
+		docURL := strings.Replace(url, HTTPTestPath, HTTPTestRESTPath+"/dons/"+don+"/openapi.json", 1)
+		req, err := http.NewRequestWithContext(testutils.Context(t), "GET", docURL, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, expectedStatus, resp.StatusCode, don)
+	}
+}
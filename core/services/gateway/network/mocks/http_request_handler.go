@@ -21,6 +21,126 @@ func (_m *HTTPRequestHandler) EXPECT() *HTTPRequestHandler_Expecter {
 	return &HTTPRequestHandler_Expecter{mock: &_m.Mock}
 }
 
+// OpenAPIDocument provides a mock function with given fields: donID, basePath
+func (_m *HTTPRequestHandler) OpenAPIDocument(donID string, basePath string) ([]byte, error) {
+	ret := _m.Called(donID, basePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenAPIDocument")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]byte, error)); ok {
+		return rf(donID, basePath)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []byte); ok {
+		r0 = rf(donID, basePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(donID, basePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HTTPRequestHandler_OpenAPIDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenAPIDocument'
+type HTTPRequestHandler_OpenAPIDocument_Call struct {
+	*mock.Call
+}
+
+// OpenAPIDocument is a helper method to define mock.On call
+//   - donID string
+//   - basePath string
+func (_e *HTTPRequestHandler_Expecter) OpenAPIDocument(donID interface{}, basePath interface{}) *HTTPRequestHandler_OpenAPIDocument_Call {
+	return &HTTPRequestHandler_OpenAPIDocument_Call{Call: _e.mock.On("OpenAPIDocument", donID, basePath)}
+}
+
+func (_c *HTTPRequestHandler_OpenAPIDocument_Call) Run(run func(donID string, basePath string)) *HTTPRequestHandler_OpenAPIDocument_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *HTTPRequestHandler_OpenAPIDocument_Call) Return(_a0 []byte, _a1 error) *HTTPRequestHandler_OpenAPIDocument_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HTTPRequestHandler_OpenAPIDocument_Call) RunAndReturn(run func(string, string) ([]byte, error)) *HTTPRequestHandler_OpenAPIDocument_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessRESTRequest provides a mock function with given fields: ctx, donID, method, rawPayload
+func (_m *HTTPRequestHandler) ProcessRESTRequest(ctx context.Context, donID string, method string, rawPayload []byte) ([]byte, int) {
+	ret := _m.Called(ctx, donID, method, rawPayload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessRESTRequest")
+	}
+
+	var r0 []byte
+	var r1 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) ([]byte, int)); ok {
+		return rf(ctx, donID, method, rawPayload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) []byte); ok {
+		r0 = rf(ctx, donID, method, rawPayload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) int); ok {
+		r1 = rf(ctx, donID, method, rawPayload)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	return r0, r1
+}
+
+// HTTPRequestHandler_ProcessRESTRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessRESTRequest'
+type HTTPRequestHandler_ProcessRESTRequest_Call struct {
+	*mock.Call
+}
+
+// ProcessRESTRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - donID string
+//   - method string
+//   - rawPayload []byte
+func (_e *HTTPRequestHandler_Expecter) ProcessRESTRequest(ctx interface{}, donID interface{}, method interface{}, rawPayload interface{}) *HTTPRequestHandler_ProcessRESTRequest_Call {
+	return &HTTPRequestHandler_ProcessRESTRequest_Call{Call: _e.mock.On("ProcessRESTRequest", ctx, donID, method, rawPayload)}
+}
+
+func (_c *HTTPRequestHandler_ProcessRESTRequest_Call) Run(run func(ctx context.Context, donID string, method string, rawPayload []byte)) *HTTPRequestHandler_ProcessRESTRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *HTTPRequestHandler_ProcessRESTRequest_Call) Return(rawResponse []byte, httpStatusCode int) *HTTPRequestHandler_ProcessRESTRequest_Call {
+	_c.Call.Return(rawResponse, httpStatusCode)
+	return _c
+}
+
+func (_c *HTTPRequestHandler_ProcessRESTRequest_Call) RunAndReturn(run func(context.Context, string, string, []byte) ([]byte, int)) *HTTPRequestHandler_ProcessRESTRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProcessRequest provides a mock function with given fields: ctx, rawRequest
 func (_m *HTTPRequestHandler) ProcessRequest(ctx context.Context, rawRequest []byte) ([]byte, int) {
 	ret := _m.Called(ctx, rawRequest)
@@ -0,0 +1,192 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/invopop/jsonschema"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
+	gw_net "github.com/smartcontractkit/chainlink/v2/core/services/gateway/network"
+)
+
+const (
+	// MessageIDHeader is the header of the message ID of REST requests. A random ID is generated if it is missing.
+	MessageIDHeader = "X-Message-Id"
+	// SignatureHeader is the header of the signature of REST requests, over the message with their message ID, method,
+	// DON ID and payload, i.e. their body.
+	SignatureHeader = "X-Signature"
+)
+
+type restError struct {
+	Error string `json:"error"`
+}
+
+// ProcessRESTRequest processes a request of the REST endpoint, whose body is the payload of the user message. The
+// response is the payload of the response of the handler.
+func (g *gateway) ProcessRESTRequest(ctx context.Context, donID string, method string, rawPayload []byte) ([]byte, int) {
+	if len(rawPayload) > 0 && !json.Valid(rawPayload) {
+		return newRESTError(api.UserMessageParseError, "payload is not valid JSON")
+	}
+	header := gw_net.RequestHeaderFromContext(ctx)
+	messageID := header.Get(MessageIDHeader)
+	if messageID == "" {
+		messageID = uuid.NewString()
+	}
+	msg := &api.Message{
+		Signature: header.Get(SignatureHeader),
+		Body: api.MessageBody{
+			MessageId: messageID,
+			Method:    method,
+			DonId:     donID,
+			Payload:   rawPayload,
+		},
+	}
+	response, errCode, errMsg := g.processMessage(ctx, msg)
+	if errCode != api.NoError {
+		return newRESTError(errCode, errMsg)
+	}
+	promRequest.WithLabelValues(api.NoError.String()).Inc()
+	if len(response.Body.Payload) == 0 {
+		return []byte("null"), api.ToHttpErrorCode(api.NoError)
+	}
+	return response.Body.Payload, api.ToHttpErrorCode(api.NoError)
+}
+
+func newRESTError(errCode api.ErrorCode, errMsg string) ([]byte, int) {
+	rawResponse, err := json.Marshal(restError{Error: errMsg})
+	if err != nil {
+		promRequest.WithLabelValues(api.FatalError.String()).Inc()
+		return []byte("fatal error"), api.ToHttpErrorCode(api.FatalError)
+	}
+	promRequest.WithLabelValues(errCode.String()).Inc()
+	return rawResponse, api.ToHttpErrorCode(errCode)
+}
+
+// OpenAPIDocument returns the OpenAPI document of the REST endpoint of the DON, with a path per method if its handler
+// describes them, or a single path taking the method as a parameter otherwise.
+func (g *gateway) OpenAPIDocument(donID string, basePath string) ([]byte, error) {
+	g.mu.RLock()
+	handler, ok := g.handlers[donID]
+	g.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported DON ID %s", donID)
+	}
+
+	_, authenticated := g.authenticators[donID]
+	parameters := []any{
+		map[string]any{
+			"name":        MessageIDHeader,
+			"in":          "header",
+			"description": "ID of the message, unique per sender. Generated if missing.",
+			"schema":      map[string]any{"type": "string", "maxLength": api.MessageIdMaxLen},
+		},
+		map[string]any{
+			"name":        SignatureHeader,
+			"in":          "header",
+			"description": "Hex-encoded signature of the message by the sender.",
+			"required":    !authenticated,
+			"schema":      map[string]any{"type": "string"},
+		},
+	}
+	errorResponse := map[string]any{
+		"description": "Error",
+		"content":     jsonContent(restError{}),
+	}
+
+	paths := make(map[string]any)
+	if describer, ok := handler.(handlers.MethodDescriber); ok {
+		for _, method := range describer.Methods() {
+			paths[fmt.Sprintf("/dons/%s/methods/%s", donID, method.Name)] = map[string]any{
+				"post": map[string]any{
+					"operationId": method.Name,
+					"summary":     method.Description,
+					"parameters":  parameters,
+					"requestBody": map[string]any{"content": jsonContent(method.Request)},
+					"responses": map[string]any{
+						"200":     map[string]any{"description": "Response of the DON", "content": jsonContent(method.Response)},
+						"default": errorResponse,
+					},
+				},
+			}
+		}
+	} else {
+		methodParameter := map[string]any{
+			"name":     "method",
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]any{"type": "string", "maxLength": api.MessageMethodMaxLen},
+		}
+		paths[fmt.Sprintf("/dons/%s/methods/{method}", donID)] = map[string]any{
+			"post": map[string]any{
+				"summary":     "Sends a message to the DON.",
+				"parameters":  append([]any{methodParameter}, parameters...),
+				"requestBody": map[string]any{"content": jsonContent(nil)},
+				"responses": map[string]any{
+					"200":     map[string]any{"description": "Response of the DON", "content": jsonContent(nil)},
+					"default": errorResponse,
+				},
+			},
+		}
+	}
+
+	document := map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   fmt.Sprintf("Gateway DON %s", donID),
+			"version": "1.0.0",
+		},
+		"servers": []any{map[string]any{"url": basePath}},
+		"paths":   paths,
+	}
+	if authenticated {
+		schemes := securitySchemes(g.authenticators[donID])
+		names := make([]string, 0, len(schemes))
+		for name := range schemes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		// signed messages need no other credentials
+		security := []any{map[string]any{}}
+		for _, name := range names {
+			security = append(security, map[string]any{name: []string{}})
+		}
+		document["components"] = map[string]any{"securitySchemes": schemes}
+		document["security"] = security
+	}
+	return json.Marshal(document)
+}
+
+// jsonContent returns the content of a request or response with a JSON payload of the type of v, or any JSON
+// payload if v is nil.
+func jsonContent(v any) map[string]any {
+	schema := &jsonschema.Schema{}
+	if v != nil {
+		reflector := jsonschema.Reflector{Anonymous: true, DoNotReference: true}
+		schema = reflector.Reflect(v)
+		schema.Version = ""
+	}
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// securitySchemes returns the OpenAPI security schemes of the credentials accepted by the authenticator.
+func securitySchemes(authenticator Authenticator) map[string]any {
+	schemes := make(map[string]any)
+	switch a := authenticator.(type) {
+	case multiAuthenticator:
+		for _, authenticator := range a {
+			for name, scheme := range securitySchemes(authenticator) {
+				schemes[name] = scheme
+			}
+		}
+	case *apiKeyAuthenticator:
+		schemes["apiKey"] = map[string]any{"type": "apiKey", "in": "header", "name": APIKeyHeader}
+	case *jwtAuthenticator:
+		schemes["bearerAuth"] = map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"}
+	}
+	return schemes
+}
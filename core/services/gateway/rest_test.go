@@ -0,0 +1,158 @@
+package gateway_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
+	handler_mocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/mocks"
+	gw_net "github.com/smartcontractkit/chainlink/v2/core/services/gateway/network"
+	net_mocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/network/mocks"
+)
+
+type testPayload struct {
+	Value int `json:"value"`
+}
+
+// describedHandler is a handler describing its methods.
+type describedHandler struct {
+	*handler_mocks.Handler
+}
+
+func (describedHandler) Methods() []handlers.MethodDescription {
+	return []handlers.MethodDescription{
+		{Name: "increment", Description: "Increments the value.", Request: testPayload{}, Response: testPayload{}},
+		{Name: "reset"},
+	}
+}
+
+func newRESTGateway(t *testing.T) (gateway.Gateway, *handler_mocks.Handler) {
+	httpServer := net_mocks.NewHttpServer(t)
+	httpServer.On("SetHTTPRequestHandler", mock.Anything).Return(nil)
+	handler := handler_mocks.NewHandler(t)
+	authenticator, err := gateway.NewAuthenticatorFromConfig(config.AuthConfig{
+		APIKeys: []config.APIKeyConfig{{KeyHash: hashAPIKey("key-1"), Sender: testSender1}},
+	}, clockwork.NewFakeClock())
+	require.NoError(t, err)
+	handlerMap := map[string]handlers.Handler{
+		"testDON":      handler,
+		"describedDON": describedHandler{handler_mocks.NewHandler(t)},
+	}
+	gw := gateway.NewGateway(&api.JsonRPCCodec{}, httpServer, handlerMap, map[string]gateway.Authenticator{"describedDON": authenticator}, nil, logger.TestLogger(t))
+	return gw, handler
+}
+
+func TestGateway_ProcessRESTRequest(t *testing.T) {
+	t.Parallel()
+
+	gw, handler := newRESTGateway(t)
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	handler.On("HandleUserMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		msg := args.Get(1).(*api.Message)
+		assert.Equal(t, "increment", msg.Body.Method)
+		assert.Equal(t, "abcd", msg.Body.MessageId)
+		assert.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), common.HexToAddress(msg.Body.Sender))
+		response := *msg
+		response.Body.Payload = []byte(`{"value":2}`)
+		callbackCh := args.Get(2).(chan<- handlers.UserCallbackPayload)
+		callbackCh <- handlers.UserCallbackPayload{Msg: &response, ErrCode: api.NoError}
+	})
+
+	payload := []byte(`{"value":1}`)
+	msg := &api.Message{Body: api.MessageBody{MessageId: "abcd", Method: "increment", DonId: "testDON", Payload: payload}}
+	require.NoError(t, msg.Sign(privateKey))
+	header := http.Header{}
+	header.Set(gateway.MessageIDHeader, "abcd")
+	header.Set(gateway.SignatureHeader, msg.Signature)
+	ctx := gw_net.WithRequestHeader(testutils.Context(t), header)
+
+	response, statusCode := gw.ProcessRESTRequest(ctx, "testDON", "increment", payload)
+	require.Equal(t, 200, statusCode)
+	require.Equal(t, `{"value":2}`, string(response))
+
+	t.Run("invalid payload", func(t *testing.T) {
+		response, statusCode := gw.ProcessRESTRequest(ctx, "testDON", "increment", []byte(`{"value":`))
+		require.Equal(t, 400, statusCode)
+		require.Equal(t, `{"error":"payload is not valid JSON"}`, string(response))
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		response, statusCode := gw.ProcessRESTRequest(testutils.Context(t), "testDON", "increment", payload)
+		require.Equal(t, 400, statusCode)
+		require.Equal(t, `{"error":"invalid hex-encoded signature length"}`, string(response))
+	})
+
+	t.Run("unsupported DON", func(t *testing.T) {
+		response, statusCode := gw.ProcessRESTRequest(ctx, "otherDON", "increment", payload)
+		require.Equal(t, 400, statusCode)
+		require.Equal(t, `{"error":"unsupported DON ID"}`, string(response))
+	})
+}
+
+func TestGateway_OpenAPIDocument(t *testing.T) {
+	t.Parallel()
+
+	gw, _ := newRESTGateway(t)
+
+	var document struct {
+		OpenAPI    string                    `json:"openapi"`
+		Servers    []map[string]string       `json:"servers"`
+		Paths      map[string]map[string]any `json:"paths"`
+		Security   []map[string][]string     `json:"security"`
+		Components struct {
+			SecuritySchemes map[string]map[string]string `json:"securitySchemes"`
+		} `json:"components"`
+	}
+	raw, err := gw.OpenAPIDocument("describedDON", "/rest")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &document))
+	assert.Equal(t, "3.1.0", document.OpenAPI)
+	assert.Equal(t, []map[string]string{{"url": "/rest"}}, document.Servers)
+	assert.Len(t, document.Paths, 2)
+	assert.Contains(t, document.Paths, "/dons/describedDON/methods/increment")
+	assert.Contains(t, document.Paths, "/dons/describedDON/methods/reset")
+	assert.Equal(t, []map[string][]string{{}, {"apiKey": {}}}, document.Security)
+	assert.Equal(t, map[string]string{"type": "apiKey", "in": "header", "name": gateway.APIKeyHeader}, document.Components.SecuritySchemes["apiKey"])
+
+	var operation struct {
+		RequestBody struct {
+			Content map[string]struct {
+				Schema struct {
+					Type       string                    `json:"type"`
+					Properties map[string]map[string]any `json:"properties"`
+				} `json:"schema"`
+			} `json:"content"`
+		} `json:"requestBody"`
+	}
+	rawOperation, err := json.Marshal(document.Paths["/dons/describedDON/methods/increment"]["post"])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(rawOperation, &operation))
+	schema := operation.RequestBody.Content["application/json"].Schema
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, "integer", schema.Properties["value"]["type"])
+
+	raw, err = gw.OpenAPIDocument("testDON", "/rest")
+	require.NoError(t, err)
+	document.Paths = nil
+	document.Security = nil
+	require.NoError(t, json.Unmarshal(raw, &document))
+	assert.Contains(t, document.Paths, "/dons/testDON/methods/{method}")
+	assert.Empty(t, document.Security)
+
+	_, err = gw.OpenAPIDocument("otherDON", "/rest")
+	require.Error(t, err)
+}
@@ -2,6 +2,8 @@ package job
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -56,3 +58,23 @@ func (kv kVStore) Get(ctx context.Context, key string) ([]byte, error) {
 
 	return val, nil
 }
+
+type notFoundAsEmptyKVStore struct {
+	KVStore
+}
+
+// NewNotFoundAsEmptyKVStore returns a KVStore which reads missing keys as empty values instead of failing.
+// Errors of a store given to a LOOP plugin reach the plugin as gRPC status messages only, so it could not
+// tell a missing key apart from a failed read otherwise.
+func NewNotFoundAsEmptyKVStore(kv KVStore) KVStore {
+	return notFoundAsEmptyKVStore{KVStore: kv}
+}
+
+// Get retrieves []byte value by key, or nil if the key is not stored.
+func (kv notFoundAsEmptyKVStore) Get(ctx context.Context, key string) ([]byte, error) {
+	val, err := kv.KVStore.Get(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return val, err
+}
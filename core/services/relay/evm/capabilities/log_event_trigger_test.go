@@ -52,7 +52,8 @@ func TestLogEventTriggerEVMHappyPath(t *testing.T) {
 	logEventTriggerService, err := logevent.NewTriggerService(ctx,
 		th.BackendTH.Lggr,
 		relayer,
-		logEventConfig)
+		logEventConfig,
+		nil)
 	require.NoError(t, err)
 
 	// Start the service
@@ -99,7 +100,8 @@ func TestLogEventTriggerCursorNewLogs(t *testing.T) {
 	logEventTriggerService, err := logevent.NewTriggerService(ctx,
 		th.BackendTH.Lggr,
 		relayer,
-		logEventConfig)
+		logEventConfig,
+		nil)
 	require.NoError(t, err)
 
 	// Start the service
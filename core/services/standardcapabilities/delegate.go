@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jonboulle/clockwork"
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
 
@@ -15,6 +16,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/compute"
 	gatewayconnector "github.com/smartcontractkit/chainlink/v2/core/capabilities/gateway_connector"
+	crontrigger "github.com/smartcontractkit/chainlink/v2/core/capabilities/triggers/cron"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/webapi"
 	webapitarget "github.com/smartcontractkit/chainlink/v2/core/capabilities/webapi/target"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/webapi/trigger"
@@ -29,6 +31,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/telemetry"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/quotas"
 	"github.com/smartcontractkit/chainlink/v2/plugins"
 )
 
@@ -50,6 +53,7 @@ type Delegate struct {
 	ks                      keystore.Master
 	peerWrapper             *ocrcommon.SingletonPeerWrapper
 	newOracleFactoryFn      func(generic.OracleFactoryParams) (core.OracleFactory, error)
+	workflowLimiter         *quotas.Limiter
 
 	isNewlyCreatedJob bool
 }
@@ -58,6 +62,7 @@ const (
 	commandOverrideForWebAPITrigger       = "__builtin_web-api-trigger"
 	commandOverrideForWebAPITarget        = "__builtin_web-api-target"
 	commandOverrideForCustomComputeAction = "__builtin_custom-compute-action"
+	commandOverrideForCronTrigger         = "__builtin_cron-trigger"
 )
 
 type NewOracleFactoryFn func(generic.OracleFactoryParams) (core.OracleFactory, error)
@@ -75,6 +80,7 @@ func NewDelegate(
 	ks keystore.Master,
 	peerWrapper *ocrcommon.SingletonPeerWrapper,
 	newOracleFactoryFn NewOracleFactoryFn,
+	workflowLimiter *quotas.Limiter,
 ) *Delegate {
 	return &Delegate{
 		logger:                  logger,
@@ -90,6 +96,7 @@ func NewDelegate(
 		ks:                      ks,
 		peerWrapper:             peerWrapper,
 		newOracleFactoryFn:      newOracleFactoryFn,
+		workflowLimiter:         workflowLimiter,
 	}
 }
 
@@ -105,7 +112,7 @@ func (d *Delegate) BeforeJobCreated(job job.Job) {
 func (d *Delegate) ServicesForSpec(ctx context.Context, spec job.Job) ([]job.ServiceCtx, error) {
 	log := d.logger.Named("StandardCapabilities").Named(spec.StandardCapabilitiesSpec.GetID())
 
-	kvStore := job.NewKVStore(spec.ID, d.ds, log)
+	kvStore := job.NewNotFoundAsEmptyKVStore(job.NewKVStore(spec.ID, d.ds, log))
 	telemetryService := generic.NewTelemetryAdapter(d.monitoringEndpointGen)
 	errorLog := &ErrorLog{jobID: spec.ID, recordError: d.jobORM.RecordError}
 	pr := generic.NewPipelineRunnerAdapter(log, spec, d.pipelineRunner)
@@ -254,13 +261,22 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, spec job.Job) ([]job.Ser
 			return uuid.New().String()
 		}
 
-		computeSrvc, err := compute.NewAction(cfg, log, d.registry, handler, idGeneratorFn)
+		var opts []func(*compute.Compute)
+		if d.workflowLimiter != nil {
+			opts = append(opts, compute.WithFetchLimiter(d.workflowLimiter), compute.WithExecutionLimiter(d.workflowLimiter))
+		}
+
+		computeSrvc, err := compute.NewAction(cfg, log, d.registry, handler, idGeneratorFn, opts...)
 		if err != nil {
 			return nil, err
 		}
 		return []job.ServiceCtx{handler, computeSrvc}, nil
 	}
 
+	if spec.StandardCapabilitiesSpec.Command == commandOverrideForCronTrigger {
+		return []job.ServiceCtx{crontrigger.NewService(d.registry, clockwork.NewRealClock(), log)}, nil
+	}
+
 	standardCapability := newStandardCapabilities(log, spec.StandardCapabilitiesSpec, d.cfg, telemetryService, kvStore, d.registry, errorLog,
 		pr, relayerSet, oracleFactory)
 
@@ -0,0 +1,188 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/exec"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+)
+
+// Control-flow steps are evaluated by the engine itself instead of being executed by a capability.
+const (
+	// ConditionStepID gates the steps that depend on it: if the `expression` in its config evaluates to true over its
+	// inputs, the step completes and outputs its inputs unchanged; otherwise it is skipped, and so are its dependents.
+	ConditionStepID = "condition@1.0.0"
+	// JoinStepID merges branches of the workflow: it runs once all the steps it depends on have been processed and at
+	// least one of them completed, and outputs its inputs, with nil for the references to steps that didn't complete.
+	JoinStepID = "join@1.0.0"
+
+	conditionConfigExpression = "expression"
+
+	// reservedFieldNameMapOver names the input of a capability step that holds a list. The capability is then
+	// executed once per element of the list, in parallel, with that input set to the element, and the step outputs
+	// the list of the outputs of every execution.
+	reservedFieldNameMapOver = "cre_map_over"
+	maxMapOverElements       = 100
+	mapOverConcurrency       = 10
+)
+
+// errConditionNotMet is returned when executing a condition step whose expression is false.
+var errConditionNotMet = errors.New("condition not met")
+
+// isEngineStep returns whether the step is evaluated by the engine instead of by a capability.
+func isEngineStep(s *step) bool {
+	return s.ID == ConditionStepID || s.ID == JoinStepID
+}
+
+// initControlFlow validates the control-flow configuration of the step, so that an invalid workflow is rejected when
+// it's parsed instead of failing its executions.
+func (s *step) initControlFlow() error {
+	switch s.ID {
+	case ConditionStepID:
+		src, ok := s.Config[conditionConfigExpression].(string)
+		if !ok {
+			return fmt.Errorf("condition step %s must have a string %q in its config", s.Ref, conditionConfigExpression)
+		}
+		expr, err := parseExpression(src)
+		if err != nil {
+			return fmt.Errorf("condition step %s: %w", s.Ref, err)
+		}
+		s.condition = expr
+		return nil
+	case JoinStepID:
+		if len(s.Dependencies) == 0 {
+			return fmt.Errorf("join step %s must depend on at least one step", s.Ref)
+		}
+		return nil
+	}
+
+	raw, ok := s.Config[reservedFieldNameMapOver]
+	if !ok {
+		return nil
+	}
+	input, ok := raw.(string)
+	if !ok || input == "" {
+		return fmt.Errorf("step %s: %s must be the name of an input", s.Ref, reservedFieldNameMapOver)
+	}
+	if _, ok := s.Inputs.Mapping[input]; !ok {
+		return fmt.Errorf("step %s: %s references input %q, which doesn't exist", s.Ref, reservedFieldNameMapOver, input)
+	}
+	s.mapOver = input
+	return nil
+}
+
+// executeEngineStep evaluates a control-flow step.
+func (e *Engine) executeEngineStep(s *step, state store.WorkflowExecution) (*values.Map, values.Value, error) {
+	var inputs any
+	if s.Inputs.OutputRef != "" {
+		inputs = s.Inputs.OutputRef
+	} else {
+		inputs = s.Inputs.Mapping
+	}
+
+	var i any
+	var err error
+	if s.ID == JoinStepID {
+		i, err = interpolateJoinInputs(inputs, state)
+	} else {
+		i, err = exec.FindAndInterpolateAllKeys(inputs, state)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputsMap, err := values.NewMap(i.(map[string]any))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.ID == ConditionStepID {
+		vars, err := values.Unwrap(inputsMap)
+		if err != nil {
+			return inputsMap, nil, err
+		}
+		varsMap, _ := vars.(map[string]any)
+		met, err := s.condition.evaluate(varsMap)
+		if err != nil {
+			return inputsMap, nil, fmt.Errorf("failed to evaluate condition: %w", err)
+		}
+		if !met {
+			return inputsMap, inputsMap, errConditionNotMet
+		}
+	}
+
+	return inputsMap, inputsMap, nil
+}
+
+// interpolateJoinInputs interpolates each input of a join step separately, setting the inputs that reference steps
+// that didn't complete to nil.
+func interpolateJoinInputs(inputs any, state store.WorkflowExecution) (any, error) {
+	mapping, ok := inputs.(map[string]any)
+	if !ok {
+		return exec.FindAndInterpolateAllKeys(inputs, state)
+	}
+
+	out := map[string]any{}
+	for k, v := range mapping {
+		iv, err := exec.FindAndInterpolateAllKeys(v, state)
+		if err != nil {
+			iv = nil
+		}
+		out[k] = iv
+	}
+	return out, nil
+}
+
+// executeMapOver executes the capability of the step once per element of its map over input. If any execution
+// errors, the step errors; executions that terminate early output nil, unless all of them do, in which case the step
+// terminates early.
+func (e *Engine) executeMapOver(ctx context.Context, s *step, req capabilities.CapabilityRequest) (values.Value, error) {
+	list, ok := req.Inputs.Underlying[s.mapOver].(*values.List)
+	if !ok {
+		return nil, fmt.Errorf("input %s must be a list to map over it, got %T", s.mapOver, req.Inputs.Underlying[s.mapOver])
+	}
+	if len(list.Underlying) > maxMapOverElements {
+		return nil, fmt.Errorf("input %s has %d elements, more than the maximum of %d", s.mapOver, len(list.Underlying), maxMapOverElements)
+	}
+
+	outputs := make([]values.Value, len(list.Underlying))
+	var stopped atomic.Int64
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(mapOverConcurrency)
+	for i, el := range list.Underlying {
+		elReq := req
+		elReq.Inputs = req.Inputs.CopyMap()
+		elReq.Inputs.Underlying[s.mapOver] = el
+		elReq.Metadata.ReferenceID = fmt.Sprintf("%s.%d", req.Metadata.ReferenceID, i)
+		g.Go(func() error {
+			resp, err := s.capability.Execute(gctx, elReq)
+			if errors.Is(err, capabilities.ErrStopExecution) {
+				stopped.Add(1)
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+			if resp.Value != nil {
+				outputs[i] = resp.Value
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	if len(outputs) > 0 && stopped.Load() == int64(len(outputs)) {
+		return nil, capabilities.ErrStopExecution
+	}
+	return &values.List{Underlying: outputs}, nil
+}
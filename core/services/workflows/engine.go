@@ -6,9 +6,12 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/dominikbraun/graph"
 	"github.com/jonboulle/clockwork"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
@@ -21,9 +24,11 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows/exec"
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk"
 
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/compute"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/transmission"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/platform"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/quotas"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
 )
 
@@ -36,6 +41,10 @@ const (
 type stepRequest struct {
 	stepRef string
 	state   store.WorkflowExecution
+	// attempt is the number of times the step was already executed, when it is retried.
+	attempt int
+	// usage accumulates the resources used by all the attempts of the step.
+	usage *stepUsage
 }
 
 type stepUpdateChannel struct {
@@ -120,6 +129,8 @@ type Engine struct {
 	maxExecutionDuration time.Duration
 	heartbeatCadence     time.Duration
 	stepTimeoutDuration  time.Duration
+	redriveInterval      time.Duration
+	limiter              *quotas.Limiter
 
 	// testing lifecycle hook to signal when an execution is finished.
 	onExecutionFinished func(string)
@@ -201,6 +212,11 @@ func (e *Engine) resolveWorkflowCapabilities(ctx context.Context) error {
 			return nil
 		}
 
+		// Control-flow steps don't have a capability.
+		if isEngineStep(s) {
+			return nil
+		}
+
 		err := e.initializeCapability(ctx, s)
 		if err != nil {
 			logCustMsg(
@@ -352,6 +368,9 @@ func (e *Engine) init(ctx context.Context) {
 		}
 	}
 
+	e.wg.Add(1)
+	go e.redriveLoop(ctx)
+
 	e.logger.Info("engine initialized")
 	logCustMsg(ctx, e.cma, "workflow registered", e.logger)
 	e.metrics.incrementWorkflowRegisteredCounter(ctx)
@@ -379,41 +398,119 @@ func (e *Engine) resumeInProgressExecutions(ctx context.Context) error {
 	// they won't change.
 	refToDeps := map[string][]*step{}
 	for _, execution := range wipExecutions {
-		for _, step := range execution.Steps {
-			// NOTE: In order to determine what tasks need to be enqueued,
-			// we look at any completed steps, and for each dependent,
-			// check if they are ready to be enqueued.
-			// This will also handle an execution that has stalled immediately on creation,
-			// since we always create an execution with an initially completed trigger step.
-			if step.Status != store.StatusCompleted {
-				continue
+		if err := e.resumeExecution(ctx, execution, execution.CreatedAt, refToDeps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resumeExecution queues the steps of an unfinished execution that are ready to be executed. The execution times out
+// after maxExecutionDuration from startedAt.
+func (e *Engine) resumeExecution(ctx context.Context, execution store.WorkflowExecution, startedAt *time.Time, refToDeps map[string][]*step) error {
+	for _, step := range execution.Steps {
+		if step.Status == store.StatusRetrying {
+			e.ensureStepUpdateLoop(ctx, execution, startedAt)
+			e.resumeRetry(ctx, execution, step)
+			continue
+		}
+
+		// NOTE: In order to determine what tasks need to be enqueued,
+		// we look at any completed steps, and for each dependent,
+		// check if they are ready to be enqueued.
+		// This will also handle an execution that has stalled immediately on creation,
+		// since we always create an execution with an initially completed trigger step.
+		if step.Status != store.StatusCompleted {
+			continue
+		}
+
+		sds, ok := refToDeps[step.Ref]
+		if !ok {
+			s, err := e.workflow.dependents(step.Ref)
+			if err != nil {
+				return err
 			}
 
-			sds, ok := refToDeps[step.Ref]
-			if !ok {
-				s, err := e.workflow.dependents(step.Ref)
-				if err != nil {
-					return err
-				}
+			sds = s
+		}
 
-				sds = s
+		for _, sd := range sds {
+			// Join steps are checked once per execution below, since they may be ready even if a step
+			// they depend on didn't complete.
+			if sd.ID == JoinStepID {
+				continue
+			}
+			// Steps that were already processed, e.g. the other branches of a re-driven execution, aren't executed
+			// again.
+			if _, processed := execution.Steps[sd.Ref]; processed {
+				continue
 			}
+			e.ensureStepUpdateLoop(ctx, execution, startedAt)
+			e.queueIfReady(execution, sd)
+		}
+	}
 
-			for _, sd := range sds {
-				ch := make(chan store.WorkflowExecutionStep)
-				added := e.stepUpdatesChMap.add(execution.ExecutionID, stepUpdateChannel{
-					ch:          ch,
-					executionID: execution.ExecutionID,
-				})
-				if added {
-					// We trigger the `stepUpdateLoop` for this execution, since the loop is not running atm.
-					e.wg.Add(1)
-					go e.stepUpdateLoop(ctx, execution.ExecutionID, ch, execution.CreatedAt)
-				}
-				e.queueIfReady(execution, sd)
+	for _, j := range e.workflow.joins {
+		e.ensureStepUpdateLoop(ctx, execution, startedAt)
+		e.queueIfReady(execution, j)
+	}
+	return nil
+}
+
+// ensureStepUpdateLoop starts the `stepUpdateLoop` of a resumed execution, unless it is already running.
+func (e *Engine) ensureStepUpdateLoop(ctx context.Context, execution store.WorkflowExecution, startedAt *time.Time) {
+	ch := make(chan store.WorkflowExecutionStep)
+	added := e.stepUpdatesChMap.add(execution.ExecutionID, stepUpdateChannel{
+		ch:          ch,
+		executionID: execution.ExecutionID,
+	})
+	if added {
+		// The execution was admitted before it was interrupted, so it only counts towards the concurrency quota.
+		e.limiter.ResumeExecution(e.workflow.owner, e.workflow.id)
+		// We trigger the `stepUpdateLoop` for this execution, since the loop is not running atm.
+		e.wg.Add(1)
+		go e.stepUpdateLoop(ctx, execution.ExecutionID, ch, startedAt)
+	}
+}
+
+// redriveLoop periodically resumes the dead-lettered executions of the workflow whose re-drive was requested by an
+// operator.
+func (e *Engine) redriveLoop(ctx context.Context) {
+	defer e.wg.Done()
+
+	ticker := e.clock.NewTicker(e.redriveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+			if err := e.redriveExecutions(ctx); err != nil {
+				e.logger.Errorf("failed to re-drive executions: %v", err)
 			}
 		}
 	}
+}
+
+// redriveExecutions resumes the re-driven executions from the steps that failed. Since they may have been
+// dead-lettered for a long time, they time out after maxExecutionDuration from when they are resumed.
+func (e *Engine) redriveExecutions(ctx context.Context) error {
+	executions, err := e.executionStates.ClaimRedrives(ctx, e.workflow.id)
+	if err != nil {
+		return err
+	}
+
+	refToDeps := map[string][]*step{}
+	for _, execution := range executions {
+		l := e.logger.With(platform.KeyWorkflowExecutionID, execution.ExecutionID)
+		l.Info("re-driving execution")
+		logCustMsg(ctx, e.cma.With(platform.KeyWorkflowExecutionID, execution.ExecutionID), "re-driving execution", l)
+		startedAt := e.clock.Now()
+		if err := e.resumeExecution(ctx, execution, &startedAt, refToDeps); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -503,6 +600,8 @@ func (e *Engine) stepUpdateLoop(ctx context.Context, executionID string, stepUpd
 		select {
 		case <-ctx.Done():
 			lggr.Debug("shutting down stepUpdateLoop")
+			// the execution is resumed, and counted again, when the engine restarts
+			e.limiter.FinishExecution(e.workflow.owner, e.workflow.id)
 			return
 		case stepUpdate, open := <-stepUpdateCh:
 			if !open {
@@ -556,8 +655,13 @@ func (e *Engine) startExecution(ctx context.Context, executionID string, event *
 		Status:      store.StatusStarted,
 	}
 
+	if err := e.limiter.StartExecution(e.workflow.owner, e.workflow.id); err != nil {
+		return e.rejectExecution(ctx, ec, err)
+	}
+
 	dbWex, err := e.executionStates.Add(ctx, ec)
 	if err != nil {
+		e.limiter.FinishExecution(e.workflow.owner, e.workflow.id)
 		return err
 	}
 
@@ -566,6 +670,7 @@ func (e *Engine) startExecution(ctx context.Context, executionID string, event *
 	// on a trigger
 	triggerDependents, err := e.workflow.dependents(workflows.KeywordTrigger)
 	if err != nil {
+		e.limiter.FinishExecution(e.workflow.owner, e.workflow.id)
 		return err
 	}
 
@@ -577,6 +682,7 @@ func (e *Engine) startExecution(ctx context.Context, executionID string, event *
 	if !added {
 		// skip this execution since there's already a stepUpdateLoop running for the execution ID
 		lggr.Debugf("won't start execution for execution %s, execution was already started", executionID)
+		e.limiter.FinishExecution(e.workflow.owner, e.workflow.id)
 		return nil
 	}
 	e.wg.Add(1)
@@ -589,6 +695,29 @@ func (e *Engine) startExecution(ctx context.Context, executionID string, event *
 	return nil
 }
 
+// rejectExecution records an execution rejected by the quotas of the workflow or its owner as rate limited, so that
+// it's visible in the execution history along with the trigger event that started it. It returns the quota error
+// once the execution is recorded.
+func (e *Engine) rejectExecution(ctx context.Context, ec *store.WorkflowExecution, quotaErr error) error {
+	ec.Status = store.StatusRateLimited
+	if _, err := e.executionStates.Add(ctx, ec); err != nil {
+		return fmt.Errorf("failed to record rate limited execution: %w", err)
+	}
+	if err := e.executionStates.UpdateStatus(ctx, ec.ExecutionID, store.StatusRateLimited); err != nil {
+		return fmt.Errorf("failed to record rate limited execution: %w", err)
+	}
+	e.onExecutionFinished(ec.ExecutionID)
+	return quotaErr
+}
+
+// incrementQuotaRejectionCounter counts a rejection by the quota that err reports as exceeded.
+func (e *Engine) incrementQuotaRejectionCounter(ctx context.Context, err error) {
+	var qerr *quotas.QuotaExceededError
+	if errors.As(err, &qerr) {
+		e.metrics.with("quota", string(qerr.Quota), "scope", string(qerr.Scope)).incrementQuotaRejectionCounter(ctx)
+	}
+}
+
 func (e *Engine) handleStepUpdate(ctx context.Context, stepUpdate store.WorkflowExecutionStep, workflowCreatedAt *time.Time) error {
 	l := e.logger.With(platform.KeyWorkflowExecutionID, stepUpdate.ExecutionID, platform.KeyStepRef, stepUpdate.Ref)
 	cma := e.cma.With(platform.KeyWorkflowExecutionID, stepUpdate.ExecutionID, platform.KeyStepRef, stepUpdate.Ref)
@@ -613,11 +742,17 @@ func (e *Engine) handleStepUpdate(ctx context.Context, stepUpdate store.Workflow
 		switch status {
 		case store.StatusTimeout:
 			l.Info("execution timed out")
+			if err := e.addDeadLetter(ctx, state); err != nil {
+				l.Errorf("failed to add dead letter: %v", err)
+			}
 		case store.StatusCompleted:
 			l.Info("workflow finished")
 		case store.StatusErrored:
 			l.Info("execution errored")
 			e.metrics.incrementTotalWorkflowStepErrorsCounter(ctx)
+			if err := e.addDeadLetter(ctx, state); err != nil {
+				l.Errorf("failed to add dead letter: %v", err)
+			}
 		case store.StatusCompletedEarlyExit:
 			l.Info("execution terminated early")
 			// NOTE: even though this marks the workflow as completed, any branches of the DAG
@@ -636,13 +771,93 @@ func (e *Engine) handleStepUpdate(ctx context.Context, stepUpdate store.Workflow
 		return err
 	}
 	for _, sd := range stepDependents {
+		if sd.ID == JoinStepID {
+			continue
+		}
 		e.queueIfReady(state, sd)
 	}
 
+	// Join steps are queued when they become ready, which may be because a step they don't directly depend on
+	// was skipped or terminated early.
+	previous := state
+	previous.Steps = maps.Clone(state.Steps)
+	delete(previous.Steps, stepUpdate.Ref)
+	for _, j := range e.workflow.joins {
+		wasReady, err := e.isJoinReady(previous, j)
+		if err != nil {
+			return err
+		}
+		isReady, err := e.isJoinReady(state, j)
+		if err != nil {
+			return err
+		}
+		if !wasReady && isReady {
+			e.enqueueStep(state, j)
+		}
+	}
+
+	return nil
+}
+
+// addDeadLetter records the errored or timed out execution as a dead letter, with the first of its steps that failed,
+// so that an operator can re-drive it once the underlying capability recovers.
+func (e *Engine) addDeadLetter(ctx context.Context, state store.WorkflowExecution) error {
+	refs := slices.Sorted(maps.Keys(state.Steps))
+	for _, ref := range refs {
+		stepState := state.Steps[ref]
+		if stepState.Status != store.StatusErrored && stepState.Status != store.StatusTimeout {
+			continue
+		}
+
+		errMsg := "execution timed out"
+		if stepState.Outputs.Err != nil {
+			errMsg = stepState.Outputs.Err.Error()
+		}
+		return e.executionStates.AddDeadLetter(ctx, &store.DeadLetter{
+			ExecutionID: state.ExecutionID,
+			WorkflowID:  e.workflow.id,
+			StepRef:     ref,
+			Error:       errMsg,
+			// steps recorded before attempts were tracked were executed once
+			Attempts: max(stepState.Attempts, 1),
+		})
+	}
 	return nil
 }
 
+// isJoinReady returns whether all the steps the join step depends on have been processed, and at least one of them
+// completed.
+func (e *Engine) isJoinReady(state store.WorkflowExecution, join *step) (bool, error) {
+	statuses, err := e.stepStatuses(state)
+	if err != nil {
+		return false, err
+	}
+	if _, processed := statuses[join.Ref]; processed {
+		return false, nil
+	}
+	for _, dr := range join.Dependencies {
+		if _, processed := statuses[dr]; !processed {
+			return false, nil
+		}
+	}
+	// If no dependency completed, the join step would have inherited their status.
+	return true, nil
+}
+
 func (e *Engine) queueIfReady(state store.WorkflowExecution, step *step) {
+	if step.ID == JoinStepID {
+		ready, err := e.isJoinReady(state, step)
+		if err != nil {
+			e.logger.With(platform.KeyStepRef, step.Ref, platform.KeyWorkflowExecutionID, state.ExecutionID).
+				Errorf("failed to check if join step is ready: %v", err)
+			return
+		}
+		if ready {
+			e.enqueueStep(state, step)
+		}
+		return
+	}
+
 	// Check if all dependencies are completed for the current step
 	var waitingOnDependencies bool
 	for _, dr := range step.Vertex.Dependencies {
@@ -664,12 +879,16 @@ func (e *Engine) queueIfReady(state store.WorkflowExecution, step *step) {
 
 	// If all dependencies are completed, enqueue the step.
 	if !waitingOnDependencies {
-		e.logger.With(platform.KeyStepRef, step.Ref, platform.KeyWorkflowExecutionID, state.ExecutionID, "state", copyState(state)).
-			Debug("step request enqueued")
-		e.pendingStepRequests <- stepRequest{
-			state:   copyState(state),
-			stepRef: step.Ref,
-		}
+		e.enqueueStep(state, step)
+	}
+}
+
+func (e *Engine) enqueueStep(state store.WorkflowExecution, step *step) {
+	e.logger.With(platform.KeyStepRef, step.Ref, platform.KeyWorkflowExecutionID, state.ExecutionID, "state", copyState(state)).
+		Debug("step request enqueued")
+	e.pendingStepRequests <- stepRequest{
+		state:   copyState(state),
+		stepRef: step.Ref,
 	}
 }
 
@@ -689,6 +908,7 @@ func (e *Engine) finishExecution(ctx context.Context, cma custmsg.MessageEmitter
 	}
 
 	e.stepUpdatesChMap.remove(executionID)
+	e.limiter.FinishExecution(e.workflow.owner, e.workflow.id)
 
 	executionDuration := int64(execState.FinishedAt.Sub(*execState.CreatedAt).Seconds())
 	switch status {
@@ -750,7 +970,11 @@ func (e *Engine) worker(ctx context.Context) {
 
 			cma := e.cma.With(platform.KeyWorkflowExecutionID, executionID)
 			err = e.startExecution(ctx, executionID, resp.Event.Outputs)
-			if err != nil {
+			if errors.Is(err, quotas.ErrQuotaExceeded) {
+				e.logger.With(platform.KeyWorkflowExecutionID, executionID).Warnf("execution rejected: %v", err)
+				logCustMsg(ctx, cma, fmt.Sprintf("execution rejected: %s", err), e.logger)
+				e.incrementQuotaRejectionCounter(ctx, err)
+			} else if err != nil {
 				e.logger.With(platform.KeyWorkflowExecutionID, executionID).Errorf("failed to start execution: %v", err)
 				logCustMsg(ctx, cma, fmt.Sprintf("failed to start execution: %s", err), e.logger)
 				e.metrics.with(platform.KeyTriggerID, te.ID).incrementTriggerWorkflowStarterErrorCounter(ctx)
@@ -779,10 +1003,6 @@ func (e *Engine) workerForStepRequest(ctx context.Context, msg stepRequest) {
 
 	logCustMsg(ctx, cma, "executing step", l)
 
-	stepExecutionStartTime := time.Now()
-	inputs, outputs, err := e.executeStep(ctx, l, msg)
-	stepExecutionDuration := time.Since(stepExecutionStartTime).Seconds()
-
 	curStepID := "UNSET"
 	curStep, verr := e.workflow.Vertex(msg.stepRef)
 	if verr == nil {
@@ -790,10 +1010,28 @@ func (e *Engine) workerForStepRequest(ctx context.Context, msg stepRequest) {
 	} else {
 		l.Errorf("failed to resolve step in workflow; error %v", verr)
 	}
-	e.metrics.with(platform.KeyCapabilityID, curStepID).updateWorkflowStepDurationHistogram(ctx, int64(stepExecutionDuration))
+	isCompute := curStepID == compute.CapabilityIDCompute
+
+	stepExecutionStartTime := time.Now()
+	ctx, usage := withStepUsage(ctx, msg.usage)
+	inputs, outputs, err := e.executeAttempt(ctx, l, msg, isCompute)
+	stepExecutionDuration := time.Since(stepExecutionStartTime)
+
+	e.metrics.with(platform.KeyCapabilityID, curStepID).updateWorkflowStepDurationHistogram(ctx, int64(stepExecutionDuration.Seconds()))
+
+	if e.retryStep(ctx, l, curStep, msg, usage, err) {
+		return
+	}
+	stepState.Attempts = msg.attempt + 1
 
 	var stepStatus string
 	switch {
+	case errors.Is(err, errConditionNotMet):
+		lmsg := "step skipped: condition not met"
+		l.Info(lmsg)
+		logCustMsg(ctx, cma, lmsg, l)
+		stepStatus = store.StatusSkipped
+		err = nil
 	case errors.Is(capabilities.ErrStopExecution, err):
 		lmsg := "step executed successfully with a termination"
 		l.Info(lmsg)
@@ -816,6 +1054,11 @@ func (e *Engine) workerForStepRequest(ctx context.Context, msg stepRequest) {
 	stepState.Outputs.Value = outputs
 	stepState.Outputs.Err = err
 	stepState.Inputs = inputs
+	usageMap, uerr := usage.toMap()
+	if uerr != nil {
+		l.Errorw("failed to convert the usage of the step", "err", uerr)
+	}
+	stepState.Outputs.Usage = usageMap
 
 	// Let's try and emit the stepUpdate.
 	// If the context is canceled, we'll just drop the update.
@@ -917,6 +1160,10 @@ func (e *Engine) executeStep(ctx context.Context, lggr logger.Logger, msg stepRe
 		return nil, nil, err
 	}
 
+	if isEngineStep(curStep) {
+		return e.executeEngineStep(curStep, msg.state)
+	}
+
 	var inputs any
 	if curStep.Inputs.OutputRef != "" {
 		inputs = curStep.Inputs.OutputRef
@@ -971,6 +1218,14 @@ func (e *Engine) executeStep(ctx context.Context, lggr logger.Logger, msg stepRe
 	defer cancel()
 
 	e.metrics.with(platform.KeyCapabilityID, curStep.ID).incrementCapabilityInvocationCounter(ctx)
+	if curStep.mapOver != "" {
+		output, err := e.executeMapOver(stepCtx, curStep, tr)
+		if err != nil && !errors.Is(err, capabilities.ErrStopExecution) {
+			e.metrics.with(platform.KeyStepRef, msg.stepRef, platform.KeyCapabilityID, curStep.ID).incrementCapabilityFailureCounter(ctx)
+		}
+		return inputsMap, output, err
+	}
+
 	output, err := curStep.capability.Execute(stepCtx, tr)
 	if err != nil {
 		e.metrics.with(platform.KeyStepRef, msg.stepRef, platform.KeyCapabilityID, curStep.ID).incrementCapabilityFailureCounter(ctx)
@@ -1005,45 +1260,7 @@ func (e *Engine) deregisterTrigger(ctx context.Context, t *triggerCapability, tr
 }
 
 func (e *Engine) isWorkflowFullyProcessed(ctx context.Context, state store.WorkflowExecution) (bool, string, error) {
-	statuses := map[string]string{}
-	// we need to first propagate the status of the errored status if it exists...
-	err := e.workflow.walkDo(workflows.KeywordTrigger, func(s *step) error {
-		stateStep, ok := state.Steps[s.Ref]
-		if !ok {
-			// The step not existing on the state means that it has not been processed yet.
-			// So ignore it.
-			return nil
-		}
-		statuses[s.Ref] = stateStep.Status
-		switch stateStep.Status {
-		// For each step with any of the following statuses, propagate the statuses to its dependants
-		// since they will not be executed.
-		case store.StatusErrored, store.StatusCompletedEarlyExit, store.StatusTimeout:
-			// Let's properly propagate the status to all dependents, not just direct dependents.
-			queue := []string{s.Ref}
-			for len(queue) > 0 {
-				current := queue[0] // Grab the current step reference
-				queue = queue[1:]   // Remove it from the queue
-
-				// Find the dependents for the current step reference
-				dependents, err := e.workflow.dependents(current)
-				if err != nil {
-					return err
-				}
-
-				// Propagate the status to all direct dependents
-				// With no direct dependents, it will go to the next step reference in the queue.
-				for _, sd := range dependents {
-					if _, dependentProcessed := statuses[sd.Ref]; !dependentProcessed {
-						statuses[sd.Ref] = stateStep.Status
-						// Queue the dependent for to be processed later
-						queue = append(queue, sd.Ref)
-					}
-				}
-			}
-		}
-		return nil
-	})
+	statuses, err := e.stepStatuses(state)
 	if err != nil {
 		return false, "", err
 	}
@@ -1066,6 +1283,7 @@ func (e *Engine) isWorkflowFullyProcessed(ctx context.Context, state store.Workf
 		return workflowProcessed, "", nil
 	}
 
+	// Skipped steps don't affect the status of the workflow.
 	var hasErrored, hasTimedOut, hasCompletedEarlyExit bool
 	// Let's determine the status of the workflow.
 	for _, status := range statuses {
@@ -1093,6 +1311,66 @@ func (e *Engine) isWorkflowFullyProcessed(ctx context.Context, state store.Workf
 	return workflowProcessed, store.StatusCompleted, nil
 }
 
+// stepStatuses returns the status of each processed step. Steps that won't be executed, because a step they depend
+// on errored, timed out, terminated early or was skipped, inherit its status.
+func (e *Engine) stepStatuses(state store.WorkflowExecution) (map[string]string, error) {
+	// Walk the steps in topological order, so that the statuses of the dependencies of a step are known when
+	// it's visited, and can be propagated to all its dependents, not just direct dependents.
+	order, err := graph.TopologicalSort(e.workflow.Graph)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := map[string]string{}
+	for _, ref := range order {
+		if stateStep, ok := state.Steps[ref]; ok {
+			// steps waiting for a retry are pending
+			if stateStep.Status != store.StatusRetrying {
+				statuses[ref] = stateStep.Status
+			}
+			continue
+		}
+
+		s, err := e.workflow.Vertex(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		var inherited string
+		allProcessed, anyCompleted := true, false
+		for _, dr := range s.Dependencies {
+			status, ok := statuses[dr]
+			switch {
+			case !ok:
+				allProcessed = false
+			case status == store.StatusCompleted:
+				anyCompleted = true
+			case statusPrecedence[status] > statusPrecedence[inherited]:
+				inherited = status
+			}
+		}
+
+		// A join step only inherits the status of its dependencies if none of them completed,
+		// or if one of them failed.
+		if s.ID == JoinStepID && inherited != store.StatusErrored && inherited != store.StatusTimeout &&
+			(!allProcessed || anyCompleted) {
+			continue
+		}
+		if inherited != "" {
+			statuses[ref] = inherited
+		}
+	}
+	return statuses, nil
+}
+
+// statusPrecedence orders the statuses that steps inherit from their dependencies.
+var statusPrecedence = map[string]int{
+	store.StatusSkipped:            1,
+	store.StatusCompletedEarlyExit: 2,
+	store.StatusTimeout:            3,
+	store.StatusErrored:            4,
+}
+
 // heartbeat runs by default every defaultHeartbeatCadence minutes
 func (e *Engine) heartbeat(ctx context.Context) {
 	defer e.wg.Done()
@@ -1204,10 +1482,14 @@ type Config struct {
 	SecretsFetcher       secretsFetcher
 	HeartbeatCadence     time.Duration
 	StepTimeout          time.Duration
+	// Limiter enforces the quotas of the workflow and its owner, and is shared by all the engines of the node.
+	// Defaults to no quotas.
+	Limiter *quotas.Limiter
 
 	// For testing purposes only
 	maxRetries          int
 	retryMs             int
+	redriveInterval     time.Duration
 	afterInit           func(success bool)
 	onExecutionFinished func(weid string)
 	clock               clockwork.Clock
@@ -1220,6 +1502,7 @@ const (
 	defaultMaxExecutionDuration = 10 * time.Minute
 	defaultHeartbeatCadence     = 5 * time.Minute
 	defaultStepTimeout          = 2 * time.Minute
+	defaultRedriveInterval      = 15 * time.Second
 )
 
 func NewEngine(ctx context.Context, cfg Config) (engine *Engine, err error) {
@@ -1259,6 +1542,10 @@ func NewEngine(ctx context.Context, cfg Config) (engine *Engine, err error) {
 		cfg.retryMs = 5000
 	}
 
+	if cfg.redriveInterval == 0 {
+		cfg.redriveInterval = defaultRedriveInterval
+	}
+
 	if cfg.afterInit == nil {
 		cfg.afterInit = func(success bool) {}
 	}
@@ -1271,6 +1558,10 @@ func NewEngine(ctx context.Context, cfg Config) (engine *Engine, err error) {
 		cfg.clock = clockwork.NewRealClock()
 	}
 
+	if cfg.Limiter == nil {
+		cfg.Limiter = quotas.NewLimiter(quotas.Limits{}, quotas.Limits{}, cfg.clock)
+	}
+
 	// TODO: validation of the workflow spec
 	// We'll need to check, among other things:
 	// - that there are no step `ref` called `trigger` as this is reserved for any triggers
@@ -1314,6 +1605,8 @@ func NewEngine(ctx context.Context, cfg Config) (engine *Engine, err error) {
 		stopCh:               make(chan struct{}),
 		newWorkerTimeout:     cfg.NewWorkerTimeout,
 		stepTimeoutDuration:  cfg.StepTimeout,
+		redriveInterval:      cfg.redriveInterval,
+		limiter:              cfg.Limiter,
 		maxExecutionDuration: cfg.MaxExecutionDuration,
 		heartbeatCadence:     cfg.HeartbeatCadence,
 		onExecutionFinished:  cfg.onExecutionFinished,
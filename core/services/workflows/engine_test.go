@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -36,6 +39,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
 	"github.com/smartcontractkit/chainlink/v2/core/services/registrysyncer"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/quotas"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
 )
 
@@ -493,6 +497,14 @@ func TestEngine_ErrorsTheWorkflowIfAStepErrors(t *testing.T) {
 	assert.Equal(t, state.Status, store.StatusErrored)
 	// evm_median is the ref of our failing consensus step
 	assert.Equal(t, state.Steps["evm_median"].Status, store.StatusErrored)
+
+	// the execution is dead-lettered even though the step has no retries, so that it can be re-driven
+	letters, count, err := eng.executionStates.ListDeadLetters(ctx, testWorkflowId, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	assert.Equal(t, eid, letters[0].ExecutionID)
+	assert.Equal(t, "evm_median", letters[0].StepRef)
+	assert.Equal(t, 1, letters[0].Attempts)
 }
 
 func TestEngine_GracefulEarlyTermination(t *testing.T) {
@@ -736,6 +748,13 @@ func TestEngine_TimesOutOldExecutions(t *testing.T) {
 	gotEx, err := dbstore.Get(ctx, "<execution-ID>")
 	require.NoError(t, err)
 	assert.Equal(t, store.StatusTimeout, gotEx.Status)
+
+	// timed out executions are dead-lettered, so that they can be re-driven
+	letters, count, err := dbstore.ListDeadLetters(ctx, testWorkflowId, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	assert.Equal(t, "<execution-ID>", letters[0].ExecutionID)
+	assert.Equal(t, "execution timed out", letters[0].Error)
 }
 
 const (
@@ -1488,6 +1507,12 @@ func TestEngine_WithCustomComputeStep(t *testing.T) {
 	res, ok := state.ResultForStep("compute")
 	assert.True(t, ok)
 	assert.True(t, res.Outputs.(*values.Map).Underlying["Value"].(*values.Bool).Underlying)
+	assert.Len(t, res.Outputs.(*values.Map).Underlying, 1, "the usage must not be added to the outputs")
+
+	usage := state.Steps["compute"].Outputs.Usage
+	require.NotNil(t, usage)
+	assert.Contains(t, usage.Underlying, "WallTimeMs")
+	assert.Contains(t, usage.Underlying, "MemoryBudgetMBs")
 }
 
 func TestEngine_CustomComputePropagatesBreaks(t *testing.T) {
@@ -1671,3 +1696,382 @@ func TestEngine_FetchesSecrets(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, gotConfig, expm)
 }
+
+const conditionalWorkflow = `
+triggers:
+  - id: "mercury-trigger@1.0.0"
+    config:
+      feedlist:
+        - "0x1111111111111111111100000000000000000000000000000000000000000000" # ETHUSD
+
+actions:
+  - id: "condition@1.0.0"
+    ref: "is_high"
+    config:
+      expression: "price > 1.1"
+    inputs:
+      price: "$(trigger.outputs.456)"
+  - id: "condition@1.0.0"
+    ref: "is_low"
+    config:
+      expression: "!(price > 1.1)"
+    inputs:
+      price: "$(trigger.outputs.456)"
+  - id: "read_chain_action@1.0.0"
+    ref: "high_action"
+    config: {}
+    inputs:
+      gate: "$(is_high.outputs)"
+  - id: "read_chain_action@1.0.0"
+    ref: "low_action"
+    config: {}
+    inputs:
+      gate: "$(is_low.outputs)"
+  - id: "join@1.0.0"
+    ref: "merged"
+    config: {}
+    inputs:
+      high: "$(high_action.outputs)"
+      low: "$(low_action.outputs)"
+
+targets:
+  - id: "write_polygon-testnet-mumbai@1.0.0"
+    inputs:
+      report: "$(merged.outputs)"
+    config:
+      address: "0x3F3554832c636721F1fD1822Ccca0354576741Ef"
+      params: ["$(report)"]
+      abi: "receive(report bytes)"
+`
+
+func TestEngine_ConditionalBranching(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	reg := coreCap.NewRegistry(logger.TestLogger(t))
+
+	trigger, _ := mockTrigger(t)
+	require.NoError(t, reg.Add(ctx, trigger))
+	action, out := mockAction(t)
+	require.NoError(t, reg.Add(ctx, action))
+	require.NoError(t, reg.Add(ctx, mockTarget("")))
+
+	eng, hooks := newTestEngineWithYAMLSpec(t, reg, conditionalWorkflow)
+	servicetest.Run(t, eng)
+
+	eid := getExecutionId(t, eng, hooks)
+	state, err := eng.executionStates.Get(ctx, eid)
+	require.NoError(t, err)
+
+	// skipping a branch doesn't affect the status of the execution
+	assert.Equal(t, store.StatusCompleted, state.Status)
+	assert.Equal(t, store.StatusCompleted, state.Steps["is_high"].Status)
+	assert.Equal(t, store.StatusSkipped, state.Steps["is_low"].Status)
+	assert.Equal(t, store.StatusCompleted, state.Steps["high_action"].Status)
+	assert.Nil(t, state.Steps["low_action"])
+	assert.Equal(t, store.StatusCompleted, state.Steps["merged"].Status)
+	assert.Equal(t, store.StatusCompleted, state.Steps["write_polygon-testnet-mumbai@1.0.0"].Status)
+
+	// the join step outputs nil for the branch that was skipped
+	merged, err := values.Unwrap(state.Steps["merged"].Outputs.Value)
+	require.NoError(t, err)
+	o, err := values.Unwrap(out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"high": o, "low": nil}, merged)
+}
+
+func TestEngine_ConditionErrorsOnInvalidInputs(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	reg := coreCap.NewRegistry(logger.TestLogger(t))
+
+	trigger, _ := mockTrigger(t)
+	require.NoError(t, reg.Add(ctx, trigger))
+	action, _ := mockAction(t)
+	require.NoError(t, reg.Add(ctx, action))
+	require.NoError(t, reg.Add(ctx, mockTarget("")))
+
+	// a price compared to a string can't be evaluated
+	spec := strings.Replace(conditionalWorkflow, `expression: "price > 1.1"`, `expression: "price > 'high'"`, 1)
+	eng, hooks := newTestEngineWithYAMLSpec(t, reg, spec)
+	servicetest.Run(t, eng)
+
+	eid := getExecutionId(t, eng, hooks)
+	state, err := eng.executionStates.Get(ctx, eid)
+	require.NoError(t, err)
+
+	assert.Equal(t, store.StatusErrored, state.Status)
+	assert.Equal(t, store.StatusErrored, state.Steps["is_high"].Status)
+	assert.Nil(t, state.Steps["merged"])
+}
+
+const mapOverWorkflow = `
+triggers:
+  - id: "mercury-trigger@1.0.0"
+    config:
+      feedlist:
+        - "0x1111111111111111111100000000000000000000000000000000000000000000" # ETHUSD
+
+actions:
+  - id: "read_chain_action@1.0.0"
+    ref: "read_prices"
+    config:
+      cre_map_over: "price"
+    inputs:
+      price:
+        - "$(trigger.outputs.123)"
+        - "$(trigger.outputs.456)"
+        - "$(trigger.outputs.789)"
+
+targets:
+  - id: "write_polygon-testnet-mumbai@1.0.0"
+    inputs:
+      report:
+        prices: "$(read_prices.outputs)"
+    config:
+      address: "0x3F3554832c636721F1fD1822Ccca0354576741Ef"
+      params: ["$(report)"]
+      abi: "receive(report bytes)"
+`
+
+func TestEngine_MapOver(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	reg := coreCap.NewRegistry(logger.TestLogger(t))
+
+	trigger, _ := mockTrigger(t)
+	require.NoError(t, reg.Add(ctx, trigger))
+	require.NoError(t, reg.Add(ctx, mockTarget("")))
+
+	var mu sync.Mutex
+	var refs []string
+	action := newMockCapability(
+		capabilities.MustNewCapabilityInfo(
+			"read_chain_action@1.0.0",
+			capabilities.CapabilityTypeAction,
+			"a read chain action",
+		),
+		func(req capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error) {
+			mu.Lock()
+			refs = append(refs, req.Metadata.ReferenceID)
+			mu.Unlock()
+
+			var price decimal.Decimal
+			if err := req.Inputs.Underlying["price"].UnwrapTo(&price); err != nil {
+				return capabilities.CapabilityResponse{}, err
+			}
+			if price.GreaterThan(decimal.NewFromFloat(1.4)) {
+				return capabilities.CapabilityResponse{}, capabilities.ErrStopExecution
+			}
+			out, err := values.NewMap(map[string]any{"doubled": price.Mul(decimal.NewFromInt(2))})
+			if err != nil {
+				return capabilities.CapabilityResponse{}, err
+			}
+			return capabilities.CapabilityResponse{Value: out}, nil
+		},
+	)
+	require.NoError(t, reg.Add(ctx, action))
+
+	eng, hooks := newTestEngineWithYAMLSpec(t, reg, mapOverWorkflow)
+	servicetest.Run(t, eng)
+
+	eid := getExecutionId(t, eng, hooks)
+	state, err := eng.executionStates.Get(ctx, eid)
+	require.NoError(t, err)
+
+	assert.Equal(t, store.StatusCompleted, state.Status)
+	assert.ElementsMatch(t, []string{"read_prices.0", "read_prices.1", "read_prices.2"}, refs)
+
+	// the outputs are in the order of the inputs, with nil for the executions that terminated early
+	outputs, err := values.Unwrap(state.Steps["read_prices"].Outputs.Value)
+	require.NoError(t, err)
+	require.Len(t, outputs, 3)
+	assert.True(t, decimal.NewFromFloat(2).Equal(outputs.([]any)[0].(map[string]any)["doubled"].(decimal.Decimal)))
+	assert.True(t, decimal.NewFromFloat(2.5).Equal(outputs.([]any)[1].(map[string]any)["doubled"].(decimal.Decimal)))
+	assert.Nil(t, outputs.([]any)[2])
+}
+
+func TestEngine_RateLimitsExecutions(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	reg := coreCap.NewRegistry(logger.TestLogger(t))
+
+	trigger := mockNoopTrigger(t).(*mockTriggerCapability)
+	require.NoError(t, reg.Add(ctx, trigger))
+	require.NoError(t, reg.Add(ctx, mockConsensus("")))
+	require.NoError(t, reg.Add(ctx, mockTarget("write_polygon-testnet-mumbai@1.0.0")))
+
+	eng, hooks := newTestEngineWithYAMLSpec(t, reg, simpleWorkflow, func(c *Config) {
+		c.Limiter = quotas.NewLimiter(quotas.Limits{}, quotas.Limits{ExecutionsPerMinute: 1}, c.clock)
+	})
+	servicetest.Run(t, eng)
+
+	outputs, err := values.NewMap(map[string]any{"123": decimal.NewFromFloat(1.00)})
+	require.NoError(t, err)
+	for _, id := range []string{"event-1", "event-2"} {
+		trigger.ch <- capabilities.TriggerResponse{
+			Event: capabilities.TriggerEvent{TriggerType: trigger.ID, ID: id, Outputs: outputs},
+		}
+	}
+
+	statuses := map[string]int{}
+	for i := 0; i < 2; i++ {
+		state, err := eng.executionStates.Get(ctx, getExecutionId(t, eng, hooks))
+		require.NoError(t, err)
+		statuses[state.Status]++
+		if state.Status == store.StatusRateLimited {
+			// the trigger event is kept for debugging, but no step is executed
+			assert.Len(t, state.Steps, 1)
+			assert.Contains(t, state.Steps, workflows.KeywordTrigger)
+			assert.NotNil(t, state.FinishedAt)
+		}
+	}
+	assert.Equal(t, map[string]int{store.StatusCompleted: 1, store.StatusRateLimited: 1}, statuses)
+}
+
+func TestEngine_ResumesPendingRetries(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		retries  int
+		attempts int
+		status   string
+		executed int32
+	}{
+		{name: "re-queues the step", retries: 2, attempts: 1, status: store.StatusCompleted, executed: 1},
+		{name: "dead-letters the step once its retries are exhausted", retries: 1, attempts: 2, status: store.StatusErrored},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := testutils.Context(t)
+			reg := coreCap.NewRegistry(logger.TestLogger(t))
+
+			var executed atomic.Int32
+			consensus := mockConsensus("")
+			transform := consensus.transform
+			consensus.transform = func(req capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error) {
+				executed.Add(1)
+				return transform(req)
+			}
+			require.NoError(t, reg.Add(ctx, mockNoopTrigger(t)))
+			require.NoError(t, reg.Add(ctx, consensus))
+			require.NoError(t, reg.Add(ctx, mockTarget("write_polygon-testnet-mumbai@1.0.0")))
+
+			resp, err := values.NewMap(map[string]any{"123": decimal.NewFromFloat(1.00)})
+			require.NoError(t, err)
+			clock := clockwork.NewRealClock()
+			executionStore := store.NewInMemoryStore(clock)
+			// the node stopped while the consensus step was waiting for a retry
+			_, err = executionStore.Add(ctx, &store.WorkflowExecution{
+				Steps: map[string]*store.WorkflowExecutionStep{
+					workflows.KeywordTrigger: {
+						Outputs:     store.StepOutput{Value: resp},
+						Status:      store.StatusCompleted,
+						ExecutionID: "<execution-ID>",
+						Ref:         workflows.KeywordTrigger,
+					},
+					"evm_median": {
+						Outputs:     store.StepOutput{Err: errors.New("consensus unavailable")},
+						Status:      store.StatusRetrying,
+						ExecutionID: "<execution-ID>",
+						Ref:         "evm_median",
+						Attempts:    tc.attempts,
+					},
+				},
+				WorkflowID:  testWorkflowId,
+				ExecutionID: "<execution-ID>",
+				Status:      store.StatusStarted,
+			})
+			require.NoError(t, err)
+
+			spec := strings.Replace(simpleWorkflow, `      encoder: "EVM"`, fmt.Sprintf(`      encoder: "EVM"
+      cre_step_retries: %d
+      cre_step_retry_backoff_ms: 10`, tc.retries), 1)
+			eng, hooks := newTestEngineWithYAMLSpec(t, reg, spec, func(c *Config) {
+				c.clock = clock
+				c.Store = executionStore
+			})
+			servicetest.Run(t, eng)
+
+			eid := getExecutionId(t, eng, hooks)
+			state, err := executionStore.Get(ctx, eid)
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, state.Status)
+			assert.Equal(t, tc.executed, executed.Load())
+			assert.Equal(t, tc.attempts+int(tc.executed), state.Steps["evm_median"].Attempts)
+
+			_, count, err := executionStore.ListDeadLetters(ctx, testWorkflowId, 0, 10)
+			require.NoError(t, err)
+			if tc.status == store.StatusErrored {
+				assert.Equal(t, 1, count)
+			} else {
+				assert.Equal(t, 0, count)
+			}
+		})
+	}
+}
+
+func TestEngine_RetriesStepsAndRedrivesDeadLetters(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	reg := coreCap.NewRegistry(logger.TestLogger(t))
+
+	var failing atomic.Bool
+	var attempts atomic.Int32
+	failing.Store(true)
+	consensus := mockConsensus("")
+	transform := consensus.transform
+	consensus.transform = func(req capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error) {
+		attempts.Add(1)
+		if failing.Load() {
+			return capabilities.CapabilityResponse{}, errors.New("consensus unavailable")
+		}
+		return transform(req)
+	}
+
+	trigger, _ := mockTrigger(t)
+	require.NoError(t, reg.Add(ctx, trigger))
+	require.NoError(t, reg.Add(ctx, consensus))
+	target := mockTarget("write_polygon-testnet-mumbai@1.0.0")
+	require.NoError(t, reg.Add(ctx, target))
+
+	spec := strings.Replace(simpleWorkflow, `      encoder: "EVM"`, `      encoder: "EVM"
+      cre_step_retries: 2
+      cre_step_retry_backoff_ms: 10`, 1)
+	eng, hooks := newTestEngineWithYAMLSpec(t, reg, spec, func(c *Config) {
+		c.clock = clockwork.NewRealClock()
+		c.redriveInterval = 10 * time.Millisecond
+	})
+	servicetest.Run(t, eng)
+
+	// the step is retried twice, then the execution errors and is dead-lettered
+	eid := getExecutionId(t, eng, hooks)
+	state, err := eng.executionStates.Get(ctx, eid)
+	require.NoError(t, err)
+	assert.Equal(t, store.StatusErrored, state.Status)
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Equal(t, 3, state.Steps["evm_median"].Attempts)
+
+	letters, count, err := eng.executionStates.ListDeadLetters(ctx, testWorkflowId, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	assert.Equal(t, eid, letters[0].ExecutionID)
+	assert.Equal(t, "evm_median", letters[0].StepRef)
+	assert.Equal(t, "consensus unavailable", letters[0].Error)
+	assert.Equal(t, 3, letters[0].Attempts)
+
+	// once the capability recovers, the re-driven execution resumes from the failed step
+	failing.Store(false)
+	require.NoError(t, eng.executionStates.RequestRedrive(ctx, testWorkflowId, eid))
+	assert.Equal(t, eid, getExecutionId(t, eng, hooks))
+
+	state, err = eng.executionStates.Get(ctx, eid)
+	require.NoError(t, err)
+	assert.Equal(t, store.StatusCompleted, state.Status)
+	assert.Equal(t, store.StatusCompleted, state.Steps["evm_median"].Status)
+	assert.Equal(t, store.StatusCompleted, state.Steps["write_polygon-testnet-mumbai@1.0.0"].Status)
+	assert.Len(t, target.response, 1)
+
+	_, count, err = eng.executionStates.ListDeadLetters(ctx, testWorkflowId, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
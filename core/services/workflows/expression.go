@@ -0,0 +1,501 @@
+package workflows
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+)
+
+// expression is a parsed boolean expression over the inputs of a condition step, e.g.
+//
+//	deviation > 0.5 && (feed.id == "ETH/USD" || len(reports) >= 2)
+//
+// Identifiers select an input by name, and nested fields or list elements with dot-separated parts, the same way
+// step references are interpolated (e.g. `reports.0.price`). Supported operators are `||`, `&&`, `!`, `==`, `!=`,
+// `<`, `<=`, `>` and `>=`; literals are numbers, single or double-quoted strings, `true`, `false` and `null`; and
+// `len(x)` returns the length of a string, list or map.
+//
+// Numbers are compared by value whatever their type, strings are ordered lexicographically, and `&&`, `||` and `!`
+// only accept booleans, so that a typo in a field name fails the step instead of silently skipping a branch.
+type expression struct {
+	src  string
+	root exprNode
+}
+
+type exprNode interface {
+	eval(vars map[string]any) (any, error)
+}
+
+// parseExpression parses src, returning an error if it is not a valid expression.
+func parseExpression(src string) (*expression, error) {
+	tokens, err := tokenizeExpression(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", src, err)
+	}
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.peek().kind != tokenEOF {
+		err = fmt.Errorf("unexpected %q at position %d", p.peek().text, p.peek().pos)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", src, err)
+	}
+	return &expression{src: src, root: root}, nil
+}
+
+// evaluate returns the value of the expression for vars, which must be a boolean.
+func (e *expression) evaluate(vars map[string]any) (bool, error) {
+	v, err := e.root.eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression %q: %w", e.src, err)
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q evaluated to %v (%T), expected a boolean", e.src, v, v)
+	}
+	return b, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var exprOperators = []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "!", "-"}
+
+func tokenizeExpression(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(src) && rune(src[end]) != c {
+				if src[end] == '\\' && c == '"' {
+					end++
+				}
+				end++
+			}
+			if end >= len(src) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			s := src[i+1 : end]
+			if c == '"' {
+				var err error
+				if s, err = strconv.Unquote(src[i : end+1]); err != nil {
+					return nil, fmt.Errorf("invalid string at position %d: %w", i, err)
+				}
+			}
+			tokens = append(tokens, token{tokenString, s, i})
+			i = end + 1
+		case unicode.IsDigit(c):
+			end := i
+			for end < len(src) && (unicode.IsDigit(rune(src[end])) || src[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{tokenNumber, src[i:end], i})
+			i = end
+		case c == '_' || unicode.IsLetter(c):
+			end := i
+			for end < len(src) && (src[end] == '_' || src[end] == '.' || unicode.IsLetter(rune(src[end])) || unicode.IsDigit(rune(src[end]))) {
+				end++
+			}
+			tokens = append(tokens, token{tokenIdent, src[i:end], i})
+			i = end
+		default:
+			var op string
+			for _, o := range exprOperators {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{tokenOperator, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{tokenEOF, "end of expression", len(src)}), nil
+}
+
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) acceptOperator(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOperator("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "||", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOperator("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "&&", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if _, ok := p.acceptOperator("!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.acceptOperator("==", "!=", "<=", ">=", "<", ">")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return &comparisonNode{op: op, left: left, right: right}, nil
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		d, err := decimal.NewFromString(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+		}
+		return &literalNode{value: d}, nil
+	case tokenString:
+		return &literalNode{value: t.text}, nil
+	case tokenOperator:
+		if t.text == "-" && p.peek().kind == tokenNumber {
+			n := p.next()
+			d, err := decimal.NewFromString(n.text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", n.text, n.pos)
+			}
+			return &literalNode{value: d.Neg()}, nil
+		}
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if r := p.next(); r.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ) at position %d, got %q", r.pos, r.text)
+		}
+		return inner, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		case "len":
+			if p.peek().kind == tokenLParen {
+				p.next()
+				arg, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				if r := p.next(); r.kind != tokenRParen {
+					return nil, fmt.Errorf("expected ) at position %d, got %q", r.pos, r.text)
+				}
+				return &lenNode{arg: arg}, nil
+			}
+		}
+		parts := strings.Split(t.text, ".")
+		for _, part := range parts {
+			if part == "" {
+				return nil, fmt.Errorf("invalid identifier %q at position %d", t.text, t.pos)
+			}
+		}
+		return &identNode{parts: parts}, nil
+	case tokenEOF, tokenRParen:
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+type literalNode struct {
+	value any
+}
+
+func (n *literalNode) eval(map[string]any) (any, error) {
+	return n.value, nil
+}
+
+type identNode struct {
+	parts []string
+}
+
+func (n *identNode) eval(vars map[string]any) (any, error) {
+	var val any = vars
+	for i, part := range n.parts {
+		switch v := val.(type) {
+		case map[string]any:
+			inner, ok := v[part]
+			if !ok {
+				return nil, fmt.Errorf("could not find %q", strings.Join(n.parts[:i+1], "."))
+			}
+			val = inner
+		case []any:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, fmt.Errorf("invalid index %q in %q", part, strings.Join(n.parts, "."))
+			}
+			val = v[idx]
+		default:
+			return nil, fmt.Errorf("cannot select %q of %s, which is a %T", part, strings.Join(n.parts[:i], "."), val)
+		}
+	}
+	return val, nil
+}
+
+type lenNode struct {
+	arg exprNode
+}
+
+func (n *lenNode) eval(vars map[string]any) (any, error) {
+	v, err := n.arg.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	switch tv := v.(type) {
+	case string:
+		return decimal.NewFromInt(int64(len(tv))), nil
+	case []byte:
+		return decimal.NewFromInt(int64(len(tv))), nil
+	case []any:
+		return decimal.NewFromInt(int64(len(tv))), nil
+	case map[string]any:
+		return decimal.NewFromInt(int64(len(tv))), nil
+	case nil:
+		return decimal.Zero, nil
+	}
+	return nil, fmt.Errorf("len is not defined for %T", v)
+}
+
+type notNode struct {
+	operand exprNode
+}
+
+func (n *notNode) eval(vars map[string]any) (any, error) {
+	v, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("operator ! expects a boolean, got %T", v)
+	}
+	return !b, nil
+}
+
+type logicalNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *logicalNode) eval(vars map[string]any) (any, error) {
+	l, err := evalBool(n.op, n.left, vars)
+	if err != nil {
+		return nil, err
+	}
+	// short-circuit, so that the right side may select fields that only exist if the left side is true
+	if (n.op == "&&" && !l) || (n.op == "||" && l) {
+		return l, nil
+	}
+	return evalBool(n.op, n.right, vars)
+}
+
+func evalBool(op string, node exprNode, vars map[string]any) (bool, error) {
+	v, err := node.eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("operator %s expects booleans, got %T", op, v)
+	}
+	return b, nil
+}
+
+type comparisonNode struct {
+	op          string
+	left, right exprNode
+}
+
+var errNotComparable = errors.New("values are not comparable")
+
+func (n *comparisonNode) eval(vars map[string]any) (any, error) {
+	l, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return exprEqual(l, r), nil
+	case "!=":
+		return !exprEqual(l, r), nil
+	}
+
+	c, err := exprCompare(l, r)
+	if err != nil {
+		return nil, fmt.Errorf("operator %s: %w: %v (%T) and %v (%T)", n.op, err, l, l, r, r)
+	}
+	switch n.op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default: // ">="
+		return c >= 0, nil
+	}
+}
+
+func exprEqual(l, r any) bool {
+	if ld, ok := toDecimal(l); ok {
+		rd, ok := toDecimal(r)
+		return ok && ld.Equal(rd)
+	}
+	if lb, ok := l.([]byte); ok {
+		rb, ok := r.([]byte)
+		return ok && bytes.Equal(lb, rb)
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+func exprCompare(l, r any) (int, error) {
+	if ld, ok := toDecimal(l); ok {
+		if rd, ok := toDecimal(r); ok {
+			return ld.Cmp(rd), nil
+		}
+		return 0, errNotComparable
+	}
+	if ls, ok := l.(string); ok {
+		if rs, ok := r.(string); ok {
+			return strings.Compare(ls, rs), nil
+		}
+	}
+	return 0, errNotComparable
+}
+
+// toDecimal converts the numeric types that unwrapped values can hold to a decimal.
+func toDecimal(v any) (decimal.Decimal, bool) {
+	switch n := v.(type) {
+	case decimal.Decimal:
+		return n, true
+	case *decimal.Decimal:
+		if n == nil {
+			return decimal.Decimal{}, false
+		}
+		return *n, true
+	case int64:
+		return decimal.NewFromInt(n), true
+	case int:
+		return decimal.NewFromInt(int64(n)), true
+	case uint64:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(n), 0), true
+	case float64:
+		return decimal.NewFromFloat(n), true
+	case *big.Int:
+		if n == nil {
+			return decimal.Decimal{}, false
+		}
+		return decimal.NewFromBigInt(n, 0), true
+	}
+	return decimal.Decimal{}, false
+}
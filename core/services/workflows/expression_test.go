@@ -0,0 +1,120 @@
+package workflows
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression_Evaluate(t *testing.T) {
+	vars := map[string]any{
+		"deviation": decimal.RequireFromString("0.75"),
+		"count":     int64(3),
+		"big":       big.NewInt(1_000_000),
+		"ok":        true,
+		"feed": map[string]any{
+			"id":    "ETH/USD",
+			"price": decimal.RequireFromString("3400.5"),
+		},
+		"reports": []any{
+			map[string]any{"price": int64(10)},
+			map[string]any{"price": int64(20)},
+		},
+		"empty": nil,
+	}
+
+	testCases := []struct {
+		expr string
+		want bool
+	}{
+		{"ok", true},
+		{"!ok", false},
+		{"deviation > 0.5", true},
+		{"deviation >= 0.75 && deviation <= 0.75", true},
+		{"deviation < 0.5", false},
+		{"count == 3", true},
+		{"count != 3.0", false},
+		{"big > 999999", true},
+		{"-1 < count", true},
+		{`feed.id == "ETH/USD"`, true},
+		{`feed.id == 'BTC/USD'`, false},
+		{`feed.id < "FOO"`, true},
+		{"feed.price > 3400", true},
+		{"reports.1.price == 20", true},
+		{"len(reports) == 2", true},
+		{"len(feed.id) == 7", true},
+		{"len(empty) == 0", true},
+		{"empty == null", true},
+		{"ok == true", true},
+		{"deviation > 1 || (count > 2 && ok)", true},
+		{"!(deviation > 1 || count > 2)", false},
+		// the right side isn't evaluated, so it may reference fields that don't exist
+		{"len(reports) > 5 && reports.5.price > 0", false},
+		{"len(reports) < 5 || reports.5.price > 0", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := parseExpression(tc.expr)
+			require.NoError(t, err)
+			got, err := e.evaluate(vars)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestExpression_ParseErrors(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"count >",
+		"count > 1 1",
+		"(count > 1",
+		"count > 1)",
+		"count = 1",
+		`feed.id == "ETH/USD`,
+		"len(count",
+		"count & ok",
+		"feed..id",
+	} {
+		t.Run(src, func(t *testing.T) {
+			_, err := parseExpression(src)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestExpression_EvaluateErrors(t *testing.T) {
+	vars := map[string]any{
+		"count": int64(3),
+		"name":  "feed",
+		"feed":  map[string]any{"id": "ETH/USD"},
+		"list":  []any{int64(1)},
+	}
+
+	for _, src := range []string{
+		// not a boolean
+		"count",
+		"!count",
+		"count && true",
+		// missing fields
+		"missing > 1",
+		"feed.missing == 1",
+		"list.1 == 1",
+		"count.field == 1",
+		// not comparable
+		"name > 1",
+		"feed > 1",
+		"len(count) > 1",
+	} {
+		t.Run(src, func(t *testing.T) {
+			e, err := parseExpression(src)
+			require.NoError(t, err)
+			_, err = e.evaluate(vars)
+			require.Error(t, err)
+		})
+	}
+}
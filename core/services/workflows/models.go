@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/dominikbraun/graph"
 
@@ -26,6 +27,8 @@ type workflow struct {
 	graph.Graph[string, *step]
 
 	triggers []*triggerCapability
+	// joins are the join steps of the workflow, which can't be queued by their direct dependencies only.
+	joins []*step
 }
 
 func (w *workflow) walkDo(start string, do func(s *step) error) error {
@@ -83,6 +86,14 @@ type step struct {
 	capability capabilities.ExecutableCapability
 	info       capabilities.CapabilityInfo
 	config     *values.Map
+
+	// condition is the expression of a condition step.
+	condition *expression
+	// mapOver is the name of the input that the capability of the step is mapped over, if any.
+	mapOver string
+	// retries is the number of times the step is retried when it fails, waiting retryBackoff before the first retry.
+	retries      int
+	retryBackoff time.Duration
 }
 
 type triggerCapability struct {
@@ -139,10 +150,20 @@ func createWorkflow(wf2 *workflows.DependencyGraph) (*workflow, error) {
 		if innerErr != nil {
 			return nil, fmt.Errorf("failed to retrieve vertex for %s: %w", vertexRef, innerErr)
 		}
-		innerErr = g.AddVertex(&step{Vertex: *v})
+		s := &step{Vertex: *v}
+		if innerErr = s.initControlFlow(); innerErr != nil {
+			return nil, innerErr
+		}
+		if innerErr = s.initRetries(); innerErr != nil {
+			return nil, innerErr
+		}
+		innerErr = g.AddVertex(s)
 		if innerErr != nil {
 			return nil, fmt.Errorf("failed to add vertex to executable workflow %s: %w", vertexRef, innerErr)
 		}
+		if s.ID == JoinStepID {
+			out.joins = append(out.joins, s)
+		}
 	}
 	// now we can add all the edges. this works because we are using vertex hash function is the same in both graphs.
 	// see comment on `stepHash` function.
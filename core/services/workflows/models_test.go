@@ -1,7 +1,9 @@
 package workflows
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -333,3 +335,192 @@ func TestParsesIntsCorrectly(t *testing.T) {
 
 	assert.Equal(t, int64(3600), n.Config["aggregation_config"].(map[string]any)["0x1111111111111111111100000000000000000000000000000000000000000000"].(map[string]any)["heartbeat"])
 }
+
+func TestParse_ControlFlow(t *testing.T) {
+	t.Parallel()
+	const header = `
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+
+actions:
+`
+	const footer = `
+targets:
+  - id: "a-target@1.0.0"
+    config: {}
+    ref: "a-target"
+    inputs:
+      trigger_output: $(trigger.outputs)
+`
+	testCases := []struct {
+		name    string
+		actions string
+		errMsg  string
+	}{
+		{
+			name: "valid",
+			actions: `
+  - id: "condition@1.0.0"
+    ref: "is-high"
+    config:
+      expression: "price > 1.5 && feed == 'ETH/USD'"
+    inputs:
+      price: $(trigger.outputs.price)
+      feed: $(trigger.outputs.feed)
+  - id: "an-action@1.0.0"
+    ref: "an-action"
+    config:
+      cre_map_over: "reports"
+    inputs:
+      reports: $(trigger.outputs.reports)
+      gate: $(is-high.outputs)
+  - id: "join@1.0.0"
+    ref: "merged"
+    config: {}
+    inputs:
+      action: $(an-action.outputs)
+      condition: $(is-high.outputs)
+`,
+		},
+		{
+			name: "condition without an expression",
+			actions: `
+  - id: "condition@1.0.0"
+    ref: "is-high"
+    config: {}
+    inputs:
+      price: $(trigger.outputs.price)
+`,
+			errMsg: `condition step is-high must have a string "expression" in its config`,
+		},
+		{
+			name: "condition with an invalid expression",
+			actions: `
+  - id: "condition@1.0.0"
+    ref: "is-high"
+    config:
+      expression: "price >"
+    inputs:
+      price: $(trigger.outputs.price)
+`,
+			errMsg: `condition step is-high: invalid expression "price >"`,
+		},
+		{
+			name: "map over a missing input",
+			actions: `
+  - id: "an-action@1.0.0"
+    ref: "an-action"
+    config:
+      cre_map_over: "reports"
+    inputs:
+      trigger_output: $(trigger.outputs)
+`,
+			errMsg: `step an-action: cre_map_over references input "reports", which doesn't exist`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, _, _, err := job.YAMLSpecFactory{}.Spec(testutils.Context(t), header+tc.actions+footer, "")
+			require.NoError(t, err)
+
+			wf, err := Parse(spec)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			condition, err := wf.Vertex("is-high")
+			require.NoError(t, err)
+			assert.NotNil(t, condition.condition)
+
+			action, err := wf.Vertex("an-action")
+			require.NoError(t, err)
+			assert.Equal(t, "reports", action.mapOver)
+
+			require.Len(t, wf.joins, 1)
+			assert.Equal(t, "merged", wf.joins[0].Ref)
+		})
+	}
+}
+
+func TestParse_StepRetries(t *testing.T) {
+	t.Parallel()
+	const workflow = `
+triggers:
+  - id: "a-trigger@1.0.0"
+    config: {}
+
+targets:
+  - id: "a-target@1.0.0"
+    ref: "a-target"
+    config:
+%s
+    inputs:
+      trigger_output: $(trigger.outputs)
+`
+	testCases := []struct {
+		name    string
+		config  string
+		retries int
+		backoff time.Duration
+		errMsg  string
+	}{
+		{
+			name:   "no retries",
+			config: `      address: "0x01"`,
+		},
+		{
+			name:    "default backoff",
+			config:  `      cre_step_retries: 3`,
+			retries: 3,
+			backoff: time.Second,
+		},
+		{
+			name: "custom backoff",
+			config: `      cre_step_retries: 2
+      cre_step_retry_backoff_ms: 500`,
+			retries: 2,
+			backoff: 500 * time.Millisecond,
+		},
+		{
+			name: "backoff is capped",
+			config: `      cre_step_retries: 2
+      cre_step_retry_backoff_ms: 3600000`,
+			retries: 2,
+			backoff: time.Minute,
+		},
+		{
+			name:   "too many retries",
+			config: `      cre_step_retries: 11`,
+			errMsg: "step a-target: cre_step_retries must be an integer between 0 and 10",
+		},
+		{
+			name: "invalid backoff",
+			config: `      cre_step_retries: 1
+      cre_step_retry_backoff_ms: "1s"`,
+			errMsg: "step a-target: cre_step_retry_backoff_ms must be a positive integer",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, _, _, err := job.YAMLSpecFactory{}.Spec(testutils.Context(t), fmt.Sprintf(workflow, tc.config), "")
+			require.NoError(t, err)
+
+			wf, err := Parse(spec)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			target, err := wf.Vertex("a-target")
+			require.NoError(t, err)
+			assert.Equal(t, tc.retries, target.retries)
+			assert.Equal(t, tc.backoff, target.retryBackoff)
+		})
+	}
+}
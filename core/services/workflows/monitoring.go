@@ -32,6 +32,8 @@ type engineMetrics struct {
 	workflowErrorDurationSeconds       metric.Int64Histogram
 	workflowTimeoutDurationSeconds     metric.Int64Histogram
 	workflowStepDurationSeconds        metric.Int64Histogram
+	quotaRejectionCounter              metric.Int64Counter
+	stepRetryCounter                   metric.Int64Counter
 }
 
 func initMonitoringResources() (em *engineMetrics, err error) {
@@ -133,6 +135,16 @@ func initMonitoringResources() (em *engineMetrics, err error) {
 		return nil, fmt.Errorf("failed to register step execution time histogram: %w", err)
 	}
 
+	em.quotaRejectionCounter, err = beholder.GetMeter().Int64Counter("platform_engine_quota_rejections")
+	if err != nil {
+		return nil, fmt.Errorf("failed to register quota rejection counter: %w", err)
+	}
+
+	em.stepRetryCounter, err = beholder.GetMeter().Int64Counter("platform_engine_step_retries")
+	if err != nil {
+		return nil, fmt.Errorf("failed to register step retry counter: %w", err)
+	}
+
 	return em, nil
 }
 
@@ -208,6 +220,16 @@ func (c workflowsMetricLabeler) updateTotalWorkflowsGauge(ctx context.Context, v
 	c.em.workflowsRunningGauge.Record(ctx, val, metric.WithAttributes(otelLabels...))
 }
 
+func (c workflowsMetricLabeler) incrementQuotaRejectionCounter(ctx context.Context) {
+	otelLabels := monutils.KvMapToOtelAttributes(c.Labels)
+	c.em.quotaRejectionCounter.Add(ctx, 1, metric.WithAttributes(otelLabels...))
+}
+
+func (c workflowsMetricLabeler) incrementStepRetryCounter(ctx context.Context) {
+	otelLabels := monutils.KvMapToOtelAttributes(c.Labels)
+	c.em.stepRetryCounter.Add(ctx, 1, metric.WithAttributes(otelLabels...))
+}
+
 func (c workflowsMetricLabeler) incrementEngineHeartbeatCounter(ctx context.Context) {
 	otelLabels := monutils.KvMapToOtelAttributes(c.Labels)
 	c.em.engineHeartbeatCounter.Add(ctx, 1, metric.WithAttributes(otelLabels...))
@@ -0,0 +1,326 @@
+// Package quotas limits the resources that workflows can use on a node, per workflow owner and per workflow, so that a
+// single noisy workflow, or owner, can't saturate the node.
+package quotas
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+)
+
+// Quota names a limited resource. It is used as a metric label.
+type Quota string
+
+const (
+	QuotaExecutionRate        Quota = "executions_per_minute"
+	QuotaConcurrentExecutions Quota = "concurrent_executions"
+	QuotaComputeTime          Quota = "compute_time_per_minute"
+	QuotaFetchCalls           Quota = "fetch_calls_per_minute"
+)
+
+// Scope is the key a quota is enforced on.
+type Scope string
+
+const (
+	ScopeOwner    Scope = "owner"
+	ScopeWorkflow Scope = "workflow"
+)
+
+// window is the period over which the per minute quotas are counted.
+const window = time.Minute
+
+// ErrQuotaExceeded is wrapped by the errors returned when a quota is exceeded.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaExceededError describes which quota was exceeded, and for which owner or workflow.
+type QuotaExceededError struct {
+	Quota Quota
+	Scope Scope
+	Key   string
+	Limit string
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: %s limit of %s reached for %s %s", ErrQuotaExceeded, e.Quota, e.Limit, e.Scope, e.Key)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// Limits are the quotas of a workflow owner, or of a single workflow. A zero value disables the quota.
+type Limits struct {
+	// ExecutionsPerMinute is the maximum number of executions started per minute.
+	ExecutionsPerMinute uint32
+	// ConcurrentExecutions is the maximum number of executions running at the same time.
+	ConcurrentExecutions uint32
+	// ComputeTimePerMinute is the maximum time spent executing compute steps per minute.
+	ComputeTimePerMinute time.Duration
+	// FetchCallsPerMinute is the maximum number of outbound HTTP calls made by compute steps per minute.
+	FetchCallsPerMinute uint32
+	// Execution are the hard limits of a single execution of a compute step.
+	Execution ExecutionLimits
+}
+
+// ExecutionLimits are the hard limits on the resources a single execution of a compute step can use. A zero value
+// disables the limit.
+type ExecutionLimits struct {
+	// Fuel is the maximum number of WASM instructions executed.
+	Fuel uint64
+	// MemoryMBs is the maximum memory of the WASM module.
+	MemoryMBs uint32
+	// FetchCalls is the maximum number of outbound HTTP calls.
+	FetchCalls uint32
+}
+
+// usage is the resources used by an owner or a workflow in the current window.
+type usage struct {
+	windowStart time.Time
+	executions  uint32
+	computeTime time.Duration
+	fetchCalls  uint32
+	running     uint32
+}
+
+// Limiter enforces the quotas of every workflow owner and workflow running on the node. It is safe for concurrent use,
+// and shared by all the workflow engines.
+type Limiter struct {
+	perOwner    Limits
+	perWorkflow Limits
+	clock       clockwork.Clock
+
+	mu          sync.Mutex
+	ownerLimits map[string]Limits
+	owners      map[string]*usage
+	workflows   map[string]*usage
+	lastSweep   time.Time
+}
+
+func NewLimiter(perOwner, perWorkflow Limits, clock clockwork.Clock) *Limiter {
+	return &Limiter{
+		perOwner:    perOwner,
+		perWorkflow: perWorkflow,
+		clock:       clock,
+		ownerLimits: map[string]Limits{},
+		owners:      map[string]*usage{},
+		workflows:   map[string]*usage{},
+		lastSweep:   clock.Now(),
+	}
+}
+
+// SetOwnerLimits overrides the quotas of a single workflow owner.
+func (l *Limiter) SetOwnerLimits(owner string, limits Limits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ownerLimits[normalizeOwner(owner)] = limits
+}
+
+// RemoveWorkflow forgets the usage of a deleted workflow. The usage of its owner is kept until its window elapsed, so
+// that deleting workflows doesn't reset the quotas of the owner.
+func (l *Limiter) RemoveWorkflow(workflowID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.workflows, workflowID)
+}
+
+// limits returns the quotas of the owner. l.mu must be held.
+func (l *Limiter) limits(owner string) Limits {
+	if limits, ok := l.ownerLimits[normalizeOwner(owner)]; ok {
+		return limits
+	}
+	return l.perOwner
+}
+
+// StartExecution counts a new execution of the workflow, unless it would exceed the execution rate or concurrency
+// quotas, in which case it returns a QuotaExceededError. Every started execution must be finished with FinishExecution.
+func (l *Limiter) StartExecution(owner, workflowID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	o, w := l.usage(owner, workflowID)
+
+	if err := checkExecution(o, l.limits(owner), ScopeOwner, normalizeOwner(owner)); err != nil {
+		return err
+	}
+	if err := checkExecution(w, l.perWorkflow, ScopeWorkflow, workflowID); err != nil {
+		return err
+	}
+	for _, u := range []*usage{o, w} {
+		u.executions++
+		u.running++
+	}
+	return nil
+}
+
+// ResumeExecution counts an execution that was started before the node restarted as running, without enforcing any
+// quota, since it was already admitted. It must be finished with FinishExecution.
+func (l *Limiter) ResumeExecution(owner, workflowID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	o, w := l.usage(owner, workflowID)
+	o.running++
+	w.running++
+}
+
+// FinishExecution releases the concurrency quota held by an execution.
+func (l *Limiter) FinishExecution(owner, workflowID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	o, w := l.usage(owner, workflowID)
+	for _, u := range []*usage{o, w} {
+		if u.running > 0 {
+			u.running--
+		}
+	}
+}
+
+// CheckComputeTime returns a QuotaExceededError if the workflow, or its owner, used all of its compute time in the
+// current window.
+func (l *Limiter) CheckComputeTime(owner, workflowID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	o, w := l.usage(owner, workflowID)
+	if err := checkComputeTime(o, l.limits(owner), ScopeOwner, normalizeOwner(owner)); err != nil {
+		return err
+	}
+	return checkComputeTime(w, l.perWorkflow, ScopeWorkflow, workflowID)
+}
+
+// RecordComputeTime adds the duration of a compute step to the usage of the workflow and its owner.
+func (l *Limiter) RecordComputeTime(owner, workflowID string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	o, w := l.usage(owner, workflowID)
+	o.computeTime += d
+	w.computeTime += d
+}
+
+// AllowFetch counts an outbound fetch call of the workflow, unless it would exceed the fetch quota, in which case it
+// returns a QuotaExceededError.
+func (l *Limiter) AllowFetch(owner, workflowID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	o, w := l.usage(owner, workflowID)
+	if err := checkFetch(o, l.limits(owner), ScopeOwner, normalizeOwner(owner)); err != nil {
+		return err
+	}
+	if err := checkFetch(w, l.perWorkflow, ScopeWorkflow, workflowID); err != nil {
+		return err
+	}
+	o.fetchCalls++
+	w.fetchCalls++
+	return nil
+}
+
+// ExecutionLimits returns the hard limits of a single execution of a compute step of the workflow, i.e. the tightest
+// of the limits of the workflow and of its owner.
+func (l *Limiter) ExecutionLimits(owner, workflowID string) ExecutionLimits {
+	l.mu.Lock()
+	o, w := l.limits(owner).Execution, l.perWorkflow.Execution
+	l.mu.Unlock()
+	return ExecutionLimits{
+		Fuel:       minLimit(o.Fuel, w.Fuel),
+		MemoryMBs:  minLimit(o.MemoryMBs, w.MemoryMBs),
+		FetchCalls: minLimit(o.FetchCalls, w.FetchCalls),
+	}
+}
+
+// minLimit returns the smallest of two limits, ignoring disabled ones.
+func minLimit[T uint32 | uint64](a, b T) T {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// usage returns the usage of the owner and of the workflow, starting a new window for each one whose window elapsed.
+// l.mu must be held.
+func (l *Limiter) usage(owner, workflowID string) (*usage, *usage) {
+	now := l.clock.Now()
+	if now.Sub(l.lastSweep) >= window {
+		l.sweep(now)
+	}
+	get := func(m map[string]*usage, key string) *usage {
+		u, ok := m[key]
+		if !ok {
+			u = &usage{windowStart: now}
+			m[key] = u
+		}
+		if now.Sub(u.windowStart) >= window {
+			*u = usage{windowStart: now, running: u.running}
+		}
+		return u
+	}
+	return get(l.owners, normalizeOwner(owner)), get(l.workflows, workflowID)
+}
+
+// sweep forgets the usage of the owners and workflows without running executions whose window elapsed, since it would be
+// reset on their next use anyway. l.mu must be held.
+func (l *Limiter) sweep(now time.Time) {
+	for _, m := range []map[string]*usage{l.owners, l.workflows} {
+		for key, u := range m {
+			if u.running == 0 && now.Sub(u.windowStart) >= window {
+				delete(m, key)
+			}
+		}
+	}
+	l.lastSweep = now
+}
+
+func checkExecution(u *usage, limits Limits, scope Scope, key string) error {
+	if limits.ExecutionsPerMinute > 0 && u.executions >= limits.ExecutionsPerMinute {
+		return &QuotaExceededError{Quota: QuotaExecutionRate, Scope: scope, Key: key, Limit: fmt.Sprint(limits.ExecutionsPerMinute)}
+	}
+	if limits.ConcurrentExecutions > 0 && u.running >= limits.ConcurrentExecutions {
+		return &QuotaExceededError{Quota: QuotaConcurrentExecutions, Scope: scope, Key: key, Limit: fmt.Sprint(limits.ConcurrentExecutions)}
+	}
+	return nil
+}
+
+func checkComputeTime(u *usage, limits Limits, scope Scope, key string) error {
+	if limits.ComputeTimePerMinute > 0 && u.computeTime >= limits.ComputeTimePerMinute {
+		return &QuotaExceededError{Quota: QuotaComputeTime, Scope: scope, Key: key, Limit: limits.ComputeTimePerMinute.String()}
+	}
+	return nil
+}
+
+func checkFetch(u *usage, limits Limits, scope Scope, key string) error {
+	if limits.FetchCallsPerMinute > 0 && u.fetchCalls >= limits.FetchCallsPerMinute {
+		return &QuotaExceededError{Quota: QuotaFetchCalls, Scope: scope, Key: key, Limit: fmt.Sprint(limits.FetchCallsPerMinute)}
+	}
+	return nil
+}
+
+// normalizeOwner returns the owner address as lower case hex without prefix, since it may be formatted differently by
+// the job spec and the workflow registry.
+func normalizeOwner(owner string) string {
+	return strings.TrimPrefix(strings.ToLower(owner), "0x")
+}
+
+// NewLimiterFromConfig returns a Limiter enforcing the workflow quotas configured for the node.
+func NewLimiterFromConfig(perOwner config.WorkflowOwnerQuota, perWorkflow config.WorkflowQuota, clock clockwork.Clock) *Limiter {
+	l := NewLimiter(limitsFromConfig(perOwner), limitsFromConfig(perWorkflow), clock)
+	for _, o := range perOwner.Overrides() {
+		l.SetOwnerLimits(o.Owner(), limitsFromConfig(o))
+	}
+	return l
+}
+
+func limitsFromConfig(cfg config.WorkflowQuota) Limits {
+	return Limits{
+		ExecutionsPerMinute:  cfg.ExecutionsPerMinute(),
+		ConcurrentExecutions: cfg.ConcurrentExecutions(),
+		ComputeTimePerMinute: cfg.ComputeTimePerMinute(),
+		FetchCallsPerMinute:  cfg.FetchCallsPerMinute(),
+		Execution: ExecutionLimits{
+			Fuel:       cfg.FuelPerExecution(),
+			MemoryMBs:  cfg.MemoryMBsPerExecution(),
+			FetchCalls: cfg.FetchCallsPerExecution(),
+		},
+	}
+}
@@ -0,0 +1,150 @@
+package quotas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	owner      = "0xABCDEF"
+	workflowA  = "workflow-a"
+	workflowB  = "workflow-b"
+	otherOwner = "0x123456"
+)
+
+func requireQuotaExceeded(t *testing.T, err error, quota Quota, scope Scope) {
+	t.Helper()
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	var qerr *QuotaExceededError
+	require.ErrorAs(t, err, &qerr)
+	assert.Equal(t, quota, qerr.Quota)
+	assert.Equal(t, scope, qerr.Scope)
+}
+
+func TestLimiter_NoLimits(t *testing.T) {
+	l := NewLimiter(Limits{}, Limits{}, clockwork.NewFakeClock())
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, l.StartExecution(owner, workflowA))
+		require.NoError(t, l.AllowFetch(owner, workflowA))
+		l.RecordComputeTime(owner, workflowA, time.Hour)
+		require.NoError(t, l.CheckComputeTime(owner, workflowA))
+	}
+}
+
+func TestLimiter_ExecutionRate(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	l := NewLimiter(Limits{ExecutionsPerMinute: 3}, Limits{ExecutionsPerMinute: 2}, clock)
+
+	require.NoError(t, l.StartExecution(owner, workflowA))
+	require.NoError(t, l.StartExecution(owner, workflowA))
+	requireQuotaExceeded(t, l.StartExecution(owner, workflowA), QuotaExecutionRate, ScopeWorkflow)
+
+	// the owner quota is shared by its workflows, and the owner address is case and prefix insensitive
+	require.NoError(t, l.StartExecution("abcdef", workflowB))
+	requireQuotaExceeded(t, l.StartExecution(owner, workflowB), QuotaExecutionRate, ScopeOwner)
+
+	// other owners aren't affected
+	require.NoError(t, l.StartExecution(otherOwner, "workflow-c"))
+
+	// finishing executions doesn't reset the rate, but a new window does
+	l.FinishExecution(owner, workflowA)
+	requireQuotaExceeded(t, l.StartExecution(owner, workflowB), QuotaExecutionRate, ScopeOwner)
+	clock.Advance(time.Minute)
+	require.NoError(t, l.StartExecution(owner, workflowA))
+}
+
+func TestLimiter_ConcurrentExecutions(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	l := NewLimiter(Limits{}, Limits{ConcurrentExecutions: 2}, clock)
+
+	l.ResumeExecution(owner, workflowA)
+	require.NoError(t, l.StartExecution(owner, workflowA))
+	requireQuotaExceeded(t, l.StartExecution(owner, workflowA), QuotaConcurrentExecutions, ScopeWorkflow)
+
+	// running executions are carried over to the next window
+	clock.Advance(time.Minute)
+	requireQuotaExceeded(t, l.StartExecution(owner, workflowA), QuotaConcurrentExecutions, ScopeWorkflow)
+
+	l.FinishExecution(owner, workflowA)
+	require.NoError(t, l.StartExecution(owner, workflowA))
+}
+
+func TestLimiter_ComputeTime(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	l := NewLimiter(Limits{ComputeTimePerMinute: 10 * time.Second}, Limits{}, clock)
+
+	require.NoError(t, l.CheckComputeTime(owner, workflowA))
+	l.RecordComputeTime(owner, workflowA, 6*time.Second)
+	require.NoError(t, l.CheckComputeTime(owner, workflowB))
+	l.RecordComputeTime(owner, workflowB, 4*time.Second)
+	requireQuotaExceeded(t, l.CheckComputeTime(owner, workflowA), QuotaComputeTime, ScopeOwner)
+
+	clock.Advance(time.Minute)
+	require.NoError(t, l.CheckComputeTime(owner, workflowA))
+}
+
+func TestLimiter_FetchCalls(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	l := NewLimiter(Limits{}, Limits{FetchCallsPerMinute: 1}, clock)
+
+	require.NoError(t, l.AllowFetch(owner, workflowA))
+	err := l.AllowFetch(owner, workflowA)
+	requireQuotaExceeded(t, err, QuotaFetchCalls, ScopeWorkflow)
+	assert.EqualError(t, err, "quota exceeded: fetch_calls_per_minute limit of 1 reached for workflow workflow-a")
+	require.NoError(t, l.AllowFetch(owner, workflowB))
+
+	clock.Advance(time.Minute)
+	require.NoError(t, l.AllowFetch(owner, workflowA))
+}
+
+func TestLimiter_ExecutionLimits(t *testing.T) {
+	l := NewLimiter(Limits{}, Limits{}, clockwork.NewFakeClock())
+	assert.Equal(t, ExecutionLimits{}, l.ExecutionLimits(owner, workflowA))
+
+	l = NewLimiter(
+		Limits{Execution: ExecutionLimits{Fuel: 1000, MemoryMBs: 256}},
+		Limits{Execution: ExecutionLimits{Fuel: 2000, MemoryMBs: 128, FetchCalls: 3}},
+		clockwork.NewFakeClock(),
+	)
+	assert.Equal(t, ExecutionLimits{Fuel: 1000, MemoryMBs: 128, FetchCalls: 3}, l.ExecutionLimits(owner, workflowA))
+}
+
+func TestLimiter_OwnerOverrides(t *testing.T) {
+	l := NewLimiter(Limits{ExecutionsPerMinute: 1, Execution: ExecutionLimits{Fuel: 1000}}, Limits{}, clockwork.NewFakeClock())
+	l.SetOwnerLimits("abcdef", Limits{ExecutionsPerMinute: 2, Execution: ExecutionLimits{Fuel: 5000}})
+
+	require.NoError(t, l.StartExecution(owner, workflowA))
+	require.NoError(t, l.StartExecution(owner, workflowA))
+	requireQuotaExceeded(t, l.StartExecution(owner, workflowA), QuotaExecutionRate, ScopeOwner)
+	assert.Equal(t, ExecutionLimits{Fuel: 5000}, l.ExecutionLimits(owner, workflowA))
+
+	require.NoError(t, l.StartExecution(otherOwner, workflowB))
+	requireQuotaExceeded(t, l.StartExecution(otherOwner, workflowB), QuotaExecutionRate, ScopeOwner)
+	assert.Equal(t, ExecutionLimits{Fuel: 1000}, l.ExecutionLimits(otherOwner, workflowB))
+}
+
+func TestLimiter_Prune(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	l := NewLimiter(Limits{ExecutionsPerMinute: 2}, Limits{ConcurrentExecutions: 1}, clock)
+
+	require.NoError(t, l.StartExecution(owner, workflowA))
+	require.NoError(t, l.StartExecution(otherOwner, workflowB))
+	l.FinishExecution(otherOwner, workflowB)
+
+	// deleting a workflow forgets its usage, but not the usage of its owner
+	l.RemoveWorkflow(workflowA)
+	require.NotContains(t, l.workflows, workflowA)
+	require.NoError(t, l.StartExecution(owner, workflowA))
+	requireQuotaExceeded(t, l.StartExecution(owner, "workflow-c"), QuotaExecutionRate, ScopeOwner)
+
+	// idle owners and workflows are forgotten once their window elapsed, running ones are kept
+	clock.Advance(time.Minute)
+	require.NoError(t, l.StartExecution(owner, "workflow-c"))
+	assert.Len(t, l.owners, 1)
+	assert.Len(t, l.workflows, 2)
+	requireQuotaExceeded(t, l.StartExecution(owner, workflowA), QuotaConcurrentExecutions, ScopeWorkflow)
+}
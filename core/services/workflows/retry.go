@@ -2,10 +2,28 @@ package workflows
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/platform"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/quotas"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+)
+
+const (
+	// reservedFieldNameStepRetries is the number of times a failed step is retried before the execution errors.
+	reservedFieldNameStepRetries = "cre_step_retries"
+	// reservedFieldNameStepRetryBackoff is the delay, in milliseconds, before the first retry of a failed step. It
+	// doubles on every retry, up to maxStepRetryBackoff.
+	reservedFieldNameStepRetryBackoff = "cre_step_retry_backoff_ms"
+	maxStepRetries                    = 10
+	defaultStepRetryBackoff           = time.Second
+	maxStepRetryBackoff               = time.Minute
 )
 
 // retryable is a helper function that retries a function until it succeeds.
@@ -51,3 +69,151 @@ func retryable(ctx context.Context, lggr logger.Logger, retryMs int, maxRetries
 		retries++
 	}
 }
+
+// initRetries validates the retry configuration of the step, so that an invalid workflow is rejected when it's parsed.
+func (s *step) initRetries() error {
+	raw, ok := s.Config[reservedFieldNameStepRetries]
+	if !ok {
+		return nil
+	}
+	retries, err := configInt(raw)
+	if err != nil || retries < 0 || retries > maxStepRetries {
+		return fmt.Errorf("step %s: %s must be an integer between 0 and %d", s.Ref, reservedFieldNameStepRetries, maxStepRetries)
+	}
+	s.retries = int(retries)
+
+	s.retryBackoff = defaultStepRetryBackoff
+	if raw, ok := s.Config[reservedFieldNameStepRetryBackoff]; ok {
+		backoffMs, err := configInt(raw)
+		if err != nil || backoffMs <= 0 {
+			return fmt.Errorf("step %s: %s must be a positive integer", s.Ref, reservedFieldNameStepRetryBackoff)
+		}
+		s.retryBackoff = min(time.Duration(backoffMs)*time.Millisecond, maxStepRetryBackoff)
+	}
+	return nil
+}
+
+func configInt(raw any) (int64, error) {
+	v, err := values.Wrap(raw)
+	if err != nil {
+		return 0, err
+	}
+	var i int64
+	err = v.UnwrapTo(&i)
+	return i, err
+}
+
+// isRetryableStepError returns whether a step that failed with err may succeed if it's executed again. Steps that were
+// skipped, terminated the execution early or were rejected by a quota aren't retried.
+func isRetryableStepError(err error) bool {
+	return !errors.Is(err, errConditionNotMet) &&
+		!errors.Is(err, capabilities.ErrStopExecution) &&
+		!errors.Is(err, quotas.ErrQuotaExceeded)
+}
+
+// executeAttempt executes a single attempt of a step, counting its duration towards the compute time quota of the
+// workflow if it's a compute step.
+func (e *Engine) executeAttempt(ctx context.Context, lggr logger.Logger, msg stepRequest, isCompute bool) (*values.Map, values.Value, error) {
+	if !isCompute {
+		return e.executeStep(ctx, lggr, msg)
+	}
+	if err := e.limiter.CheckComputeTime(e.workflow.owner, e.workflow.id); err != nil {
+		e.incrementQuotaRejectionCounter(ctx, err)
+		return nil, nil, err
+	}
+	attemptStartTime := time.Now()
+	defer func() {
+		e.limiter.RecordComputeTime(e.workflow.owner, e.workflow.id, time.Since(attemptStartTime))
+	}()
+	return e.executeStep(ctx, lggr, msg)
+}
+
+// retryStep re-enqueues a step that failed with err after its exponential backoff, as configured in its spec, so that
+// the worker is free to execute other steps in the meantime. It returns false if the step isn't retried, because it
+// succeeded, its error isn't retryable or its retries are exhausted.
+//
+// The step is recorded as retrying, with the number of times it was executed, so that the retry is resumed if the node
+// restarts before the step is executed again.
+func (e *Engine) retryStep(ctx context.Context, lggr logger.Logger, s *step, msg stepRequest, usage *stepUsage, err error) bool {
+	retry := msg.attempt + 1
+	if s == nil || err == nil || retry > s.retries || !isRetryableStepError(err) {
+		return false
+	}
+
+	backoff := retryBackoff(s, retry)
+	lggr.Warnf("step failed, retrying in %s (retry %d of %d): %v", backoff, retry, s.retries, err)
+	e.metrics.with(platform.KeyCapabilityID, s.ID).incrementStepRetryCounter(ctx)
+
+	stepState := &store.WorkflowExecutionStep{
+		ExecutionID: msg.state.ExecutionID,
+		Ref:         msg.stepRef,
+		Status:      store.StatusRetrying,
+		Outputs:     store.StepOutput{Err: err},
+		Attempts:    retry,
+	}
+	if _, uerr := e.executionStates.UpsertStep(ctx, stepState); uerr != nil {
+		// the retry is still scheduled, it just won't be resumed after a restart
+		lggr.Errorw("failed to record the retry of the step", "err", uerr)
+	}
+
+	msg.attempt = retry
+	msg.usage = usage
+	e.scheduleRetry(ctx, msg, backoff)
+	return true
+}
+
+// retryBackoff returns the delay before the retry of a step, which doubles on every retry.
+func retryBackoff(s *step, retry int) time.Duration {
+	return min(s.retryBackoff<<(retry-1), maxStepRetryBackoff)
+}
+
+// scheduleRetry re-enqueues the step request after backoff.
+func (e *Engine) scheduleRetry(ctx context.Context, msg stepRequest, backoff time.Duration) {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.clock.After(backoff):
+		}
+		select {
+		case <-ctx.Done():
+		case e.pendingStepRequests <- msg:
+		}
+	}()
+}
+
+// resumeRetry resumes the retry of a step that was pending when the node stopped. The step is executed again after its
+// backoff, unless its retries are exhausted, or it was removed from the workflow, in which case it errors, so that the
+// execution is dead-lettered.
+func (e *Engine) resumeRetry(ctx context.Context, execution store.WorkflowExecution, stepState *store.WorkflowExecutionStep) {
+	l := e.logger.With(platform.KeyWorkflowExecutionID, execution.ExecutionID, platform.KeyStepRef, stepState.Ref)
+
+	s, err := e.workflow.Vertex(stepState.Ref)
+	if err == nil && stepState.Attempts >= 1 && stepState.Attempts <= s.retries {
+		backoff := retryBackoff(s, stepState.Attempts)
+		l.Infof("resuming the retry of the step in %s (retry %d of %d)", backoff, stepState.Attempts, s.retries)
+		e.scheduleRetry(ctx, stepRequest{
+			stepRef: stepState.Ref,
+			state:   execution,
+			attempt: stepState.Attempts,
+		}, backoff)
+		return
+	}
+
+	l.Errorw("failed to resume the retry of the step", "attempts", stepState.Attempts, "err", err)
+	errored := *stepState
+	errored.Status = store.StatusErrored
+	errored.Attempts = max(stepState.Attempts, 1)
+	if errored.Outputs.Err == nil {
+		errored.Outputs.Err = errors.New("failed to resume the retry of the step")
+	}
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		if err := e.stepUpdatesChMap.send(ctx, execution.ExecutionID, errored); err != nil {
+			l.Errorf("failed to issue step state update; error %v", err)
+		}
+	}()
+}
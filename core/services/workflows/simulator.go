@@ -0,0 +1,338 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jonboulle/clockwork"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk"
+
+	coreCap "github.com/smartcontractkit/chainlink/v2/core/capabilities"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/compute"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/fakes"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/webapi"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/connector"
+	ghcapabilities "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/capabilities"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
+	"github.com/smartcontractkit/chainlink/v2/core/services/registrysyncer"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+)
+
+const defaultSimulationTimeout = time.Minute
+
+// SimulatedTriggerEvent is an event sent by the mock of a trigger of a simulated workflow.
+type SimulatedTriggerEvent struct {
+	// TriggerID is the ID of the trigger capability, e.g. `streams-trigger@1.0.0`.
+	TriggerID string `json:"trigger"`
+	// Outputs are the outputs of the event.
+	Outputs map[string]any `json:"outputs"`
+}
+
+// SimulatorConfig configures a simulation of a workflow.
+type SimulatorConfig struct {
+	Lggr logger.Logger
+	// Spec is the workflow to simulate, either YAML or WASM.
+	Spec job.WorkflowSpec
+	// Events are sent by the mocks of the triggers of the workflow, one at a time, each one starting an execution.
+	Events []SimulatedTriggerEvent
+	// Outputs are the outputs of the mocks of action and consensus capabilities, by capability ID. Steps of
+	// capabilities without outputs output their inputs.
+	Outputs map[string]map[string]any
+	// Secrets are the secrets available to the workflow.
+	Secrets map[string]string
+	// Timeout is the maximum duration of each execution, defaults to 1 minute.
+	Timeout time.Duration
+}
+
+// Simulate runs a workflow in-process against mock capabilities, and returns its executions, one per event, in the
+// order of the events.
+//
+// The mocks of the triggers send the events, the mocks of actions and consensus capabilities return the configured
+// outputs, and the mocks of targets record their requests without side effects. Custom compute steps are run by
+// the real compute capability, without access to the network.
+func Simulate(ctx context.Context, cfg SimulatorConfig) ([]store.WorkflowExecution, error) {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultSimulationTimeout
+	}
+	lggr := cfg.Lggr.Named("WorkflowSimulator")
+
+	sdkSpec, err := cfg.Spec.SDKSpec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow sdk spec: %w", err)
+	}
+	binary, err := cfg.Spec.RawSpec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow spec binary: %w", err)
+	}
+	config, err := cfg.Spec.GetConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow spec config: %w", err)
+	}
+
+	registry := coreCap.NewRegistry(lggr)
+	registry.SetLocalRegistry(simulatorLocalRegistry{})
+
+	triggerSinks, closeCapabilities, err := addSimulatedCapabilities(ctx, lggr, registry, sdkSpec, cfg.Outputs)
+	defer closeCapabilities()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*values.Map, len(cfg.Events))
+	for i, event := range cfg.Events {
+		if _, ok := triggerSinks[event.TriggerID]; !ok {
+			return nil, fmt.Errorf("event %d: workflow has no trigger %s", i, event.TriggerID)
+		}
+		events[i], err = values.NewMap(event.Outputs)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: invalid outputs: %w", i, err)
+		}
+	}
+
+	initialized := make(chan bool, 1)
+	finished := make(chan string, 1)
+	engine, err := NewEngine(ctx, Config{
+		Workflow:             sdkSpec,
+		WorkflowID:           cfg.Spec.WorkflowID,
+		WorkflowOwner:        cfg.Spec.WorkflowOwner,
+		WorkflowName:         cfg.Spec.WorkflowName,
+		Lggr:                 lggr,
+		Registry:             registry,
+		MaxExecutionDuration: cfg.Timeout,
+		Store:                store.NewInMemoryStore(clockwork.NewRealClock()),
+		Config:               config,
+		Binary:               binary,
+		SecretsFetcher:       simulatorSecretsFetcher(cfg.Secrets),
+		maxRetries:           1,
+		afterInit:            func(success bool) { initialized <- success },
+		onExecutionFinished:  func(executionID string) { finished <- executionID },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow engine: %w", err)
+	}
+	if err = engine.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start workflow engine: %w", err)
+	}
+	defer engine.Close()
+
+	select {
+	case success := <-initialized:
+		if !success {
+			return nil, errors.New("failed to initialize the workflow, see the logs for details")
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var executions []store.WorkflowExecution
+	for i, event := range events {
+		triggerSinks[cfg.Events[i].TriggerID].SendOutput(event)
+
+		var executionID string
+		select {
+		case executionID = <-finished:
+		case <-time.After(cfg.Timeout):
+			return executions, fmt.Errorf("event %d: execution did not finish within %s", i, cfg.Timeout)
+		case <-ctx.Done():
+			return executions, ctx.Err()
+		}
+
+		execution, err := engine.executionStates.Get(ctx, executionID)
+		if err != nil {
+			return executions, fmt.Errorf("event %d: %w", i, err)
+		}
+		executions = append(executions, execution)
+	}
+	return executions, nil
+}
+
+// addSimulatedCapabilities adds mocks of the capabilities of the workflow to the registry, and returns the mocks of
+// its triggers by ID, and a function that closes the capabilities that were started.
+func addSimulatedCapabilities(ctx context.Context, lggr logger.Logger, registry *coreCap.Registry, spec sdk.WorkflowSpec, outputs map[string]map[string]any) (map[string]*fakes.TriggerSink, func(), error) {
+	var closers []func() error
+	closeAll := func() {
+		for _, c := range closers {
+			if err := c(); err != nil {
+				lggr.Errorw("Failed to close simulated capability", "err", err)
+			}
+		}
+	}
+
+	triggerSinks := map[string]*fakes.TriggerSink{}
+	for _, t := range spec.Triggers {
+		if _, ok := triggerSinks[t.ID]; ok {
+			continue
+		}
+		name, version, _ := strings.Cut(t.ID, "@")
+		sink := fakes.NewTriggerSink(name, version)
+		if err := sink.Start(ctx); err != nil {
+			return nil, closeAll, err
+		}
+		closers = append(closers, sink.Close)
+		if err := registry.Add(ctx, sink.CreateNewTrigger()); err != nil {
+			return nil, closeAll, fmt.Errorf("failed to add trigger %s: %w", t.ID, err)
+		}
+		triggerSinks[t.ID] = sink
+	}
+
+	added := map[string]bool{}
+	for _, t := range spec.Targets {
+		if added[t.ID] {
+			continue
+		}
+		name, version, _ := strings.Cut(t.ID, "@")
+		if err := registry.Add(ctx, fakes.NewTargetSink(name, version).CreateNewTarget()); err != nil {
+			return nil, closeAll, fmt.Errorf("failed to add target %s: %w", t.ID, err)
+		}
+		added[t.ID] = true
+	}
+
+	steps := []struct {
+		defs           []sdk.StepDefinition
+		capabilityType capabilities.CapabilityType
+	}{
+		{spec.Actions, capabilities.CapabilityTypeAction},
+		{spec.Consensus, capabilities.CapabilityTypeConsensus},
+	}
+	for _, s := range steps {
+		for _, def := range s.defs {
+			if added[def.ID] || def.ID == ConditionStepID || def.ID == JoinStepID {
+				continue
+			}
+			added[def.ID] = true
+
+			if def.ID == compute.CapabilityIDCompute {
+				c, err := newSimulatedCompute(lggr, registry)
+				if err != nil {
+					return nil, closeAll, err
+				}
+				if err = c.Start(ctx); err != nil {
+					return nil, closeAll, fmt.Errorf("failed to start compute capability: %w", err)
+				}
+				closers = append(closers, c.Close)
+				continue
+			}
+
+			var out *values.Map
+			if o, ok := outputs[def.ID]; ok {
+				var err error
+				if out, err = values.NewMap(o); err != nil {
+					return nil, closeAll, fmt.Errorf("invalid outputs of %s: %w", def.ID, err)
+				}
+			}
+			mock := &simulatedCapability{
+				CapabilityInfo: capabilities.MustNewCapabilityInfo(def.ID, s.capabilityType, "simulated capability "+def.ID),
+				outputs:        out,
+			}
+			if err := registry.Add(ctx, mock); err != nil {
+				return nil, closeAll, fmt.Errorf("failed to add capability %s: %w", def.ID, err)
+			}
+		}
+	}
+	return triggerSinks, closeAll, nil
+}
+
+// newSimulatedCompute returns a compute capability, which adds itself to the registry when started. Fetch requests
+// fail, since there is no gateway to send them to.
+func newSimulatedCompute(lggr logger.Logger, registry *coreCap.Registry) (*compute.Compute, error) {
+	cfg := compute.Config{
+		ServiceConfig: webapi.ServiceConfig{
+			RateLimiter: common.RateLimiterConfig{
+				GlobalRPS:      100.0,
+				GlobalBurst:    100,
+				PerSenderRPS:   100.0,
+				PerSenderBurst: 100,
+			},
+		},
+	}
+	handler, err := webapi.NewOutgoingConnectorHandler(offlineGatewayConnector{}, cfg.ServiceConfig, ghcapabilities.MethodComputeAction, lggr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compute handler: %w", err)
+	}
+	c, err := compute.NewAction(cfg, lggr, registry, handler, func() string { return uuid.New().String() })
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compute capability: %w", err)
+	}
+	return c, nil
+}
+
+// simulatedCapability is a mock of an action or consensus capability, which outputs its configured outputs, or its
+// inputs if it has none.
+type simulatedCapability struct {
+	capabilities.CapabilityInfo
+	outputs *values.Map
+}
+
+var _ capabilities.ExecutableCapability = (*simulatedCapability)(nil)
+
+func (c *simulatedCapability) Execute(ctx context.Context, req capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error) {
+	if c.outputs != nil {
+		return capabilities.CapabilityResponse{Value: c.outputs.CopyMap()}, nil
+	}
+	return capabilities.CapabilityResponse{Value: req.Inputs.CopyMap()}, nil
+}
+
+func (c *simulatedCapability) RegisterToWorkflow(ctx context.Context, req capabilities.RegisterToWorkflowRequest) error {
+	return nil
+}
+
+func (c *simulatedCapability) UnregisterFromWorkflow(ctx context.Context, req capabilities.UnregisterFromWorkflowRequest) error {
+	return nil
+}
+
+// simulatorLocalRegistry describes a local node in a DON of its own, without capability configuration.
+type simulatorLocalRegistry struct{}
+
+func (simulatorLocalRegistry) LocalNode(ctx context.Context) (capabilities.Node, error) {
+	peerID := p2ptypes.PeerID{}
+	return capabilities.Node{
+		PeerID: &peerID,
+		WorkflowDON: capabilities.DON{
+			ID:      1,
+			Members: []p2ptypes.PeerID{peerID},
+			F:       0,
+		},
+	}, nil
+}
+
+func (simulatorLocalRegistry) ConfigForCapability(ctx context.Context, capabilityID string, donID uint32) (registrysyncer.CapabilityConfiguration, error) {
+	return registrysyncer.CapabilityConfiguration{}, nil
+}
+
+type simulatorSecretsFetcher map[string]string
+
+func (s simulatorSecretsFetcher) SecretsFor(ctx context.Context, workflowOwner, workflowName, workflowID string) (map[string]string, error) {
+	return s, nil
+}
+
+// offlineGatewayConnector is a gateway connector without gateways.
+type offlineGatewayConnector struct {
+	connector.GatewayConnector
+}
+
+func (offlineGatewayConnector) DonID() string {
+	return ""
+}
+
+func (offlineGatewayConnector) GatewayIDs() []string {
+	return nil
+}
+
+func (offlineGatewayConnector) SignAndSendToAnyGateway(ctx context.Context, msg *api.MessageBody) (string, error) {
+	return "", errors.New("no gateway nodes available")
+}
+
+func (offlineGatewayConnector) AddHandler(methods []string, handler connector.GatewayConnectorHandler) error {
+	return nil
+}
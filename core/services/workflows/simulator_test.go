@@ -0,0 +1,95 @@
+package workflows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+)
+
+const simulatedWorkflow = `
+triggers:
+  - id: "streams-trigger@1.0.0"
+    config:
+      feedIds:
+        - "0x1111111111111111111100000000000000000000000000000000000000000000"
+
+actions:
+  - id: "condition@1.0.0"
+    ref: "is_high"
+    config:
+      expression: "price > 100"
+    inputs:
+      price: "$(trigger.outputs.price)"
+
+consensus:
+  - id: "offchain_reporting@1.0.0"
+    ref: "evm_median"
+    inputs:
+      observations:
+        - "$(is_high.outputs)"
+    config:
+      aggregation_method: "data_feeds"
+
+targets:
+  - id: "write_ethereum-testnet-sepolia@1.0.0"
+    inputs:
+      report: "$(evm_median.outputs.report)"
+    config:
+      address: "0x3F3554832c636721F1fD1822Ccca0354576741Ef"
+`
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+
+	executions, err := Simulate(testutils.Context(t), SimulatorConfig{
+		Lggr: logger.TestLogger(t),
+		Spec: job.WorkflowSpec{Workflow: simulatedWorkflow, SpecType: job.YamlSpec},
+		Events: []SimulatedTriggerEvent{
+			{TriggerID: "streams-trigger@1.0.0", Outputs: map[string]any{"price": 150}},
+			{TriggerID: "streams-trigger@1.0.0", Outputs: map[string]any{"price": 50}},
+		},
+		Outputs: map[string]map[string]any{
+			"offchain_reporting@1.0.0": {"report": map[string]any{"price": 150}},
+		},
+		Timeout: 10 * time.Second,
+	})
+	require.NoError(t, err)
+	require.Len(t, executions, 2)
+
+	// the first event passes the condition, so the report is written
+	assert.Equal(t, store.StatusCompleted, executions[0].Status)
+	require.Contains(t, executions[0].Steps, "write_ethereum-testnet-sepolia@1.0.0")
+	target := executions[0].Steps["write_ethereum-testnet-sepolia@1.0.0"]
+	assert.Equal(t, store.StatusCompleted, target.Status)
+	inputs, err := values.Unwrap(target.Inputs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"report": map[string]any{"price": int64(150)}}, inputs)
+
+	// the second one doesn't
+	assert.Equal(t, store.StatusCompleted, executions[1].Status)
+	assert.Equal(t, store.StatusSkipped, executions[1].Steps["is_high"].Status)
+	assert.NotContains(t, executions[1].Steps, "evm_median")
+	assert.NotContains(t, executions[1].Steps, "write_ethereum-testnet-sepolia@1.0.0")
+}
+
+func TestSimulate_UnknownTrigger(t *testing.T) {
+	t.Parallel()
+
+	_, err := Simulate(testutils.Context(t), SimulatorConfig{
+		Lggr: logger.TestLogger(t),
+		Spec: job.WorkflowSpec{Workflow: simulatedWorkflow, SpecType: job.YamlSpec},
+		Events: []SimulatedTriggerEvent{
+			{TriggerID: "cron-trigger@1.0.0", Outputs: map[string]any{}},
+		},
+	})
+	require.ErrorContains(t, err, "event 0: workflow has no trigger cron-trigger@1.0.0")
+}
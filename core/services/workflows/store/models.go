@@ -15,6 +15,12 @@ const (
 	StatusTimeout            = "timeout"
 	StatusCompleted          = "completed"
 	StatusCompletedEarlyExit = "completed_early_exit"
+	// StatusRateLimited is only used for executions, when they are rejected by the quotas of the workflow or its owner.
+	StatusRateLimited = "rate_limited"
+	// StatusSkipped is only used for steps, when the condition gating them isn't met.
+	StatusSkipped = "skipped"
+	// StatusRetrying is only used for steps, when they failed and are waiting for their next attempt.
+	StatusRetrying = "retrying"
 )
 
 var ValidStatuses = map[string]bool{
@@ -23,11 +29,16 @@ var ValidStatuses = map[string]bool{
 	StatusTimeout:            true,
 	StatusCompleted:          true,
 	StatusCompletedEarlyExit: true,
+	StatusRateLimited:        true,
+	StatusSkipped:            true,
+	StatusRetrying:           true,
 }
 
 type StepOutput struct {
 	Err   error
 	Value values.Value
+	// Usage is the resources used by the step, as reported by its capability, if any.
+	Usage *values.Map
 }
 
 type WorkflowExecutionStep struct {
@@ -37,6 +48,9 @@ type WorkflowExecutionStep struct {
 
 	Inputs  *values.Map
 	Outputs StepOutput
+	// Attempts is the number of times the step was executed, so that its retries resume where they left off when the
+	// engine restarts. It is 0 for the steps recorded before attempts were tracked.
+	Attempts int
 
 	UpdatedAt *time.Time
 }
@@ -66,3 +80,20 @@ func (w WorkflowExecution) ResultForStep(s string) (*exec.Result, bool) {
 }
 
 var _ exec.Results = WorkflowExecution{}
+
+// DeadLetter records an execution that errored, because one of its steps still failed after exhausting its retries, or
+// timed out. The execution can be re-driven, i.e. resumed from its failed steps, once the underlying capability recovers.
+type DeadLetter struct {
+	ExecutionID string
+	WorkflowID  string
+	// StepRef is the step that failed or timed out.
+	StepRef string
+	// Error is the error returned by the last attempt of the step.
+	Error string
+	// Attempts is the number of times the step was executed.
+	Attempts  int
+	CreatedAt *time.Time
+	// RedriveRequestedAt is set when an operator requests a re-drive of the execution, until the engine running the
+	// workflow picks it up.
+	RedriveRequestedAt *time.Time
+}
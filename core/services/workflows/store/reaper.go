@@ -0,0 +1,231 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/beholder"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	reapReasonAge       = "age"
+	reapReasonFailedAge = "failed_age"
+	reapReasonCount     = "count"
+	reapReasonStale     = "stale"
+)
+
+// Reaper periodically deletes finished workflow executions, and their steps, that are past their retention:
+//   - completed executions older than ReaperThreshold,
+//   - errored and timed out executions older than FailedReaperThreshold,
+//   - the oldest finished executions of each workflow above MaxExecutionsPerWorkflow,
+//   - unfinished executions not updated for StaleExecutionThreshold, which are left over by a crash or a bug.
+//
+// The thresholds and the maximum number of executions can be overridden per workflow.
+type Reaper struct {
+	services.StateMachine
+	lggr   logger.Logger
+	ds     sqlutil.DataSource
+	cfg    config.CapabilitiesWorkflowExecutions
+	clock  clockwork.Clock
+	stopCh services.StopChan
+	wg     sync.WaitGroup
+
+	prunedCounter metric.Int64Counter
+}
+
+var _ services.Service = (*Reaper)(nil)
+
+func NewReaper(ds sqlutil.DataSource, cfg config.CapabilitiesWorkflowExecutions, lggr logger.Logger, clock clockwork.Clock) *Reaper {
+	return &Reaper{
+		lggr:   lggr.Named("WorkflowExecutionReaper"),
+		ds:     ds,
+		cfg:    cfg,
+		clock:  clock,
+		stopCh: make(services.StopChan),
+	}
+}
+
+func (r *Reaper) Start(context.Context) error {
+	return r.StartOnce("WorkflowExecutionReaper", func() (err error) {
+		r.prunedCounter, err = beholder.GetMeter().Int64Counter("platform_workflow_executions_pruned")
+		if err != nil {
+			return fmt.Errorf("failed to register pruned workflow executions counter: %w", err)
+		}
+		if r.cfg.ReaperInterval() == 0 {
+			r.lggr.Info("Workflow execution reaper is disabled: ReaperInterval=0")
+			return nil
+		}
+		r.wg.Add(1)
+		go r.run()
+		return nil
+	})
+}
+
+func (r *Reaper) Close() error {
+	return r.StopOnce("WorkflowExecutionReaper", func() error {
+		close(r.stopCh)
+		r.wg.Wait()
+		return nil
+	})
+}
+
+func (r *Reaper) HealthReport() map[string]error {
+	return map[string]error{r.Name(): r.Healthy()}
+}
+
+func (r *Reaper) Name() string {
+	return r.lggr.Name()
+}
+
+func (r *Reaper) run() {
+	defer r.wg.Done()
+	ticker := services.NewTicker(r.cfg.ReaperInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			ctx, cancel := r.stopCh.CtxWithTimeout(r.cfg.ReaperInterval())
+			if _, err := r.ReapExecutions(ctx); err != nil {
+				r.lggr.Errorw("Failed to prune workflow_executions", "err", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// ReapExecutions deletes the workflow executions that are past their retention, in batches of ReaperBatchSize.
+// It returns the number of deleted executions.
+// Caller is expected to set timeout on calling context.
+func (r *Reaper) ReapExecutions(ctx context.Context) (int64, error) {
+	now := r.clock.Now()
+	overrides := r.cfg.Overrides()
+	workflowIDs := make([]string, len(overrides))
+	finishedBefore := make([]time.Time, len(overrides))
+	failedBefore := make([]time.Time, len(overrides))
+	maxExecutions := make([]int64, len(overrides))
+	for i, o := range overrides {
+		workflowIDs[i] = o.WorkflowID()
+		finishedBefore[i] = now.Add(-o.ReaperThreshold())
+		failedBefore[i] = now.Add(-o.FailedReaperThreshold())
+		maxExecutions[i] = int64(o.MaxExecutionsPerWorkflow())
+	}
+	var total int64
+
+	n, err := r.reapByAge(ctx, reapReasonAge, []string{StatusCompleted, StatusCompletedEarlyExit, StatusRateLimited}, now.Add(-r.cfg.ReaperThreshold()), workflowIDs, finishedBefore)
+	total += n
+	if err != nil {
+		return total, err
+	}
+
+	n, err = r.reapByAge(ctx, reapReasonFailedAge, []string{StatusErrored, StatusTimeout}, now.Add(-r.cfg.FailedReaperThreshold()), workflowIDs, failedBefore)
+	total += n
+	if err != nil {
+		return total, err
+	}
+
+	if defaultMax := int64(r.cfg.MaxExecutionsPerWorkflow()); defaultMax > 0 || slices.ContainsFunc(maxExecutions, func(m int64) bool { return m > 0 }) {
+		n, err = r.deleteInBatches(ctx, reapReasonCount, `WITH ranked AS (
+	SELECT e.id,
+		row_number() OVER (PARTITION BY e.workflow_id ORDER BY e.created_at DESC, e.id) AS rn,
+		COALESCE(o.max_executions, $1) AS max_executions
+	FROM workflow_executions e
+	LEFT JOIN unnest($2::text[], $3::bigint[]) AS o(workflow_id, max_executions) ON o.workflow_id = e.workflow_id
+	WHERE e.finished_at IS NOT NULL
+)
+DELETE FROM workflow_executions
+WHERE id IN (
+	SELECT id FROM ranked WHERE max_executions > 0 AND rn > max_executions LIMIT $4
+)`, defaultMax, pq.Array(workflowIDs), pq.Array(maxExecutions))
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+
+	if staleThreshold := r.cfg.StaleExecutionThreshold(); staleThreshold > 0 {
+		n, err = r.deleteInBatches(ctx, reapReasonStale, `DELETE FROM workflow_executions
+WHERE id IN (
+	SELECT id FROM workflow_executions
+	WHERE finished_at IS NULL AND COALESCE(updated_at, created_at) < $1
+	ORDER BY COALESCE(updated_at, created_at) ASC
+	LIMIT $2
+)`, now.Add(-staleThreshold))
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// reapByAge deletes the executions with one of the given statuses that finished before the threshold of their workflow,
+// which is the one in before for the workflowIDs, and defaultBefore for the others.
+func (r *Reaper) reapByAge(ctx context.Context, reason string, statuses []string, defaultBefore time.Time, workflowIDs []string, before []time.Time) (int64, error) {
+	// bounds the scan of the finished_at index
+	latestBefore := defaultBefore
+	for _, b := range before {
+		if b.After(latestBefore) {
+			latestBefore = b
+		}
+	}
+	timestamps := make([]string, len(before))
+	for i, b := range before {
+		timestamps[i] = b.Format(time.RFC3339Nano)
+	}
+	return r.deleteInBatches(ctx, reason, `DELETE FROM workflow_executions
+WHERE id IN (
+	SELECT e.id FROM workflow_executions e
+	LEFT JOIN unnest($4::text[], $5::timestamptz[]) AS o(workflow_id, finished_before) ON o.workflow_id = e.workflow_id
+	WHERE e.status::text = ANY($1) AND e.finished_at < $2 AND e.finished_at < COALESCE(o.finished_before, $3)
+	ORDER BY e.finished_at ASC
+	LIMIT $6
+)`, pq.Array(statuses), latestBefore, defaultBefore, pq.Array(workflowIDs), pq.Array(timestamps))
+}
+
+// deleteInBatches runs the delete query, whose last parameter is the batch size, until it deletes less than a full
+// batch.
+func (r *Reaper) deleteInBatches(ctx context.Context, reason string, query string, args ...any) (int64, error) {
+	start := time.Now()
+	batchSize := r.cfg.ReaperBatchSize()
+	args = append(args, batchSize)
+
+	var total int64
+	for {
+		res, err := r.ds.ExecContext(ctx, query, args...)
+		if err != nil {
+			return total, fmt.Errorf("failed to delete workflow_executions by %s: %w", reason, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("failed to get number of deleted workflow_executions: %w", err)
+		}
+		total += n
+		if n > 0 && r.prunedCounter != nil {
+			r.prunedCounter.Add(ctx, n, metric.WithAttributes(attribute.String("reason", reason)))
+		}
+		if n < int64(batchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		r.lggr.Debugw("Pruned workflow_executions", "reason", reason, "nPruned", total, "batchSize", batchSize, "duration", time.Since(start))
+	}
+	return total, nil
+}
@@ -0,0 +1,225 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+type testReaperConfig struct {
+	threshold       time.Duration
+	failedThreshold time.Duration
+	maxExecutions   uint32
+	batchSize       uint32
+	staleThreshold  time.Duration
+	overrides       []config.WorkflowExecutionsOverride
+}
+
+func (c testReaperConfig) ReaperInterval() time.Duration                  { return time.Minute }
+func (c testReaperConfig) ReaperThreshold() time.Duration                 { return c.threshold }
+func (c testReaperConfig) FailedReaperThreshold() time.Duration           { return c.failedThreshold }
+func (c testReaperConfig) MaxExecutionsPerWorkflow() uint32               { return c.maxExecutions }
+func (c testReaperConfig) ReaperBatchSize() uint32                        { return c.batchSize }
+func (c testReaperConfig) StaleExecutionThreshold() time.Duration         { return c.staleThreshold }
+func (c testReaperConfig) Overrides() []config.WorkflowExecutionsOverride { return c.overrides }
+
+type testReaperOverride struct {
+	workflowID      string
+	threshold       time.Duration
+	failedThreshold time.Duration
+	maxExecutions   uint32
+}
+
+func (o testReaperOverride) WorkflowID() string                   { return o.workflowID }
+func (o testReaperOverride) ReaperThreshold() time.Duration       { return o.threshold }
+func (o testReaperOverride) FailedReaperThreshold() time.Duration { return o.failedThreshold }
+func (o testReaperOverride) MaxExecutionsPerWorkflow() uint32     { return o.maxExecutions }
+
+func Test_Reaper_ReapExecutions(t *testing.T) {
+	ctx := tests.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	clock := clockwork.NewFakeClock()
+	store := &DBStore{db: db, lggr: logger.TestLogger(t), clock: clock}
+
+	wid := randomID()
+	createWorkflow(t, store, wid)
+	// addExecution adds an execution with the given status, which finishes now unless it is still started.
+	addExecution := func(status string) string {
+		id := randomID()
+		_, err := store.Add(ctx, &WorkflowExecution{
+			ExecutionID: id,
+			WorkflowID:  wid,
+			Status:      StatusStarted,
+			Steps: map[string]*WorkflowExecutionStep{
+				"trigger": {ExecutionID: id, Ref: "trigger", Status: StatusCompleted},
+			},
+		})
+		require.NoError(t, err)
+		if status != StatusStarted {
+			require.NoError(t, store.UpdateStatus(ctx, id, status))
+		}
+		clock.Advance(time.Second)
+		return id
+	}
+
+	running := addExecution(StatusStarted)
+	completed := addExecution(StatusCompleted)
+	earlyExit := addExecution(StatusCompletedEarlyExit)
+	rateLimited := addExecution(StatusRateLimited)
+	errored := addExecution(StatusErrored)
+	timedOut := addExecution(StatusTimeout)
+	clock.Advance(2 * time.Hour)
+	recent := addExecution(StatusCompleted)
+
+	reaper := NewReaper(db, testReaperConfig{threshold: time.Hour, failedThreshold: 24 * time.Hour, batchSize: 1}, logger.TestLogger(t), clock)
+
+	n, err := reaper.ReapExecutions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	for _, id := range []string{completed, earlyExit, rateLimited} {
+		_, err = store.Get(ctx, id)
+		require.ErrorIs(t, err, ErrExecutionNotFound)
+	}
+	for _, id := range []string{running, errored, timedOut, recent} {
+		_, err = store.Get(ctx, id)
+		require.NoError(t, err)
+	}
+
+	// failed executions are kept longer
+	clock.Advance(24 * time.Hour)
+	n, err = reaper.ReapExecutions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	for _, id := range []string{errored, timedOut, recent} {
+		_, err = store.Get(ctx, id)
+		require.ErrorIs(t, err, ErrExecutionNotFound)
+	}
+
+	// executions that are still running are never deleted
+	_, err = store.Get(ctx, running)
+	require.NoError(t, err)
+}
+
+func Test_Reaper_MaxExecutionsPerWorkflow(t *testing.T) {
+	ctx := tests.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	clock := clockwork.NewFakeClock()
+	store := &DBStore{db: db, lggr: logger.TestLogger(t), clock: clock}
+
+	var wids []string
+	ids := map[string][]string{}
+	for range 2 {
+		wid := randomID()
+		createWorkflow(t, store, wid)
+		wids = append(wids, wid)
+		for range 4 {
+			id := randomID()
+			_, err := store.Add(ctx, &WorkflowExecution{ExecutionID: id, WorkflowID: wid, Status: StatusStarted})
+			require.NoError(t, err)
+			require.NoError(t, store.UpdateStatus(ctx, id, StatusCompleted))
+			ids[wid] = append(ids[wid], id)
+			clock.Advance(time.Second)
+		}
+	}
+
+	reaper := NewReaper(db, testReaperConfig{threshold: time.Hour, failedThreshold: time.Hour, maxExecutions: 2, batchSize: 1}, logger.TestLogger(t), clock)
+	n, err := reaper.ReapExecutions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+
+	// only the 2 most recent executions of each workflow are kept
+	for _, wid := range wids {
+		executions, count, err := store.ListExecutions(ctx, wid, "", 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		require.Len(t, executions, 2)
+		assert.Equal(t, ids[wid][3], executions[0].ExecutionID)
+		assert.Equal(t, ids[wid][2], executions[1].ExecutionID)
+	}
+}
+
+func Test_Reaper_StaleExecutions(t *testing.T) {
+	ctx := tests.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	clock := clockwork.NewFakeClock()
+	store := &DBStore{db: db, lggr: logger.TestLogger(t), clock: clock}
+
+	wid := randomID()
+	createWorkflow(t, store, wid)
+	stale := randomID()
+	_, err := store.Add(ctx, &WorkflowExecution{ExecutionID: stale, WorkflowID: wid, Status: StatusStarted})
+	require.NoError(t, err)
+	clock.Advance(2 * time.Hour)
+	running := randomID()
+	_, err = store.Add(ctx, &WorkflowExecution{ExecutionID: running, WorkflowID: wid, Status: StatusStarted})
+	require.NoError(t, err)
+
+	reaper := NewReaper(db, testReaperConfig{threshold: time.Hour, failedThreshold: time.Hour, staleThreshold: time.Hour, batchSize: 10}, logger.TestLogger(t), clock)
+	n, err := reaper.ReapExecutions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	_, err = store.Get(ctx, stale)
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+	_, err = store.Get(ctx, running)
+	require.NoError(t, err)
+}
+
+func Test_Reaper_Overrides(t *testing.T) {
+	ctx := tests.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	clock := clockwork.NewFakeClock()
+	store := &DBStore{db: db, lggr: logger.TestLogger(t), clock: clock}
+
+	addExecutions := func(wid string, status string, count int) []string {
+		var ids []string
+		for range count {
+			id := randomID()
+			_, err := store.Add(ctx, &WorkflowExecution{ExecutionID: id, WorkflowID: wid, Status: StatusStarted})
+			require.NoError(t, err)
+			require.NoError(t, store.UpdateStatus(ctx, id, status))
+			ids = append(ids, id)
+			clock.Advance(time.Second)
+		}
+		return ids
+	}
+	wid, keptLongerWid := randomID(), randomID()
+	createWorkflow(t, store, wid)
+	createWorkflow(t, store, keptLongerWid)
+	completed := addExecutions(wid, StatusCompleted, 1)
+	keptLonger := addExecutions(keptLongerWid, StatusCompleted, 3)
+	errored := addExecutions(keptLongerWid, StatusErrored, 1)
+	clock.Advance(2 * time.Hour)
+
+	reaper := NewReaper(db, testReaperConfig{
+		threshold:       time.Hour,
+		failedThreshold: time.Hour,
+		maxExecutions:   10,
+		batchSize:       10,
+		overrides: []config.WorkflowExecutionsOverride{
+			testReaperOverride{workflowID: keptLongerWid, threshold: 24 * time.Hour, failedThreshold: time.Hour, maxExecutions: 2},
+		},
+	}, logger.TestLogger(t), clock)
+	n, err := reaper.ReapExecutions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	// the oldest completed execution of the overridden workflow is over its max executions, and the errored one over
+	// its failed threshold
+	for _, id := range []string{completed[0], keptLonger[0], errored[0]} {
+		_, err = store.Get(ctx, id)
+		require.ErrorIs(t, err, ErrExecutionNotFound)
+	}
+	for _, id := range keptLonger[1:] {
+		_, err = store.Get(ctx, id)
+		require.NoError(t, err)
+	}
+}
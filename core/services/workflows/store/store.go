@@ -10,6 +10,19 @@ type Store interface {
 	UpdateStatus(ctx context.Context, executionID string, status string) error
 	Get(ctx context.Context, executionID string) (WorkflowExecution, error)
 	GetUnfinished(ctx context.Context, workflowID string, offset, limit int) ([]WorkflowExecution, error)
+	// ListExecutions returns a page of the executions of workflowID, newest first, without their steps, and the
+	// total number of matching executions. An empty status matches all executions.
+	ListExecutions(ctx context.Context, workflowID string, status string, offset, limit int) ([]WorkflowExecution, int, error)
+	// AddDeadLetter records an errored execution whose step exhausted its retries, so that it can be re-driven.
+	AddDeadLetter(ctx context.Context, letter *DeadLetter) error
+	// ListDeadLetters returns a page of the dead letters of workflowID, newest first, and their total number.
+	ListDeadLetters(ctx context.Context, workflowID string, offset, limit int) ([]DeadLetter, int, error)
+	// RequestRedrive flags the dead letter of the execution to be re-driven by the engine running workflowID. It
+	// returns ErrDeadLetterNotFound if the workflow has no dead letter for the execution.
+	RequestRedrive(ctx context.Context, workflowID string, executionID string) error
+	// ClaimRedrives removes the dead letters of workflowID whose re-drive was requested, and restarts their
+	// executions: their errored and timed out steps are removed, so that they are executed again when resumed.
+	ClaimRedrives(ctx context.Context, workflowID string) ([]WorkflowExecution, error)
 }
 
 var _ Store = (*DBStore)(nil)
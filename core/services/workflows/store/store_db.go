@@ -4,13 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sync"
 	"time"
 
 	"google.golang.org/protobuf/proto"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/jonboulle/clockwork"
+	"github.com/lib/pq"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	"github.com/smartcontractkit/chainlink-common/pkg/values"
@@ -21,22 +21,19 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services"
 )
 
-const (
-	defaultPruneFrequencySec   = 20
-	defaultPruneTimeoutSec     = 60
-	defaultPruneRecordAgeHours = 3
-	defaultPruneBatchSize      = 3000
-)
+// ErrExecutionNotFound is returned by Get if there is no workflow execution with the given ID.
+var ErrExecutionNotFound = errors.New("could not find workflow execution")
+
+// ErrDeadLetterNotFound is returned by RequestRedrive if the workflow has no dead letter for the execution.
+var ErrDeadLetterNotFound = errors.New("could not find dead letter")
 
 // `DBStore` is a postgres-backed
 // data store that persists workflow progress.
 type DBStore struct {
 	commonservices.StateMachine
-	lggr              logger.Logger
-	db                sqlutil.DataSource
-	shutdownWaitGroup sync.WaitGroup
-	chStop            commonservices.StopChan
-	clock             clockwork.Clock
+	lggr  logger.Logger
+	db    sqlutil.DataSource
+	clock clockwork.Clock
 }
 
 var _ services.ServiceCtx = (*DBStore)(nil)
@@ -62,9 +59,23 @@ type workflowStepRow struct {
 	Inputs              []byte
 	OutputErr           *string    `db:"output_err"`
 	OutputValue         []byte     `db:"output_value"`
+	OutputUsage         []byte     `db:"output_usage"`
+	Attempts            int        `db:"attempts"`
 	UpdatedAt           *time.Time `db:"updated_at"`
 }
 
+// workflowDeadLetterRow describes a row
+// of the `workflow_dead_letters` table
+type workflowDeadLetterRow struct {
+	ExecutionID        string     `db:"execution_id"`
+	WorkflowID         string     `db:"workflow_id"`
+	StepRef            string     `db:"step_ref"`
+	Error              string     `db:"error"`
+	Attempts           int        `db:"attempts"`
+	CreatedAt          *time.Time `db:"created_at"`
+	RedriveRequestedAt *time.Time `db:"redrive_requested_at"`
+}
+
 // workflowExecutionWithStep is a struct that represents a row from the join of the workflow_executions and workflow_steps tables.
 type workflowExecutionWithStep struct {
 	// WorkflowExecutionStep fields
@@ -74,6 +85,8 @@ type workflowExecutionWithStep struct {
 	WSInputs              []byte     `db:"ws_inputs"`
 	WSOutputErr           *string    `db:"ws_output_err"`
 	WSOutputValue         []byte     `db:"ws_output_value"`
+	WSOutputUsage         []byte     `db:"ws_output_usage"`
+	WSAttempts            int        `db:"ws_attempts"`
 	WSUpdatedAt           *time.Time `db:"ws_updated_at"`
 
 	// WorkflowExecution fields
@@ -86,52 +99,11 @@ type workflowExecutionWithStep struct {
 }
 
 func (d *DBStore) Start(context.Context) error {
-	return d.StartOnce("DBStore", func() error {
-		d.shutdownWaitGroup.Add(1)
-		go d.pruneDBEntries()
-		return nil
-	})
+	return d.StartOnce("DBStore", func() error { return nil })
 }
 
 func (d *DBStore) Close() error {
-	return d.StopOnce("DBStore", func() error {
-		close(d.chStop)
-		d.shutdownWaitGroup.Wait()
-		return nil
-	})
-}
-
-func (d *DBStore) pruneDBEntries() {
-	defer d.shutdownWaitGroup.Done()
-	ticker := time.NewTicker(defaultPruneFrequencySec * time.Second)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-d.chStop:
-			return
-		case <-ticker.C:
-			ctx, cancel := d.chStop.CtxWithTimeout(defaultPruneTimeoutSec * time.Second)
-			nPruned := int64(0)
-			err := sqlutil.TransactDataSource(ctx, d.db, nil, func(tx sqlutil.DataSource) error {
-				stmt := fmt.Sprintf("DELETE FROM workflow_executions WHERE (id) IN (SELECT id FROM workflow_executions WHERE (created_at < now() - interval '%d hours') LIMIT %d);", defaultPruneRecordAgeHours, defaultPruneBatchSize)
-				res, err := tx.ExecContext(ctx, stmt)
-				if err != nil {
-					return err
-				}
-				nPruned, err = res.RowsAffected()
-				if err != nil {
-					d.lggr.Warnw("Failed to get number of pruned workflow_executions", "err", err)
-				}
-				return nil
-			})
-			if err != nil {
-				d.lggr.Errorw("Failed to prune workflow_executions", "err", err)
-			} else if nPruned > 0 {
-				d.lggr.Debugw("Pruned oldest workflow_executions", "nPruned", nPruned, "batchSize", defaultPruneBatchSize, "ageLimitHours", defaultPruneRecordAgeHours)
-			}
-			cancel()
-		}
-	}
+	return d.StopOnce("DBStore", func() error { return nil })
 }
 
 // `UpdateStatus` updates the status of the given workflow execution
@@ -178,6 +150,8 @@ func (d *DBStore) Get(ctx context.Context, executionID string) (WorkflowExecutio
 			workflow_steps.inputs AS ws_inputs,
 			workflow_steps.output_err AS ws_output_err,
 			workflow_steps.output_value AS ws_output_value,
+			workflow_steps.output_usage AS ws_output_usage,
+			workflow_steps.attempts AS ws_attempts,
 			workflow_steps.updated_at AS ws_updated_at
 	FROM workflow_executions JOIN workflow_steps
 	ON workflow_executions.id = workflow_steps.workflow_execution_id
@@ -194,7 +168,7 @@ func (d *DBStore) Get(ctx context.Context, executionID string) (WorkflowExecutio
 	}
 	state, ok := idToExecutionState[executionID]
 	if !ok {
-		return WorkflowExecution{}, fmt.Errorf("could not find workflow execution with id %s", executionID)
+		return WorkflowExecution{}, fmt.Errorf("%w with id %s", ErrExecutionNotFound, executionID)
 	}
 	return *state, nil
 }
@@ -223,6 +197,8 @@ func workflowExecutionsWithStepToWorkflowExecutions(wews []workflowExecutionWith
 			Ref:                 jr.WSRef,
 			OutputErr:           jr.WSOutputErr,
 			OutputValue:         jr.WSOutputValue,
+			OutputUsage:         jr.WSOutputUsage,
+			Attempts:            jr.WSAttempts,
 			Inputs:              jr.WSInputs,
 			Status:              jr.WSStatus,
 			UpdatedAt:           jr.WSUpdatedAt,
@@ -275,6 +251,20 @@ func stepToState(step workflowStepRow) (*WorkflowExecutionStep, error) {
 		}
 	}
 
+	var usage *values.Map
+	if len(step.OutputUsage) != 0 {
+		vmProto := &valuespb.Map{}
+		err := proto.Unmarshal(step.OutputUsage, vmProto)
+		if err != nil {
+			return nil, err
+		}
+
+		usage, err = values.FromMapValueProto(vmProto)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &WorkflowExecutionStep{
 		ExecutionID: step.WorkflowExecutionID,
 		Ref:         step.Ref,
@@ -283,7 +273,10 @@ func stepToState(step workflowStepRow) (*WorkflowExecutionStep, error) {
 		Outputs: StepOutput{
 			Err:   outputErr,
 			Value: outputs,
+			Usage: usage,
 		},
+		Attempts:  step.Attempts,
+		UpdatedAt: step.UpdatedAt,
 	}, nil
 }
 
@@ -303,6 +296,7 @@ func stateToStep(state *WorkflowExecutionStep) (workflowStepRow, error) {
 		Ref:                 state.Ref,
 		Status:              state.Status,
 		Inputs:              inpb,
+		Attempts:            state.Attempts,
 	}
 
 	if state.Outputs.Value != nil {
@@ -319,6 +313,15 @@ func stateToStep(state *WorkflowExecutionStep) (workflowStepRow, error) {
 		errs := state.Outputs.Err.Error()
 		wsr.OutputErr = &errs
 	}
+
+	if state.Outputs.Usage != nil {
+		ub, err := proto.Marshal(values.ProtoMap(state.Outputs.Usage))
+		if err != nil {
+			return workflowStepRow{}, err
+		}
+
+		wsr.OutputUsage = ub
+	}
 	return wsr, nil
 }
 
@@ -375,15 +378,15 @@ func (d *DBStore) Add(ctx context.Context, state *WorkflowExecution) (WorkflowEx
 }
 
 func (d *DBStore) upsertSteps(ctx context.Context, steps []workflowStepRow) error {
-	for _, s := range steps {
-		now := d.clock.Now()
-		s.UpdatedAt = &now
+	now := d.clock.Now()
+	for i := range steps {
+		steps[i].UpdatedAt = &now
 	}
 
 	sql := `
 	INSERT INTO
-	workflow_steps(workflow_execution_id, ref, status, inputs, output_err, output_value, updated_at)
-	VALUES (:workflow_execution_id, :ref, :status, :inputs, :output_err, :output_value, :updated_at)
+	workflow_steps(workflow_execution_id, ref, status, inputs, output_err, output_value, output_usage, attempts, updated_at)
+	VALUES (:workflow_execution_id, :ref, :status, :inputs, :output_err, :output_value, :output_usage, :attempts, :updated_at)
 	ON CONFLICT ON CONSTRAINT uniq_workflow_execution_id_ref
 	DO UPDATE SET
 		workflow_execution_id = EXCLUDED.workflow_execution_id,
@@ -392,6 +395,8 @@ func (d *DBStore) upsertSteps(ctx context.Context, steps []workflowStepRow) erro
 		inputs = EXCLUDED.inputs,
 		output_err = EXCLUDED.output_err,
 		output_value = EXCLUDED.output_value,
+		output_usage = EXCLUDED.output_usage,
+		attempts = EXCLUDED.attempts,
 		updated_at = EXCLUDED.updated_at;
 	`
 	stmt, args, err := sqlx.Named(sql, steps)
@@ -435,6 +440,8 @@ func (d *DBStore) GetUnfinished(ctx context.Context, workflowID string, offset,
 		workflow_steps.inputs AS ws_inputs,
 		workflow_steps.output_err AS ws_output_err,
 		workflow_steps.output_value AS ws_output_value,
+		workflow_steps.output_usage AS ws_output_usage,
+		workflow_steps.attempts AS ws_attempts,
 		workflow_steps.updated_at AS ws_updated_at,
 		workflow_executions.id AS we_id,
 		workflow_executions.workflow_id AS we_workflow_id,
@@ -470,8 +477,145 @@ func (d *DBStore) GetUnfinished(ctx context.Context, workflowID string, offset,
 	return states, nil
 }
 
+// ListExecutions returns a page of the executions of workflowID, newest first, without their steps.
+func (d *DBStore) ListExecutions(ctx context.Context, workflowID string, status string, offset, limit int) ([]WorkflowExecution, int, error) {
+	var count int
+	err := d.db.GetContext(ctx, &count, `SELECT count(*) FROM workflow_executions
+	WHERE workflow_id = $1 AND ($2 = '' OR status::text = $2)`, workflowID, status)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count workflow executions: %w", err)
+	}
+
+	var rows []workflowExecutionRow
+	err = d.db.SelectContext(ctx, &rows, `SELECT * FROM workflow_executions
+	WHERE workflow_id = $1 AND ($2 = '' OR status::text = $2)
+	ORDER BY created_at DESC, id
+	LIMIT $3
+	OFFSET $4`, workflowID, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list workflow executions: %w", err)
+	}
+
+	executions := make([]WorkflowExecution, len(rows))
+	for i, row := range rows {
+		executions[i] = WorkflowExecution{
+			ExecutionID: row.ID,
+			WorkflowID:  workflowID,
+			Status:      row.Status,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+			FinishedAt:  row.FinishedAt,
+		}
+	}
+	return executions, count, nil
+}
+
+// AddDeadLetter records an errored execution whose step exhausted its retries.
+func (d *DBStore) AddDeadLetter(ctx context.Context, letter *DeadLetter) error {
+	_, err := d.db.ExecContext(ctx, `INSERT INTO
+	workflow_dead_letters(execution_id, workflow_id, step_ref, error, attempts, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (execution_id) DO UPDATE SET
+		step_ref = EXCLUDED.step_ref,
+		error = EXCLUDED.error,
+		attempts = EXCLUDED.attempts,
+		created_at = EXCLUDED.created_at,
+		redrive_requested_at = NULL`,
+		letter.ExecutionID, letter.WorkflowID, letter.StepRef, letter.Error, letter.Attempts, d.clock.Now())
+	if err != nil {
+		return fmt.Errorf("failed to add dead letter for execution %s: %w", letter.ExecutionID, err)
+	}
+	return nil
+}
+
+// ListDeadLetters returns a page of the dead letters of workflowID, newest first.
+func (d *DBStore) ListDeadLetters(ctx context.Context, workflowID string, offset, limit int) ([]DeadLetter, int, error) {
+	var count int
+	err := d.db.GetContext(ctx, &count, `SELECT count(*) FROM workflow_dead_letters WHERE workflow_id = $1`, workflowID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	var rows []workflowDeadLetterRow
+	err = d.db.SelectContext(ctx, &rows, `SELECT * FROM workflow_dead_letters
+	WHERE workflow_id = $1
+	ORDER BY created_at DESC, execution_id
+	LIMIT $2
+	OFFSET $3`, workflowID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	letters := make([]DeadLetter, len(rows))
+	for i, row := range rows {
+		letters[i] = DeadLetter{
+			ExecutionID:        row.ExecutionID,
+			WorkflowID:         row.WorkflowID,
+			StepRef:            row.StepRef,
+			Error:              row.Error,
+			Attempts:           row.Attempts,
+			CreatedAt:          row.CreatedAt,
+			RedriveRequestedAt: row.RedriveRequestedAt,
+		}
+	}
+	return letters, count, nil
+}
+
+// RequestRedrive flags the dead letter of the execution to be re-driven.
+func (d *DBStore) RequestRedrive(ctx context.Context, workflowID string, executionID string) error {
+	res, err := d.db.ExecContext(ctx, `UPDATE workflow_dead_letters SET redrive_requested_at = $1
+	WHERE workflow_id = $2 AND execution_id = $3`, d.clock.Now(), workflowID, executionID)
+	if err != nil {
+		return fmt.Errorf("failed to request re-drive of execution %s: %w", executionID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w for execution %s", ErrDeadLetterNotFound, executionID)
+	}
+	return nil
+}
+
+// ClaimRedrives removes the dead letters of workflowID whose re-drive was requested, and restarts their executions.
+func (d *DBStore) ClaimRedrives(ctx context.Context, workflowID string) ([]WorkflowExecution, error) {
+	var executionIDs []string
+	err := d.transact(ctx, func(db *DBStore) error {
+		err := db.db.SelectContext(ctx, &executionIDs, `DELETE FROM workflow_dead_letters
+		WHERE workflow_id = $1 AND redrive_requested_at IS NOT NULL
+		RETURNING execution_id`, workflowID)
+		if err != nil || len(executionIDs) == 0 {
+			return err
+		}
+
+		_, err = db.db.ExecContext(ctx, `DELETE FROM workflow_steps
+		WHERE workflow_execution_id = ANY($1) AND status IN ($2, $3)`, pq.Array(executionIDs), StatusErrored, StatusTimeout)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.db.ExecContext(ctx, `UPDATE workflow_executions SET status = $1, updated_at = $2, finished_at = NULL
+		WHERE id = ANY($3)`, StatusStarted, d.clock.Now(), pq.Array(executionIDs))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim re-drives: %w", err)
+	}
+
+	executions := make([]WorkflowExecution, 0, len(executionIDs))
+	for _, id := range executionIDs {
+		execution, err := d.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		executions = append(executions, execution)
+	}
+	return executions, nil
+}
+
 func NewDBStore(ds sqlutil.DataSource, lggr logger.Logger, clock clockwork.Clock) *DBStore {
-	return &DBStore{db: ds, lggr: lggr.Named("WorkflowDBStore"), clock: clock, chStop: make(chan struct{})}
+	return &DBStore{db: ds, lggr: lggr.Named("WorkflowDBStore"), clock: clock}
 }
 
 func (d *DBStore) HealthReport() map[string]error {
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/assert"
@@ -36,6 +37,13 @@ func newTestDBStore(t *testing.T) *DBStore {
 	return &DBStore{db: db, lggr: logger.TestLogger(t), clock: clockwork.NewFakeClock()}
 }
 
+// clearStepTimestamps zeroes out the updated_at timestamps of the steps, which are set by the db store.
+func clearStepTimestamps(es WorkflowExecution) {
+	for _, step := range es.Steps {
+		step.UpdatedAt = nil
+	}
+}
+
 func Test_StoreDB(t *testing.T) {
 	store := newTestDBStore(t)
 
@@ -65,6 +73,7 @@ func Test_StoreDB(t *testing.T) {
 	// but is added by the db store.
 	gotEs.CreatedAt = nil
 	require.NoError(t, err)
+	clearStepTimestamps(gotEs)
 	assert.Equal(t, es, gotEs)
 }
 
@@ -156,7 +165,8 @@ func Test_StoreDB_UpdateStep(t *testing.T) {
 	_, err := store.Add(tests.Context(t), &es)
 	require.NoError(t, err)
 
-	stepOne.Status = StatusCompleted
+	stepOne.Status = StatusRetrying
+	stepOne.Attempts = 2
 	nm, err := values.NewMap(map[string]any{"hello": "world"})
 	require.NoError(t, err)
 
@@ -167,13 +177,18 @@ func Test_StoreDB_UpdateStep(t *testing.T) {
 	require.NoError(t, err)
 
 	gotStep := es.Steps[stepOne.Ref]
+	require.NotNil(t, gotStep.UpdatedAt)
+	gotStep.UpdatedAt = nil
 	assert.Equal(t, stepOne, gotStep)
 
-	stepTwo.Outputs = StepOutput{Value: nm}
+	usage, err := values.NewMap(map[string]any{"WallTimeMs": int64(12)})
+	require.NoError(t, err)
+	stepTwo.Outputs = StepOutput{Value: nm, Usage: usage}
 	es, err = store.UpsertStep(tests.Context(t), stepTwo)
 	require.NoError(t, err)
 
 	gotStep = es.Steps[stepTwo.Ref]
+	gotStep.UpdatedAt = nil
 	assert.Equal(t, stepTwo, gotStep)
 }
 
@@ -311,5 +326,69 @@ func Test_StoreDB_GetUnfinishedSteps(t *testing.T) {
 	assert.Len(t, states, 1)
 	// Zero out the completedAt timestamp
 	states[0].CreatedAt = nil
+	clearStepTimestamps(states[0])
 	assert.Equal(t, es, states[0])
 }
+
+func Test_StoreDB_ListExecutions(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	store := &DBStore{db: pgtest.NewSqlxDB(t), lggr: logger.TestLogger(t), clock: clock}
+	ctx := tests.Context(t)
+
+	wid := randomID()
+	createWorkflow(t, store, wid)
+	var ids []string
+	for _, status := range []string{StatusCompleted, StatusErrored, StatusCompleted} {
+		id := randomID()
+		ids = append(ids, id)
+		_, err := store.Add(ctx, &WorkflowExecution{
+			ExecutionID: id,
+			WorkflowID:  wid,
+			Status:      status,
+			Steps: map[string]*WorkflowExecutionStep{
+				"trigger": {ExecutionID: id, Ref: "trigger", Status: StatusCompleted},
+			},
+		})
+		require.NoError(t, err)
+		clock.Advance(time.Second)
+	}
+
+	// executions of other workflows are not listed
+	otherWID := randomID()
+	createWorkflow(t, store, otherWID)
+	_, err := store.Add(ctx, &WorkflowExecution{ExecutionID: randomID(), WorkflowID: otherWID, Status: StatusCompleted})
+	require.NoError(t, err)
+
+	executions, count, err := store.ListExecutions(ctx, wid, "", 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, executions, 2)
+	assert.Equal(t, ids[2], executions[0].ExecutionID)
+	assert.Equal(t, ids[1], executions[1].ExecutionID)
+	assert.Equal(t, wid, executions[0].WorkflowID)
+	assert.Empty(t, executions[0].Steps)
+
+	executions, count, err = store.ListExecutions(ctx, wid, "", 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, executions, 1)
+	assert.Equal(t, ids[0], executions[0].ExecutionID)
+
+	executions, count, err = store.ListExecutions(ctx, wid, StatusErrored, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, executions, 1)
+	assert.Equal(t, ids[1], executions[0].ExecutionID)
+
+	_, err = store.Get(ctx, randomID())
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+}
+
+func Test_StoreDB_DeadLetters(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	store := &DBStore{db: pgtest.NewSqlxDB(t), lggr: logger.TestLogger(t), clock: clock}
+
+	wid := randomID()
+	createWorkflow(t, store, wid)
+	testDeadLetters(t, clock, store, wid)
+}
@@ -0,0 +1,248 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// InMemoryStore is a Store that keeps workflow executions in memory, for running workflows without a database,
+// e.g. in the workflow simulator. Executions are lost when the process exits.
+type InMemoryStore struct {
+	mu         sync.RWMutex
+	clock      clockwork.Clock
+	executions map[string]*WorkflowExecution
+	// deadLetters are keyed by execution ID.
+	deadLetters map[string]*DeadLetter
+}
+
+var _ Store = (*InMemoryStore)(nil)
+
+func NewInMemoryStore(clock clockwork.Clock) *InMemoryStore {
+	return &InMemoryStore{clock: clock, executions: map[string]*WorkflowExecution{}, deadLetters: map[string]*DeadLetter{}}
+}
+
+// Add adds a new execution, and its steps, to the store.
+func (s *InMemoryStore) Add(ctx context.Context, state *WorkflowExecution) (WorkflowExecution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.executions[state.ExecutionID]; ok {
+		return WorkflowExecution{}, fmt.Errorf("could not insert workflow execution %s: already exists", state.ExecutionID)
+	}
+
+	now := s.clock.Now()
+	execution := &WorkflowExecution{
+		Steps:       map[string]*WorkflowExecutionStep{},
+		ExecutionID: state.ExecutionID,
+		WorkflowID:  state.WorkflowID,
+		Status:      state.Status,
+		CreatedAt:   &now,
+		UpdatedAt:   &now,
+	}
+	for ref, step := range state.Steps {
+		stepCopy := *step
+		stepCopy.UpdatedAt = &now
+		execution.Steps[ref] = &stepCopy
+	}
+	s.executions[state.ExecutionID] = execution
+	return copyExecution(execution), nil
+}
+
+// UpsertStep adds or replaces the step of an execution, and returns the updated execution.
+func (s *InMemoryStore) UpsertStep(ctx context.Context, step *WorkflowExecutionStep) (WorkflowExecution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	execution, ok := s.executions[step.ExecutionID]
+	if !ok {
+		return WorkflowExecution{}, fmt.Errorf("%w with id %s", ErrExecutionNotFound, step.ExecutionID)
+	}
+
+	now := s.clock.Now()
+	stepCopy := *step
+	stepCopy.UpdatedAt = &now
+	execution.Steps[step.Ref] = &stepCopy
+	return copyExecution(execution), nil
+}
+
+// UpdateStatus updates the status of the execution, and sets its finish time unless it is still started.
+func (s *InMemoryStore) UpdateStatus(ctx context.Context, executionID string, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	execution, ok := s.executions[executionID]
+	if !ok {
+		return fmt.Errorf("%w with id %s", ErrExecutionNotFound, executionID)
+	}
+
+	now := s.clock.Now()
+	execution.Status = status
+	execution.UpdatedAt = &now
+	if status != StatusStarted {
+		execution.FinishedAt = &now
+	}
+	return nil
+}
+
+// Get returns the execution with its steps.
+func (s *InMemoryStore) Get(ctx context.Context, executionID string) (WorkflowExecution, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	execution, ok := s.executions[executionID]
+	if !ok {
+		return WorkflowExecution{}, fmt.Errorf("%w with id %s", ErrExecutionNotFound, executionID)
+	}
+	return copyExecution(execution), nil
+}
+
+// GetUnfinished returns a page of the executions of workflowID that are still started, newest first.
+func (s *InMemoryStore) GetUnfinished(ctx context.Context, workflowID string, offset, limit int) ([]WorkflowExecution, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var executions []WorkflowExecution
+	for _, execution := range s.sorted(workflowID, StatusStarted) {
+		executions = append(executions, copyExecution(execution))
+	}
+	return page(executions, offset, limit), nil
+}
+
+// ListExecutions returns a page of the executions of workflowID, newest first, without their steps.
+func (s *InMemoryStore) ListExecutions(ctx context.Context, workflowID string, status string, offset, limit int) ([]WorkflowExecution, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var executions []WorkflowExecution
+	for _, execution := range s.sorted(workflowID, status) {
+		e := copyExecution(execution)
+		e.Steps = nil
+		executions = append(executions, e)
+	}
+	return page(executions, offset, limit), len(executions), nil
+}
+
+// AddDeadLetter records an errored execution whose step exhausted its retries.
+func (s *InMemoryStore) AddDeadLetter(ctx context.Context, letter *DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.executions[letter.ExecutionID]; !ok {
+		return fmt.Errorf("%w with id %s", ErrExecutionNotFound, letter.ExecutionID)
+	}
+
+	now := s.clock.Now()
+	letterCopy := *letter
+	letterCopy.CreatedAt = &now
+	letterCopy.RedriveRequestedAt = nil
+	s.deadLetters[letter.ExecutionID] = &letterCopy
+	return nil
+}
+
+// ListDeadLetters returns a page of the dead letters of workflowID, newest first.
+func (s *InMemoryStore) ListDeadLetters(ctx context.Context, workflowID string, offset, limit int) ([]DeadLetter, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var letters []DeadLetter
+	for _, letter := range s.deadLetters {
+		if letter.WorkflowID == workflowID {
+			letters = append(letters, *letter)
+		}
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		a, b := letters[i], letters[j]
+		if !a.CreatedAt.Equal(*b.CreatedAt) {
+			return a.CreatedAt.After(*b.CreatedAt)
+		}
+		return a.ExecutionID < b.ExecutionID
+	})
+
+	count := len(letters)
+	if offset >= count {
+		return nil, count, nil
+	}
+	letters = letters[offset:]
+	if limit < len(letters) {
+		letters = letters[:limit]
+	}
+	return letters, count, nil
+}
+
+// RequestRedrive flags the dead letter of the execution to be re-driven.
+func (s *InMemoryStore) RequestRedrive(ctx context.Context, workflowID string, executionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	letter, ok := s.deadLetters[executionID]
+	if !ok || letter.WorkflowID != workflowID {
+		return fmt.Errorf("%w for execution %s", ErrDeadLetterNotFound, executionID)
+	}
+	now := s.clock.Now()
+	letter.RedriveRequestedAt = &now
+	return nil
+}
+
+// ClaimRedrives removes the dead letters of workflowID whose re-drive was requested, and restarts their executions.
+func (s *InMemoryStore) ClaimRedrives(ctx context.Context, workflowID string) ([]WorkflowExecution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	var executions []WorkflowExecution
+	for id, letter := range s.deadLetters {
+		if letter.WorkflowID != workflowID || letter.RedriveRequestedAt == nil {
+			continue
+		}
+		delete(s.deadLetters, id)
+
+		execution, ok := s.executions[id]
+		if !ok {
+			continue
+		}
+		for ref, step := range execution.Steps {
+			if step.Status == StatusErrored || step.Status == StatusTimeout {
+				delete(execution.Steps, ref)
+			}
+		}
+		execution.Status = StatusStarted
+		execution.UpdatedAt = &now
+		execution.FinishedAt = nil
+		executions = append(executions, copyExecution(execution))
+	}
+	return executions, nil
+}
+
+// sorted returns the executions of workflowID with the given status, or any status if it's empty, newest first.
+func (s *InMemoryStore) sorted(workflowID string, status string) []*WorkflowExecution {
+	var executions []*WorkflowExecution
+	for _, execution := range s.executions {
+		if execution.WorkflowID == workflowID && (status == "" || execution.Status == status) {
+			executions = append(executions, execution)
+		}
+	}
+	sort.Slice(executions, func(i, j int) bool {
+		a, b := executions[i], executions[j]
+		if !a.CreatedAt.Equal(*b.CreatedAt) {
+			return a.CreatedAt.After(*b.CreatedAt)
+		}
+		return a.ExecutionID < b.ExecutionID
+	})
+	return executions
+}
+
+func page(executions []WorkflowExecution, offset, limit int) []WorkflowExecution {
+	if offset >= len(executions) {
+		return nil
+	}
+	executions = executions[offset:]
+	if limit < len(executions) {
+		executions = executions[:limit]
+	}
+	return executions
+}
+
+// copyExecution returns a copy of the execution that can't be used to modify the stored steps.
+func copyExecution(execution *WorkflowExecution) WorkflowExecution {
+	c := *execution
+	c.Steps = make(map[string]*WorkflowExecutionStep, len(execution.Steps))
+	for ref, step := range execution.Steps {
+		stepCopy := *step
+		c.Steps[ref] = &stepCopy
+	}
+	return c
+}
@@ -0,0 +1,160 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+)
+
+func Test_InMemoryStore(t *testing.T) {
+	ctx := tests.Context(t)
+	clock := clockwork.NewFakeClock()
+	store := NewInMemoryStore(clock)
+
+	wid := randomID()
+	ids := make([]string, 3)
+	for i := range ids {
+		ids[i] = randomID()
+		_, err := store.Add(ctx, &WorkflowExecution{
+			ExecutionID: ids[i],
+			WorkflowID:  wid,
+			Status:      StatusStarted,
+			Steps: map[string]*WorkflowExecutionStep{
+				"trigger": {ExecutionID: ids[i], Ref: "trigger", Status: StatusCompleted},
+			},
+		})
+		require.NoError(t, err)
+		clock.Advance(time.Second)
+	}
+
+	_, err := store.Add(ctx, &WorkflowExecution{ExecutionID: ids[0], WorkflowID: wid, Status: StatusStarted})
+	require.Error(t, err)
+
+	outputs, err := values.NewMap(map[string]any{"price": 100})
+	require.NoError(t, err)
+	state, err := store.UpsertStep(ctx, &WorkflowExecutionStep{
+		ExecutionID: ids[0],
+		Ref:         "consensus",
+		Status:      StatusCompleted,
+		Outputs:     StepOutput{Value: outputs},
+	})
+	require.NoError(t, err)
+	require.Len(t, state.Steps, 2)
+	assert.Equal(t, outputs, state.Steps["consensus"].Outputs.Value)
+	assert.Equal(t, clock.Now(), *state.Steps["consensus"].UpdatedAt)
+
+	// the returned executions don't share their steps with the store
+	delete(state.Steps, "consensus")
+	state, err = store.Get(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Len(t, state.Steps, 2)
+
+	require.NoError(t, store.UpdateStatus(ctx, ids[0], StatusCompleted))
+	state, err = store.Get(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, state.Status)
+	require.NotNil(t, state.FinishedAt)
+
+	unfinished, err := store.GetUnfinished(ctx, wid, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, unfinished, 2)
+	assert.Equal(t, ids[2], unfinished[0].ExecutionID)
+	assert.Equal(t, ids[1], unfinished[1].ExecutionID)
+
+	executions, count, err := store.ListExecutions(ctx, wid, "", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, executions, 1)
+	assert.Equal(t, ids[1], executions[0].ExecutionID)
+	assert.Nil(t, executions[0].Steps)
+
+	executions, count, err = store.ListExecutions(ctx, wid, StatusCompleted, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, executions, 1)
+	assert.Equal(t, ids[0], executions[0].ExecutionID)
+
+	_, err = store.Get(ctx, randomID())
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+	_, err = store.UpsertStep(ctx, &WorkflowExecutionStep{ExecutionID: randomID(), Ref: "consensus"})
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+}
+
+func Test_InMemoryStore_DeadLetters(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	testDeadLetters(t, clock, NewInMemoryStore(clock), randomID())
+}
+
+// testDeadLetters checks that the dead letters of errored executions can be listed and re-driven.
+func testDeadLetters(t *testing.T, clock clockwork.FakeClock, store Store, wid string) {
+	ctx := tests.Context(t)
+
+	ids := make([]string, 2)
+	for i := range ids {
+		ids[i] = randomID()
+		_, err := store.Add(ctx, &WorkflowExecution{
+			ExecutionID: ids[i],
+			WorkflowID:  wid,
+			Status:      StatusStarted,
+			Steps: map[string]*WorkflowExecutionStep{
+				"trigger": {ExecutionID: ids[i], Ref: "trigger", Status: StatusCompleted},
+				"write":   {ExecutionID: ids[i], Ref: "write", Status: StatusErrored, Outputs: StepOutput{Err: errors.New("rpc unavailable")}},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateStatus(ctx, ids[i], StatusErrored))
+		require.NoError(t, store.AddDeadLetter(ctx, &DeadLetter{
+			ExecutionID: ids[i],
+			WorkflowID:  wid,
+			StepRef:     "write",
+			Error:       "rpc unavailable",
+			Attempts:    4,
+		}))
+		clock.Advance(time.Second)
+	}
+
+	letters, count, err := store.ListDeadLetters(ctx, wid, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	require.Len(t, letters, 1)
+	assert.Equal(t, ids[1], letters[0].ExecutionID)
+	assert.Equal(t, "write", letters[0].StepRef)
+	assert.Equal(t, "rpc unavailable", letters[0].Error)
+	assert.Equal(t, 4, letters[0].Attempts)
+	assert.Nil(t, letters[0].RedriveRequestedAt)
+
+	// nothing is re-driven until it's requested
+	executions, err := store.ClaimRedrives(ctx, wid)
+	require.NoError(t, err)
+	assert.Empty(t, executions)
+
+	require.ErrorIs(t, store.RequestRedrive(ctx, randomID(), ids[0]), ErrDeadLetterNotFound)
+	require.ErrorIs(t, store.RequestRedrive(ctx, wid, randomID()), ErrDeadLetterNotFound)
+	require.NoError(t, store.RequestRedrive(ctx, wid, ids[0]))
+
+	executions, err = store.ClaimRedrives(ctx, wid)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.Equal(t, ids[0], executions[0].ExecutionID)
+	assert.Equal(t, StatusStarted, executions[0].Status)
+	assert.Nil(t, executions[0].FinishedAt)
+	assert.Contains(t, executions[0].Steps, "trigger")
+	assert.NotContains(t, executions[0].Steps, "write")
+
+	// re-driven executions are claimed once, and their dead letter is removed
+	executions, err = store.ClaimRedrives(ctx, wid)
+	require.NoError(t, err)
+	assert.Empty(t, executions)
+	letters, count, err = store.ListDeadLetters(ctx, wid, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, letters, 1)
+	assert.Equal(t, ids[1], letters[0].ExecutionID)
+}
@@ -0,0 +1,213 @@
+package syncer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	artifactCacheBlobsDir     = "blobs"
+	artifactCacheWorkflowsDir = "workflows"
+	// defaultArtifactCacheMaxBytes bounds the total size of the cached artifacts.
+	defaultArtifactCacheMaxBytes = 512 << 20
+)
+
+// ArtifactCache is a persistent on-disk cache of the binaries and configs of workflows. Artifacts are stored once per
+// content, keyed by their sha256 hash, so that the versions and workflows sharing a binary share its entry, and each
+// workflow ID references the hashes of its binary and config.
+//
+// Since the workflow ID recorded in the workflow registry events is the hash of the binary and config of the
+// workflow, cached artifacts never need to be fetched again, e.g. when a workflow is rolled back to a previous
+// version. Cached artifacts must still be verified against their workflow ID, since the disk may be tampered with.
+//
+// The least recently used artifacts are evicted when the total size of the cache grows beyond its maximum size.
+type ArtifactCache struct {
+	lggr     logger.Logger
+	dir      string
+	maxBytes int64
+	clock    clockwork.Clock
+}
+
+func NewArtifactCache(lggr logger.Logger, dir string, maxBytes int64, clock clockwork.Clock) *ArtifactCache {
+	if maxBytes <= 0 {
+		maxBytes = defaultArtifactCacheMaxBytes
+	}
+	return &ArtifactCache{lggr: lggr.Named("ArtifactCache"), dir: dir, maxBytes: maxBytes, clock: clock}
+}
+
+// Get returns the cached binary and config of the workflow, and whether they were found.
+func (c *ArtifactCache) Get(workflowID [32]byte) (binary []byte, config []byte, ok bool, err error) {
+	ref, err := os.ReadFile(c.workflowPath(workflowID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, false, nil
+	}
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to read cached workflow: %w", err)
+	}
+	if len(ref) != 2*sha256.Size {
+		return nil, nil, false, c.Delete(workflowID)
+	}
+	binaryHash, configHash := [sha256.Size]byte(ref[:sha256.Size]), [sha256.Size]byte(ref[sha256.Size:])
+
+	binary, ok, err = c.getBlob(binaryHash)
+	if err == nil && ok {
+		config, ok, err = c.getBlob(configHash)
+	}
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if !ok {
+		// an artifact of the workflow was evicted, so that it must be cached again
+		return nil, nil, false, c.Delete(workflowID)
+	}
+	return binary, config, true, nil
+}
+
+// getBlob returns the cached artifact with the hash, and whether it was found. Artifacts not matching their hash are
+// removed.
+func (c *ArtifactCache) getBlob(hash [sha256.Size]byte) ([]byte, bool, error) {
+	path := c.blobPath(hash)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cached artifact: %w", err)
+	}
+	if sum := sha256.Sum256(content); !bytes.Equal(sum[:], hash[:]) {
+		c.lggr.Warnw("removing corrupted cached artifact", "hash", hex.EncodeToString(hash[:]))
+		return nil, false, os.Remove(path)
+	}
+
+	// the modification time of the artifact tracks when it was last used, for evictions
+	now := c.clock.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		c.lggr.Warnw("failed to update the last use of a cached artifact", "hash", hex.EncodeToString(hash[:]), "err", err)
+	}
+	return content, true, nil
+}
+
+// Put caches the binary and config of the workflow, unless the workflow is already cached. Files are written to a
+// temporary file first, and then renamed, so that partially written files are never read.
+func (c *ArtifactCache) Put(workflowID [32]byte, binary []byte, config []byte) error {
+	if _, err := os.Stat(c.workflowPath(workflowID)); err == nil {
+		return nil
+	}
+	// artifacts larger than the cache would be evicted right away
+	if int64(len(binary))+int64(len(config)) > c.maxBytes {
+		return nil
+	}
+	for _, dir := range []string{artifactCacheBlobsDir, artifactCacheWorkflowsDir} {
+		if err := os.MkdirAll(filepath.Join(c.dir, dir), 0o700); err != nil {
+			return fmt.Errorf("failed to create artifact cache directory: %w", err)
+		}
+	}
+
+	binaryHash, configHash := sha256.Sum256(binary), sha256.Sum256(config)
+	for _, blob := range []struct {
+		hash    [sha256.Size]byte
+		content []byte
+	}{{binaryHash, binary}, {configHash, config}} {
+		if err := c.writeFile(c.blobPath(blob.hash), blob.content); err != nil {
+			return fmt.Errorf("failed to write cached artifact: %w", err)
+		}
+	}
+	if err := c.writeFile(c.workflowPath(workflowID), append(binaryHash[:], configHash[:]...)); err != nil {
+		return fmt.Errorf("failed to write cached workflow: %w", err)
+	}
+	return c.evict()
+}
+
+// writeFile atomically writes the content to path, and marks it as used now.
+func (c *ArtifactCache) writeFile(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	now := c.clock.Now()
+	if err := os.Chtimes(tmp.Name(), now, now); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Delete removes the workflow from the cache, if cached. Its artifacts are kept, since other workflows may share them,
+// until they are evicted.
+func (c *ArtifactCache) Delete(workflowID [32]byte) error {
+	if err := os.Remove(c.workflowPath(workflowID)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (c *ArtifactCache) workflowPath(workflowID [32]byte) string {
+	return filepath.Join(c.dir, artifactCacheWorkflowsDir, hex.EncodeToString(workflowID[:]))
+}
+
+func (c *ArtifactCache) blobPath(hash [sha256.Size]byte) string {
+	return filepath.Join(c.dir, artifactCacheBlobsDir, hex.EncodeToString(hash[:]))
+}
+
+// evict removes the least recently used artifacts until the cache fits in its maximum size. The workflows referencing
+// evicted artifacts are misses from then on, and are removed when read.
+func (c *ArtifactCache) evict() error {
+	entries, err := os.ReadDir(filepath.Join(c.dir, artifactCacheBlobsDir))
+	if err != nil {
+		return fmt.Errorf("failed to list cached artifacts: %w", err)
+	}
+
+	type blob struct {
+		name     string
+		size     int64
+		lastUsed time.Time
+	}
+	var blobs []blob
+	var total int64
+	for _, e := range entries {
+		if e.IsDir() || len(e.Name()) != hex.EncodedLen(sha256.Size) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		blobs = append(blobs, blob{name: e.Name(), size: info.Size(), lastUsed: info.ModTime()})
+		total += info.Size()
+	}
+	if total <= c.maxBytes {
+		return nil
+	}
+
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].lastUsed.Before(blobs[j].lastUsed) })
+	for _, b := range blobs {
+		if total <= c.maxBytes {
+			break
+		}
+		c.lggr.Debugw("evicting cached workflow artifact", "hash", b.name, "size", b.size)
+		if err := os.Remove(filepath.Join(c.dir, artifactCacheBlobsDir, b.name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to evict cached artifact: %w", err)
+		}
+		total -= b.size
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package syncer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func Test_ArtifactCache(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	clock := clockwork.NewFakeClockAt(time.Now())
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	// fits two workflows with a binary of 7 bytes and an empty config
+	cache := NewArtifactCache(lggr, dir, 14, clock)
+
+	wid1, wid2, wid3 := [32]byte{1}, [32]byte{2}, [32]byte{3}
+
+	_, _, ok, err := cache.Get(wid1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, cache.Put(wid1, []byte("binary1"), nil))
+	binary, config, ok, err := cache.Get(wid1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("binary1"), binary)
+	assert.Empty(t, config)
+
+	t.Run("entries are content addressed", func(t *testing.T) {
+		require.NoError(t, cache.Put(wid1, []byte("other"), nil))
+		binary, _, ok, err := cache.Get(wid1)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []byte("binary1"), binary)
+	})
+
+	t.Run("workflows share the artifacts with the same hash", func(t *testing.T) {
+		widShared := [32]byte{4}
+		require.NoError(t, cache.Put(widShared, []byte("binary1"), nil))
+		binary, _, ok, err := cache.Get(widShared)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []byte("binary1"), binary)
+		require.NoError(t, cache.Delete(widShared))
+
+		blobs, err := os.ReadDir(filepath.Join(dir, artifactCacheBlobsDir))
+		require.NoError(t, err)
+		assert.Len(t, blobs, 2)
+	})
+
+	t.Run("least recently used artifacts are evicted beyond the maximum size", func(t *testing.T) {
+		clock.Advance(time.Minute)
+		require.NoError(t, cache.Put(wid2, []byte("binary2"), nil))
+		clock.Advance(time.Minute)
+		_, _, ok, err := cache.Get(wid1)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		clock.Advance(time.Minute)
+		require.NoError(t, cache.Put(wid3, []byte("binary3"), nil))
+
+		for wid, cached := range map[[32]byte]bool{wid1: true, wid2: false, wid3: true} {
+			_, _, ok, err := cache.Get(wid)
+			require.NoError(t, err)
+			assert.Equal(t, cached, ok)
+		}
+		var size int64
+		blobs, err := os.ReadDir(filepath.Join(dir, artifactCacheBlobsDir))
+		require.NoError(t, err)
+		for _, blob := range blobs {
+			info, err := blob.Info()
+			require.NoError(t, err)
+			size += info.Size()
+		}
+		assert.LessOrEqual(t, size, int64(14))
+
+		// the evicted workflow is cached again
+		clock.Advance(time.Minute)
+		require.NoError(t, cache.Put(wid2, []byte("binary2"), nil))
+		_, _, ok, err = cache.Get(wid2)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("artifacts larger than the cache are not cached", func(t *testing.T) {
+		wid := [32]byte{5}
+		require.NoError(t, cache.Put(wid, []byte("a binary larger than the cache"), nil))
+		_, _, ok, err := cache.Get(wid)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("corrupted artifacts are not returned", func(t *testing.T) {
+		wid := [32]byte{6}
+		require.NoError(t, cache.Put(wid, []byte("binary6"), nil))
+		sum := sha256.Sum256([]byte("binary6"))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, artifactCacheBlobsDir, hex.EncodeToString(sum[:])), []byte("tampered"), 0o600))
+		_, _, ok, err := cache.Get(wid)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("deleted entries are not cached", func(t *testing.T) {
+		require.NoError(t, cache.Delete(wid1))
+		_, _, ok, err := cache.Get(wid1)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		require.NoError(t, cache.Delete(wid1))
+	})
+}
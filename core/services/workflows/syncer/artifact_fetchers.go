@@ -0,0 +1,168 @@
+package syncer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArtifactFetcher fetches workflow artifacts, i.e. binaries, configs and secrets, from the URLs of some schemes.
+type ArtifactFetcher interface {
+	// Schemes returns the schemes of the URLs supported by the fetcher.
+	Schemes() []string
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+var (
+	_ ArtifactFetcher = (*FetcherService)(nil)
+	_ ArtifactFetcher = (*LocalFetcher)(nil)
+	_ ArtifactFetcher = (*IPFSFetcher)(nil)
+)
+
+// Schemes implements ArtifactFetcher: the gateway fetches artifacts over HTTP.
+func (s *FetcherService) Schemes() []string {
+	return []string{"http", "https"}
+}
+
+// MultiFetcher fetches each artifact with the fetcher supporting the scheme of its URL.
+type MultiFetcher struct {
+	fetchers map[string]ArtifactFetcher
+}
+
+// NewMultiFetcher returns a MultiFetcher with the given fetchers. If several fetchers support the same scheme, the
+// last one is used.
+func NewMultiFetcher(fetchers ...ArtifactFetcher) *MultiFetcher {
+	m := &MultiFetcher{fetchers: map[string]ArtifactFetcher{}}
+	for _, f := range fetchers {
+		for _, scheme := range f.Schemes() {
+			m.fetchers[scheme] = f
+		}
+	}
+	return m
+}
+
+// Fetch implements FetcherFunc.
+func (m *MultiFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact URL: %w", err)
+	}
+	f, ok := m.fetchers[strings.ToLower(u.Scheme)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported artifact URL scheme %q", u.Scheme)
+	}
+	return f.Fetch(ctx, rawURL)
+}
+
+// LocalFetcher fetches artifacts from file:// URLs, e.g. for workflows developed on a local node. Only files in its
+// directory can be fetched.
+type LocalFetcher struct {
+	dir string
+}
+
+func NewLocalFetcher(dir string) *LocalFetcher {
+	return &LocalFetcher{dir: dir}
+}
+
+func (f *LocalFetcher) Schemes() []string {
+	return []string{"file"}
+}
+
+func (f *LocalFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact URL: %w", err)
+	}
+	if u.Host != "" && u.Host != "localhost" {
+		return nil, fmt.Errorf("file URL %s must not have a remote host", rawURL)
+	}
+
+	dir, err := filepath.Abs(f.dir)
+	if err != nil {
+		return nil, err
+	}
+	// symlinks are resolved before the check, so that links in the directory can't point outside of it
+	if dir, err = filepath.EvalSymlinks(dir); err != nil {
+		return nil, fmt.Errorf("invalid local artifacts directory: %w", err)
+	}
+	path, err := filepath.EvalSymlinks(filepath.Clean(u.Path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve file %s: %w", u.Path, err)
+	}
+	if rel, err := filepath.Rel(dir, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("file %s is outside of the local artifacts directory %s", u.Path, dir)
+	}
+	return os.ReadFile(path)
+}
+
+const (
+	// ipfsCIDPrefix is the multibase prefix of base32 encoded CIDs.
+	ipfsCIDPrefix = "b"
+	// ipfsRawSHA256Prefix is the binary prefix of version 1 CIDs of raw blocks hashed with sha2-256: the CID version,
+	// the raw multicodec, then the sha2-256 multihash code and digest length.
+	ipfsRawSHA256Prefix = "\x01\x55\x12\x20"
+)
+
+var errUnsupportedCID = errors.New("only base32 CIDv1 of raw blocks hashed with sha2-256 are supported")
+
+// IPFSFetcher fetches content addressed artifacts from ipfs://<CID> URLs through an IPFS HTTP gateway, and verifies
+// that their content matches the hash of their CID.
+//
+// Only CIDv1 of raw blocks hashed with sha2-256 (`bafkrei...`) are supported, which is what IPFS assigns to files
+// added with `--raw-leaves --cid-version=1` that fit in a single block.
+type IPFSFetcher struct {
+	gatewayURL string
+	fetch      FetcherFunc
+}
+
+// NewIPFSFetcher returns an IPFSFetcher fetching artifacts from the gateway at gatewayURL with fetch.
+func NewIPFSFetcher(gatewayURL string, fetch FetcherFunc) *IPFSFetcher {
+	return &IPFSFetcher{gatewayURL: strings.TrimSuffix(gatewayURL, "/"), fetch: fetch}
+}
+
+func (f *IPFSFetcher) Schemes() []string {
+	return []string{"ipfs"}
+}
+
+func (f *IPFSFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact URL: %w", err)
+	}
+	cid := u.Host
+	digest, err := sha256DigestFromCID(cid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CID %q: %w", cid, err)
+	}
+
+	content, err := f.fetch(ctx, f.gatewayURL+"/ipfs/"+cid)
+	if err != nil {
+		return nil, err
+	}
+	if sum := sha256.Sum256(content); !bytes.Equal(sum[:], digest) {
+		return nil, fmt.Errorf("content of %s doesn't match its CID", rawURL)
+	}
+	return content, nil
+}
+
+// sha256DigestFromCID returns the sha2-256 digest of the content addressed by cid.
+func sha256DigestFromCID(cid string) ([]byte, error) {
+	if !strings.HasPrefix(cid, ipfsCIDPrefix) {
+		return nil, errUnsupportedCID
+	}
+	b, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(cid[len(ipfsCIDPrefix):]))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != len(ipfsRawSHA256Prefix)+sha256.Size || !strings.HasPrefix(string(b), ipfsRawSHA256Prefix) {
+		return nil, errUnsupportedCID
+	}
+	return b[len(ipfsRawSHA256Prefix):], nil
+}
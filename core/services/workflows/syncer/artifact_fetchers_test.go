@@ -0,0 +1,116 @@
+package syncer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+)
+
+type stubArtifactFetcher struct {
+	schemes []string
+	content []byte
+}
+
+func (f *stubArtifactFetcher) Schemes() []string { return f.schemes }
+
+func (f *stubArtifactFetcher) Fetch(context.Context, string) ([]byte, error) { return f.content, nil }
+
+// rawCID returns the base32 CIDv1 of a raw block with the given content.
+func rawCID(content []byte) string {
+	sum := sha256.Sum256(content)
+	b := append([]byte(ipfsRawSHA256Prefix), sum[:]...)
+	return ipfsCIDPrefix + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+}
+
+func Test_MultiFetcher(t *testing.T) {
+	ctx := testutils.Context(t)
+	fetcher := NewMultiFetcher(
+		&stubArtifactFetcher{schemes: []string{"http", "https"}, content: []byte("remote")},
+		&stubArtifactFetcher{schemes: []string{"file"}, content: []byte("local")},
+	)
+
+	got, err := fetcher.Fetch(ctx, "HTTPS://example.com/binary.wasm")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("remote"), got)
+
+	got, err = fetcher.Fetch(ctx, "file:///tmp/binary.wasm")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("local"), got)
+
+	_, err = fetcher.Fetch(ctx, "ftp://example.com/binary.wasm")
+	require.ErrorContains(t, err, `unsupported artifact URL scheme "ftp"`)
+}
+
+func Test_LocalFetcher(t *testing.T) {
+	ctx := testutils.Context(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "binary.wasm"), []byte("binary"), 0o600))
+	outside := filepath.Join(t.TempDir(), "secrets.json")
+	require.NoError(t, os.WriteFile(outside, []byte("secrets"), 0o600))
+	fetcher := NewLocalFetcher(dir)
+
+	t.Run("OK-file in directory", func(t *testing.T) {
+		got, err := fetcher.Fetch(ctx, "file://"+filepath.Join(dir, "binary.wasm"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("binary"), got)
+	})
+
+	t.Run("NOK-file outside of directory", func(t *testing.T) {
+		_, err := fetcher.Fetch(ctx, "file://"+outside)
+		require.ErrorContains(t, err, "outside of the local artifacts directory")
+
+		_, err = fetcher.Fetch(ctx, "file://"+dir+"/../"+filepath.Base(filepath.Dir(outside))+"/secrets.json")
+		require.ErrorContains(t, err, "outside of the local artifacts directory")
+	})
+
+	t.Run("NOK-symlink to a file outside of directory", func(t *testing.T) {
+		link := filepath.Join(dir, "link.json")
+		require.NoError(t, os.Symlink(outside, link))
+		_, err := fetcher.Fetch(ctx, "file://"+link)
+		require.ErrorContains(t, err, "outside of the local artifacts directory")
+	})
+
+	t.Run("NOK-remote host", func(t *testing.T) {
+		_, err := fetcher.Fetch(ctx, "file://example.com"+filepath.Join(dir, "binary.wasm"))
+		require.ErrorContains(t, err, "must not have a remote host")
+	})
+}
+
+func Test_IPFSFetcher(t *testing.T) {
+	ctx := testutils.Context(t)
+	content := []byte("binary")
+	cid := rawCID(content)
+	require.True(t, strings.HasPrefix(cid, "bafkrei"))
+
+	t.Run("OK-content matches CID", func(t *testing.T) {
+		fetcher := NewIPFSFetcher("https://ipfs.example.com/", newMockFetcher(map[string]mockFetchResp{
+			"https://ipfs.example.com/ipfs/" + cid: {Body: content},
+		}))
+		got, err := fetcher.Fetch(ctx, "ipfs://"+cid)
+		require.NoError(t, err)
+		assert.Equal(t, content, got)
+	})
+
+	t.Run("NOK-content doesn't match CID", func(t *testing.T) {
+		fetcher := NewIPFSFetcher("https://ipfs.example.com", newMockFetcher(map[string]mockFetchResp{
+			"https://ipfs.example.com/ipfs/" + cid: {Body: []byte("tampered")},
+		}))
+		_, err := fetcher.Fetch(ctx, "ipfs://"+cid)
+		require.ErrorContains(t, err, "doesn't match its CID")
+	})
+
+	t.Run("NOK-unsupported CID", func(t *testing.T) {
+		fetcher := NewIPFSFetcher("https://ipfs.example.com", newMockFetcher(nil))
+		_, err := fetcher.Fetch(ctx, "ipfs://QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG")
+		require.ErrorIs(t, err, errUnsupportedCID)
+	})
+}
@@ -15,7 +15,6 @@ import (
 	gcmocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/connector/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/capabilities"
 	ghcapabilities "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/capabilities"
-	"github.com/smartcontractkit/chainlink/v2/core/utils/matches"
 )
 
 type wrapper struct {
@@ -45,12 +44,10 @@ func TestNewFetcherService(t *testing.T) {
 		defer fetcher.Close()
 
 		gatewayResp := gatewayResponse(t, msgID)
-		connector.EXPECT().SignAndSendToGateway(mock.Anything, "gateway1", mock.Anything).Run(func(ctx context.Context, gatewayID string, msg *api.MessageBody) {
+		connector.EXPECT().SignAndSendToAnyGateway(mock.Anything, mock.Anything).Run(func(ctx context.Context, msg *api.MessageBody) {
 			fetcher.och.HandleGatewayMessage(ctx, "gateway1", gatewayResp)
-		}).Return(nil).Times(1)
+		}).Return("gateway1", nil).Times(1)
 		connector.EXPECT().DonID().Return("don-id")
-		connector.EXPECT().AwaitConnection(matches.AnyContext, "gateway1").Return(nil)
-		connector.EXPECT().GatewayIDs().Return([]string{"gateway1", "gateway2"})
 
 		payload, err := fetcher.Fetch(ctx, url)
 		require.NoError(t, err)
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -17,13 +18,16 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	"github.com/smartcontractkit/chainlink-common/pkg/types/core"
 	pkgworkflows "github.com/smartcontractkit/chainlink-common/pkg/workflows"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk"
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows/secrets"
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/host"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/compute"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/platform"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/workflowkey"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/quotas"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
 )
 
@@ -144,6 +148,8 @@ type eventHandler struct {
 	secretsFreshnessDuration time.Duration
 	encryptionKey            workflowkey.Key
 	engineFactory            engineFactoryFn
+	limiter                  *quotas.Limiter
+	artifactCache            *ArtifactCache
 }
 
 type Event interface {
@@ -165,6 +171,22 @@ func WithEngineFactoryFn(efn engineFactoryFn) func(*eventHandler) {
 	}
 }
 
+// WithLimiter sets the limiter that enforces the quotas of the workflows, which is shared with the other engines of
+// the node.
+func WithLimiter(l *quotas.Limiter) func(*eventHandler) {
+	return func(e *eventHandler) {
+		e.limiter = l
+	}
+}
+
+// WithArtifactCache sets the on-disk cache of the binaries and configs of workflows, so that they aren't fetched again
+// when a workflow is registered with a previously seen workflow ID.
+func WithArtifactCache(c *ArtifactCache) func(*eventHandler) {
+	return func(e *eventHandler) {
+		e.artifactCache = c
+	}
+}
+
 // NewEventHandler returns a new eventHandler instance.
 func NewEventHandler(
 	lggr logger.Logger,
@@ -415,16 +437,11 @@ func (h *eventHandler) workflowRegisteredEvent(
 		}
 	}
 
-	// Calculate the hash of the binary and config files
-	hash, err := pkgworkflows.GenerateWorkflowID(payload.WorkflowOwner, payload.WorkflowName, decodedBinary, config, payload.SecretsURL)
-	if err != nil {
-		return fmt.Errorf("failed to generate workflow id: %w", err)
-	}
-
 	// Pre-check: verify that the workflowID matches; if it doesn’t abort and log an error via Beholder.
-	if !bytes.Equal(hash[:], payload.WorkflowID[:]) {
-		return fmt.Errorf("workflowID mismatch: %x != %x", hash, payload.WorkflowID)
+	if err = verifyWorkflowID(payload, decodedBinary, config); err != nil {
+		return err
 	}
+	h.cacheArtifacts(payload.WorkflowID, decodedBinary, config)
 
 	// Ensure that there is no running workflow engine for the given workflow ID.
 	if h.engineRegistry.IsRunning(hex.EncodeToString(payload.WorkflowID[:])) {
@@ -495,6 +512,10 @@ func (h *eventHandler) getWorkflowArtifacts(
 ) ([]byte, []byte, error) {
 	spec, err := h.orm.GetWorkflowSpecByID(ctx, hex.EncodeToString(payload.WorkflowID[:]))
 	if err != nil {
+		if binary, config, ok := h.getCachedArtifacts(payload); ok {
+			return binary, config, nil
+		}
+
 		binary, err2 := h.fetcher(ctx, payload.BinaryURL)
 		if err2 != nil {
 			return nil, nil, fmt.Errorf("failed to fetch binary from %s : %w", payload.BinaryURL, err)
@@ -523,12 +544,63 @@ func (h *eventHandler) getWorkflowArtifacts(
 	return decodedBinary, []byte(spec.Config), nil
 }
 
+// getCachedArtifacts returns the binary and config of the workflow from the artifact cache, if they are cached and
+// match the workflow ID.
+func (h *eventHandler) getCachedArtifacts(payload WorkflowRegistryWorkflowRegisteredV1) ([]byte, []byte, bool) {
+	if h.artifactCache == nil {
+		return nil, nil, false
+	}
+	l := h.lggr.With("workflowID", hex.EncodeToString(payload.WorkflowID[:]))
+	binary, config, ok, err := h.artifactCache.Get(payload.WorkflowID)
+	if err != nil {
+		l.Warnw("failed to read cached workflow artifacts, fetching them", "err", err)
+		return nil, nil, false
+	}
+	if !ok {
+		return nil, nil, false
+	}
+	if err := verifyWorkflowID(payload, binary, config); err != nil {
+		l.Warnw("cached workflow artifacts are corrupted, fetching them", "err", err)
+		if err := h.artifactCache.Delete(payload.WorkflowID); err != nil {
+			l.Errorw("failed to delete corrupted cached workflow artifacts", "err", err)
+		}
+		return nil, nil, false
+	}
+	l.Debug("using cached workflow artifacts")
+	return binary, config, true
+}
+
+// cacheArtifacts caches the verified binary and config of the workflow. Failing to cache them isn't fatal, since they
+// can be fetched again.
+func (h *eventHandler) cacheArtifacts(workflowID [32]byte, binary []byte, config []byte) {
+	if h.artifactCache == nil {
+		return
+	}
+	if err := h.artifactCache.Put(workflowID, binary, config); err != nil {
+		h.lggr.Warnw("failed to cache workflow artifacts", "workflowID", hex.EncodeToString(workflowID[:]), "err", err)
+	}
+}
+
+// verifyWorkflowID returns an error unless the workflow ID of the event is the hash of the binary and config of the
+// workflow, so that tampered artifacts are never executed.
+func verifyWorkflowID(payload WorkflowRegistryWorkflowRegisteredV1, binary []byte, config []byte) error {
+	hash, err := pkgworkflows.GenerateWorkflowID(payload.WorkflowOwner, payload.WorkflowName, binary, config, payload.SecretsURL)
+	if err != nil {
+		return fmt.Errorf("failed to generate workflow id: %w", err)
+	}
+	if !bytes.Equal(hash[:], payload.WorkflowID[:]) {
+		return fmt.Errorf("workflowID mismatch: %x != %x", hash, payload.WorkflowID)
+	}
+	return nil
+}
+
 func (h *eventHandler) engineFactoryFn(ctx context.Context, id string, owner string, name string, config []byte, binary []byte) (services.Service, error) {
 	moduleConfig := &host.ModuleConfig{Logger: h.lggr, Labeler: h.emitter}
 	sdkSpec, err := host.GetWorkflowSpec(ctx, moduleConfig, binary, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get workflow sdk spec: %w", err)
 	}
+	h.warmComputeModule(ctx, sdkSpec, owner, id, binary)
 
 	cfg := workflows.Config{
 		Lggr:           h.lggr,
@@ -541,10 +613,33 @@ func (h *eventHandler) engineFactoryFn(ctx context.Context, id string, owner str
 		Config:         config,
 		Binary:         binary,
 		SecretsFetcher: h,
+		Limiter:        h.limiter,
 	}
 	return workflows.NewEngine(ctx, cfg)
 }
 
+// ModuleWarmer compiles the modules of workflows ahead of their first execution.
+type ModuleWarmer interface {
+	Warm(binary []byte, owner, workflowID string)
+}
+
+// warmComputeModule compiles the module of the custom compute steps of the workflow in the background, so that their
+// first execution isn't delayed by its compilation. Workflows are registered for all the active workflows when the node
+// starts, which warms the modules of all of them.
+func (h *eventHandler) warmComputeModule(ctx context.Context, spec *sdk.WorkflowSpec, owner, workflowID string, binary []byte) {
+	if !slices.ContainsFunc(spec.Steps(), func(s sdk.StepDefinition) bool { return s.ID == compute.CapabilityIDCompute }) {
+		return
+	}
+	c, err := h.capRegistry.Get(ctx, compute.CapabilityIDCompute)
+	if err != nil {
+		h.lggr.Debugw("compute capability not available, not warming module", "workflowID", workflowID, "err", err)
+		return
+	}
+	if w, ok := c.(ModuleWarmer); ok {
+		w.Warm(binary, owner, workflowID)
+	}
+}
+
 // workflowUpdatedEvent handles the WorkflowUpdatedEvent event type by first finding the
 // current workflow engine, stopping it, and then starting a new workflow engine with the
 // updated workflow spec.
@@ -556,6 +651,9 @@ func (h *eventHandler) workflowUpdatedEvent(
 	if err := h.tryEngineCleanup(hex.EncodeToString(payload.OldWorkflowID[:])); err != nil {
 		return err
 	}
+	if h.limiter != nil {
+		h.limiter.RemoveWorkflow(hex.EncodeToString(payload.OldWorkflowID[:]))
+	}
 
 	registeredEvent := WorkflowRegistryWorkflowRegisteredV1{
 		WorkflowID:    payload.NewWorkflowID,
@@ -645,6 +743,16 @@ func (h *eventHandler) workflowDeletedEvent(
 	if err := h.orm.DeleteWorkflowSpec(ctx, hex.EncodeToString(payload.WorkflowOwner), payload.WorkflowName); err != nil {
 		return fmt.Errorf("failed to delete workflow spec: %w", err)
 	}
+
+	if h.limiter != nil {
+		h.limiter.RemoveWorkflow(hex.EncodeToString(payload.WorkflowID[:]))
+	}
+
+	if h.artifactCache != nil {
+		if err := h.artifactCache.Delete(payload.WorkflowID); err != nil {
+			h.lggr.Warnw("failed to delete cached workflow artifacts", "workflowID", hex.EncodeToString(payload.WorkflowID[:]), "err", err)
+		}
+	}
 	return nil
 }
 
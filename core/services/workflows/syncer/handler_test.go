@@ -382,6 +382,77 @@ func Test_workflowRegisteredHandler(t *testing.T) {
 				}
 			},
 		},
+		{
+			Name:       "uses cached artifacts",
+			GiveConfig: config,
+			ConfigURL:  configURL,
+			SecretsURL: secretsURL,
+			BinaryURL:  binaryURL,
+			GiveBinary: binary,
+			WFOwner:    wfOwner,
+			fetcher: newMockFetcher(map[string]mockFetchResp{
+				secretsURL: {Body: []byte("secrets"), Err: nil},
+			}),
+			engineFactoryFn: func(ctx context.Context, wfid string, owner string, name string, config []byte, binary []byte) (services.Service, error) {
+				return &mockEngine{}, nil
+			},
+			validationFn: func(t *testing.T, ctx context.Context, event WorkflowRegistryWorkflowRegisteredV1, h *eventHandler, wfOwner []byte, wfName string, wfID string) {
+				h.artifactCache = NewArtifactCache(h.lggr, t.TempDir(), 0, clockwork.NewFakeClock())
+				require.NoError(t, h.artifactCache.Put(event.WorkflowID, binary, config))
+
+				defaultValidationFn(t, ctx, event, h, wfOwner, wfName, wfID)
+			},
+			Event: func(wfID []byte) WorkflowRegistryWorkflowRegisteredV1 {
+				return WorkflowRegistryWorkflowRegisteredV1{
+					Status:        uint8(0),
+					WorkflowID:    [32]byte(wfID),
+					WorkflowOwner: wfOwner,
+					WorkflowName:  "workflow-name",
+					BinaryURL:     binaryURL,
+					ConfigURL:     configURL,
+					SecretsURL:    secretsURL,
+				}
+			},
+		},
+		{
+			Name:       "fetches and caches artifacts if cached artifacts are corrupted",
+			GiveConfig: config,
+			ConfigURL:  configURL,
+			SecretsURL: secretsURL,
+			BinaryURL:  binaryURL,
+			GiveBinary: binary,
+			WFOwner:    wfOwner,
+			fetcher: newMockFetcher(map[string]mockFetchResp{
+				binaryURL:  {Body: encodedBinary, Err: nil},
+				configURL:  {Body: config, Err: nil},
+				secretsURL: {Body: []byte("secrets"), Err: nil},
+			}),
+			engineFactoryFn: func(ctx context.Context, wfid string, owner string, name string, config []byte, binary []byte) (services.Service, error) {
+				return &mockEngine{}, nil
+			},
+			validationFn: func(t *testing.T, ctx context.Context, event WorkflowRegistryWorkflowRegisteredV1, h *eventHandler, wfOwner []byte, wfName string, wfID string) {
+				h.artifactCache = NewArtifactCache(h.lggr, t.TempDir(), 0, clockwork.NewFakeClock())
+				require.NoError(t, h.artifactCache.Put(event.WorkflowID, []byte("tampered"), config))
+
+				defaultValidationFn(t, ctx, event, h, wfOwner, wfName, wfID)
+
+				cachedBinary, _, ok, err := h.artifactCache.Get(event.WorkflowID)
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, binary, cachedBinary)
+			},
+			Event: func(wfID []byte) WorkflowRegistryWorkflowRegisteredV1 {
+				return WorkflowRegistryWorkflowRegisteredV1{
+					Status:        uint8(0),
+					WorkflowID:    [32]byte(wfID),
+					WorkflowOwner: wfOwner,
+					WorkflowName:  "workflow-name",
+					BinaryURL:     binaryURL,
+					ConfigURL:     configURL,
+					SecretsURL:    secretsURL,
+				}
+			},
+		},
 		{
 			Name:       "skips fetch if secrets url is missing",
 			GiveConfig: config,
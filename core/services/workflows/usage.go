@@ -0,0 +1,45 @@
+package workflows
+
+import (
+	"context"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/compute"
+)
+
+// stepUsage accumulates the resources used by the executions of a compute step, across its retries and the elements
+// of a map-over step. The compute capability reports them to the context of the step.
+type stepUsage struct {
+	mu    sync.Mutex
+	usage *compute.ExecutionUsage
+}
+
+// withStepUsage carries u, or a new stepUsage if it's nil, in ctx so that the usage of every attempt of a step is
+// accumulated.
+func withStepUsage(ctx context.Context, u *stepUsage) (context.Context, *stepUsage) {
+	if u == nil {
+		u = &stepUsage{}
+	}
+	return compute.WithUsageReporter(ctx, u.add), u
+}
+
+func (u *stepUsage) add(o compute.ExecutionUsage) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.usage == nil {
+		u.usage = &compute.ExecutionUsage{}
+	}
+	u.usage.Add(o)
+}
+
+// toMap returns the accumulated usage, or nil if the step reported none.
+func (u *stepUsage) toMap() (*values.Map, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.usage == nil {
+		return nil, nil
+	}
+	return u.usage.ToMap()
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE evm.reorgs (
+    id BIGSERIAL PRIMARY KEY,
+    evm_chain_id numeric(78,0) NOT NULL,
+    depth bigint NOT NULL,
+    old_head_hash bytea NOT NULL,
+    old_head_number bigint NOT NULL,
+    new_head_hash bytea NOT NULL,
+    new_head_number bigint NOT NULL,
+    common_ancestor_hash bytea NOT NULL,
+    common_ancestor_number bigint NOT NULL,
+    detected_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX idx_evm_reorgs_evm_chain_id_detected_at ON evm.reorgs (evm_chain_id, detected_at DESC);
+
+-- +goose Down
+DROP TABLE evm.reorgs;
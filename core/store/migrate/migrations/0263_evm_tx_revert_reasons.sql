@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE evm.tx_revert_reasons (
+    tx_hash bytea PRIMARY KEY REFERENCES evm.tx_attempts (hash) ON DELETE CASCADE,
+    evm_chain_id numeric(78,0) NOT NULL,
+    reason text NOT NULL,
+    revert_data bytea,
+    source text NOT NULL,
+    created_at timestamp with time zone NOT NULL
+);
+
+-- +goose Down
+DROP TABLE evm.tx_revert_reasons;
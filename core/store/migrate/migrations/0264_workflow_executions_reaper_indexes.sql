@@ -0,0 +1,11 @@
+-- +goose Up
+-- Used by the workflow execution reaper to find old executions, and to rank the executions of each workflow.
+CREATE INDEX idx_workflow_executions_finished_at ON workflow_executions (finished_at) WHERE finished_at IS NOT NULL;
+CREATE INDEX idx_workflow_executions_workflow_id_created_at ON workflow_executions (workflow_id, created_at DESC);
+-- Used by the workflow execution reaper to find stale unfinished executions.
+CREATE INDEX idx_workflow_executions_unfinished_updated_at ON workflow_executions ((COALESCE(updated_at, created_at))) WHERE finished_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_workflow_executions_unfinished_updated_at;
+DROP INDEX IF EXISTS idx_workflow_executions_workflow_id_created_at;
+DROP INDEX IF EXISTS idx_workflow_executions_finished_at;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Executions that errored because a step exhausted its retries, until they are re-driven or reaped.
+CREATE TABLE workflow_dead_letters (
+	execution_id varchar(64) PRIMARY KEY REFERENCES workflow_executions(id) ON DELETE CASCADE,
+	workflow_id varchar(64) NOT NULL,
+	step_ref text NOT NULL,
+	error text NOT NULL,
+	attempts integer NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	redrive_requested_at timestamp with time zone
+);
+CREATE INDEX idx_workflow_dead_letters_workflow_id_created_at ON workflow_dead_letters (workflow_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS workflow_dead_letters;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Resources used by workflow steps, as reported by their capabilities.
+ALTER TABLE workflow_steps ADD COLUMN output_usage bytea;
+
+-- +goose Down
+ALTER TABLE workflow_steps DROP COLUMN IF EXISTS output_usage;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Rate limit buckets and pending requests shared by the Gateways of a DON, e.g. replicas behind a load balancer.
+CREATE TABLE gateway_rate_limit_buckets (
+    don_id TEXT NOT NULL,
+    limiter TEXT NOT NULL,
+    sender TEXT NOT NULL,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (don_id, limiter, sender)
+);
+
+CREATE TABLE gateway_pending_requests (
+    don_id TEXT NOT NULL,
+    sender TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (don_id, sender, message_id)
+);
+
+CREATE INDEX idx_gateway_pending_requests_expires_at ON gateway_pending_requests (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS gateway_pending_requests;
+DROP TABLE IF EXISTS gateway_rate_limit_buckets;
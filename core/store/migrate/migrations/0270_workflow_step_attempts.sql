@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TYPE workflow_status ADD VALUE 'retrying';
+ALTER TABLE workflow_steps ADD COLUMN attempts integer NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE workflow_steps DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,56 @@
+package web
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// CapabilitiesController displays the capabilities of the node, and the state of the shims of remote capabilities.
+type CapabilitiesController struct {
+	App chainlink.Application
+}
+
+// Index returns the capabilities of the capabilities registry, local and remote, ordered by ID.
+// Example:
+// "GET <application>/capabilities"
+func (cc *CapabilitiesController) Index(c *gin.Context) {
+	caps, err := cc.App.GetCapabilitiesRegistry().List(c.Request.Context())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resources := make([]presenters.CapabilityResource, 0, len(caps))
+	for _, capability := range caps {
+		info, err := capability.Info(c.Request.Context())
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		resources = append(resources, presenters.NewCapabilityResource(info))
+	}
+	slices.SortFunc(resources, func(a, b presenters.CapabilityResource) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	jsonAPIResponse(c, resources, "capabilities")
+}
+
+// Remote returns the shims of remote capabilities: the trigger publishers and subscribers, and the executable
+// servers and clients, with their peers, pending requests and counters.
+// Example:
+// "GET <application>/capabilities/remote"
+func (cc *CapabilitiesController) Remote(c *gin.Context) {
+	resources := presenters.NewRemoteCapabilityShimResources(cc.App.GetRemoteCapabilityShims())
+	slices.SortFunc(resources, func(a, b presenters.RemoteCapabilityShimResource) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	jsonAPIResponse(c, resources, "remoteCapabilityShims")
+}
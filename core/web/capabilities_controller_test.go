@@ -0,0 +1,79 @@
+package web_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+type fakeTarget struct {
+	capabilities.CapabilityInfo
+}
+
+func (fakeTarget) Execute(context.Context, capabilities.CapabilityRequest) (capabilities.CapabilityResponse, error) {
+	return capabilities.CapabilityResponse{}, nil
+}
+
+func (fakeTarget) RegisterToWorkflow(context.Context, capabilities.RegisterToWorkflowRequest) error {
+	return nil
+}
+
+func (fakeTarget) UnregisterFromWorkflow(context.Context, capabilities.UnregisterFromWorkflowRequest) error {
+	return nil
+}
+
+func TestCapabilitiesController_Index(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+
+	ids := []string{"write_ethereum-testnet-sepolia@1.0.0", "write_arbitrum-testnet-sepolia@1.0.0"}
+	for _, id := range ids {
+		require.NoError(t, app.GetCapabilitiesRegistry().Add(ctx, fakeTarget{
+			CapabilityInfo: capabilities.MustNewCapabilityInfo(id, capabilities.CapabilityTypeTarget, "a target"),
+		}))
+	}
+
+	client := app.NewHTTPClient(nil)
+	resp, cleanup := client.Get("/v2/capabilities")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var resources []presenters.CapabilityResource
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resources))
+	require.Len(t, resources, 2)
+	assert.Equal(t, ids[1], resources[0].ID)
+	assert.Equal(t, ids[0], resources[1].ID)
+	assert.Equal(t, "target", resources[0].CapabilityType)
+	assert.Equal(t, "1.0.0", resources[0].Version)
+	assert.True(t, resources[0].IsLocal)
+	assert.Nil(t, resources[0].DonID)
+}
+
+func TestCapabilitiesController_Remote(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+
+	client := app.NewHTTPClient(nil)
+	resp, cleanup := client.Get("/v2/capabilities/remote")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	// without peering, the node has no remote capabilities
+	var resources []presenters.RemoteCapabilityShimResource
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resources))
+	assert.Empty(t, resources)
+}
@@ -42,6 +42,31 @@ func (cc *EVMForwardersController) Index(c *gin.Context, size, page, offset int)
 	paginatedResponse(c, "forwarder", size, page, resources, count, err)
 }
 
+// Verify checks on-chain that the node's enabled keys are authorized senders of each tracked EVM forwarder.
+func (cc *EVMForwardersController) Verify(c *gin.Context, size, page, offset int) {
+	ctx := c.Request.Context()
+	orm := forwarders.NewORM(cc.App.GetDB())
+	fwds, count, err := orm.FindForwarders(ctx, offset, size)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var resources []presenters.EVMForwarderStatusResource
+	for _, fwd := range fwds {
+		var status forwarders.Status
+		chain, err2 := cc.App.GetRelayers().LegacyEVMChains().Get(fwd.EVMChainID.String())
+		if err2 != nil {
+			status = forwarders.Status{Address: fwd.Address, EVMChainID: fwd.EVMChainID, Err: err2}
+		} else {
+			status = forwarders.Verify(ctx, chain.Client(), cc.App.GetKeyStore().Eth(), fwd)
+		}
+		resources = append(resources, presenters.NewEVMForwarderStatusResource(fwd.ID, status))
+	}
+
+	paginatedResponse(c, "forwarder_status", size, page, resources, count, err)
+}
+
 // TrackEVMForwarderRequest is a JSONAPI request for creating an EVM forwarder.
 type TrackEVMForwarderRequest struct {
 	EVMChainID *ubig.Big      `json:"evmChainId"`
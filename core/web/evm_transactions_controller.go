@@ -1,10 +1,14 @@
 package web
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"net/http"
 
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	evmrelaytypes "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -45,5 +49,45 @@ func (tc *TransactionsController) Show(c *gin.Context) {
 		return
 	}
 
-	jsonAPIResponse(c, presenters.NewEthTxResourceFromAttempt(*ethTxAttempt), "transaction")
+	r := presenters.NewEthTxResourceFromAttempt(*ethTxAttempt)
+	revertReason, err := tc.App.TxmStorageService().FindRevertReason(c, hash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if revertReason != nil {
+		// custom errors of the job's contracts are decoded on display, as the txmgr does not know about jobs
+		decoder := txmgr.DefaultRevertReasonDecoder().With(tc.jobContractABIs(c, ethTxAttempt.Tx)...)
+		if reason, ok := decoder.Decode(revertReason.Data); ok {
+			revertReason.Reason = reason
+		}
+		r.SetRevertReason(*revertReason)
+	}
+
+	jsonAPIResponse(c, r, "transaction")
+}
+
+// jobContractABIs returns the contract ABIs of the chain reader config of the job that sent tx, if any.
+func (tc *TransactionsController) jobContractABIs(ctx context.Context, tx txmgr.Tx) []string {
+	meta, err := tx.GetMeta()
+	if err != nil || meta == nil || meta.JobID == nil {
+		return nil
+	}
+	jb, err := tc.App.JobORM().FindJob(ctx, *meta.JobID)
+	if err != nil || jb.OCR2OracleSpec == nil {
+		return nil
+	}
+	b, err := json.Marshal(jb.OCR2OracleSpec.RelayConfig)
+	if err != nil {
+		return nil
+	}
+	var relayConfig evmrelaytypes.RelayConfig
+	if err = json.Unmarshal(b, &relayConfig); err != nil || relayConfig.ChainReader == nil {
+		return nil
+	}
+	var abis []string
+	for _, contract := range relayConfig.ChainReader.Contracts {
+		abis = append(abis, contract.ContractABI)
+	}
+	return abis
 }
@@ -0,0 +1,95 @@
+package presenters
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+
+	remotetypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types"
+	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
+)
+
+// CapabilityResource represents a capability of the capabilities registry of the node
+type CapabilityResource struct {
+	JAID
+	CapabilityType string   `json:"capabilityType"`
+	Description    string   `json:"description"`
+	Version        string   `json:"version"`
+	IsLocal        bool     `json:"isLocal"`
+	DonID          *uint32  `json:"donId"`
+	DonMembers     []string `json:"donMembers,omitempty"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r CapabilityResource) GetName() string {
+	return "capabilities"
+}
+
+// NewCapabilityResource constructs a new CapabilityResource, identified by the ID of the capability.
+func NewCapabilityResource(info capabilities.CapabilityInfo) CapabilityResource {
+	r := CapabilityResource{
+		JAID:           NewJAID(info.ID),
+		CapabilityType: string(info.CapabilityType),
+		Description:    info.Description,
+		Version:        info.Version(),
+		IsLocal:        info.IsLocal,
+	}
+	if info.DON != nil {
+		donID := info.DON.ID
+		r.DonID = &donID
+		r.DonMembers = peerIDStrings(info.DON.Members)
+	}
+	return r
+}
+
+// RemoteCapabilityShimResource represents a shim of a remote capability, i.e. the local end of a capability shared
+// between DONs
+type RemoteCapabilityShimResource struct {
+	JAID
+	Kind             string   `json:"kind"`
+	CapabilityID     string   `json:"capabilityId"`
+	DonID            uint32   `json:"donId"`
+	Peers            []string `json:"peers"`
+	Registrations    int      `json:"registrations"`
+	PendingRequests  int      `json:"pendingRequests"`
+	MessagesReceived uint64   `json:"messagesReceived"`
+	Errors           uint64   `json:"errors"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r RemoteCapabilityShimResource) GetName() string {
+	return "remoteCapabilityShims"
+}
+
+// NewRemoteCapabilityShimResource constructs a new RemoteCapabilityShimResource, identified by its kind, DON and
+// capability.
+func NewRemoteCapabilityShimResource(stats remotetypes.ShimStats) RemoteCapabilityShimResource {
+	return RemoteCapabilityShimResource{
+		JAID:             NewJAID(fmt.Sprintf("%s/%d/%s", stats.Kind, stats.DonID, stats.CapabilityID)),
+		Kind:             stats.Kind,
+		CapabilityID:     stats.CapabilityID,
+		DonID:            stats.DonID,
+		Peers:            peerIDStrings(stats.Peers),
+		Registrations:    stats.Registrations,
+		PendingRequests:  stats.PendingRequests,
+		MessagesReceived: stats.MessagesReceived,
+		Errors:           stats.Errors,
+	}
+}
+
+// NewRemoteCapabilityShimResources initializes a slice of JSONAPI remote capability shim resources
+func NewRemoteCapabilityShimResources(shims []remotetypes.ShimStats) []RemoteCapabilityShimResource {
+	rs := make([]RemoteCapabilityShimResource, len(shims))
+	for i, shim := range shims {
+		rs[i] = NewRemoteCapabilityShimResource(shim)
+	}
+	return rs
+}
+
+func peerIDStrings(peerIDs []p2ptypes.PeerID) []string {
+	ss := make([]string, len(peerIDs))
+	for i, peerID := range peerIDs {
+		ss[i] = peerID.String()
+	}
+	return ss
+}
@@ -0,0 +1,59 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+
+	remotetypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types"
+	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
+)
+
+func TestCapabilityResource(t *testing.T) {
+	t.Parallel()
+
+	peer := p2ptypes.PeerID{1}
+	info, err := capabilities.NewRemoteCapabilityInfo("streams-trigger@1.0.0", capabilities.CapabilityTypeTrigger, "Remote Trigger",
+		&capabilities.DON{ID: 2, Members: []p2ptypes.PeerID{peer}})
+	require.NoError(t, err)
+
+	r := NewCapabilityResource(info)
+	assert.Equal(t, "streams-trigger@1.0.0", r.ID)
+	assert.Equal(t, "capabilities", r.GetName())
+	assert.Equal(t, "trigger", r.CapabilityType)
+	assert.Equal(t, "1.0.0", r.Version)
+	assert.False(t, r.IsLocal)
+	require.NotNil(t, r.DonID)
+	assert.Equal(t, uint32(2), *r.DonID)
+	assert.Equal(t, []string{peer.String()}, r.DonMembers)
+}
+
+func TestRemoteCapabilityShimResource(t *testing.T) {
+	t.Parallel()
+
+	peer := p2ptypes.PeerID{1}
+	rs := NewRemoteCapabilityShimResources([]remotetypes.ShimStats{{
+		Kind:             remotetypes.ShimExecutableClient,
+		CapabilityID:     "write_ethereum-testnet-sepolia@1.0.0",
+		DonID:            3,
+		Peers:            []p2ptypes.PeerID{peer},
+		PendingRequests:  4,
+		MessagesReceived: 10,
+		Errors:           1,
+	}})
+	require.Len(t, rs, 1)
+
+	r := rs[0]
+	assert.Equal(t, "executable-client/3/write_ethereum-testnet-sepolia@1.0.0", r.ID)
+	assert.Equal(t, "remoteCapabilityShims", r.GetName())
+	assert.Equal(t, remotetypes.ShimExecutableClient, r.Kind)
+	assert.Equal(t, "write_ethereum-testnet-sepolia@1.0.0", r.CapabilityID)
+	assert.Equal(t, uint32(3), r.DonID)
+	assert.Equal(t, []string{peer.String()}, r.Peers)
+	assert.Equal(t, 4, r.PendingRequests)
+	assert.Equal(t, uint64(10), r.MessagesReceived)
+	assert.Equal(t, uint64(1), r.Errors)
+}
@@ -26,6 +26,10 @@ type EthTxResource struct {
 	To         *common.Address `json:"to"`
 	Value      string          `json:"value"`
 	EVMChainID big.Big         `json:"evmChainID"`
+	// RevertReason is only set for transactions that reverted on-chain, if revert reasons are enabled for the chain
+	RevertReason       string        `json:"revertReason,omitempty"`
+	RevertData         hexutil.Bytes `json:"revertData,omitempty"`
+	RevertReasonSource string        `json:"revertReasonSource,omitempty"`
 }
 
 // GetName implements the api2go EntityNamer interface
@@ -55,6 +59,13 @@ func NewEthTxResource(tx txmgr.Tx) EthTxResource {
 	return r
 }
 
+// SetRevertReason sets the revert reason of a transaction that reverted on-chain.
+func (r *EthTxResource) SetRevertReason(rr txmgr.RevertReason) {
+	r.RevertReason = rr.Reason
+	r.RevertData = rr.Data
+	r.RevertReasonSource = string(rr.Source)
+}
+
 func NewEthTxResourceFromAttempt(txa txmgr.TxAttempt) EthTxResource {
 	tx := txa.Tx
 
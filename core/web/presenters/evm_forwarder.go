@@ -33,3 +33,37 @@ func NewEVMForwarderResource(fwd forwarders.Forwarder) EVMForwarderResource {
 		UpdatedAt:  fwd.UpdatedAt,
 	}
 }
+
+// EVMForwarderStatusResource is the JSONAPI resource for the on-chain verification of an EVM forwarder.
+type EVMForwarderStatusResource struct {
+	JAID
+	Address           common.Address   `json:"address"`
+	EVMChainID        big.Big          `json:"evmChainId"`
+	AuthorizedSenders []common.Address `json:"authorizedSenders"`
+	AuthorizedKeys    []common.Address `json:"authorizedKeys"`
+	UnauthorizedKeys  []common.Address `json:"unauthorizedKeys"`
+	VerifiedAt        time.Time        `json:"verifiedAt"`
+	Error             string           `json:"error,omitempty"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r EVMForwarderStatusResource) GetName() string {
+	return "evm_forwarder_status"
+}
+
+// NewEVMForwarderStatusResource returns a new EVMForwarderStatusResource for the forwarder with the given ID.
+func NewEVMForwarderStatusResource(id int64, status forwarders.Status) EVMForwarderStatusResource {
+	r := EVMForwarderStatusResource{
+		JAID:              NewJAIDInt64(id),
+		Address:           status.Address,
+		EVMChainID:        status.EVMChainID,
+		AuthorizedSenders: status.AuthorizedSenders,
+		AuthorizedKeys:    status.AuthorizedKeys,
+		UnauthorizedKeys:  status.UnauthorizedKeys,
+		VerifiedAt:        status.VerifiedAt,
+	}
+	if status.Err != nil {
+		r.Error = status.Err.Error()
+	}
+	return r
+}
@@ -0,0 +1,45 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+)
+
+// WorkflowDeadLetterResource represents an execution of a workflow that errored after a step exhausted its retries
+type WorkflowDeadLetterResource struct {
+	JAID
+	WorkflowID         string     `json:"workflowId"`
+	StepRef            string     `json:"stepRef"`
+	Error              string     `json:"error"`
+	Attempts           int        `json:"attempts"`
+	CreatedAt          *time.Time `json:"createdAt"`
+	RedriveRequestedAt *time.Time `json:"redriveRequestedAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r WorkflowDeadLetterResource) GetName() string {
+	return "workflowDeadLetters"
+}
+
+// NewWorkflowDeadLetterResource constructs a new WorkflowDeadLetterResource, identified by the ID of the execution.
+func NewWorkflowDeadLetterResource(dl store.DeadLetter) WorkflowDeadLetterResource {
+	return WorkflowDeadLetterResource{
+		JAID:               NewJAID(dl.ExecutionID),
+		WorkflowID:         dl.WorkflowID,
+		StepRef:            dl.StepRef,
+		Error:              dl.Error,
+		Attempts:           dl.Attempts,
+		CreatedAt:          dl.CreatedAt,
+		RedriveRequestedAt: dl.RedriveRequestedAt,
+	}
+}
+
+// NewWorkflowDeadLetterResources initializes a slice of JSONAPI workflow dead letter resources
+func NewWorkflowDeadLetterResources(dls []store.DeadLetter) []WorkflowDeadLetterResource {
+	rs := make([]WorkflowDeadLetterResource, len(dls))
+	for i, dl := range dls {
+		rs[i] = NewWorkflowDeadLetterResource(dl)
+	}
+	return rs
+}
@@ -0,0 +1,107 @@
+package presenters
+
+import (
+	"sort"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+)
+
+// WorkflowExecutionResource represents an execution of a workflow
+type WorkflowExecutionResource struct {
+	JAID
+	WorkflowID string                          `json:"workflowId"`
+	Status     string                          `json:"status"`
+	CreatedAt  *time.Time                      `json:"createdAt"`
+	UpdatedAt  *time.Time                      `json:"updatedAt"`
+	FinishedAt *time.Time                      `json:"finishedAt"`
+	Steps      []WorkflowExecutionStepResource `json:"steps,omitempty"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r WorkflowExecutionResource) GetName() string {
+	return "workflowExecutions"
+}
+
+// WorkflowExecutionStepResource represents a step of a workflow execution
+type WorkflowExecutionStepResource struct {
+	Ref       string     `json:"ref"`
+	Status    string     `json:"status"`
+	Inputs    any        `json:"inputs"`
+	Outputs   any        `json:"outputs"`
+	Usage     any        `json:"usage,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+// NewWorkflowExecutionResource constructs a new WorkflowExecutionResource, with its steps ordered by the time
+// they were last updated.
+func NewWorkflowExecutionResource(we store.WorkflowExecution) WorkflowExecutionResource {
+	r := WorkflowExecutionResource{
+		JAID:       NewJAID(we.ExecutionID),
+		WorkflowID: we.WorkflowID,
+		Status:     we.Status,
+		CreatedAt:  we.CreatedAt,
+		UpdatedAt:  we.UpdatedAt,
+		FinishedAt: we.FinishedAt,
+	}
+	for _, step := range we.Steps {
+		r.Steps = append(r.Steps, newWorkflowExecutionStepResource(step))
+	}
+	sort.SliceStable(r.Steps, func(i, j int) bool {
+		a, b := r.Steps[i].UpdatedAt, r.Steps[j].UpdatedAt
+		if a != nil && b != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		if (a == nil) != (b == nil) {
+			return a == nil
+		}
+		return r.Steps[i].Ref < r.Steps[j].Ref
+	})
+	return r
+}
+
+// NewWorkflowExecutionResources initializes a slice of JSONAPI workflow execution resources
+func NewWorkflowExecutionResources(wes []store.WorkflowExecution) []WorkflowExecutionResource {
+	rs := make([]WorkflowExecutionResource, len(wes))
+	for i, we := range wes {
+		rs[i] = NewWorkflowExecutionResource(we)
+	}
+	return rs
+}
+
+func newWorkflowExecutionStepResource(step *store.WorkflowExecutionStep) WorkflowExecutionStepResource {
+	r := WorkflowExecutionStepResource{
+		Ref:       step.Ref,
+		Status:    step.Status,
+		UpdatedAt: step.UpdatedAt,
+	}
+	if step.Inputs != nil {
+		r.Inputs = unwrapValue(step.Inputs)
+	}
+	if step.Outputs.Value != nil {
+		r.Outputs = unwrapValue(step.Outputs.Value)
+	}
+	if step.Outputs.Usage != nil {
+		r.Usage = unwrapValue(step.Outputs.Usage)
+	}
+	if step.Outputs.Err != nil {
+		r.Error = step.Outputs.Err.Error()
+	}
+	return r
+}
+
+// unwrapValue returns v as native Go types, falling back to the error if it cannot be unwrapped.
+func unwrapValue(v values.Value) any {
+	if m, ok := v.(*values.Map); ok && m == nil {
+		// capabilities without outputs, e.g. targets, return a nil map
+		return nil
+	}
+	u, err := v.Unwrap()
+	if err != nil {
+		return "failed to unwrap value: " + err.Error()
+	}
+	return u
+}
@@ -0,0 +1,88 @@
+package presenters
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+)
+
+func TestWorkflowExecutionResource(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	triggeredAt := createdAt.Add(time.Second)
+	finishedAt := createdAt.Add(2 * time.Second)
+	inputs, err := values.NewMap(map[string]any{"feedID": "0x01"})
+	require.NoError(t, err)
+	usage, err := values.NewMap(map[string]any{"WallTimeMs": 12, "FetchCalls": 1})
+	require.NoError(t, err)
+
+	r := NewWorkflowExecutionResource(store.WorkflowExecution{
+		ExecutionID: "execution-id",
+		WorkflowID:  "workflow-id",
+		Status:      store.StatusErrored,
+		CreatedAt:   &createdAt,
+		UpdatedAt:   &finishedAt,
+		FinishedAt:  &finishedAt,
+		Steps: map[string]*store.WorkflowExecutionStep{
+			"write": {
+				Ref:       "write",
+				Status:    store.StatusErrored,
+				Inputs:    inputs,
+				Outputs:   store.StepOutput{Err: errors.New("execution reverted")},
+				UpdatedAt: &finishedAt,
+			},
+			"trigger": {
+				Ref:       "trigger",
+				Status:    store.StatusCompleted,
+				Outputs:   store.StepOutput{Value: inputs, Usage: usage},
+				UpdatedAt: &triggeredAt,
+			},
+		},
+	})
+
+	b, err := jsonapi.Marshal(r)
+	require.NoError(t, err)
+
+	expected := `
+	{
+		"data": {
+			"type": "workflowExecutions",
+			"id": "execution-id",
+			"attributes": {
+				"workflowId": "workflow-id",
+				"status": "errored",
+				"createdAt": "2024-01-01T12:00:00Z",
+				"updatedAt": "2024-01-01T12:00:02Z",
+				"finishedAt": "2024-01-01T12:00:02Z",
+				"steps": [
+					{
+						"ref": "trigger",
+						"status": "completed",
+						"inputs": null,
+						"outputs": {"feedID": "0x01"},
+						"usage": {"WallTimeMs": 12, "FetchCalls": 1},
+						"updatedAt": "2024-01-01T12:00:01Z"
+					},
+					{
+						"ref": "write",
+						"status": "errored",
+						"inputs": {"feedID": "0x01"},
+						"outputs": null,
+						"error": "execution reverted",
+						"updatedAt": "2024-01-01T12:00:02Z"
+					}
+				]
+			}
+		}
+	}`
+	assert.JSONEq(t, expected, string(b))
+}
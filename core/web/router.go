@@ -378,6 +378,18 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/jobs/:ID/runs", paginatedRequest(prc.Index))
 		authv2.GET("/jobs/:ID/runs/:runID", prc.Show)
 
+		wec := WorkflowExecutionsController{app}
+		authv2.GET("/workflows/:ID/executions", paginatedRequest(wec.Index))
+		authv2.GET("/workflows/:ID/executions/:executionID", wec.Show)
+
+		wdc := WorkflowDeadLettersController{app}
+		authv2.GET("/workflows/:ID/dead_letters", paginatedRequest(wdc.Index))
+		authv2.POST("/workflows/:ID/dead_letters/:executionID/redrive", auth.RequiresRunRole(wdc.Redrive))
+
+		capc := CapabilitiesController{app}
+		authv2.GET("/capabilities", capc.Index)
+		authv2.GET("/capabilities/remote", capc.Remote)
+
 		// FeaturesController
 		fc := FeaturesController{app}
 		authv2.GET("/features", fc.Index)
@@ -423,6 +435,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 
 		efc := EVMForwardersController{app}
 		authv2.GET("/nodes/evm/forwarders", paginatedRequest(efc.Index))
+		authv2.GET("/nodes/evm/forwarders/verify", paginatedRequest(efc.Verify))
 		authv2.POST("/nodes/evm/forwarders/track", auth.RequiresEditRole(efc.Track))
 		authv2.DELETE("/nodes/evm/forwarders/:fwdID", auth.RequiresEditRole(efc.Delete))
 
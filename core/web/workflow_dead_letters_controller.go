@@ -0,0 +1,61 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// WorkflowDeadLettersController lists the executions of workflows that errored after a step exhausted its retries,
+// and re-drives them.
+type WorkflowDeadLettersController struct {
+	App chainlink.Application
+}
+
+// Index returns the paginated dead letters of a workflow, newest first.
+// Example:
+// "GET <application>/workflows/:ID/dead_letters"
+func (wdc *WorkflowDeadLettersController) Index(c *gin.Context, size, page, offset int) {
+	letters, count, err := wdc.App.WorkflowORM().ListDeadLetters(c.Request.Context(), c.Param("ID"), offset, size)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	paginatedResponse(c, "workflowDeadLetters", size, page, presenters.NewWorkflowDeadLetterResources(letters), count, err)
+}
+
+// Redrive requests a dead-lettered execution to be resumed from its failed steps by the engine running the workflow.
+// Example:
+// "POST <application>/workflows/:ID/dead_letters/:executionID/redrive"
+func (wdc *WorkflowDeadLettersController) Redrive(c *gin.Context) {
+	ctx := c.Request.Context()
+	workflowORM := wdc.App.WorkflowORM()
+	err := workflowORM.RequestRedrive(ctx, c.Param("ID"), c.Param("executionID"))
+	if errors.Is(err, store.ErrDeadLetterNotFound) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Workflow dead letter not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	execution, err := workflowORM.Get(ctx, c.Param("executionID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	wdc.App.GetAuditLogger().Audit(audit.WorkflowExecutionRedriven, map[string]interface{}{
+		"workflowID":  execution.WorkflowID,
+		"executionID": execution.ExecutionID,
+	})
+	jsonAPIResponseWithStatus(c, presenters.NewWorkflowExecutionResource(execution), "workflowExecution", http.StatusAccepted)
+}
@@ -0,0 +1,63 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestWorkflowDeadLettersController_Index(t *testing.T) {
+	client, workflowID, executionIDs := setupWorkflowExecutionsControllerTests(t)
+
+	resp, cleanup := client.Get("/v2/workflows/" + workflowID + "/dead_letters")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var letters []presenters.WorkflowDeadLetterResource
+	var links jsonapi.Links
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &letters, &links))
+	require.Len(t, letters, 1)
+	assert.Equal(t, executionIDs[1], letters[0].ID)
+	assert.Equal(t, "write", letters[0].StepRef)
+	assert.Equal(t, "execution reverted", letters[0].Error)
+	assert.Equal(t, 3, letters[0].Attempts)
+	assert.Nil(t, letters[0].RedriveRequestedAt)
+}
+
+func TestWorkflowDeadLettersController_Redrive(t *testing.T) {
+	client, workflowID, executionIDs := setupWorkflowExecutionsControllerTests(t)
+
+	resp, cleanup := client.Post("/v2/workflows/"+workflowID+"/dead_letters/"+executionIDs[1]+"/redrive", nil)
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusAccepted)
+
+	var execution presenters.WorkflowExecutionResource
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &execution))
+	assert.Equal(t, executionIDs[1], execution.ID)
+	assert.Equal(t, store.StatusErrored, execution.Status)
+
+	resp, cleanup = client.Get("/v2/workflows/" + workflowID + "/dead_letters")
+	t.Cleanup(cleanup)
+	var letters []presenters.WorkflowDeadLetterResource
+	var links jsonapi.Links
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &letters, &links))
+	require.Len(t, letters, 1)
+	assert.NotNil(t, letters[0].RedriveRequestedAt)
+
+	// only dead-lettered executions of the workflow can be re-driven
+	resp, cleanup = client.Post("/v2/workflows/"+workflowID+"/dead_letters/"+executionIDs[0]+"/redrive", nil)
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Post("/v2/workflows/"+randomWorkflowID()+"/dead_letters/"+executionIDs[1]+"/redrive", nil)
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,54 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// WorkflowExecutionsController displays the execution history of workflows.
+type WorkflowExecutionsController struct {
+	App chainlink.Application
+}
+
+// Index returns the paginated executions of a workflow, newest first, optionally filtered by status.
+// Example:
+// "GET <application>/workflows/:ID/executions?status=errored"
+func (wec *WorkflowExecutionsController) Index(c *gin.Context, size, page, offset int) {
+	status := c.Query("status")
+	if status != "" && !store.ValidStatuses[status] {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid status %q", status))
+		return
+	}
+
+	executions, count, err := wec.App.WorkflowORM().ListExecutions(c.Request.Context(), c.Param("ID"), status, offset, size)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	paginatedResponse(c, "workflowExecutions", size, page, presenters.NewWorkflowExecutionResources(executions), count, err)
+}
+
+// Show returns an execution of a workflow with the inputs, outputs and errors of each of its steps.
+// Example:
+// "GET <application>/workflows/:ID/executions/:executionID"
+func (wec *WorkflowExecutionsController) Show(c *gin.Context) {
+	execution, err := wec.App.WorkflowORM().Get(c.Request.Context(), c.Param("executionID"))
+	if errors.Is(err, store.ErrExecutionNotFound) || (err == nil && execution.WorkflowID != c.Param("ID")) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Workflow execution not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewWorkflowExecutionResource(execution), "workflowExecution")
+}
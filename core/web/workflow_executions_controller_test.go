@@ -0,0 +1,121 @@
+package web_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func randomWorkflowID() string {
+	b := testutils.Random32Byte()
+	return hex.EncodeToString(b[:])
+}
+
+func setupWorkflowExecutionsControllerTests(t *testing.T) (cltest.HTTPClientCleaner, string, []string) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+
+	wid := randomWorkflowID()
+	_, err := app.GetDB().ExecContext(ctx, `INSERT INTO workflow_specs (workflow, workflow_id, workflow_owner, workflow_name, created_at, updated_at)
+	VALUES ('', $1, $2, 'workflow', NOW(), NOW())`, wid, testutils.NewAddress().Hex()[2:])
+	require.NoError(t, err)
+
+	inputs, err := values.NewMap(map[string]any{"feedID": "0x01"})
+	require.NoError(t, err)
+	var executionIDs []string
+	for _, status := range []string{store.StatusCompleted, store.StatusErrored} {
+		id := randomWorkflowID()
+		executionIDs = append(executionIDs, id)
+		_, err = app.WorkflowORM().Add(ctx, &store.WorkflowExecution{
+			ExecutionID: id,
+			WorkflowID:  wid,
+			Status:      status,
+			Steps: map[string]*store.WorkflowExecutionStep{
+				"trigger": {ExecutionID: id, Ref: "trigger", Status: store.StatusCompleted, Outputs: store.StepOutput{Value: inputs}},
+				"write":   {ExecutionID: id, Ref: "write", Status: status, Inputs: inputs, Outputs: store.StepOutput{Err: errors.New("execution reverted")}},
+			},
+		})
+		require.NoError(t, err)
+	}
+	// the errored execution is dead-lettered, since its write step exhausted its retries
+	require.NoError(t, app.WorkflowORM().AddDeadLetter(ctx, &store.DeadLetter{
+		ExecutionID: executionIDs[1],
+		WorkflowID:  wid,
+		StepRef:     "write",
+		Error:       "execution reverted",
+		Attempts:    3,
+	}))
+
+	return app.NewHTTPClient(nil), wid, executionIDs
+}
+
+func TestWorkflowExecutionsController_Index(t *testing.T) {
+	client, workflowID, executionIDs := setupWorkflowExecutionsControllerTests(t)
+
+	resp, cleanup := client.Get("/v2/workflows/" + workflowID + "/executions")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var executions []presenters.WorkflowExecutionResource
+	var links jsonapi.Links
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &executions, &links))
+	require.Len(t, executions, 2)
+	assert.ElementsMatch(t, executionIDs, []string{executions[0].ID, executions[1].ID})
+	assert.Empty(t, executions[0].Steps)
+
+	resp, cleanup = client.Get("/v2/workflows/" + workflowID + "/executions?status=errored")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &executions, &links))
+	require.Len(t, executions, 1)
+	assert.Equal(t, executionIDs[1], executions[0].ID)
+	assert.Equal(t, store.StatusErrored, executions[0].Status)
+
+	resp, cleanup = client.Get("/v2/workflows/" + workflowID + "/executions?status=unknown")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
+func TestWorkflowExecutionsController_Show(t *testing.T) {
+	client, workflowID, executionIDs := setupWorkflowExecutionsControllerTests(t)
+
+	resp, cleanup := client.Get("/v2/workflows/" + workflowID + "/executions/" + executionIDs[1])
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var execution presenters.WorkflowExecutionResource
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &execution))
+	assert.Equal(t, executionIDs[1], execution.ID)
+	assert.Equal(t, workflowID, execution.WorkflowID)
+	require.Len(t, execution.Steps, 2)
+	assert.Equal(t, "trigger", execution.Steps[0].Ref)
+	assert.Equal(t, map[string]any{"feedID": "0x01"}, execution.Steps[0].Outputs)
+	assert.Equal(t, "write", execution.Steps[1].Ref)
+	assert.Equal(t, map[string]any{"feedID": "0x01"}, execution.Steps[1].Inputs)
+	assert.Equal(t, "execution reverted", execution.Steps[1].Error)
+	assert.NotNil(t, execution.Steps[1].UpdatedAt)
+
+	resp, cleanup = client.Get("/v2/workflows/" + workflowID + "/executions/unknown")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	// the execution must belong to the workflow
+	resp, cleanup = client.Get("/v2/workflows/" + randomWorkflowID() + "/executions/" + executionIDs[1])
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}